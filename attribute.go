@@ -0,0 +1,107 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// An Attribute is an immutable attribute, with a name chosen by the program
+// and a typed value, that a safehtml/template action can emit in the start
+// tag of an element:
+//
+//	<button {{.Disabled}}>Save</button>
+//
+// where .Disabled is AttributeFromConstant("disabled", !canSave). Since its
+// name is not known until the template is executed, the template checks at
+// execution time that the element allows the attribute, and sanitizes its
+// value for the attribute as it would in the attribute value context of the
+// element, so the value must have one of the types described for Attr.
+//
+// Attributes whose value is true emit the attribute with an empty value, such
+// as disabled="", and the zero Attribute and Attributes whose value is false
+// emit nothing, so that optional attributes can be computed by the program.
+type Attribute struct {
+	name  string
+	value interface{}
+}
+
+// attributeNamePattern matches the names of the attributes that Attribute
+// values can have. Event handler attributes are excluded below.
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(?:-[a-z0-9]+)*$`)
+
+// AttributeFromConstant returns the attribute with the given name, which must
+// be an untyped string constant, and value. It panics if name is not a valid
+// attribute name, if it is an event handler attribute such as onclick, or if
+// value does not have one of the types described for Attr.
+func AttributeFromConstant(name stringConstant, value interface{}) Attribute {
+	a, err := newAttribute(string(name), value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return a
+}
+
+// AttributeFromAllowlist returns the attribute with the given name and value,
+// or an error if name, such as an ARIA attribute name from a component
+// configuration, is not one of the allowed names, which must be untyped
+// string constants, or if AttributeFromConstant would panic.
+func AttributeFromAllowlist(name string, value interface{}, allowed ...stringConstant) (Attribute, error) {
+	name = strings.ToLower(name)
+	for _, a := range allowed {
+		if strings.ToLower(string(a)) == name {
+			return newAttribute(name, value)
+		}
+	}
+	return Attribute{}, fmt.Errorf("attribute %q is not in the allowlist", name)
+}
+
+func newAttribute(name string, value interface{}) (Attribute, error) {
+	name = strings.ToLower(name)
+	if err := checkAttributeName(name); err != nil {
+		return Attribute{}, err
+	}
+	switch value.(type) {
+	case string, int, bool, URL, TrustedResourceURL, URLSet, Identifier, Style, HTML:
+	default:
+		return Attribute{}, fmt.Errorf("attribute %q has value of type %T, want string, int, bool, or a safe type", name, value)
+	}
+	return Attribute{name, value}, nil
+}
+
+// checkAttributeName returns an error if the lowercase name is not a valid
+// attribute name, or if it is the name of an event handler attribute.
+func checkAttributeName(name string) error {
+	if !attributeNamePattern.MatchString(name) || strings.HasPrefix(name, "on") {
+		return fmt.Errorf("%q is not an allowed attribute name", name)
+	}
+	return nil
+}
+
+// Name returns the lowercase name of a, or the empty string if a is the zero
+// Attribute.
+func (a Attribute) Name() string {
+	return a.name
+}
+
+// Value returns the value of a, which has one of the types described for
+// Attr, or nil if a is the zero Attribute.
+func (a Attribute) Value() interface{} {
+	return a.value
+}
+
+// String returns the name and value of a, such as `disabled=true`, for
+// debugging. It is not the HTML of the attribute, which depends on the
+// element it occurs in.
+func (a Attribute) String() string {
+	if a.name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s=%v", a.name, a.value)
+}
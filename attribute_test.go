@@ -0,0 +1,94 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAttributeFromConstant(t *testing.T) {
+	tryAttributeFromConstant := func(name string, value interface{}) (a Attribute, panicMsg string) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicMsg = fmt.Sprint(r)
+			}
+		}()
+		return AttributeFromConstant(stringConstant(name), value), ""
+	}
+
+	for _, test := range [...]struct {
+		name     string
+		value    interface{}
+		want     string
+		panicMsg string
+	}{
+		{"disabled", true, "disabled=true", ""},
+		{"aria-label", "Close", "aria-label=Close", ""},
+		{"Tabindex", 0, "tabindex=0", ""},
+		{"href", URLSanitized("https://www.example.com"), "href=https://www.example.com", ""},
+		{"data-x", "y", "data-x=y", ""},
+		{"onclick", "alert(1)", "", `"onclick" is not an allowed attribute name`},
+		{"a b", "y", "", `"a b" is not an allowed attribute name`},
+		{"aria-", "y", "", `"aria-" is not an allowed attribute name`},
+		{"title", 1.5, "", `attribute "title" has value of type float64`},
+		{"title", nil, "", `attribute "title" has value of type <nil>`},
+	} {
+		a, panicMsg := tryAttributeFromConstant(test.name, test.value)
+		if test.panicMsg != "" {
+			if !strings.Contains(panicMsg, test.panicMsg) {
+				t.Errorf("AttributeFromConstant(%q, %v) panicked with %q, want %q", test.name, test.value, panicMsg, test.panicMsg)
+			}
+			continue
+		}
+		if panicMsg != "" {
+			t.Errorf("AttributeFromConstant(%q, %v) panicked: %s", test.name, test.value, panicMsg)
+			continue
+		}
+		if got := a.String(); got != test.want {
+			t.Errorf("AttributeFromConstant(%q, %v) = %q, want %q", test.name, test.value, got, test.want)
+		}
+	}
+}
+
+func TestAttributeFromAllowlist(t *testing.T) {
+	allowed := []stringConstant{"aria-label", "aria-describedby"}
+	for _, test := range [...]struct {
+		name, want, err string
+	}{
+		{"aria-label", "aria-label=x", ""},
+		{"ARIA-DescribedBy", "aria-describedby=x", ""},
+		{"aria-hidden", "", `attribute "aria-hidden" is not in the allowlist`},
+		{"onload", "", `attribute "onload" is not in the allowlist`},
+	} {
+		a, err := AttributeFromAllowlist(test.name, "x", allowed...)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("AttributeFromAllowlist(%q) error = %v, want %q", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("AttributeFromAllowlist(%q) failed: %v", test.name, err)
+			continue
+		}
+		if got := a.String(); got != test.want {
+			t.Errorf("AttributeFromAllowlist(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+	if _, err := AttributeFromAllowlist("onclick", "x", "onclick"); err == nil {
+		t.Error("AttributeFromAllowlist allowed an event handler attribute")
+	}
+}
+
+func TestAttributeZeroValue(t *testing.T) {
+	var a Attribute
+	if a.Name() != "" || a.Value() != nil || a.String() != "" {
+		t.Errorf("zero Attribute has name %q, value %v, string %q", a.Name(), a.Value(), a.String())
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package audit finds the uses of the escape hatches of safehtml in Go
+// source code, such as unchecked and legacy conversions and custom template
+// policies, for periodic security reviews. The safehtmlaudit command reports
+// them for a module.
+//
+// The analysis is syntactic: it resolves package names through the imports
+// of each file, so it finds every reference to the conversion packages, but
+// reports methods such as WithPolicy by name in files importing the package
+// that defines them, which may include methods of unrelated types with the
+// same name.
+package audit
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Kind is a kind of escape hatch.
+type Kind string
+
+// The kinds of escape hatches.
+const (
+	// UncheckedConversion is a use of uncheckedconversions, which creates
+	// safe values from strings that the caller asserts to be safe.
+	UncheckedConversion Kind = "unchecked-conversion"
+	// LegacyConversion is a use of legacyconversions, which creates safe
+	// values from unchecked strings in code migrating to safehtml.
+	LegacyConversion Kind = "legacy-conversion"
+	// TestConversion is a use of testconversions, which must only be used
+	// in tests.
+	TestConversion Kind = "test-conversion"
+	// CompatTemplate is a use of template/htmlcompat, which parses templates
+	// from arbitrary strings in code migrating from html/template.
+	CompatTemplate Kind = "compat-template"
+	// ProtoConversion is a use of safehtmlproto, which creates safe values
+	// from protocol buffer messages that the application trusts.
+	ProtoConversion Kind = "proto-conversion"
+	// CustomPolicy is a change of the sanitization rules of templates, such
+	// as a Registry allowing other elements.
+	CustomPolicy Kind = "custom-policy"
+	// ReportOnly is an execution of a template in report-only mode, which
+	// falls back to html/template instead of rejecting unsafe values.
+	ReportOnly Kind = "report-only"
+)
+
+// A Finding is a use of an escape hatch.
+type Finding struct {
+	Kind Kind `json:"kind"`
+	// Package is the import path of the package declaring the symbol used.
+	Package string `json:"package"`
+	// Symbol is the name of the function, method, type, or variable used,
+	// such as "HTMLFromStringKnownToSatisfyTypeContract".
+	Symbol string `json:"symbol"`
+	// File is the path of the file, relative to the scanned directory,
+	// using forward slashes.
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	// Test reports whether the file is a test file.
+	Test bool `json:"test"`
+}
+
+const module = "github.com/google/safehtml"
+
+// packageKinds maps the import paths of the conversion packages to the kinds
+// of their uses.
+var packageKinds = map[string]Kind{
+	module + "/uncheckedconversions":          UncheckedConversion,
+	module + "/template/uncheckedconversions": UncheckedConversion,
+	module + "/legacyconversions":             LegacyConversion,
+	module + "/testconversions":               TestConversion,
+	module + "/template/htmlcompat":           CompatTemplate,
+	module + "/safehtmlproto":                 ProtoConversion,
+}
+
+// funcKinds maps the import paths of packages to their functions and types
+// whose uses are escape hatches.
+var funcKinds = map[string]map[string]Kind{
+	module + "/template": {
+		"WithReportOnly": ReportOnly,
+	},
+	// The Scan methods of these types convert database values without
+	// checking their type contracts.
+	module + "/sqltypes": {
+		"TrustedResourceURL": UncheckedConversion,
+		"Script":             UncheckedConversion,
+		"Style":              UncheckedConversion,
+		"StyleSheet":         UncheckedConversion,
+	},
+}
+
+// methodKinds maps the import paths of packages to the methods of their
+// types whose uses are escape hatches.
+var methodKinds = map[string]map[string]Kind{
+	module + "/template": {
+		"WithPolicy":    CustomPolicy,
+		"WithPolicyFor": CustomPolicy,
+		"WithRegistry":  CustomPolicy,
+	},
+	module + "/registry": {
+		"AllowAttribute":        CustomPolicy,
+		"AllowElement":          CustomPolicy,
+		"AllowElementAttribute": CustomPolicy,
+		"AllowVoidElement":      CustomPolicy,
+	},
+}
+
+// Dir returns the uses of escape hatches in the Go files in the directory
+// tree rooted at root, sorted by file and position. It skips testdata and
+// vendor directories, and directories whose names start with "." or "_", as
+// the go command does.
+func Dir(root string) ([]Finding, error) {
+	var findings []Finding
+	fset := token.NewFileSet()
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		for _, finding := range File(fset, f) {
+			finding.File = filepath.ToSlash(rel)
+			finding.Test = strings.HasSuffix(name, "_test.go")
+			findings = append(findings, finding)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings, nil
+}
+
+// File returns the uses of escape hatches in f, in order of their positions.
+// The File field of the findings is the file name recorded in fset.
+func File(fset *token.FileSet, f *ast.File) []Finding {
+	// names maps the names of the imported packages in f to their paths.
+	names := make(map[string]string)
+	for _, imp := range f.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		_, isConversion := packageKinds[path]
+		_, hasFuncs := funcKinds[path]
+		if _, ok := methodKinds[path]; !ok && !isConversion && !hasFuncs {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		names[name] = path
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	// methods maps the names of the methods to find to the import paths of
+	// the packages declaring them.
+	methods := make(map[string]string)
+	for _, path := range names {
+		for m := range methodKinds[path] {
+			methods[m] = path
+		}
+	}
+	var findings []Finding
+	add := func(n ast.Node, kind Kind, path, symbol string) {
+		pos := fset.Position(n.Pos())
+		findings = append(findings, Finding{
+			Kind:    kind,
+			Package: path,
+			Symbol:  symbol,
+			File:    pos.Filename,
+			Line:    pos.Line,
+			Column:  pos.Column,
+		})
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok {
+			if path, ok := names[x.Name]; ok {
+				kind, ok := packageKinds[path]
+				if !ok {
+					kind, ok = funcKinds[path][sel.Sel.Name]
+				}
+				if ok {
+					add(sel, kind, path, sel.Sel.Name)
+				}
+				return false
+			}
+		}
+		if path, ok := methods[sel.Sel.Name]; ok {
+			add(sel.Sel, methodKinds[path][sel.Sel.Name], path, sel.Sel.Name)
+		}
+		return true
+	})
+	return findings
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package audit
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	got, err := Dir("testdata/src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		safehtml = "github.com/google/safehtml"
+		appFile  = "app/app.go"
+		testFile = "app/app_test.go"
+	)
+	want := []Finding{
+		{Kind: UncheckedConversion, Package: safehtml + "/uncheckedconversions", Symbol: "HTMLFromStringKnownToSatisfyTypeContract", File: appFile, Line: 12, Column: 9},
+		{Kind: LegacyConversion, Package: safehtml + "/legacyconversions", Symbol: "RiskilyAssumeHTML", File: appFile, Line: 16, Column: 9},
+		{Kind: CustomPolicy, Package: safehtml + "/template", Symbol: "WithRegistry", File: appFile, Line: 20, Column: 2},
+		{Kind: CustomPolicy, Package: safehtml + "/registry", Symbol: "AllowElement", File: appFile, Line: 20, Column: 34},
+		{Kind: ReportOnly, Package: safehtml + "/template", Symbol: "WithReportOnly", File: appFile, Line: 22, Column: 18},
+		{Kind: TestConversion, Package: safehtml + "/testconversions", Symbol: "MakeHTMLForTest", File: testFile, Line: 5, Column: 16, Test: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dir(testdata/src) =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestFile(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, src string
+		want      []Finding
+	}{
+		{
+			"no safehtml imports",
+			`package p; import "strings"; var s = strings.ToUpper("x")`,
+			nil,
+		},
+		{
+			"methods without their package imported",
+			`package p; func f(t T) { t.WithPolicy(nil) }`,
+			nil,
+		},
+		{
+			"nested conversion package",
+			`package p
+import "github.com/google/safehtml/template/uncheckedconversions"
+var x = uncheckedconversions.TrustedTemplateFromStringKnownToSatisfyTypeContract`,
+			[]Finding{{Kind: UncheckedConversion, Package: "github.com/google/safehtml/template/uncheckedconversions", Symbol: "TrustedTemplateFromStringKnownToSatisfyTypeContract", File: "p.go", Line: 3, Column: 9}},
+		},
+		{
+			"compat templates",
+			`package p
+import template "github.com/google/safehtml/template/htmlcompat"
+var t = template.New("t")`,
+			[]Finding{{Kind: CompatTemplate, Package: "github.com/google/safehtml/template/htmlcompat", Symbol: "New", File: "p.go", Line: 3, Column: 9}},
+		},
+		{
+			"proto conversions",
+			`package p
+import "github.com/google/safehtml/safehtmlproto"
+var h = safehtmlproto.HTMLFromProto(m)`,
+			[]Finding{{Kind: ProtoConversion, Package: "github.com/google/safehtml/safehtmlproto", Symbol: "HTMLFromProto", File: "p.go", Line: 3, Column: 9}},
+		},
+		{
+			"unchecked database types",
+			`package p
+import "github.com/google/safehtml/sqltypes"
+var h sqltypes.HTML
+var s, t = sqltypes.Script{}, []sqltypes.TrustedResourceURL{}
+func f(*sqltypes.Style, sqltypes.StyleSheet) {}`,
+			[]Finding{
+				{Kind: UncheckedConversion, Package: "github.com/google/safehtml/sqltypes", Symbol: "Script", File: "p.go", Line: 4, Column: 12},
+				{Kind: UncheckedConversion, Package: "github.com/google/safehtml/sqltypes", Symbol: "TrustedResourceURL", File: "p.go", Line: 4, Column: 33},
+				{Kind: UncheckedConversion, Package: "github.com/google/safehtml/sqltypes", Symbol: "Style", File: "p.go", Line: 5, Column: 9},
+				{Kind: UncheckedConversion, Package: "github.com/google/safehtml/sqltypes", Symbol: "StyleSheet", File: "p.go", Line: 5, Column: 25},
+			},
+		},
+		{
+			"other template functions",
+			`package p
+import "github.com/google/safehtml/template"
+var t = template.Must(template.New("t").WithPolicy(nil).Parse(""))`,
+			[]Finding{{Kind: CustomPolicy, Package: "github.com/google/safehtml/template", Symbol: "WithPolicy", File: "p.go", Line: 3, Column: 41}},
+		},
+		{
+			"policies for template name prefixes",
+			`package p
+import "github.com/google/safehtml/template"
+var t = template.New("t").WithPolicyFor("admin/", nil)`,
+			[]Finding{{Kind: CustomPolicy, Package: "github.com/google/safehtml/template", Symbol: "WithPolicyFor", File: "p.go", Line: 3, Column: 27}},
+		},
+		{
+			"blank import",
+			`package p; import _ "github.com/google/safehtml/legacyconversions"`,
+			nil,
+		},
+	} {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "p.go", test.src, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		if got := File(fset, f); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got\n%+v\nwant\n%+v", test.desc, got, test.want)
+		}
+	}
+}
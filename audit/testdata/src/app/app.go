@@ -0,0 +1,22 @@
+package app
+
+import (
+	"github.com/google/safehtml"
+	legacy "github.com/google/safehtml/legacyconversions"
+	"github.com/google/safehtml/registry"
+	"github.com/google/safehtml/template"
+	"github.com/google/safehtml/uncheckedconversions"
+)
+
+func render(s string) safehtml.HTML {
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(s)
+}
+
+func legacyRender(s string) safehtml.HTML {
+	return legacy.RiskilyAssumeHTML(s)
+}
+
+var page = template.Must(template.New("page").Parse(`<p>{{.}}</p>`)).
+	WithRegistry(registry.Default().AllowElement("x-card", registry.HTML))
+
+var reportOnly = template.WithReportOnly(nil)
@@ -0,0 +1,5 @@
+package app
+
+import "github.com/google/safehtml/testconversions"
+
+var testHTML = testconversions.MakeHTMLForTest("<b>test</b>")
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/google/safehtml/urlsafe"
+)
+
+// maxAvatarSize is the maximum size of Gravatar images in pixels.
+const maxAvatarSize = 2048
+
+// AvatarURL returns the URL of the Gravatar image of the user with the given
+// email address, size pixels wide and high. size is clamped to between 1 and
+// 2048. If defaultImage is not empty, it is the URL of the image that
+// Gravatar returns for users without a Gravatar image.
+//
+// The email address is trimmed, lowercased, and hashed with SHA-256 as
+// Gravatar requires, so it does not appear in the URL.
+func AvatarURL(email string, size int, defaultImage URL) URL {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	if size < 1 {
+		size = 1
+	} else if size > maxAvatarSize {
+		size = maxAvatarSize
+	}
+	url := "https://www.gravatar.com/avatar/" + hex.EncodeToString(hash[:])
+	if d := defaultImage.String(); d != "" {
+		url += "?d=" + urlsafe.QueryEscapeURL(d) + "&s=" + strconv.Itoa(size)
+	} else {
+		url += "?s=" + strconv.Itoa(size)
+	}
+	return URL{url}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestAvatarURL(t *testing.T) {
+	// The SHA-256 hash of "user@example.com".
+	const hash = "b4c9a289323b21a01c3e940f150eb9b8c542587f1abfd8f0e1cc1ffc5e475514"
+	for _, test := range [...]struct {
+		email        string
+		size         int
+		defaultImage URL
+		want         string
+	}{
+		{"user@example.com", 80, URL{}, "https://www.gravatar.com/avatar/" + hash + "?s=80"},
+		{"  User@Example.COM\n", 0, URL{}, "https://www.gravatar.com/avatar/" + hash + "?s=1"},
+		{"user@example.com", 4096, URL{}, "https://www.gravatar.com/avatar/" + hash + "?s=2048"},
+		{
+			"user@example.com", 64, URLSanitized("https://example.com/default.png?a=1&b=2"),
+			"https://www.gravatar.com/avatar/" + hash + "?d=https%3a%2f%2fexample.com%2fdefault.png%3fa%3d1%26b%3d2&s=64",
+		},
+		{
+			"user@example.com", 64, URLSanitized("javascript:alert(1)"),
+			"https://www.gravatar.com/avatar/" + hash + "?d=about%3ainvalid%23zGoSafez&s=64",
+		},
+	} {
+		if got := AvatarURL(test.email, test.size, test.defaultImage).String(); got != test.want {
+			t.Errorf("AvatarURL(%q, %d, %q) = %q, want %q", test.email, test.size, test.defaultImage, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+// A NavItem is a link of a breadcrumb trail or navigation menu.
+type NavItem struct {
+	// Label is the text of the link.
+	Label string
+	// URL is the target of the link. Items without a URL are rendered as
+	// text.
+	URL URL
+	// Current reports whether the item is the current page. It is ignored by
+	// breadcrumb trails, whose last item is always the current page.
+	Current bool
+}
+
+// BreadcrumbHTML returns a breadcrumb trail of the given items, from the root
+// to the current page, as an ordered list in a nav element:
+//
+//	<nav aria-label="Breadcrumb"><ol>
+//	<li><a href="/">Home</a></li>
+//	<li aria-current="page">Settings</li>
+//	</ol></nav>
+//
+// The last item is the current page, and is rendered without a link.
+func BreadcrumbHTML(items []NavItem) HTML {
+	var w elementWriter
+	w.open("nav")
+	w.attr("aria-label", "Breadcrumb")
+	w.closeTag()
+	w.open("ol")
+	w.closeTag()
+	for i, item := range items {
+		w.open("li")
+		if i == len(items)-1 {
+			w.attr("aria-current", "page")
+			w.closeTag()
+			w.text(item.Label)
+		} else {
+			w.closeTag()
+			writeNavLink(&w, item, false)
+		}
+		w.end("li")
+	}
+	w.end("ol")
+	w.end("nav")
+	return w.html()
+}
+
+// BreadcrumbStructuredDataHTML returns a JSON-LD script element describing
+// the breadcrumb trail of the given items as a schema.org BreadcrumbList, so
+// that search engines can show the trail rendered by BreadcrumbHTML. Search
+// engines require the URLs of the items to be absolute.
+func BreadcrumbStructuredDataHTML(items []NavItem) (HTML, error) {
+	type listItem struct {
+		Type     string `json:"@type"`
+		Position int    `json:"position"`
+		Name     string `json:"name"`
+		Item     string `json:"item,omitempty"`
+	}
+	list := struct {
+		Context string     `json:"@context"`
+		Type    string     `json:"@type"`
+		Items   []listItem `json:"itemListElement"`
+	}{Context: "https://schema.org", Type: "BreadcrumbList", Items: []listItem{}}
+	for i, item := range items {
+		list.Items = append(list.Items, listItem{
+			Type:     "ListItem",
+			Position: i + 1,
+			Name:     item.Label,
+			Item:     item.URL.String(),
+		})
+	}
+	return StructuredDataHTML(list)
+}
+
+// NavMenuHTML returns a navigation menu of the given items as an unordered
+// list in a nav element with the given accessible label, such as "Main".
+// Items whose Current field is true are marked with aria-current="page".
+func NavMenuHTML(label string, items []NavItem) HTML {
+	var w elementWriter
+	w.open("nav")
+	w.attr("aria-label", label)
+	w.closeTag()
+	w.open("ul")
+	w.closeTag()
+	for _, item := range items {
+		w.open("li")
+		w.closeTag()
+		writeNavLink(&w, item, item.Current)
+		w.end("li")
+	}
+	w.end("ul")
+	w.end("nav")
+	return w.html()
+}
+
+func writeNavLink(w *elementWriter, item NavItem, current bool) {
+	if item.URL.String() == "" {
+		w.text(item.Label)
+		return
+	}
+	w.open("a")
+	w.urlAttr("href", item.URL.String())
+	if current {
+		w.attr("aria-current", "page")
+	}
+	w.closeTag()
+	w.text(item.Label)
+	w.end("a")
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+var testBreadcrumb = []NavItem{
+	{Label: "Home", URL: URLSanitized("https://example.com/")},
+	{Label: "<Docs>", URL: URLSanitized("javascript:alert(1)")},
+	{Label: "Settings & Privacy", URL: URLSanitized("https://example.com/docs/settings")},
+}
+
+func TestBreadcrumbHTML(t *testing.T) {
+	want := `<nav aria-label="Breadcrumb"><ol>` +
+		`<li><a href="https://example.com/">Home</a></li>` +
+		`<li><a href="about:invalid#zGoSafez">&lt;Docs&gt;</a></li>` +
+		`<li aria-current="page">Settings &amp; Privacy</li>` +
+		`</ol></nav>`
+	if got := BreadcrumbHTML(testBreadcrumb).String(); got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+	if got, want := BreadcrumbHTML(nil).String(), `<nav aria-label="Breadcrumb"><ol></ol></nav>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBreadcrumbStructuredDataHTML(t *testing.T) {
+	got, err := BreadcrumbStructuredDataHTML(testBreadcrumb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<script type="application/ld+json">{"@context":"https://schema.org","@type":"BreadcrumbList","itemListElement":[` +
+		`{"@type":"ListItem","position":1,"name":"Home","item":"https://example.com/"},` +
+		`{"@type":"ListItem","position":2,"name":"\u003cDocs\u003e","item":"about:invalid#zGoSafez"},` +
+		`{"@type":"ListItem","position":3,"name":"Settings \u0026 Privacy","item":"https://example.com/docs/settings"}` +
+		`]}</script>`
+	if got.String() != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
+
+func TestNavMenuHTML(t *testing.T) {
+	items := []NavItem{
+		{Label: "Home", URL: URLSanitized("/")},
+		{Label: "Blog", URL: URLSanitized("/blog"), Current: true},
+		{Label: "Soon"},
+	}
+	want := `<nav aria-label="Main &#34;menu&#34;"><ul>` +
+		`<li><a href="/">Home</a></li>` +
+		`<li><a href="/blog" aria-current="page">Blog</a></li>` +
+		`<li>Soon</li>` +
+		`</ul></nav>`
+	if got := NavMenuHTML(`Main "menu"`, items).String(); got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
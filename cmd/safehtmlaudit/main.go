@@ -0,0 +1,81 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Command safehtmlaudit reports the uses of the escape hatches of safehtml,
+// such as unchecked and legacy conversions and custom template policies, in
+// the Go files of the directory trees given as arguments, or of the current
+// directory.
+//
+// Usage:
+//
+//	safehtmlaudit [-tests=false] [-fail] [dir ...]
+//
+// The report is a JSON object whose findings field lists the uses found, of
+// the form described by audit.Finding, with file names relative to the
+// directory given, for automated security reviews. Run with -fail, the
+// command exits with status 3 if it finds any use.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/safehtml/audit"
+)
+
+var (
+	tests = flag.Bool("tests", true, "report the uses in test files")
+	fail  = flag.Bool("fail", false, "exit with status 3 if any use is found")
+)
+
+// A report is the output of safehtmlaudit.
+type report struct {
+	Findings []finding `json:"findings"`
+}
+
+type finding struct {
+	// Dir is the directory given as argument whose tree contains the file.
+	Dir string `json:"dir"`
+	audit.Finding
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: safehtmlaudit [flags] [dir ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	r := report{Findings: []finding{}}
+	for _, dir := range dirs {
+		findings, err := audit.Dir(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "safehtmlaudit: %v\n", err)
+			os.Exit(1)
+		}
+		for _, f := range findings {
+			if f.Test && !*tests {
+				continue
+			}
+			r.Findings = append(r.Findings, finding{Dir: filepath.ToSlash(dir), Finding: f})
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(r); err != nil {
+		fmt.Fprintf(os.Stderr, "safehtmlaudit: %v\n", err)
+		os.Exit(1)
+	}
+	if *fail && len(r.Findings) > 0 {
+		os.Exit(3)
+	}
+}
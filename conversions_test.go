@@ -7,6 +7,8 @@
 package safehtml_test
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/google/safehtml/legacyconversions"
@@ -131,3 +133,50 @@ func TestIdentifierFromStringKnownToSatisfyTypeContract(t *testing.T) {
 			identifier, out, identifier)
 	}
 }
+
+const svg = `<svg onload="alert('this will not be sanitized')"></svg>`
+
+func TestSVGFromStringKnownToSatisfyTypeContract(t *testing.T) {
+	if out := uncheckedconversions.SVGFromStringKnownToSatisfyTypeContract(svg).String(); svg != out {
+		t.Errorf("uncheckedconversions.SVGFromStringKnownToSatisfyTypeContract(%q).String() = %q, want %q",
+			svg, out, svg)
+	}
+	if out := legacyconversions.RiskilyAssumeSVG(svg).String(); svg != out {
+		t.Errorf("legacyconversions.RiskilyAssumeSVG(%q).String() = %q, want %q",
+			svg, out, svg)
+	}
+	if out := testconversions.MakeSVGForTest(svg).String(); svg != out {
+		t.Errorf("testconversions.MakeSVGForTest(%q).String() = %q, want %q",
+			svg, out, svg)
+	}
+}
+
+func TestUnmarshaledTypes(t *testing.T) {
+	type cached struct {
+		HTML               uncheckedconversions.UnmarshaledHTML
+		URL                uncheckedconversions.UnmarshaledURL
+		TrustedResourceURL uncheckedconversions.UnmarshaledTrustedResourceURL
+		Script             uncheckedconversions.UnmarshaledScript
+		Style              uncheckedconversions.UnmarshaledStyle
+		StyleSheet         uncheckedconversions.UnmarshaledStyleSheet
+		Identifier         uncheckedconversions.UnmarshaledIdentifier
+	}
+	const in = `{"HTML":"<b>h</b>","URL":"/u","TrustedResourceURL":"/t.js","Script":"s();","Style":"color:red;","StyleSheet":"p{}","Identifier":"i"}`
+	var v cached
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	got := []string{v.HTML.String(), v.URL.String(), v.TrustedResourceURL.String(), v.Script.String(), v.Style.String(), v.StyleSheet.String(), v.Identifier.String()}
+	want := []string{"<b>h</b>", "/u", "/t.js", "s();", "color:red;", "p{}", "i"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again cached
+	if err := json.Unmarshal(b, &again); err != nil || again != v {
+		t.Errorf("round trip through %s = %+v, %v, want %+v", b, again, err, v)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package discovery writes the files that browsers discover through the
+// head of the pages of a site: OpenSearch descriptions, referenced by link
+// elements such as those returned by safehtml.LinkHTML with the "search"
+// link type, and the browserconfig.xml files of Windows tiles.
+//
+// Files are written with encoding/xml, which escapes all values, and the
+// resources they reference are TrustedResourceURLs, as in the head of the
+// page:
+//
+//	err := discovery.WriteOpenSearch(w, discovery.OpenSearch{
+//		ShortName: "Example",
+//		Template:  safehtml.TrustedResourceURLFromConstant("https://example.com/search?q={searchTerms}"),
+//	})
+package discovery
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/urlsafe"
+)
+
+// An OpenSearch describes the search engine of a site, as described at
+// https://github.com/dewitt/opensearch.
+type OpenSearch struct {
+	// ShortName is the name of the search engine, of at most 16 characters.
+	ShortName string
+	// Description is the optional description of the search engine, of at
+	// most 1024 characters.
+	Description string
+	// Template is the absolute http or https URL of the search results page,
+	// in which "{searchTerms}" is replaced by the search terms.
+	Template safehtml.TrustedResourceURL
+	// SuggestionsTemplate is the optional URL template of the search
+	// suggestions, in the application/x-suggestions+json format.
+	SuggestionsTemplate safehtml.TrustedResourceURL
+	// Icon is the optional absolute URL of the icon of the search engine.
+	Icon safehtml.TrustedResourceURL
+	// IconSize is the size in pixels of the square icon, or 0 if unknown.
+	IconSize int
+	// IconType is the MIME type of the icon, such as "image/png".
+	IconType string
+}
+
+type openSearchDescription struct {
+	XMLName       xml.Name         `xml:"http://a9.com/-/spec/opensearch/1.1/ OpenSearchDescription"`
+	ShortName     string           `xml:"ShortName"`
+	Description   string           `xml:"Description"`
+	InputEncoding string           `xml:"InputEncoding"`
+	Image         *openSearchImage `xml:"Image"`
+	URLs          []openSearchURL  `xml:"Url"`
+}
+
+type openSearchImage struct {
+	Width  string `xml:"width,attr,omitempty"`
+	Height string `xml:"height,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	URL    string `xml:",chardata"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+// WriteOpenSearch writes the OpenSearch description of s to w. It returns an
+// error if s.ShortName is empty or too long, s.Description is too long, the
+// templates and icon are not absolute http or https URLs, a template does not
+// contain "{searchTerms}", or writing to w fails.
+func WriteOpenSearch(w io.Writer, s OpenSearch) error {
+	if s.ShortName == "" || utf8.RuneCountInString(s.ShortName) > 16 {
+		return fmt.Errorf("discovery: short name %q is empty or longer than 16 characters", s.ShortName)
+	}
+	if utf8.RuneCountInString(s.Description) > 1024 {
+		return fmt.Errorf("discovery: description is longer than 1024 characters")
+	}
+	description := s.Description
+	if description == "" {
+		// Description is required.
+		description = s.ShortName
+	}
+	doc := openSearchDescription{
+		ShortName:     s.ShortName,
+		Description:   description,
+		InputEncoding: "UTF-8",
+	}
+	add := func(typ string, template safehtml.TrustedResourceURL) error {
+		t := template.String()
+		if err := checkAbsolute(t); err != nil {
+			return err
+		}
+		if !strings.Contains(t, "{searchTerms}") {
+			return fmt.Errorf("discovery: template %q does not contain {searchTerms}", t)
+		}
+		doc.URLs = append(doc.URLs, openSearchURL{Type: typ, Template: t})
+		return nil
+	}
+	if err := add("text/html", s.Template); err != nil {
+		return err
+	}
+	if s.SuggestionsTemplate.String() != "" {
+		if err := add("application/x-suggestions+json", s.SuggestionsTemplate); err != nil {
+			return err
+		}
+	}
+	if icon := s.Icon.String(); icon != "" {
+		if err := checkAbsolute(icon); err != nil {
+			return err
+		}
+		img := &openSearchImage{Type: s.IconType, URL: urlsafe.NormalizeURL(icon)}
+		if s.IconSize > 0 {
+			img.Width = strconv.Itoa(s.IconSize)
+			img.Height = img.Width
+		}
+		doc.Image = img
+	}
+	return writeXML(w, doc)
+}
+
+// A BrowserConfig describes the tiles of a site pinned to the Windows start
+// menu.
+type BrowserConfig struct {
+	// Square70x70Logo, Square150x150Logo, Wide310x150Logo, and
+	// Square310x310Logo are the optional URLs of the logos of the tiles.
+	Square70x70Logo   safehtml.TrustedResourceURL
+	Square150x150Logo safehtml.TrustedResourceURL
+	Wide310x150Logo   safehtml.TrustedResourceURL
+	Square310x310Logo safehtml.TrustedResourceURL
+	// TileColor is the optional background color of the tiles, a
+	// hexadecimal color such as "#da532c".
+	TileColor string
+}
+
+type browserConfig struct {
+	XMLName xml.Name `xml:"browserconfig"`
+	Tile    struct {
+		Square70x70   *tileLogo `xml:"square70x70logo"`
+		Square150x150 *tileLogo `xml:"square150x150logo"`
+		Wide310x150   *tileLogo `xml:"wide310x150logo"`
+		Square310x310 *tileLogo `xml:"square310x310logo"`
+		TileColor     string    `xml:"TileColor,omitempty"`
+	} `xml:"msapplication>tile"`
+}
+
+type tileLogo struct {
+	Src string `xml:"src,attr"`
+}
+
+// hexColorPattern matches hexadecimal CSS colors.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// WriteBrowserConfig writes the browserconfig.xml file of c to w. It returns
+// an error if c.TileColor is not a hexadecimal color or writing to w fails.
+func WriteBrowserConfig(w io.Writer, c BrowserConfig) error {
+	if c.TileColor != "" && !hexColorPattern.MatchString(c.TileColor) {
+		return fmt.Errorf("discovery: %q is not a hexadecimal color", c.TileColor)
+	}
+	var doc browserConfig
+	doc.Tile.Square70x70 = logo(c.Square70x70Logo)
+	doc.Tile.Square150x150 = logo(c.Square150x150Logo)
+	doc.Tile.Wide310x150 = logo(c.Wide310x150Logo)
+	doc.Tile.Square310x310 = logo(c.Square310x310Logo)
+	doc.Tile.TileColor = c.TileColor
+	return writeXML(w, doc)
+}
+
+func logo(u safehtml.TrustedResourceURL) *tileLogo {
+	if u.String() == "" {
+		return nil
+	}
+	return &tileLogo{Src: urlsafe.NormalizeURL(u.String())}
+}
+
+// checkAbsolute returns an error if s is not an absolute http or https URL.
+func checkAbsolute(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("discovery: %q is not an absolute http or https URL", s)
+	}
+	return nil
+}
+
+// writeXML writes v to w as an XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if err := xml.NewEncoder(w).Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package discovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestWriteOpenSearch(t *testing.T) {
+	var b strings.Builder
+	err := WriteOpenSearch(&b, OpenSearch{
+		ShortName:           "Tom & Jerry",
+		Template:            safehtml.TrustedResourceURLFromConstant("https://example.com/search?q={searchTerms}&src=os"),
+		SuggestionsTemplate: safehtml.TrustedResourceURLFromConstant("https://example.com/suggest?q={searchTerms}"),
+		Icon:                safehtml.TrustedResourceURLFromConstant("https://example.com/favicon.png"),
+		IconSize:            16,
+		IconType:            "image/png",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/"><ShortName>Tom &amp; Jerry</ShortName>` +
+		`<Description>Tom &amp; Jerry</Description><InputEncoding>UTF-8</InputEncoding>` +
+		`<Image width="16" height="16" type="image/png">https://example.com/favicon.png</Image>` +
+		`<Url type="text/html" template="https://example.com/search?q={searchTerms}&amp;src=os"></Url>` +
+		`<Url type="application/x-suggestions+json" template="https://example.com/suggest?q={searchTerms}"></Url>` +
+		"</OpenSearchDescription>\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteOpenSearch:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestWriteOpenSearchErrors(t *testing.T) {
+	template := safehtml.TrustedResourceURLFromConstant("https://example.com/search?q={searchTerms}")
+	for _, test := range [...]struct {
+		desc string
+		s    OpenSearch
+	}{
+		{"no short name", OpenSearch{Template: template}},
+		{"long short name", OpenSearch{ShortName: "A very long search name", Template: template}},
+		{"no template", OpenSearch{ShortName: "a"}},
+		{"relative template", OpenSearch{ShortName: "a", Template: safehtml.TrustedResourceURLFromConstant("/search?q={searchTerms}")}},
+		{"no search terms", OpenSearch{ShortName: "a", Template: safehtml.TrustedResourceURLFromConstant("https://example.com/search")}},
+		{"relative icon", OpenSearch{ShortName: "a", Template: template, Icon: safehtml.TrustedResourceURLFromConstant("/favicon.png")}},
+	} {
+		if err := WriteOpenSearch(new(strings.Builder), test.s); err == nil {
+			t.Errorf("%s: WriteOpenSearch succeeded, want error", test.desc)
+		}
+	}
+}
+
+func TestWriteBrowserConfig(t *testing.T) {
+	var b strings.Builder
+	err := WriteBrowserConfig(&b, BrowserConfig{
+		Square150x150Logo: safehtml.TrustedResourceURLFromConstant("/icons/mstile-150x150.png"),
+		Wide310x150Logo:   safehtml.TrustedResourceURLFromConstant("/icons/mstile 310x150.png"),
+		TileColor:         "#da532c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<browserconfig><msapplication><tile><square150x150logo src="/icons/mstile-150x150.png"></square150x150logo>` +
+		`<wide310x150logo src="/icons/mstile%20310x150.png"></wide310x150logo><TileColor>#da532c</TileColor></tile></msapplication>` +
+		"</browserconfig>\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteBrowserConfig:\ngot  %s\nwant %s", got, want)
+	}
+	if err := WriteBrowserConfig(new(strings.Builder), BrowserConfig{TileColor: "red"}); err == nil {
+		t.Errorf("WriteBrowserConfig with invalid color succeeded, want error")
+	}
+}
@@ -7,4 +7,26 @@
 // Package safehtml provides immutable string-like types which represent values that
 // are guaranteed to be safe, by construction or by escaping or sanitization, to use
 // in various HTML contexts and with various DOM APIs.
+//
+// Functions such as TrustedResourceURLFromConstant only accept untyped string
+// constants, because their parameters have an unexported string type. Libraries
+// wrapping these functions cannot declare parameters of that type, and a
+// generic parameter such as T ~string would accept any string value, so
+// wrappers should instead accept the safe type, which their callers construct
+// from a constant:
+//
+//	// ScriptElement returns a script element that loads src.
+//	func ScriptElement(src safehtml.TrustedResourceURL) safehtml.HTML
+//
+//	h := ScriptElement(safehtml.TrustedResourceURLFromConstant("/static/app.js"))
+//
+// Building with the safehtml_minimal build tag excludes the functions that
+// depend on modules other than the standard library, namely CurrencyHTML,
+// HTMLToText, HTMLTruncate, and NumberHTML in this package, and TextFuncs,
+// WithAccessibilityCheck, and WithHTMLCheck in safehtml/template. This
+// package and safehtml/template then have no external dependencies, for
+// applications that must keep their dependencies to a minimum. Other
+// packages of this module build with the tag, but keep their own
+// dependencies, such as those of safehtml/sanitizer on golang.org/x/net/html,
+// and safehtml/feed ignores Feed.SummaryLength, which uses HTMLTruncate.
 package safehtml
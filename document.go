@@ -0,0 +1,103 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/safehtml/internal/diag"
+)
+
+// A DocOption configures the document returned by DocumentFromParts.
+type DocOption func(*docConfig)
+
+type docConfig struct {
+	lang, dir, charset string
+}
+
+// langPattern matches BCP 47 language tags, such as "en", "pt-BR", and
+// "zh-Hant-TW", without checking that their subtags are registered.
+var langPattern = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// DocLang sets the lang attribute of the html element of the document to
+// lang, a BCP 47 language tag such as "en" or "pt-BR". DocumentFromParts
+// omits the attribute, and logs the tag to the Logger set by SetLogger, if
+// lang is not a language tag.
+func DocLang(lang string) DocOption {
+	return func(c *docConfig) {
+		c.lang = lang
+	}
+}
+
+// DocDir sets the dir attribute of the html element of the document to dir,
+// "ltr", "rtl", or "auto". DocumentFromParts omits the attribute, and logs
+// dir to the Logger set by SetLogger, if dir is not one of those values.
+func DocDir(dir string) DocOption {
+	return func(c *docConfig) {
+		c.dir = dir
+	}
+}
+
+// DocCharset sets the character encoding declared by the document, which
+// defaults to "utf-8", the only encoding that the HTML standard allows new
+// documents to use. Since documents are always encoded in UTF-8, declaring
+// another encoding would make browsers decode them differently than they were
+// escaped, so DocumentFromParts declares "utf-8", and logs charset to the
+// Logger set by SetLogger, if charset is not "utf-8" in any case.
+func DocCharset(charset string) DocOption {
+	return func(c *docConfig) {
+		c.charset = charset
+	}
+}
+
+// DocumentFromParts returns the HTML document with the content head in its
+// head element, after the declaration of its character encoding, and the
+// content body in its body element:
+//
+//	<!DOCTYPE html>
+//	<html lang="en"><head><meta charset="utf-8">...</head><body>...</body></html>
+//
+// head should only contain elements that belong in the head of a document,
+// such as the output of PageMetadataHTML and StyleSheetElement, since
+// browsers move other content to the body.
+func DocumentFromParts(head, body HTML, opts ...DocOption) HTML {
+	c := docConfig{charset: "utf-8"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.lang != "" && !langPattern.MatchString(c.lang) {
+		diag.Log("safehtml: dropped invalid document language", "lang", c.lang)
+		c.lang = ""
+	}
+	switch c.dir {
+	case "", "ltr", "rtl", "auto":
+	default:
+		diag.Log("safehtml: dropped invalid document direction", "dir", c.dir)
+		c.dir = ""
+	}
+	if !strings.EqualFold(c.charset, "utf-8") {
+		diag.Log("safehtml: replaced invalid document charset", "charset", c.charset)
+		c.charset = "utf-8"
+	}
+	var w elementWriter
+	w.b.WriteString("<!DOCTYPE html>\n")
+	w.open("html")
+	w.attr("lang", c.lang)
+	w.attr("dir", c.dir)
+	w.closeTag()
+	w.b.WriteString("<head>")
+	w.open("meta")
+	w.attr("charset", c.charset)
+	w.closeTag()
+	w.writeHTML(head)
+	w.b.WriteString("</head><body>")
+	w.writeHTML(body)
+	w.b.WriteString("</body>")
+	w.end("html")
+	return w.html()
+}
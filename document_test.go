@@ -0,0 +1,53 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocumentFromParts(t *testing.T) {
+	head := HTML{"<title>Gopher</title>"}
+	body := HTML{"<p>Hello</p>"}
+	for _, test := range [...]struct {
+		desc string
+		opts []DocOption
+		want string
+	}{
+		{"defaults", nil, "<!DOCTYPE html>\n" + `<html><head><meta charset="utf-8"><title>Gopher</title></head><body><p>Hello</p></body></html>`},
+		{"lang and dir", []DocOption{DocLang("ar-EG"), DocDir("rtl")},
+			"<!DOCTYPE html>\n" + `<html lang="ar-EG" dir="rtl"><head><meta charset="utf-8"><title>Gopher</title></head><body><p>Hello</p></body></html>`},
+		{"charset", []DocOption{DocCharset("UTF-8"), DocLang("zh-Hant-TW")},
+			"<!DOCTYPE html>\n" + `<html lang="zh-Hant-TW"><head><meta charset="UTF-8"><title>Gopher</title></head><body><p>Hello</p></body></html>`},
+		{"other charset", []DocOption{DocCharset("ISO-8859-1")},
+			"<!DOCTYPE html>\n" + `<html><head><meta charset="utf-8"><title>Gopher</title></head><body><p>Hello</p></body></html>`},
+		{"invalid", []DocOption{DocLang(`en"><script>`), DocDir("up"), DocCharset("utf 8")},
+			"<!DOCTYPE html>\n" + `<html><head><meta charset="utf-8"><title>Gopher</title></head><body><p>Hello</p></body></html>`},
+	} {
+		if got := DocumentFromParts(head, body, test.opts...).String(); got != test.want {
+			t.Errorf("%s: DocumentFromParts:\ngot  %q\nwant %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestDocumentFromPartsLogsInvalidOptions(t *testing.T) {
+	var logged []logEntry
+	SetLogger(LoggerFunc(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, logEntry{msg, keyvals})
+	}))
+	defer SetLogger(nil)
+	DocumentFromParts(HTML{}, HTML{}, DocLang("en_US"), DocDir("up"), DocCharset("utf-7"))
+	want := []logEntry{
+		{"safehtml: dropped invalid document language", []interface{}{"lang", "en_US"}},
+		{"safehtml: dropped invalid document direction", []interface{}{"dir", "up"}},
+		{"safehtml: replaced invalid document charset", []interface{}{"charset", "utf-7"}},
+	}
+	if !reflect.DeepEqual(logged, want) {
+		t.Errorf("got %v, want %v", logged, want)
+	}
+}
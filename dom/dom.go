@@ -0,0 +1,484 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package dom provides parsed node trees of safe HTML, for post-processing the
+// output of templates structurally, such as adding attributes to links or
+// asserting that a page has a single h1 element, without breaking the type
+// contract of safehtml.HTML.
+//
+// A Tree can only be modified in ways that keep it safe: attribute values are
+// checked and sanitized as safehtml/template checks and sanitizes the values
+// of actions, text is escaped, and markup can only be inserted as
+// safehtml.HTML values. A Tree can only be serialized back to a
+// safehtml.HTML value.
+package dom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/registry"
+	"github.com/google/safehtml/template"
+	"github.com/google/safehtml/uncheckedconversions"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// A Tree is the parsed node tree of an HTML document or fragment.
+type Tree struct {
+	// root is the document node whose children are the top-level nodes of
+	// the tree. It is not part of the HTML of the tree.
+	root *html.Node
+}
+
+// Parse parses h into a Tree. If h starts with a doctype or an html
+// element, it is parsed as a complete document, which the parser completes
+// with the html, head, and body elements that it omits. Otherwise, it is
+// parsed as the content of a div element.
+func Parse(h safehtml.HTML) (*Tree, error) {
+	s := h.String()
+	if isDocument(s) {
+		root, err := html.Parse(strings.NewReader(s))
+		if err != nil {
+			return nil, err
+		}
+		return &Tree{root}, nil
+	}
+	root := &html.Node{Type: html.DocumentNode}
+	if err := appendHTML(root, fragmentContext, s); err != nil {
+		return nil, err
+	}
+	return &Tree{root}, nil
+}
+
+// Execute executes t with data, as t.ExecuteToHTML does, and parses the output
+// into a Tree.
+func Execute(t *template.Template, data interface{}) (*Tree, error) {
+	h, err := t.ExecuteToHTML(data)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(h)
+}
+
+// ExecuteTemplate executes the template associated with t that has the given
+// name with data, as t.ExecuteTemplateToHTML does, and parses the output into
+// a Tree.
+func ExecuteTemplate(t *template.Template, name string, data interface{}) (*Tree, error) {
+	h, err := t.ExecuteTemplateToHTML(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(h)
+}
+
+// isDocument reports whether s starts with a doctype or an html start tag.
+func isDocument(s string) bool {
+	s = strings.ToLower(strings.TrimLeft(s, " \t\n\f\r"))
+	return strings.HasPrefix(s, "<!doctype") || strings.HasPrefix(s, "<html") && (len(s) == len("<html") || strings.ContainsRune(" \t\n\f\r/>", rune(s[len("<html")])))
+}
+
+// fragmentContext is the element whose content HTML fragments are parsed
+// as.
+var fragmentContext = &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+
+// HTML returns the serialization of t.
+func (t *Tree) HTML() safehtml.HTML {
+	var b strings.Builder
+	for c := t.root.FirstChild; c != nil; c = c.NextSibling {
+		if err := html.Render(&b, c); err != nil {
+			// Rendering only fails for trees that cannot be produced by the
+			// parser or the methods of Node, or if writing fails, which it
+			// does not for a strings.Builder.
+			panic(fmt.Sprintf("dom: cannot render tree: %v", err))
+		}
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(b.String())
+}
+
+// Children returns the top-level nodes of t. For documents, it returns the
+// doctype, if any, and the html element.
+func (t *Tree) Children() []Node {
+	return children(t.root)
+}
+
+// Walk calls f for each node of t in depth-first order. If f returns false,
+// Walk skips the descendants of the node.
+func (t *Tree) Walk(f func(Node) bool) {
+	for c := t.root.FirstChild; c != nil; c = c.NextSibling {
+		walk(c, f)
+	}
+}
+
+func walk(n *html.Node, f func(Node) bool) {
+	if !f(Node{n}) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		// f may remove c.
+		next := c.NextSibling
+		walk(c, f)
+		c = next
+	}
+}
+
+// Elements returns the elements of t with the given tag name, such as "a",
+// in document order.
+func (t *Tree) Elements(tag string) []Node {
+	tag = strings.ToLower(tag)
+	var ret []Node
+	t.Walk(func(n Node) bool {
+		if n.Tag() == tag {
+			ret = append(ret, n)
+		}
+		return true
+	})
+	return ret
+}
+
+// AppendHTML appends the nodes of h, parsed as the content of a div element,
+// to the top-level nodes of t. It returns an error if t is a document, whose
+// content must be appended to one of its elements instead.
+func (t *Tree) AppendHTML(h safehtml.HTML) error {
+	for c := t.root.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "html" {
+			return errors.New("cannot append to a document outside of its html element")
+		}
+	}
+	return appendHTML(t.root, fragmentContext, h.String())
+}
+
+// A Node is an element, text, comment, or doctype node of a Tree. The zero
+// Node is not valid.
+type Node struct {
+	n *html.Node
+}
+
+func children(n *html.Node) []Node {
+	var ret []Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		ret = append(ret, Node{c})
+	}
+	return ret
+}
+
+// IsElement reports whether n is an element.
+func (n Node) IsElement() bool {
+	return n.n.Type == html.ElementNode
+}
+
+// IsText reports whether n is a text node.
+func (n Node) IsText() bool {
+	return n.n.Type == html.TextNode
+}
+
+// Tag returns the lowercase tag name of n, or the empty string if n is not an
+// element.
+func (n Node) Tag() string {
+	if n.n.Type != html.ElementNode {
+		return ""
+	}
+	return n.n.Data
+}
+
+// Attr returns the value of the attribute of n with the given name, and
+// reports whether n has the attribute.
+func (n Node) Attr(name string) (string, bool) {
+	name = strings.ToLower(name)
+	for _, a := range n.n.Attr {
+		if a.Namespace == "" && a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// Attrs returns a copy of the attributes of n, in document order.
+func (n Node) Attrs() []html.Attribute {
+	return append([]html.Attribute(nil), n.n.Attr...)
+}
+
+// Text returns the text of n if it is a text node, or the concatenated text of
+// its descendants if it is an element.
+func (n Node) Text() string {
+	if n.n.Type == html.TextNode {
+		return n.n.Data
+	}
+	var b strings.Builder
+	walk(n.n, func(d Node) bool {
+		if d.n.Type == html.TextNode {
+			b.WriteString(d.n.Data)
+		}
+		return true
+	})
+	return b.String()
+}
+
+// Parent returns the parent of n, and reports whether n has one. Top-level
+// nodes, and nodes that have been removed, have none.
+func (n Node) Parent() (Node, bool) {
+	p := n.n.Parent
+	if p == nil || p.Type == html.DocumentNode {
+		return Node{}, false
+	}
+	return Node{p}, true
+}
+
+// Children returns the child nodes of n.
+func (n Node) Children() []Node {
+	return children(n.n)
+}
+
+// Remove removes n and its descendants from their tree.
+func (n Node) Remove() {
+	if n.n.Parent != nil {
+		n.n.Parent.RemoveChild(n.n)
+	}
+}
+
+// SetAttr sets the value of the attribute of an element n with the given name
+// to value, whose type must be allowed by the contract of the attribute in
+// package registry, as in the actions of a template:
+//   - Attributes without a contract accept string values.
+//   - URL attributes, such as the href of a elements, accept safehtml.URL
+//     values, and strings, which are sanitized. Those that also accept
+//     safehtml.TrustedResourceURL values, such as the src of img elements,
+//     accept them unchanged.
+//   - Attributes that load code, such as the src of script elements, only
+//     accept safehtml.TrustedResourceURL values.
+//   - srcset attributes accept safehtml.URLSet values and sanitized strings.
+//   - Identifier and style attributes, such as id and style, only accept
+//     safehtml.Identifier and safehtml.Style values, and srcdoc attributes
+//     only safehtml.HTML values.
+//   - Attributes that accept a few values, such as dir, only accept strings
+//     with one of those values.
+//
+// SetAttr returns an error if the attribute is not allowed in n, if value does
+// not have an allowed type, or if n is a script, style, or foreign element or
+// the attribute is the rel attribute of a link element, whose attributes
+// determine how the element is interpreted.
+func (n Node) SetAttr(name string, value interface{}) error {
+	name = strings.ToLower(name)
+	if err := checkAttrChange(n, name); err != nil {
+		return err
+	}
+	rel, _ := n.Attr("rel")
+	ctx, ok := (*registry.Registry)(nil).Attribute(n.n.Data, name, rel)
+	if !ok {
+		return fmt.Errorf("attribute %q is not allowed in %q elements", name, n.n.Data)
+	}
+	val, err := attrValue(ctx, value)
+	if err != nil {
+		return fmt.Errorf("attribute %q of %q element: %v", name, n.n.Data, err)
+	}
+	for i, a := range n.n.Attr {
+		if a.Namespace == "" && a.Key == name {
+			n.n.Attr[i].Val = val
+			return nil
+		}
+	}
+	n.n.Attr = append(n.n.Attr, html.Attribute{Key: name, Val: val})
+	return nil
+}
+
+// RemoveAttr removes the attribute of an element n with the given name, if
+// any. Like SetAttr, it returns an error if n is a script, style, or foreign
+// element or the attribute is the rel attribute of a link element.
+func (n Node) RemoveAttr(name string) error {
+	name = strings.ToLower(name)
+	if err := checkAttrChange(n, name); err != nil {
+		return err
+	}
+	attrs := n.n.Attr[:0]
+	for _, a := range n.n.Attr {
+		if a.Namespace != "" || a.Key != name {
+			attrs = append(attrs, a)
+		}
+	}
+	n.n.Attr = attrs
+	return nil
+}
+
+// checkAttrChange returns an error if the attribute of n with the given name
+// cannot be changed.
+func checkAttrChange(n Node, name string) error {
+	switch {
+	case n.n.Type != html.ElementNode:
+		return errors.New("cannot change the attributes of a node that is not an element")
+	case n.n.Namespace != "":
+		return fmt.Errorf("cannot change the attributes of foreign %s element %q", n.n.Namespace, n.n.Data)
+	case n.n.Data == "script" || n.n.Data == "style":
+		return fmt.Errorf("cannot change the attributes of %q elements", n.n.Data)
+	case n.n.Data == "link" && name == "rel":
+		return errors.New(`cannot change the "rel" attribute of "link" elements`)
+	}
+	return nil
+}
+
+// enumValues maps the contexts that accept only a few strings to those
+// strings.
+var enumValues = map[registry.Context][]string{
+	registry.AsyncEnum:   {"async"},
+	registry.DirEnum:     {"auto", "ltr", "rtl"},
+	registry.LoadingEnum: {"eager", "lazy"},
+	registry.TargetEnum:  {"_blank", "_self"},
+}
+
+// attrValue returns the value of an attribute in context ctx for value, which
+// must have a type allowed in ctx.
+func attrValue(ctx registry.Context, value interface{}) (string, error) {
+	switch ctx {
+	case registry.None:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return "", fmt.Errorf("got value of type %T, want string", value)
+	case registry.URL, registry.TrustedResourceURLOrURL:
+		switch v := value.(type) {
+		case safehtml.URL:
+			return v.String(), nil
+		case safehtml.TrustedResourceURL:
+			if ctx == registry.URL {
+				// As in safehtml/template, a TrustedResourceURL is
+				// sanitized like a string where it is not expected.
+				return safehtml.URLSanitized(v.String()).String(), nil
+			}
+			return v.String(), nil
+		case string:
+			return safehtml.URLSanitized(v).String(), nil
+		}
+		return "", fmt.Errorf("got value of type %T, want safehtml.URL or string", value)
+	case registry.TrustedResourceURL:
+		if u, ok := value.(safehtml.TrustedResourceURL); ok {
+			return u.String(), nil
+		}
+		return "", fmt.Errorf("got value of type %T, want safehtml.TrustedResourceURL", value)
+	case registry.URLSet:
+		switch v := value.(type) {
+		case safehtml.URLSet:
+			return v.String(), nil
+		case string:
+			return safehtml.URLSetSanitized(v).String(), nil
+		}
+		return "", fmt.Errorf("got value of type %T, want safehtml.URLSet or string", value)
+	case registry.Identifier:
+		if id, ok := value.(safehtml.Identifier); ok {
+			return id.String(), nil
+		}
+		return "", fmt.Errorf("got value of type %T, want safehtml.Identifier", value)
+	case registry.Style:
+		if s, ok := value.(safehtml.Style); ok {
+			return s.String(), nil
+		}
+		return "", fmt.Errorf("got value of type %T, want safehtml.Style", value)
+	case registry.HTMLValOnly:
+		if h, ok := value.(safehtml.HTML); ok {
+			return h.String(), nil
+		}
+		return "", fmt.Errorf("got value of type %T, want safehtml.HTML", value)
+	case registry.AsyncEnum, registry.DirEnum, registry.LoadingEnum, registry.TargetEnum:
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("got value of type %T, want string", value)
+		}
+		for _, allowed := range enumValues[ctx] {
+			if strings.EqualFold(s, allowed) {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("%q is not one of %q", s, enumValues[ctx])
+	}
+	return "", fmt.Errorf("values in context %s are not supported", ctx)
+}
+
+// SetText sets the text of a text node n to text, which is escaped when the
+// tree is serialized. It returns an error if n is not a text node, or if it is
+// the content of an element such as script whose content is not escaped.
+func (n Node) SetText(text string) error {
+	if n.n.Type != html.TextNode {
+		return errors.New("cannot set the text of a node that is not a text node")
+	}
+	if p := n.n.Parent; p != nil && rawTextElements[p.Data] {
+		return fmt.Errorf("cannot set the text of the content of a %q element", p.Data)
+	}
+	n.n.Data = text
+	return nil
+}
+
+// AppendHTML appends the nodes of h, parsed as the content of n, to the
+// children of an element n. It returns an error if the content of n is not
+// HTML, as for void elements, script elements, and foreign elements such as
+// svg.
+func (n Node) AppendHTML(h safehtml.HTML) error {
+	if err := checkAppend(n.n); err != nil {
+		return err
+	}
+	return appendHTML(n.n, n.n, h.String())
+}
+
+// AppendChild moves c, with its descendants, from its place in its tree,
+// which need not be the tree of n, to the end of the children of an element
+// n. It returns an error if the content of n is not HTML, as for AppendHTML,
+// or if c is n or one of its ancestors.
+func (n Node) AppendChild(c Node) error {
+	if err := checkAppend(n.n); err != nil {
+		return err
+	}
+	for a := n.n; a != nil; a = a.Parent {
+		if a == c.n {
+			return errors.New("cannot append a node to itself or to one of its descendants")
+		}
+	}
+	if c.n.Type == html.DoctypeNode {
+		return errors.New("cannot append a doctype")
+	}
+	c.Remove()
+	n.n.AppendChild(c.n)
+	return nil
+}
+
+// rawTextElements contains the elements whose text content the renderer
+// writes without escaping it.
+var rawTextElements = map[string]bool{
+	"iframe":    true,
+	"noembed":   true,
+	"noframes":  true,
+	"noscript":  true,
+	"plaintext": true,
+	"script":    true,
+	"style":     true,
+	"xmp":       true,
+}
+
+// checkAppend returns an error if nodes cannot be appended to n, because its
+// content is not HTML.
+func checkAppend(n *html.Node) error {
+	if n.Type != html.ElementNode {
+		return errors.New("cannot append to a node that is not an element")
+	}
+	if n.Namespace != "" {
+		return fmt.Errorf("cannot append to foreign %s element %q", n.Namespace, n.Data)
+	}
+	if ctx, ok := (*registry.Registry)(nil).ElementContent(n.Data); !ok || ctx != registry.HTML || rawTextElements[n.Data] {
+		return fmt.Errorf("cannot append to %q elements, whose content is not HTML", n.Data)
+	}
+	return nil
+}
+
+// appendHTML parses s as the content of context, and appends its nodes to
+// the children of n.
+func appendHTML(n, context *html.Node, s string) error {
+	nodes, err := html.ParseFragment(strings.NewReader(s), context)
+	if err != nil {
+		return err
+	}
+	for _, c := range nodes {
+		n.AppendChild(c)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package dom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/template"
+	"github.com/google/safehtml/testconversions"
+)
+
+func mustParse(t *testing.T, s string) *Tree {
+	t.Helper()
+	tree, err := Parse(testconversions.MakeHTMLForTest(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range [...]struct {
+		in, want string
+	}{
+		{"", ""},
+		{`<p class=x>Hello, <b>world</b>`, `<p class="x">Hello, <b>world</b></p>`},
+		{`<td>cell</td>text`, `celltext`},
+		{`<script>if (a < b) {}</script>&lt;`, `<script>if (a < b) {}</script>&lt;`},
+		{`<!DOCTYPE html><title>T</title><p>x`, `<!DOCTYPE html><html><head><title>T</title></head><body><p>x</p></body></html>`},
+		{`<html lang="en"><body>x</body></html>`, `<html lang="en"><head></head><body>x</body></html>`},
+		{`<htmlx>y`, `<htmlx>y</htmlx>`},
+	} {
+		if got := mustParse(t, test.in).HTML().String(); got != test.want {
+			t.Errorf("Parse(%q).HTML() = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`<ul>{{range .}}<li><a href="{{.}}">{{.}}</a></li>{{end}}</ul>`))
+	tree, err := Execute(tmpl, []string{"https://example.com", "javascript:alert(1)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range tree.Elements("A") {
+		if href, _ := a.Attr("href"); strings.HasPrefix(href, "https:") {
+			if err := a.SetAttr("target", "_blank"); err != nil {
+				t.Fatal(err)
+			}
+			if err := a.SetAttr("rel", "noopener"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	want := `<ul><li><a href="https://example.com" target="_blank" rel="noopener">https://example.com</a></li>` +
+		`<li><a href="about:invalid#zGoSafez">javascript:alert(1)</a></li></ul>`
+	if got := tree.HTML().String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := ExecuteTemplate(tmpl, "missing", nil); err == nil {
+		t.Error("expected error for missing template")
+	}
+}
+
+func TestSetAttr(t *testing.T) {
+	for _, test := range [...]struct {
+		in          string
+		name        string
+		value       interface{}
+		want, error string
+	}{
+		{`<p>x</p>`, "title", `"quoted" <title>`, `<p title="&#34;quoted&#34; &lt;title&gt;">x</p>`, ""},
+		{`<a title="a">x</a>`, "TITLE", "b", `<a title="b">x</a>`, ""},
+		{`<a>x</a>`, "href", "javascript:alert(1)", `<a href="about:invalid#zGoSafez">x</a>`, ""},
+		{`<a>x</a>`, "href", safehtml.URLSanitized("/path"), `<a href="/path">x</a>`, ""},
+		{`<img>`, "src", testconversions.MakeTrustedResourceURLForTest("/img.png"), `<img src="/img.png"/>`, ""},
+		{`<img>`, "srcset", "/a.png 1x, javascript:x 2x", `<img srcset="/a.png 1x"/>`, ""},
+		{`<p>x</p>`, "dir", "RTL", `<p dir="rtl">x</p>`, ""},
+		{`<p>x</p>`, "dir", "up", "", `"up" is not one of`},
+		{`<p>x</p>`, "id", "main", "", "want safehtml.Identifier"},
+		{`<p>x</p>`, "id", safehtml.IdentifierFromConstant("main"), `<p id="main">x</p>`, ""},
+		{`<p>x</p>`, "style", "color: red", "", "want safehtml.Style"},
+		{`<p>x</p>`, "onclick", "alert(1)", "", `attribute "onclick" is not allowed`},
+		{`<iframe></iframe>`, "src", "/frame", "", "want safehtml.TrustedResourceURL"},
+		{`<script src="/a.js"></script>`, "async", "async", "", `cannot change the attributes of "script" elements`},
+		{`<link rel="alternate" href="/feed">`, "rel", "stylesheet", "", `cannot change the "rel" attribute`},
+		{`<svg><a></a></svg>`, "href", "/x", "", "cannot change the attributes of foreign svg element"},
+	} {
+		tree := mustParse(t, test.in)
+		var n Node
+		tree.Walk(func(m Node) bool {
+			if m.IsElement() && n == (Node{}) || m.Tag() == "a" {
+				n = m
+			}
+			return true
+		})
+		err := n.SetAttr(test.name, test.value)
+		switch {
+		case test.error != "":
+			if err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("%s: SetAttr(%q, %v): got error %v, want %q", test.in, test.name, test.value, err, test.error)
+			}
+		case err != nil:
+			t.Errorf("%s: SetAttr(%q, %v): %v", test.in, test.name, test.value, err)
+		default:
+			if got := tree.HTML().String(); got != test.want {
+				t.Errorf("%s: SetAttr(%q, %v): got %q, want %q", test.in, test.name, test.value, got, test.want)
+			}
+		}
+	}
+}
+
+func TestModifications(t *testing.T) {
+	tree := mustParse(t, `<div id="a"><p class="x">One</p><p>Two</p></div><div id="b"></div><script>var x;</script>`)
+	divs := tree.Elements("div")
+	ps := tree.Elements("p")
+	if len(divs) != 2 || len(ps) != 2 {
+		t.Fatalf("got %d div and %d p elements, want 2 and 2", len(divs), len(ps))
+	}
+	if err := divs[1].AppendChild(ps[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps[0].AppendChild(divs[1]); err == nil {
+		t.Error("expected error appending an element to its descendant")
+	}
+	ps[1].Remove()
+	if err := divs[0].AppendHTML(testconversions.MakeHTMLForTest(`<b>bold</b>`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps[0].RemoveAttr("class"); err != nil {
+		t.Fatal(err)
+	}
+	text := ps[0].Children()[0]
+	if err := text.SetText("<One>"); err != nil {
+		t.Fatal(err)
+	}
+	script := tree.Elements("script")[0]
+	if err := script.AppendChild(text); err == nil {
+		t.Error("expected error appending to a script element")
+	}
+	if err := script.Children()[0].SetText("alert(1)"); err == nil {
+		t.Error("expected error setting the text of a script element")
+	}
+	if err := script.AppendHTML(testconversions.MakeHTMLForTest("x")); err == nil {
+		t.Error("expected error appending HTML to a script element")
+	}
+	if err := tree.AppendHTML(testconversions.MakeHTMLForTest("<hr>")); err != nil {
+		t.Fatal(err)
+	}
+	want := `<div id="a"><b>bold</b></div><div id="b"><p>&lt;One&gt;</p></div><script>var x;</script><hr/>`
+	if got := tree.HTML().String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if p, ok := ps[0].Parent(); !ok || p != divs[1] {
+		t.Errorf("Parent() = %v, %t, want the second div", p, ok)
+	}
+	if _, ok := divs[0].Parent(); ok {
+		t.Error("top-level element has a parent")
+	}
+	if got, want := divs[1].Text(), "<One>"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+	doc := mustParse(t, `<!DOCTYPE html><p>x`)
+	if err := doc.AppendHTML(testconversions.MakeHTMLForTest("y")); err == nil {
+		t.Error("expected error appending to a document")
+	}
+	if n := len(doc.Children()); n != 2 {
+		t.Errorf("document has %d top-level nodes, want 2", n)
+	}
+}
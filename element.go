@@ -0,0 +1,96 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/safehtml/urlsafe"
+)
+
+// An elementWriter builds the HTML of elements for the element builders of
+// this package. Element and attribute names must be constants chosen by the
+// builder, and attribute values must already be safe in the context of their
+// attribute; elementWriter only escapes them.
+type elementWriter struct {
+	b strings.Builder
+}
+
+// open writes the start of the start tag of the named element.
+func (w *elementWriter) open(name string) {
+	w.b.WriteByte('<')
+	w.b.WriteString(name)
+}
+
+// attr writes an attribute with the given value, or nothing if value is empty.
+func (w *elementWriter) attr(name, value string) {
+	if value == "" {
+		return
+	}
+	w.requiredAttr(name, value)
+}
+
+// requiredAttr writes an attribute with the given value, even if it is empty.
+func (w *elementWriter) requiredAttr(name, value string) {
+	w.b.WriteByte(' ')
+	w.b.WriteString(name)
+	w.b.WriteString(`="`)
+	w.b.WriteString(escapeAndCoerceToInterchangeValid(value))
+	w.b.WriteByte('"')
+}
+
+// urlAttr writes an attribute whose value is the given safe URL, normalized
+// as safehtml/template normalizes URL attribute values, or nothing if url is
+// empty.
+func (w *elementWriter) urlAttr(name, url string) {
+	w.attr(name, urlsafe.NormalizeURL(url))
+}
+
+// intAttr writes an attribute whose value is n, or nothing if n is 0 or less.
+func (w *elementWriter) intAttr(name string, n int) {
+	if n <= 0 {
+		return
+	}
+	w.attr(name, strconv.Itoa(n))
+}
+
+// boolAttr writes a boolean attribute if value is true.
+func (w *elementWriter) boolAttr(name string, value bool) {
+	if !value {
+		return
+	}
+	w.b.WriteByte(' ')
+	w.b.WriteString(name)
+}
+
+// closeTag writes the end of a start tag.
+func (w *elementWriter) closeTag() {
+	w.b.WriteByte('>')
+}
+
+// text writes text content, HTML-escaped.
+func (w *elementWriter) text(s string) {
+	w.b.WriteString(escapeAndCoerceToInterchangeValid(s))
+}
+
+// writeHTML writes safe HTML content.
+func (w *elementWriter) writeHTML(h HTML) {
+	w.b.WriteString(h.str)
+}
+
+// end writes the end tag of the named element.
+func (w *elementWriter) end(name string) {
+	w.b.WriteString("</")
+	w.b.WriteString(name)
+	w.b.WriteByte('>')
+}
+
+// html returns the HTML written to w.
+func (w *elementWriter) html() HTML {
+	return HTML{w.b.String()}
+}
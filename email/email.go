@@ -0,0 +1,342 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package email renders safehtml.HTML values, such as the output of
+// safehtml/template, into HTML for the bodies of email messages.
+//
+// Email clients ignore or remove style elements, scripts, frames, and forms,
+// and support only a subset of HTML, so Render inlines the styles of elements
+// into their style attributes and keeps only the elements and attributes that
+// email clients support:
+//
+//	body, err := email.Render(h,
+//		email.Rule{"p", safehtml.StyleFromConstant("margin: 0 0 16px;")},
+//		email.Rule{"a.button", safehtml.StyleFromConstant("color: #fff; background: #1a73e8;")},
+//	)
+//
+// Markup that cannot work in an email, such as scripts and relative URLs, is
+// a bug in the template that produced it, so Render reports it as an error
+// instead of removing it.
+package email
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// A Rule applies Style to the elements matching Selector.
+//
+// Selector is an element name, one or more class selectors, or an element
+// name followed by class selectors, such as "td", ".footer", or
+// "a.button.primary". Email clients do not support more complex selectors
+// consistently, so Render does not either.
+type Rule struct {
+	Selector string
+	Style    safehtml.Style
+}
+
+// selectorPattern matches the selectors of Rules, and captures their element
+// name and class selectors.
+var selectorPattern = regexp.MustCompile(`^([a-z][a-z0-9]*)?((?:\.[A-Za-z_][A-Za-z0-9_-]*)*)$`)
+
+type selector struct {
+	element string
+	classes []string
+}
+
+func (s selector) matches(n *html.Node) bool {
+	if s.element != "" && s.element != n.Data {
+		return false
+	}
+	if len(s.classes) == 0 {
+		return true
+	}
+	var classes []string
+	for _, a := range n.Attr {
+		if a.Namespace == "" && a.Key == "class" {
+			classes = strings.Fields(a.Val)
+		}
+	}
+	for _, want := range s.classes {
+		found := false
+		for _, c := range classes {
+			if c == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// rejectedElements contains the elements that email clients remove or do not
+// run, and that Render reports as errors.
+var rejectedElements = map[string]bool{
+	"applet":   true,
+	"audio":    true,
+	"base":     true,
+	"button":   true,
+	"embed":    true,
+	"form":     true,
+	"frame":    true,
+	"frameset": true,
+	"iframe":   true,
+	"input":    true,
+	"link":     true,
+	"meta":     true,
+	"object":   true,
+	"script":   true,
+	"select":   true,
+	"style":    true,
+	"textarea": true,
+	"video":    true,
+}
+
+// droppedElements contains the elements that Render removes with their
+// content, because email clients would show their content as text or not at
+// all.
+var droppedElements = map[string]bool{
+	"noscript": true,
+	"template": true,
+	"title":    true,
+}
+
+// globalAttrs contains the attributes that Render keeps in all elements.
+var globalAttrs = map[string]bool{
+	"align":   true,
+	"bgcolor": true,
+	"class":   true,
+	"dir":     true,
+	"lang":    true,
+	"role":    true,
+	"style":   true,
+	"title":   true,
+	"valign":  true,
+}
+
+// elements contains the elements that Render keeps, and the attributes other
+// than globalAttrs that it keeps in them.
+var elements = map[string][]string{
+	"a":          {"href", "name", "target"},
+	"abbr":       nil,
+	"b":          nil,
+	"blockquote": nil,
+	"br":         nil,
+	"caption":    nil,
+	"center":     nil,
+	"code":       nil,
+	"col":        {"span", "width"},
+	"colgroup":   {"span", "width"},
+	"dd":         nil,
+	"del":        nil,
+	"div":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"font":       {"color", "face", "size"},
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         {"width"},
+	"i":          nil,
+	"img":        {"alt", "border", "height", "src", "width"},
+	"ins":        nil,
+	"li":         nil,
+	"ol":         {"start", "type"},
+	"p":          nil,
+	"pre":        nil,
+	"s":          nil,
+	"small":      nil,
+	"span":       nil,
+	"strike":     nil,
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      {"border", "cellpadding", "cellspacing", "height", "width"},
+	"tbody":      nil,
+	"td":         {"colspan", "height", "rowspan", "width"},
+	"tfoot":      nil,
+	"th":         {"colspan", "height", "rowspan", "scope", "width"},
+	"thead":      nil,
+	"tr":         {"height"},
+	"u":          nil,
+	"ul":         {"type"},
+}
+
+// voidElements contains the elements of elements that have no end tag.
+var voidElements = map[string]bool{
+	"br":  true,
+	"col": true,
+	"hr":  true,
+	"img": true,
+}
+
+// absoluteURLPattern matches the URLs that email clients can load and
+// navigate to, which must be absolute since a message has no base URL.
+var absoluteURLPattern = regexp.MustCompile(`(?i)^(?:https?://|mailto:|tel:|cid:)`)
+
+// Render returns h as HTML for the body of an email message, with the styles
+// of rules inlined into the style attributes of the elements they match.
+//
+// Styles are inlined in the order of rules, followed by the element's own
+// style attribute, so that later rules and the element's own style take
+// precedence, as in a style sheet. Elements that email clients do not
+// support, such as section and nav, are replaced by their content, SVG and
+// MathML content is removed, and attributes that they do not support, such as
+// id, are removed.
+//
+// Render returns an error if a selector of rules is not supported, or if h
+// contains elements that email clients remove or do not run, such as
+// scripts, iframes, style elements, and forms, or relative URLs, which email
+// clients cannot resolve.
+func Render(h safehtml.HTML, rules ...Rule) (safehtml.HTML, error) {
+	sels := make([]selector, len(rules))
+	for i, r := range rules {
+		m := selectorPattern.FindStringSubmatch(r.Selector)
+		if m == nil || r.Selector == "" {
+			return safehtml.HTML{}, fmt.Errorf("email: selector %q is not supported", r.Selector)
+		}
+		sels[i] = selector{element: m[1], classes: strings.FieldsFunc(m[2], func(r rune) bool { return r == '.' })}
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(h.String()), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return safehtml.HTML{}, err
+	}
+	r := renderer{rules: rules, sels: sels}
+	for _, n := range nodes {
+		if err := r.render(n); err != nil {
+			return safehtml.HTML{}, err
+		}
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(r.b.String()), nil
+}
+
+type renderer struct {
+	b     strings.Builder
+	rules []Rule
+	sels  []selector
+}
+
+func (r *renderer) render(n *html.Node) error {
+	switch n.Type {
+	case html.TextNode:
+		r.b.WriteString(html.EscapeString(n.Data))
+		return nil
+	case html.ElementNode:
+	default:
+		// Drop comments, which some email clients show.
+		return nil
+	}
+	switch {
+	case n.Namespace != "" || droppedElements[n.Data]:
+		return nil
+	case rejectedElements[n.Data]:
+		return fmt.Errorf("email: %s elements are not supported in email", n.Data)
+	case !hasElement(n.Data):
+		return r.renderChildren(n)
+	}
+	r.b.WriteByte('<')
+	r.b.WriteString(n.Data)
+	var style string
+	for _, a := range n.Attr {
+		if a.Namespace != "" || !isAttrAllowed(n.Data, a.Key) {
+			continue
+		}
+		switch a.Key {
+		case "style":
+			style = a.Val
+			continue
+		case "href", "src":
+			if !absoluteURLPattern.MatchString(strings.TrimSpace(a.Val)) {
+				return fmt.Errorf("email: %s URL %q of %s element is not absolute", a.Key, a.Val, n.Data)
+			}
+		}
+		r.writeAttr(a.Key, a.Val)
+	}
+	if style = r.style(n, style); style != "" {
+		r.writeAttr("style", style)
+	}
+	r.b.WriteByte('>')
+	if voidElements[n.Data] {
+		return nil
+	}
+	if n.Data == "pre" && n.FirstChild != nil && n.FirstChild.Type == html.TextNode && strings.HasPrefix(n.FirstChild.Data, "\n") {
+		// The parser drops a newline immediately following a pre start tag,
+		// so preserve the newline that the content starts with.
+		r.b.WriteByte('\n')
+	}
+	if err := r.renderChildren(n); err != nil {
+		return err
+	}
+	r.b.WriteString("</")
+	r.b.WriteString(n.Data)
+	r.b.WriteByte('>')
+	return nil
+}
+
+func (r *renderer) renderChildren(n *html.Node) error {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if err := r.render(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) writeAttr(key, val string) {
+	r.b.WriteByte(' ')
+	r.b.WriteString(key)
+	r.b.WriteString(`="`)
+	r.b.WriteString(html.EscapeString(val))
+	r.b.WriteByte('"')
+}
+
+// style returns the style attribute of n, the styles of the rules matching n
+// followed by own, the style attribute of n in the rendered markup.
+func (r *renderer) style(n *html.Node, own string) string {
+	var b strings.Builder
+	for i, sel := range r.sels {
+		if sel.matches(n) {
+			b.WriteString(r.rules[i].Style.String())
+		}
+	}
+	if own = strings.TrimSpace(own); own != "" {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), ";") {
+			b.WriteByte(';')
+		}
+		b.WriteString(own)
+	}
+	return b.String()
+}
+
+func hasElement(name string) bool {
+	_, ok := elements[name]
+	return ok
+}
+
+func isAttrAllowed(element, attr string) bool {
+	if globalAttrs[attr] {
+		return true
+	}
+	for _, a := range elements[element] {
+		if a == attr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package email
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/testconversions"
+)
+
+func TestRender(t *testing.T) {
+	rules := []Rule{
+		{"p", safehtml.StyleFromConstant("margin: 0;")},
+		{".note", safehtml.StyleFromConstant("color: gray;")},
+		{"a.button.primary", safehtml.StyleFromConstant("background: blue;")},
+	}
+	for _, test := range [...]struct {
+		desc, in, want string
+	}{
+		{"element rule", `<p>Hi</p>`, `<p style="margin: 0;">Hi</p>`},
+		{"class rule", `<span class="a note">x</span>`, `<span class="a note" style="color: gray;">x</span>`},
+		{"rules in order", `<p class="note">x</p>`, `<p class="note" style="margin: 0;color: gray;">x</p>`},
+		{"own style last", `<p style="margin: 4px">x</p>`, `<p style="margin: 0;margin: 4px">x</p>`},
+		{"compound selector", `<a class="button" href="https://a.example/">x</a><a class="primary button" href="https://a.example/">y</a>`,
+			`<a class="button" href="https://a.example/">x</a><a class="primary button" href="https://a.example/" style="background: blue;">y</a>`},
+		{"table attributes", `<table cellpadding="0" width="600" id="t"><tr><td valign="top" colspan="2">x</td></tr></table>`,
+			`<table cellpadding="0" width="600"><tbody><tr><td valign="top" colspan="2">x</td></tr></tbody></table>`},
+		{"unsupported elements", `<section><nav>a</nav></section><svg><text>b</text></svg>`, `a`},
+		{"comments", `a<!-- note -->b`, `ab`},
+		{"void elements", `<img src="cid:logo" alt="Logo"><br>`, `<img src="cid:logo" alt="Logo"><br>`},
+		{"pre", "<pre>\n\nx</pre>", "<pre>\n\nx</pre>"},
+		{"text", `a &lt;b&gt; &amp; c`, `a &lt;b&gt; &amp; c`},
+	} {
+		got, err := Render(testconversions.MakeHTMLForTest(test.in), rules...)
+		if err != nil {
+			t.Errorf("%s: Render(%q): unexpected error: %v", test.desc, test.in, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: Render(%q):\ngot  %q\nwant %q", test.desc, test.in, got, test.want)
+		}
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		in    string
+		rules []Rule
+		err   string
+	}{
+		{`<p>a<script>alert(1)</script></p>`, nil, "script elements are not supported"},
+		{`<iframe src="https://a.example/"></iframe>`, nil, "iframe elements are not supported"},
+		{`<style>p { color: red }</style>`, nil, "style elements are not supported"},
+		{`<form><input name="q"></form>`, nil, "form elements are not supported"},
+		{`<a href="/account">x</a>`, nil, `href URL "/account" of a element is not absolute`},
+		{`<img src="logo.png">`, nil, `src URL "logo.png" of img element is not absolute`},
+		{`<p>x</p>`, []Rule{{"div p", safehtml.StyleFromConstant("margin: 0;")}}, `selector "div p" is not supported`},
+		{`<p>x</p>`, []Rule{{"", safehtml.StyleFromConstant("margin: 0;")}}, `selector "" is not supported`},
+	} {
+		_, err := Render(testconversions.MakeHTMLForTest(test.in), test.rules...)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Render(%q): got error %v, want error containing %q", test.in, err, test.err)
+		}
+	}
+}
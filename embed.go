@@ -0,0 +1,162 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// VideoEmbedOptions are the options of an embedded video player.
+type VideoEmbedOptions struct {
+	// Width and Height are the optional dimensions of the player in pixels.
+	// Dimensions that are 0 or less are omitted.
+	Width, Height int
+	// Title is the accessible name of the player's iframe, such as the title
+	// of the video.
+	Title string
+	// Autoplay starts playing the video when the player loads. Most
+	// browsers only allow muted videos to play automatically.
+	Autoplay bool
+	// Muted mutes the video.
+	Muted bool
+	// Start is the optional offset in seconds at which the video starts.
+	Start int
+	// PrivacyEnhanced embeds YouTube videos from www.youtube-nocookie.com,
+	// which does not store cookies until the video is played. It has no
+	// effect on Vimeo videos.
+	PrivacyEnhanced bool
+}
+
+var (
+	// youTubeVideoIDPattern matches YouTube video IDs, which consist of 11
+	// characters of the base64url alphabet.
+	youTubeVideoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	// vimeoVideoIDPattern matches Vimeo video IDs, which are numeric.
+	vimeoVideoIDPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+)
+
+// YouTubeEmbedURL returns the URL of the YouTube player for the video with the
+// given ID. It returns an error if id is not a well-formed YouTube video ID.
+func YouTubeEmbedURL(id string, opts VideoEmbedOptions) (TrustedResourceURL, error) {
+	if !youTubeVideoIDPattern.MatchString(id) {
+		return TrustedResourceURL{}, fmt.Errorf("%q is not a valid YouTube video ID", id)
+	}
+	format := `https://www.youtube.com/embed/%{id}`
+	if opts.PrivacyEnhanced {
+		format = `https://www.youtube-nocookie.com/embed/%{id}`
+	}
+	u, err := trustedResourceURLFormat(format, map[string]string{"id": id})
+	if err != nil {
+		return TrustedResourceURL{}, err
+	}
+	return TrustedResourceURLWithParams(u, embedParams(opts, "mute", "start")), nil
+}
+
+// YouTubeEmbedHTML returns an iframe element that embeds the YouTube player
+// for the video with the given ID. The iframe is sandboxed, and only allows the
+// features that the player needs. It returns an error if id is not a
+// well-formed YouTube video ID.
+func YouTubeEmbedHTML(id string, opts VideoEmbedOptions) (HTML, error) {
+	u, err := YouTubeEmbedURL(id, opts)
+	if err != nil {
+		return HTML{}, err
+	}
+	return embedIframe(u, opts, "accelerometer; autoplay; clipboard-write; encrypted-media; gyroscope; picture-in-picture; fullscreen"), nil
+}
+
+// VimeoEmbedURL returns the URL of the Vimeo player for the video with the
+// given ID. It returns an error if id is not a well-formed Vimeo video ID.
+func VimeoEmbedURL(id string, opts VideoEmbedOptions) (TrustedResourceURL, error) {
+	if !vimeoVideoIDPattern.MatchString(id) {
+		return TrustedResourceURL{}, fmt.Errorf("%q is not a valid Vimeo video ID", id)
+	}
+	u, err := trustedResourceURLFormat(`https://player.vimeo.com/video/%{id}`, map[string]string{"id": id})
+	if err != nil {
+		return TrustedResourceURL{}, err
+	}
+	u = TrustedResourceURLWithParams(u, embedParams(opts, "muted", ""))
+	if opts.Start > 0 {
+		// Vimeo takes the start offset in the fragment.
+		u.str += "#t=" + strconv.Itoa(opts.Start) + "s"
+	}
+	return u, nil
+}
+
+// VimeoEmbedHTML returns an iframe element that embeds the Vimeo player for the
+// video with the given ID. The iframe is sandboxed, and only allows the
+// features that the player needs. It returns an error if id is not a
+// well-formed Vimeo video ID.
+func VimeoEmbedHTML(id string, opts VideoEmbedOptions) (HTML, error) {
+	u, err := VimeoEmbedURL(id, opts)
+	if err != nil {
+		return HTML{}, err
+	}
+	return embedIframe(u, opts, "autoplay; fullscreen; picture-in-picture"), nil
+}
+
+// embedParams returns the query parameters of a video player for opts, given
+// the names of the player's mute and start parameters. If start is empty, the
+// start offset is not passed as a query parameter.
+func embedParams(opts VideoEmbedOptions, mute, start string) map[string]string {
+	params := make(map[string]string)
+	if opts.Autoplay {
+		params["autoplay"] = "1"
+	}
+	if opts.Muted {
+		params[mute] = "1"
+	}
+	if start != "" && opts.Start > 0 {
+		params[start] = strconv.Itoa(opts.Start)
+	}
+	return params
+}
+
+// embedSandbox contains the sandbox tokens that embedded video players need:
+// scripts to play the video, their own origin to store preferences, the
+// fullscreen presentation, and popups to open the video on the provider's
+// site.
+const embedSandbox = "allow-scripts allow-same-origin allow-presentation allow-popups allow-popups-to-escape-sandbox"
+
+// An iframeSpec describes the iframe element of an embed.
+type iframeSpec struct {
+	src             TrustedResourceURL
+	width, height   int
+	title           string
+	sandbox, allow  string
+	allowFullscreen bool
+}
+
+func (s iframeSpec) html() HTML {
+	var w elementWriter
+	w.open("iframe")
+	w.urlAttr("src", s.src.String())
+	w.intAttr("width", s.width)
+	w.intAttr("height", s.height)
+	w.attr("title", s.title)
+	w.attr("sandbox", s.sandbox)
+	w.attr("allow", s.allow)
+	w.boolAttr("allowfullscreen", s.allowFullscreen)
+	w.attr("referrerpolicy", "strict-origin-when-cross-origin")
+	w.attr("loading", "lazy")
+	w.closeTag()
+	w.end("iframe")
+	return w.html()
+}
+
+func embedIframe(src TrustedResourceURL, opts VideoEmbedOptions, allow string) HTML {
+	return iframeSpec{
+		src:             src,
+		width:           opts.Width,
+		height:          opts.Height,
+		title:           opts.Title,
+		sandbox:         embedSandbox,
+		allow:           allow,
+		allowFullscreen: true,
+	}.html()
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVideoEmbedURL(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		f    func(string, VideoEmbedOptions) (TrustedResourceURL, error)
+		id   string
+		opts VideoEmbedOptions
+		want string
+		err  string
+	}{
+		{"YouTube", YouTubeEmbedURL, "dQw4w9WgXcQ", VideoEmbedOptions{}, "https://www.youtube.com/embed/dQw4w9WgXcQ", ""},
+		{
+			"YouTube with options", YouTubeEmbedURL, "a-b_c0d1e2f",
+			VideoEmbedOptions{Autoplay: true, Muted: true, Start: 90, PrivacyEnhanced: true},
+			"https://www.youtube-nocookie.com/embed/a-b_c0d1e2f?autoplay=1&mute=1&start=90", "",
+		},
+		{"YouTube ID with path", YouTubeEmbedURL, "../../x?y=z", VideoEmbedOptions{}, "", "is not a valid YouTube video ID"},
+		{"YouTube ID too short", YouTubeEmbedURL, "abc", VideoEmbedOptions{}, "", "is not a valid YouTube video ID"},
+		{"Vimeo", VimeoEmbedURL, "76979871", VideoEmbedOptions{}, "https://player.vimeo.com/video/76979871", ""},
+		{
+			"Vimeo with options", VimeoEmbedURL, "76979871",
+			VideoEmbedOptions{Autoplay: true, Muted: true, Start: 30, PrivacyEnhanced: true},
+			"https://player.vimeo.com/video/76979871?autoplay=1&muted=1#t=30s", "",
+		},
+		{"Vimeo ID not numeric", VimeoEmbedURL, "7697x", VideoEmbedOptions{}, "", "is not a valid Vimeo video ID"},
+	} {
+		got, err := test.f(test.id, test.opts)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: got error %v, want error containing %q", test.desc, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestYouTubeEmbedHTML(t *testing.T) {
+	got, err := YouTubeEmbedHTML("dQw4w9WgXcQ", VideoEmbedOptions{Width: 560, Height: 315, Title: `"Video" <1>`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" width="560" height="315" title="&#34;Video&#34; &lt;1&gt;"` +
+		` sandbox="allow-scripts allow-same-origin allow-presentation allow-popups allow-popups-to-escape-sandbox"` +
+		` allow="accelerometer; autoplay; clipboard-write; encrypted-media; gyroscope; picture-in-picture; fullscreen"` +
+		` allowfullscreen referrerpolicy="strict-origin-when-cross-origin" loading="lazy"></iframe>`
+	if got.String() != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+	if _, err := YouTubeEmbedHTML(`"><script>`, VideoEmbedOptions{}); err == nil {
+		t.Error("expected error for invalid video ID")
+	}
+}
+
+func TestVimeoEmbedHTML(t *testing.T) {
+	got, err := VimeoEmbedHTML("76979871", VideoEmbedOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<iframe src="https://player.vimeo.com/video/76979871" sandbox=`; !strings.HasPrefix(got.String(), want) {
+		t.Errorf("got %q, want prefix %q", got, want)
+	}
+	if want := ` allow="autoplay; fullscreen; picture-in-picture" `; !strings.Contains(got.String(), want) {
+		t.Errorf("got %q, want it to contain %q", got, want)
+	}
+}
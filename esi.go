@@ -0,0 +1,38 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+// ESIIncludeOptions are the options of an Edge Side Includes include tag.
+type ESIIncludeOptions struct {
+	// Alt is the optional URL of the resource to include if the resource at
+	// the src of the tag cannot be fetched.
+	Alt TrustedResourceURL
+	// ContinueOnError makes the ESI processor remove the tag, rather than
+	// fail to assemble the page, if no resource can be fetched.
+	ContinueOnError bool
+}
+
+// ESIInclude returns an Edge Side Includes include tag, which ESI processors
+// such as CDNs and caches replace with the resource at src when they
+// assemble the page:
+//
+//	<esi:include src="https://fragments.example.com/header" onerror="continue"/>
+//
+// src and opts.Alt must be TrustedResourceURLs, since the included resources
+// become part of the page. esi:include tags in templates accept the same
+// values in their src and alt attributes.
+func ESIInclude(src TrustedResourceURL, opts ESIIncludeOptions) HTML {
+	var w elementWriter
+	w.open("esi:include")
+	w.requiredAttr("src", src.str)
+	w.attr("alt", opts.Alt.str)
+	if opts.ContinueOnError {
+		w.attr("onerror", "continue")
+	}
+	w.b.WriteString("/>")
+	return w.html()
+}
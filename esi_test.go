@@ -0,0 +1,32 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import "testing"
+
+func TestESIInclude(t *testing.T) {
+	for _, test := range [...]struct {
+		src  TrustedResourceURL
+		opts ESIIncludeOptions
+		want string
+	}{
+		{
+			TrustedResourceURLFromConstant("/fragments/header?a=1&b=2"),
+			ESIIncludeOptions{},
+			`<esi:include src="/fragments/header?a=1&amp;b=2"/>`,
+		},
+		{
+			TrustedResourceURLFromConstant("https://fragments.example.com/nav"),
+			ESIIncludeOptions{Alt: TrustedResourceURLFromConstant("/fallback/nav"), ContinueOnError: true},
+			`<esi:include src="https://fragments.example.com/nav" alt="/fallback/nav" onerror="continue"/>`,
+		},
+	} {
+		if got := ESIInclude(test.src, test.opts).String(); got != test.want {
+			t.Errorf("ESIInclude(%q, %+v) = %q, want %q", test.src, test.opts, got, test.want)
+		}
+	}
+}
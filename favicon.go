@@ -0,0 +1,100 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// A FaviconBundle describes the favicons, touch icon, and web app manifest of
+// a site, which are served from a common directory with the conventional file
+// names:
+//
+//	favicon-16x16.png, favicon-32x32.png, ...
+//	apple-touch-icon.png
+//	site.webmanifest
+type FaviconBundle struct {
+	// Base is the URL of the directory containing the icons, such as
+	// "/static/icons/". It must have one of the prefixes allowed by
+	// TrustedResourceURLAppend.
+	Base TrustedResourceURL
+	// Sizes are the sizes in pixels of the square PNG favicons.
+	Sizes []int
+	// AppleTouchIconSize is the size in pixels of apple-touch-icon.png, or
+	// 0 if there is none.
+	AppleTouchIconSize int
+	// Manifest reports whether there is a site.webmanifest.
+	Manifest bool
+	// ThemeColor is the theme color of the site, a hexadecimal color such as
+	// "#ffffff", or empty for none.
+	ThemeColor string
+}
+
+// hexColorPattern matches hexadecimal CSS colors.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// FaviconBundleHTML returns the link and meta elements referencing the icons
+// of b, for the head of a document:
+//
+//	<link rel="icon" href="/static/icons/favicon-32x32.png" type="image/png" sizes="32x32">
+//	<link rel="apple-touch-icon" href="/static/icons/apple-touch-icon.png" sizes="180x180">
+//	<link rel="manifest" href="/static/icons/site.webmanifest">
+//	<meta name="theme-color" content="#ffffff">
+//
+// It returns an error if b.Base has a disallowed prefix, a size is not
+// positive, or b.ThemeColor is not a hexadecimal color.
+func FaviconBundleHTML(b FaviconBundle) (HTML, error) {
+	if b.ThemeColor != "" && !hexColorPattern.MatchString(b.ThemeColor) {
+		return HTML{}, fmt.Errorf("%q is not a hexadecimal color", b.ThemeColor)
+	}
+	var links []HTML
+	link := func(rel Rel, file string, opts LinkOptions) error {
+		href, err := TrustedResourceURLAppend(b.Base, file)
+		if err != nil {
+			return err
+		}
+		l, err := LinkHTML(rel, href, opts)
+		links = append(links, l)
+		return err
+	}
+	for _, size := range b.Sizes {
+		if size <= 0 {
+			return HTML{}, fmt.Errorf("invalid favicon size %d", size)
+		}
+		s := strconv.Itoa(size)
+		if err := link(RelIcon, "favicon-"+s+"x"+s+".png", LinkOptions{Type: "image/png", Sizes: s + "x" + s}); err != nil {
+			return HTML{}, err
+		}
+	}
+	if size := b.AppleTouchIconSize; size != 0 {
+		if size < 0 {
+			return HTML{}, fmt.Errorf("invalid apple-touch-icon size %d", size)
+		}
+		s := strconv.Itoa(size)
+		if err := link("apple-touch-icon", "apple-touch-icon.png", LinkOptions{Sizes: s + "x" + s}); err != nil {
+			return HTML{}, err
+		}
+	}
+	if b.Manifest {
+		if err := link(RelManifest, "site.webmanifest", LinkOptions{}); err != nil {
+			return HTML{}, err
+		}
+	}
+	var w elementWriter
+	for _, l := range links {
+		w.writeHTML(l)
+	}
+	if b.ThemeColor != "" {
+		w.open("meta")
+		w.attr("name", "theme-color")
+		w.attr("content", b.ThemeColor)
+		w.closeTag()
+	}
+	return w.html(), nil
+}
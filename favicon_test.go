@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestFaviconBundleHTML(t *testing.T) {
+	got, err := FaviconBundleHTML(FaviconBundle{
+		Base:               TrustedResourceURLFromConstant("/static/icons/"),
+		Sizes:              []int{16, 32},
+		AppleTouchIconSize: 180,
+		Manifest:           true,
+		ThemeColor:         "#1a73e8",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<link rel="icon" href="/static/icons/favicon-16x16.png" type="image/png" sizes="16x16">` +
+		`<link rel="icon" href="/static/icons/favicon-32x32.png" type="image/png" sizes="32x32">` +
+		`<link rel="apple-touch-icon" href="/static/icons/apple-touch-icon.png" sizes="180x180">` +
+		`<link rel="manifest" href="/static/icons/site.webmanifest">` +
+		`<meta name="theme-color" content="#1a73e8">`
+	if got.String() != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
+
+func TestFaviconBundleHTMLErrors(t *testing.T) {
+	base := TrustedResourceURLFromConstant("/static/icons/")
+	for _, b := range []FaviconBundle{
+		{Base: TrustedResourceURLFromConstant("javascript:"), Sizes: []int{16}},
+		{Base: base, Sizes: []int{0}},
+		{Base: base, AppleTouchIconSize: -1},
+		{Base: base, ThemeColor: "red;x:y"},
+	} {
+		if _, err := FaviconBundleHTML(b); err == nil {
+			t.Errorf("FaviconBundleHTML(%+v) succeeded, want error", b)
+		}
+	}
+}
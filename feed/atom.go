@@ -0,0 +1,118 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  *atomAuthor `xml:"author"`
+	Summary string      `xml:"subtitle,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published,omitempty"`
+	Link      *atomLink   `xml:"link"`
+	Author    *atomAuthor `xml:"author"`
+	Summary   *atomText   `xml:"summary"`
+	Content   *atomText   `xml:"content"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// atomText is an Atom text construct holding escaped HTML.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// atomTime formats t as an Atom date, or returns "" if t is zero.
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func atomAuthorOf(name string) *atomAuthor {
+	if name == "" {
+		return nil
+	}
+	return &atomAuthor{name}
+}
+
+func atomHTML(s string) *atomText {
+	if s == "" {
+		return nil
+	}
+	return &atomText{Type: "html", Value: s}
+}
+
+// WriteAtom writes f to w as an Atom feed. It returns an error if f has no
+// title or link, if an item has no title or content, if an item has no ID or
+// link, if f or an item has no updated or published time, which Atom
+// requires, or if writing to w fails.
+func (f *Feed) WriteAtom(w io.Writer) error {
+	if err := f.check(); err != nil {
+		return err
+	}
+	updated := f.updated()
+	if updated.IsZero() {
+		return fmt.Errorf("feed: feed has no updated time")
+	}
+	doc := atomFeed{
+		Title:   f.Title,
+		ID:      f.id(),
+		Updated: atomTime(updated),
+		Link:    atomLink{Href: f.Link.String(), Rel: "alternate"},
+		Author:  atomAuthorOf(f.Author),
+		Summary: f.Description,
+	}
+	for i := range f.Items {
+		it := &f.Items[i]
+		if it.id() == "" {
+			return fmt.Errorf("feed: item %d has no ID or link", i)
+		}
+		if it.updated().IsZero() {
+			return fmt.Errorf("feed: item %d has no updated or published time", i)
+		}
+		entry := atomEntry{
+			Title:     it.Title,
+			ID:        it.id(),
+			Updated:   atomTime(it.updated()),
+			Published: atomTime(it.Published),
+			Author:    atomAuthorOf(it.Author),
+			Summary:   atomHTML(f.summary(it).String()),
+			Content:   atomHTML(it.Content.String()),
+		}
+		if link := it.Link.String(); link != "" {
+			entry.Link = &atomLink{Href: link}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return writeXML(w, doc)
+}
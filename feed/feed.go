@@ -0,0 +1,144 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package feed writes RSS and Atom feeds whose items have safehtml.HTML
+// content, such as the output of safehtml/template or safehtml/sanitizer.
+//
+// Feeds are written with encoding/xml, which escapes all text, including the
+// HTML content of items, and replaces the characters that XML does not allow,
+// so feeds are well-formed whatever their titles and content, unlike feeds
+// built by formatting strings:
+//
+//	f := &feed.Feed{
+//		Title: "Gopher News",
+//		Link:  safehtml.URLSanitized("https://example.com/"),
+//		Items: []feed.Item{{
+//			Title:     "Hello",
+//			Link:      safehtml.URLSanitized("https://example.com/hello"),
+//			Published: time.Now(),
+//			Content:   content,
+//		}},
+//		SummaryLength: 280,
+//	}
+//	err := f.WriteAtom(w)
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/safehtml"
+)
+
+// A Feed is an RSS or Atom feed.
+type Feed struct {
+	// Title is the title of the feed.
+	Title string
+	// Link is the URL of the site or page that the feed belongs to.
+	Link safehtml.URL
+	// Description is the optional description of the feed.
+	Description string
+	// ID is the permanent unique identifier of an Atom feed, such as a URL or
+	// a tag URI. It defaults to Link.
+	ID string
+	// Updated is the time the feed last changed. It defaults to the latest
+	// time an item was updated or published.
+	Updated time.Time
+	// Author is the name of the author of the feed. Atom requires an author
+	// for the feed or for each of its items.
+	Author string
+	// Items are the items of the feed.
+	Items []Item
+	// SummaryLength, if greater than 0, is the number of runes of text that
+	// the content of items without a Summary is truncated to, with
+	// safehtml.HTMLTruncate, to summarize them. It is ignored in builds with
+	// the safehtml_minimal tag, which exclude HTMLTruncate.
+	SummaryLength int
+}
+
+// An Item is an item of a feed, such as an article.
+type Item struct {
+	// Title is the title of the item.
+	Title string
+	// Link is the URL of the item.
+	Link safehtml.URL
+	// ID is the permanent unique identifier of the item. It defaults to Link.
+	ID string
+	// Published is the time the item was first published.
+	Published time.Time
+	// Updated is the time the item last changed. It defaults to Published.
+	Updated time.Time
+	// Author is the optional name of the author of the item.
+	Author string
+	// Summary is the optional summary of the item.
+	Summary safehtml.HTML
+	// Content is the optional full content of the item.
+	Content safehtml.HTML
+}
+
+func (f *Feed) id() string {
+	if f.ID != "" {
+		return f.ID
+	}
+	return f.Link.String()
+}
+
+func (f *Feed) updated() time.Time {
+	if !f.Updated.IsZero() {
+		return f.Updated
+	}
+	var t time.Time
+	for i := range f.Items {
+		if u := f.Items[i].updated(); u.After(t) {
+			t = u
+		}
+	}
+	return t
+}
+
+func (f *Feed) check() error {
+	if f.Title == "" {
+		return fmt.Errorf("feed: feed has no title")
+	}
+	if f.Link.String() == "" {
+		return fmt.Errorf("feed: feed has no link")
+	}
+	for i := range f.Items {
+		if f.Items[i].Title == "" && f.Items[i].Summary.String() == "" && f.Items[i].Content.String() == "" {
+			return fmt.Errorf("feed: item %d has no title or content", i)
+		}
+	}
+	return nil
+}
+
+func (it *Item) id() string {
+	if it.ID != "" {
+		return it.ID
+	}
+	return it.Link.String()
+}
+
+func (it *Item) updated() time.Time {
+	if !it.Updated.IsZero() {
+		return it.Updated
+	}
+	return it.Published
+}
+
+// writeXML writes v to w as an XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/testconversions"
+)
+
+func testFeed() *Feed {
+	published := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &Feed{
+		Title:       "Tom & Jerry's <News>",
+		Link:        safehtml.URLSanitized("https://example.com/?a=1&b=2"),
+		Description: "News\x00 feed",
+		Author:      "Gopher",
+		Items: []Item{
+			{
+				Title:     "First",
+				Link:      safehtml.URLSanitized("https://example.com/first"),
+				Published: published,
+				Content:   testconversions.MakeHTMLForTest(`<p>Hello <b>world</b> &amp; all</p>`),
+			},
+			{
+				Title:     "Second",
+				ID:        "tag:example.com,2026:second",
+				Published: published.Add(time.Hour),
+				Summary:   testconversions.MakeHTMLForTest(`<i>short</i>`),
+				Content:   testconversions.MakeHTMLForTest(`<p>long</p>`),
+			},
+		},
+		SummaryLength: 5,
+	}
+}
+
+func TestWriteRSS(t *testing.T) {
+	var b strings.Builder
+	if err := testFeed().WriteRSS(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+		`<channel><title>Tom &amp; Jerry&#39;s &lt;News&gt;</title><link>https://example.com/?a=1&amp;b=2</link>` +
+		`<description>News` + "�" + ` feed</description><lastBuildDate>Fri, 02 Jan 2026 04:04:05 +0000</lastBuildDate>` +
+		`<item><title>First</title><link>https://example.com/first</link><guid isPermaLink="true">https://example.com/first</guid>` +
+		`<pubDate>Fri, 02 Jan 2026 03:04:05 +0000</pubDate>` +
+		`<description>&lt;p&gt;Hello&lt;/p&gt;</description>` +
+		`<content:encoded>&lt;p&gt;Hello &lt;b&gt;world&lt;/b&gt; &amp;amp; all&lt;/p&gt;</content:encoded></item>` +
+		`<item><title>Second</title><guid isPermaLink="false">tag:example.com,2026:second</guid>` +
+		`<pubDate>Fri, 02 Jan 2026 04:04:05 +0000</pubDate>` +
+		`<description>&lt;i&gt;short&lt;/i&gt;</description><content:encoded>&lt;p&gt;long&lt;/p&gt;</content:encoded></item>` +
+		"</channel></rss>\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteRSS:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	var b strings.Builder
+	if err := testFeed().WriteAtom(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Tom &amp; Jerry&#39;s &lt;News&gt;</title>` +
+		`<id>https://example.com/?a=1&amp;b=2</id><updated>2026-01-02T04:04:05Z</updated>` +
+		`<link href="https://example.com/?a=1&amp;b=2" rel="alternate"></link><author><name>Gopher</name></author>` +
+		`<subtitle>News` + "�" + ` feed</subtitle>` +
+		`<entry><title>First</title><id>https://example.com/first</id><updated>2026-01-02T03:04:05Z</updated>` +
+		`<published>2026-01-02T03:04:05Z</published><link href="https://example.com/first"></link>` +
+		`<summary type="html">&lt;p&gt;Hello&lt;/p&gt;</summary>` +
+		`<content type="html">&lt;p&gt;Hello &lt;b&gt;world&lt;/b&gt; &amp;amp; all&lt;/p&gt;</content></entry>` +
+		`<entry><title>Second</title><id>tag:example.com,2026:second</id><updated>2026-01-02T04:04:05Z</updated>` +
+		`<published>2026-01-02T04:04:05Z</published>` +
+		`<summary type="html">&lt;i&gt;short&lt;/i&gt;</summary><content type="html">&lt;p&gt;long&lt;/p&gt;</content></entry>` +
+		"</feed>\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteAtom:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc   string
+		modify func(f *Feed)
+		rss    bool
+	}{
+		{"no title", func(f *Feed) { f.Title = "" }, true},
+		{"no link", func(f *Feed) { f.Link = safehtml.URL{} }, true},
+		{"empty item", func(f *Feed) { f.Items = append(f.Items, Item{}) }, true},
+		{"no item ID", func(f *Feed) { f.Items[0].Link = safehtml.URL{} }, false},
+		{"no item time", func(f *Feed) { f.Items[0].Published = time.Time{} }, false},
+		{"no feed time", func(f *Feed) { f.Items = nil }, false},
+	} {
+		f := testFeed()
+		test.modify(f)
+		if err := f.WriteAtom(new(strings.Builder)); err == nil {
+			t.Errorf("%s: WriteAtom succeeded, want error", test.desc)
+		}
+		if err := f.WriteRSS(new(strings.Builder)); (err != nil) != test.rss {
+			t.Errorf("%s: WriteRSS error = %v, want error: %t", test.desc, err, test.rss)
+		}
+	}
+}
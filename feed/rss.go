@@ -0,0 +1,94 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title,omitempty"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        *rssGUID `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Description string   `xml:"description,omitempty"`
+	Content     string   `xml:"content:encoded,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// rssTime formats t as an RSS date, or returns "" if t is zero.
+func rssTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+// WriteRSS writes f to w as an RSS 2.0 feed. The description of each item is
+// its summary, or its content if it has no summary, and the content of items
+// with both is written in a content:encoded element. It returns an error if f
+// has no title or link, if an item has no title or content, or if writing to
+// w fails.
+func (f *Feed) WriteRSS(w io.Writer) error {
+	if err := f.check(); err != nil {
+		return err
+	}
+	doc := rss{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link.String(),
+			Description:   f.Description,
+			LastBuildDate: rssTime(f.updated()),
+		},
+	}
+	for i := range f.Items {
+		it := &f.Items[i]
+		item := rssItem{
+			Title:   it.Title,
+			Link:    it.Link.String(),
+			PubDate: rssTime(it.Published),
+			Creator: it.Author,
+		}
+		if id := it.id(); id != "" {
+			item.GUID = &rssGUID{IsPermaLink: it.ID == "", Value: id}
+		}
+		if summary := f.summary(it).String(); summary != "" {
+			item.Description = summary
+			item.Content = it.Content.String()
+		} else {
+			item.Description = it.Content.String()
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+	return writeXML(w, doc)
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package feed
+
+import (
+	"github.com/google/safehtml"
+)
+
+func (f *Feed) summary(it *Item) safehtml.HTML {
+	if it.Summary.String() == "" && f.SummaryLength > 0 {
+		return safehtml.HTMLTruncate(it.Content, f.SummaryLength)
+	}
+	return it.Summary
+}
@@ -0,0 +1,20 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build safehtml_minimal
+// +build safehtml_minimal
+
+package feed
+
+import (
+	"github.com/google/safehtml"
+)
+
+// summary ignores f.SummaryLength, since safehtml.HTMLTruncate is excluded
+// from builds with the safehtml_minimal tag.
+func (f *Feed) summary(it *Item) safehtml.HTML {
+	return it.Summary
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// A FormField is a hidden input of a form.
+type FormField struct {
+	// Name is the name of the input. It is an Identifier, like the values
+	// of name attributes in safehtml/template, so that it cannot clobber
+	// properties of the DOM.
+	Name  Identifier
+	Value string
+}
+
+// A FormSpec describes a form that works without script, submitting to a
+// server action, and that scripts can enhance using an optional JSON config.
+type FormSpec struct {
+	// ID is the id of the form. It is required if Config is not nil.
+	ID Identifier
+	// Action is the URL the form submits to.
+	Action URL
+	// Method is the HTTP method of the form, "get" or "post". It defaults
+	// to "post".
+	Method string
+	// Hidden are the hidden inputs of the form, written before Content.
+	Hidden []FormField
+	// Content is the HTML of the visible controls of the form.
+	Content HTML
+	// SubmitLabel is the text of a submit button written after Content.
+	// If it is empty, the form has no submit button other than those in
+	// Content.
+	SubmitLabel string
+	// Config, if not nil, is encoded as JSON into a script element of type
+	// application/json following the form, for scripts enhancing the form.
+	Config interface{}
+}
+
+// FormHTML returns the form described by f:
+//
+//	<form id="signup" action="/signup" method="post" data-config="signup-config">
+//	<input type="hidden" name="token" value="...">
+//	...
+//	<button type="submit">Sign up</button>
+//	</form>
+//	<script type="application/json" id="signup-config">{...}</script>
+//
+// The config script has the id of the form followed by "-config", which the
+// form references in its data-config attribute, so scripts can find the config
+// of a form using JSON.parse(document.getElementById(form.dataset.config).textContent).
+//
+// It returns an error if f.Method is not a valid method, if f.Config is not nil
+// but f.ID is empty, or if JSON encoding of f.Config fails.
+func FormHTML(f FormSpec) (HTML, error) {
+	method, err := formMethod(f.Method)
+	if err != nil {
+		return HTML{}, err
+	}
+	var config HTML
+	var configID Identifier
+	if f.Config != nil {
+		if f.ID.String() == "" {
+			return HTML{}, errors.New("form with a config must have an id")
+		}
+		configID = Identifier{f.ID.str + "-config"}
+		if config, err = JSONScriptHTML(configID, f.Config); err != nil {
+			return HTML{}, err
+		}
+	}
+	var w elementWriter
+	w.open("form")
+	w.attr("id", f.ID.String())
+	w.urlAttr("action", f.Action.String())
+	w.attr("method", method)
+	w.attr("data-config", configID.String())
+	w.closeTag()
+	writeHiddenInputs(&w, f.Hidden)
+	w.writeHTML(f.Content)
+	if f.SubmitLabel != "" {
+		w.open("button")
+		w.attr("type", "submit")
+		w.closeTag()
+		w.text(f.SubmitLabel)
+		w.end("button")
+	}
+	w.end("form")
+	w.writeHTML(config)
+	return w.html(), nil
+}
+
+// formMethod returns the lowercase form of method, or "post" if method is
+// empty.
+func formMethod(method string) (string, error) {
+	switch m := strings.ToLower(method); m {
+	case "":
+		return "post", nil
+	case "get", "post":
+		return m, nil
+	}
+	return "", fmt.Errorf("%q is not a valid form method", method)
+}
+
+func writeHiddenInputs(w *elementWriter, fields []FormField) {
+	for _, field := range fields {
+		w.open("input")
+		w.attr("type", "hidden")
+		w.attr("name", field.Name.String())
+		w.requiredAttr("value", field.Value)
+		w.closeTag()
+	}
+}
+
+// An AutoSubmitForm describes a form that posts hidden fields to an external
+// action as soon as the page loads, such as to hand a user over to a payment
+// gateway or identity provider.
+type AutoSubmitForm struct {
+	// Action is the URL the form posts to. It must be an absolute https URL.
+	Action URL
+	// Fields are the hidden inputs of the form.
+	Fields []FormField
+	// Nonce is the Content-Security-Policy nonce of the script submitting
+	// the form. It may be empty if the page does not have a nonce-based
+	// Content-Security-Policy.
+	Nonce string
+	// SubmitLabel is the text of the button users without script press to
+	// submit the form. It defaults to "Continue".
+	SubmitLabel string
+}
+
+// autoSubmitScript submits the form preceding the script element.
+const autoSubmitScript = `document.currentScript.previousElementSibling.submit();`
+
+// AutoSubmitFormHTML returns a form that posts f.Fields to f.Action, followed
+// by a script that submits it:
+//
+//	<form action="https://pay.example.com/checkout" method="post">
+//	<input type="hidden" name="order" value="...">
+//	<noscript><button type="submit">Continue</button></noscript>
+//	</form>
+//	<script nonce="...">...</script>
+//
+// The origin of f.Action, such as "https://pay.example.com", must be one of
+// allowedOrigins, so that the fields, which often contain credentials or signed
+// assertions, are never posted to another site. AutoSubmitFormHTML returns an
+// error if it is not, or if f.Action is not an absolute https URL.
+func AutoSubmitFormHTML(f AutoSubmitForm, allowedOrigins []string) (HTML, error) {
+	action := f.Action.String()
+	u, err := url.Parse(action)
+	if err != nil {
+		return HTML{}, err
+	}
+	if u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return HTML{}, fmt.Errorf("form action %q is not an absolute https URL", action)
+	}
+	origin := u.Scheme + "://" + strings.ToLower(u.Host)
+	if !containsOrigin(allowedOrigins, origin) {
+		return HTML{}, fmt.Errorf("form action origin %q is not allowed", origin)
+	}
+	label := f.SubmitLabel
+	if label == "" {
+		label = "Continue"
+	}
+	var w elementWriter
+	w.open("form")
+	w.urlAttr("action", action)
+	w.attr("method", "post")
+	w.closeTag()
+	writeHiddenInputs(&w, f.Fields)
+	w.open("noscript")
+	w.closeTag()
+	w.open("button")
+	w.attr("type", "submit")
+	w.closeTag()
+	w.text(label)
+	w.end("button")
+	w.end("noscript")
+	w.end("form")
+	w.open("script")
+	w.attr("nonce", f.Nonce)
+	w.closeTag()
+	w.b.WriteString(autoSubmitScript)
+	w.end("script")
+	return w.html(), nil
+}
+
+func containsOrigin(origins []string, origin string) bool {
+	for _, o := range origins {
+		if strings.EqualFold(strings.TrimSuffix(o, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
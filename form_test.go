@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestFormHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		form FormSpec
+		want string
+	}{
+		{
+			"minimal",
+			FormSpec{Action: URLSanitized("/search"), Method: "GET"},
+			`<form action="/search" method="get"></form>`,
+		},
+		{
+			"unsafe action",
+			FormSpec{Action: URLSanitized("javascript:alert(1)")},
+			`<form action="about:invalid#zGoSafez" method="post"></form>`,
+		},
+		{
+			"hidden fields and content",
+			FormSpec{
+				ID:     IdentifierFromConstant("signup"),
+				Action: URLSanitized("/signup"),
+				Hidden: []FormField{
+					{Name: IdentifierFromConstant("token"), Value: `"><script>`},
+					{Name: IdentifierFromConstant("empty")},
+				},
+				Content:     HTMLEscaped("<Email>"),
+				SubmitLabel: "Sign up & go",
+			},
+			`<form id="signup" action="/signup" method="post">` +
+				`<input type="hidden" name="token" value="&#34;&gt;&lt;script&gt;">` +
+				`<input type="hidden" name="empty" value="">` +
+				`&lt;Email&gt;<button type="submit">Sign up &amp; go</button></form>`,
+		},
+		{
+			"config",
+			FormSpec{
+				ID:     IdentifierFromConstant("signup"),
+				Action: URLSanitized("/signup"),
+				Config: map[string]string{"endpoint": "/api/signup", "msg": "</script>"},
+			},
+			`<form id="signup" action="/signup" method="post" data-config="signup-config"></form>` +
+				`<script type="application/json" id="signup-config">{"endpoint":"/api/signup","msg":"\u003c/script\u003e"}</script>`,
+		},
+	} {
+		got, err := FormHTML(test.form)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestFormHTMLErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		form FormSpec
+	}{
+		{"invalid method", FormSpec{Method: "delete"}},
+		{"config without id", FormSpec{Config: true}},
+		{"unencodable config", FormSpec{ID: IdentifierFromConstant("f"), Config: func() {}}},
+	} {
+		if _, err := FormHTML(test.form); err == nil {
+			t.Errorf("%s: expected error", test.desc)
+		}
+	}
+}
+
+func TestJSONScriptHTML(t *testing.T) {
+	got, err := JSONScriptHTML(IdentifierFromConstant("data"), []string{"<!--", "a&b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<script type="application/json" id="data">["\u003c!--","a\u0026b"]</script>`; got.String() != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAutoSubmitFormHTML(t *testing.T) {
+	allowed := []string{"https://pay.example.com/", "https://idp.example.org"}
+	got, err := AutoSubmitFormHTML(AutoSubmitForm{
+		Action: URLSanitized("https://PAY.example.com/checkout?x=1&y=2"),
+		Fields: []FormField{{Name: IdentifierFromConstant("SAMLResponse"), Value: `PHNhbWw+"`}},
+		Nonce:  "r4nd0m",
+	}, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<form action="https://PAY.example.com/checkout?x=1&amp;y=2" method="post">` +
+		`<input type="hidden" name="SAMLResponse" value="PHNhbWw+&#34;">` +
+		`<noscript><button type="submit">Continue</button></noscript></form>` +
+		`<script nonce="r4nd0m">document.currentScript.previousElementSibling.submit();</script>`
+	if got.String() != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+	for _, action := range [...]string{
+		"https://evil.example.com/checkout",
+		"https://pay.example.com.evil.com/",
+		"https://user@pay.example.com/",
+		"http://pay.example.com/checkout",
+		"//pay.example.com/checkout",
+		"/checkout",
+		"javascript:alert(1)",
+	} {
+		if _, err := AutoSubmitFormHTML(AutoSubmitForm{Action: URLSanitized(action)}, allowed); err == nil {
+			t.Errorf("%q: expected error", action)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A FragmentVersion identifies a version of a cached HTML fragment, such as
+// a page header assembled into pages by an edge cache.
+type FragmentVersion struct {
+	// ID identifies the fragment.
+	ID Identifier
+	// Version is the version of the fragment, such as a content hash or an
+	// ETag without its quotes. It must only contain alphanumerics and the
+	// characters '.', '_', '~', '+', '/', '=', ':', and '-', and must not
+	// contain "--" or end with '-'.
+	Version string
+}
+
+// fragmentVersionPattern matches valid FragmentVersion versions.
+var fragmentVersionPattern = regexp.MustCompile(`^[-A-Za-z0-9._~+/=:]+$`)
+
+// fragmentStartPattern matches the comment that VersionedHTML starts
+// fragments with.
+var fragmentStartPattern = regexp.MustCompile(`^<!--safehtml-fragment ([a-zA-Z][-_a-zA-Z0-9]*) ([-A-Za-z0-9._~+/=:]+)-->`)
+
+// VersionedHTML returns h wrapped in comments recording v, so that the
+// version of the fragment can be verified with ParseVersionedHTML after it has
+// been cached or sent between services:
+//
+//	<!--safehtml-fragment header v42-->...<!--/safehtml-fragment header-->
+//
+// Comments are used rather than a wrapping element, so that the fragment
+// can be embedded wherever h could, such as in a table. VersionedHTML
+// returns an error if v.ID is empty or v.Version is not valid.
+func VersionedHTML(v FragmentVersion, h HTML) (HTML, error) {
+	if v.ID.str == "" {
+		return HTML{}, fmt.Errorf("fragment version has no identifier")
+	}
+	if !fragmentVersionPattern.MatchString(v.Version) || strings.Contains(v.Version, "--") || strings.HasSuffix(v.Version, "-") {
+		return HTML{}, fmt.Errorf("invalid fragment version %q", v.Version)
+	}
+	return HTML{"<!--safehtml-fragment " + v.ID.str + " " + v.Version + "-->" + h.str + fragmentEnd(v.ID.str)}, nil
+}
+
+func fragmentEnd(id string) string {
+	return "<!--/safehtml-fragment " + id + "-->"
+}
+
+// ParseVersionedHTML returns the version and content of a fragment returned by
+// VersionedHTML, and reports whether h is such a fragment.
+func ParseVersionedHTML(h HTML) (FragmentVersion, HTML, bool) {
+	m := fragmentStartPattern.FindStringSubmatch(h.str)
+	if m == nil {
+		return FragmentVersion{}, HTML{}, false
+	}
+	end := fragmentEnd(m[1])
+	if !strings.HasSuffix(h.str, end) || len(h.str) < len(m[0])+len(end) {
+		return FragmentVersion{}, HTML{}, false
+	}
+	v := FragmentVersion{ID: Identifier{m[1]}, Version: m[2]}
+	return v, HTML{h.str[len(m[0]) : len(h.str)-len(end)]}, true
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import "testing"
+
+func TestVersionedHTML(t *testing.T) {
+	v := FragmentVersion{ID: IdentifierFromConstant("header"), Version: "v42.a1b2"}
+	h, err := VersionedHTML(v, HTML{"<b>Hi</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := h.String(), "<!--safehtml-fragment header v42.a1b2--><b>Hi</b><!--/safehtml-fragment header-->"; got != want {
+		t.Errorf("VersionedHTML(%v) = %q, want %q", v, got, want)
+	}
+	gotV, content, ok := ParseVersionedHTML(h)
+	if !ok || gotV != v || content.String() != "<b>Hi</b>" {
+		t.Errorf("ParseVersionedHTML(%q) = %v, %q, %t, want %v, %q, true", h, gotV, content, ok, v, "<b>Hi</b>")
+	}
+	for _, bad := range []FragmentVersion{
+		{Version: "v1"},
+		{ID: IdentifierFromConstant("header"), Version: ""},
+		{ID: IdentifierFromConstant("header"), Version: "a b"},
+		{ID: IdentifierFromConstant("header"), Version: "a-->b"},
+		{ID: IdentifierFromConstant("header"), Version: "a--b"},
+		{ID: IdentifierFromConstant("header"), Version: "a-"},
+	} {
+		if _, err := VersionedHTML(bad, HTML{}); err == nil {
+			t.Errorf("VersionedHTML(%v) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestParseVersionedHTML(t *testing.T) {
+	for _, in := range []string{
+		"<b>Hi</b>",
+		"<!--safehtml-fragment header v1--><b>Hi</b>",
+		"<!--safehtml-fragment header v1--><b>Hi</b><!--/safehtml-fragment footer-->",
+		"<!--safehtml-fragment header v1-->",
+		" <!--safehtml-fragment header v1--><!--/safehtml-fragment header-->",
+	} {
+		if _, _, ok := ParseVersionedHTML(HTML{in}); ok {
+			t.Errorf("ParseVersionedHTML(%q) succeeded, want failure", in)
+		}
+	}
+	v, content, ok := ParseVersionedHTML(HTML{"<!--safehtml-fragment a v1--><!--/safehtml-fragment a-->"})
+	if !ok || v.ID.String() != "a" || v.Version != "v1" || content.String() != "" {
+		t.Errorf("ParseVersionedHTML of empty fragment = %v, %q, %t", v, content, ok)
+	}
+}
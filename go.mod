@@ -2,4 +2,7 @@ module github.com/google/safehtml
 
 go 1.16
 
-require golang.org/x/text v0.3.3
+require (
+	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4
+	golang.org/x/text v0.3.3
+)
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/google/safehtml/urlsafe"
+)
+
+// PageMetadata describes a page for browsers, search engines, and the link
+// previews of social networks, which read the Open Graph and Twitter card
+// metadata of the page.
+type PageMetadata struct {
+	// Title is the title of the page.
+	Title string
+	// Description is the optional summary of the page.
+	Description string
+	// Canonical is the optional preferred URL of the page. It should be an
+	// absolute URL, which Open Graph requires.
+	Canonical TrustedResourceURL
+	// Type is the Open Graph type of the page, such as "website" or
+	// "article". It defaults to "website".
+	Type string
+	// SiteName is the optional name of the site the page belongs to.
+	SiteName string
+	// Image is the optional URL of the image shown in link previews. It
+	// should be an absolute URL, which Open Graph requires.
+	Image URL
+	// ImageAlt is the text alternative of Image.
+	ImageAlt string
+	// Locale is the optional locale of the page, such as "en_US".
+	Locale string
+	// TwitterCard is the type of Twitter card, "summary",
+	// "summary_large_image", "app", or "player". It defaults to "summary",
+	// or to "summary_large_image" if Image is set.
+	TwitterCard string
+	// TwitterSite is the optional Twitter handle of the site, such as
+	// "@golang".
+	TwitterSite string
+}
+
+var (
+	// ogTypePattern matches Open Graph types, such as "article" and
+	// "music.song".
+	ogTypePattern = regexp.MustCompile(`^[a-z]+(\.[a-z_]+)?$`)
+	// ogLocalePattern matches Open Graph locales.
+	ogLocalePattern = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?$`)
+	// twitterHandlePattern matches Twitter handles.
+	twitterHandlePattern = regexp.MustCompile(`^@[A-Za-z0-9_]{1,15}$`)
+)
+
+// PageMetadataHTML returns the title, meta, and link elements describing the
+// page m, for the head of a document, which safehtml/template does not allow
+// actions in:
+//
+//	<title>Gopher</title>
+//	<meta name="description" content="About the Gopher">
+//	<link rel="canonical" href="https://example.com/gopher">
+//	<meta property="og:title" content="Gopher">
+//	<meta property="og:description" content="About the Gopher">
+//	<meta property="og:url" content="https://example.com/gopher">
+//	<meta property="og:type" content="website">
+//	<meta name="twitter:card" content="summary">
+//
+// It returns an error if m.Title is empty or m.Type, m.Locale, m.TwitterCard,
+// or m.TwitterSite are not valid.
+func PageMetadataHTML(m PageMetadata) (HTML, error) {
+	if m.Title == "" {
+		return HTML{}, fmt.Errorf("page metadata has no title")
+	}
+	if m.Type == "" {
+		m.Type = "website"
+	}
+	if !ogTypePattern.MatchString(m.Type) {
+		return HTML{}, fmt.Errorf("%q is not a valid Open Graph type", m.Type)
+	}
+	if m.Locale != "" && !ogLocalePattern.MatchString(m.Locale) {
+		return HTML{}, fmt.Errorf("%q is not a valid Open Graph locale", m.Locale)
+	}
+	switch m.TwitterCard {
+	case "":
+		m.TwitterCard = "summary"
+		if m.Image.str != "" {
+			m.TwitterCard = "summary_large_image"
+		}
+	case "summary", "summary_large_image", "app", "player":
+	default:
+		return HTML{}, fmt.Errorf("%q is not a valid Twitter card type", m.TwitterCard)
+	}
+	if m.TwitterSite != "" && !twitterHandlePattern.MatchString(m.TwitterSite) {
+		return HTML{}, fmt.Errorf("%q is not a valid Twitter handle", m.TwitterSite)
+	}
+	var w elementWriter
+	w.open("title")
+	w.closeTag()
+	w.text(m.Title)
+	w.end("title")
+	meta := func(key, name, content string) {
+		if content == "" {
+			return
+		}
+		w.open("meta")
+		w.attr(key, name)
+		w.attr("content", content)
+		w.closeTag()
+	}
+	meta("name", "description", m.Description)
+	if m.Canonical.str != "" {
+		w.open("link")
+		w.attr("rel", string(RelCanonical))
+		w.urlAttr("href", m.Canonical.str)
+		w.closeTag()
+	}
+	meta("property", "og:title", m.Title)
+	meta("property", "og:description", m.Description)
+	if m.Canonical.str != "" {
+		meta("property", "og:url", urlsafe.NormalizeURL(m.Canonical.str))
+	}
+	meta("property", "og:type", m.Type)
+	meta("property", "og:site_name", m.SiteName)
+	meta("property", "og:locale", m.Locale)
+	if m.Image.str != "" {
+		meta("property", "og:image", urlsafe.NormalizeURL(m.Image.str))
+		meta("property", "og:image:alt", m.ImageAlt)
+	}
+	meta("name", "twitter:card", m.TwitterCard)
+	meta("name", "twitter:site", m.TwitterSite)
+	return w.html(), nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import "testing"
+
+func TestPageMetadataHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		m    PageMetadata
+		want string
+	}{
+		{
+			"title only",
+			PageMetadata{Title: `Tom & "Jerry"`},
+			`<title>Tom &amp; &#34;Jerry&#34;</title>` +
+				`<meta property="og:title" content="Tom &amp; &#34;Jerry&#34;">` +
+				`<meta property="og:type" content="website">` +
+				`<meta name="twitter:card" content="summary">`,
+		},
+		{
+			"all fields",
+			PageMetadata{
+				Title:       "Gopher",
+				Description: "About <the> Gopher",
+				Canonical:   TrustedResourceURLFromConstant("https://example.com/gopher"),
+				Type:        "article",
+				SiteName:    "Example",
+				Image:       URLSanitized("https://example.com/gopher.png?w=1&h=2"),
+				ImageAlt:    "A gopher",
+				Locale:      "en_US",
+				TwitterSite: "@golang",
+			},
+			`<title>Gopher</title>` +
+				`<meta name="description" content="About &lt;the&gt; Gopher">` +
+				`<link rel="canonical" href="https://example.com/gopher">` +
+				`<meta property="og:title" content="Gopher">` +
+				`<meta property="og:description" content="About &lt;the&gt; Gopher">` +
+				`<meta property="og:url" content="https://example.com/gopher">` +
+				`<meta property="og:type" content="article">` +
+				`<meta property="og:site_name" content="Example">` +
+				`<meta property="og:locale" content="en_US">` +
+				`<meta property="og:image" content="https://example.com/gopher.png?w=1&amp;h=2">` +
+				`<meta property="og:image:alt" content="A gopher">` +
+				`<meta name="twitter:card" content="summary_large_image">` +
+				`<meta name="twitter:site" content="@golang">`,
+		},
+		{
+			"unsafe image",
+			PageMetadata{Title: "T", Image: URLSanitized("javascript:alert(1)"), TwitterCard: "summary"},
+			`<title>T</title>` +
+				`<meta property="og:title" content="T">` +
+				`<meta property="og:type" content="website">` +
+				`<meta property="og:image" content="about:invalid#zGoSafez">` +
+				`<meta name="twitter:card" content="summary">`,
+		},
+	} {
+		got, err := PageMetadataHTML(test.m)
+		if err != nil {
+			t.Errorf("%s: PageMetadataHTML failed: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: PageMetadataHTML:\ngot  %q\nwant %q", test.desc, got, test.want)
+		}
+	}
+	for _, m := range []PageMetadata{
+		{},
+		{Title: "T", Type: "Web Site"},
+		{Title: "T", Locale: "en-us"},
+		{Title: "T", TwitterCard: "large"},
+		{Title: "T", TwitterSite: "golang"},
+	} {
+		if _, err := PageMetadataHTML(m); err == nil {
+			t.Errorf("PageMetadataHTML(%+v) succeeded, want error", m)
+		}
+	}
+}
@@ -9,9 +9,10 @@ package safehtml
 import (
 	"bytes"
 	"html"
+	"io"
+	"strings"
 	"unicode"
-
-	"golang.org/x/text/unicode/rangetable"
+	"unicode/utf8"
 )
 
 // An HTML is an immutable string-like type that is safe to use in HTML
@@ -44,6 +45,71 @@ func HTMLEscaped(text string) HTML {
 	return HTML{escapeAndCoerceToInterchangeValid(text)}
 }
 
+// HTMLEscapedBytes is like HTMLEscaped, but escapes text held as a byte
+// slice. It escapes and coerces text in a single pass, without converting it
+// to a string first.
+func HTMLEscapedBytes(text []byte) HTML {
+	var b strings.Builder
+	b.Grow(len(text))
+	for len(text) > 0 {
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+		switch {
+		case r == '&':
+			b.WriteString("&amp;")
+		case r == '<':
+			b.WriteString("&lt;")
+		case r == '>':
+			b.WriteString("&gt;")
+		case r == '"':
+			// "&#34;" is shorter than "&quot;", and matches html.EscapeString.
+			b.WriteString("&#34;")
+		case r == '\'':
+			// "&#39;" is shorter than "&apos;", and matches html.EscapeString.
+			b.WriteString("&#39;")
+		case unicode.Is(controlAndNonCharacter, r):
+			b.WriteRune(unicode.ReplacementChar)
+		default:
+			// Invalid UTF-8 is decoded as unicode.ReplacementChar.
+			b.WriteRune(r)
+		}
+	}
+	return HTML{b.String()}
+}
+
+// TextOptions configures the conversion of plain text to HTML by HTMLFromText.
+type TextOptions struct {
+	// CollapseWhitespace replaces each run of spaces, tabs, and form feeds in
+	// a line by a single space, and removes them at the start and end of
+	// lines.
+	CollapseWhitespace bool
+}
+
+// HTMLFromText returns an HTML whose value is text escaped as by HTMLEscaped,
+// with each line break replaced by a <br> element, for rendering plain text
+// such as user comments. Line breaks are "\n", "\r\n", or "\r".
+func HTMLFromText(text string, opts TextOptions) HTML {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	var b strings.Builder
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.WriteString("<br>")
+		}
+		if opts.CollapseWhitespace {
+			line = strings.Join(strings.FieldsFunc(line, isHTMLSpace), " ")
+		}
+		b.WriteString(escapeAndCoerceToInterchangeValid(line))
+	}
+	return HTML{b.String()}
+}
+
+// isHTMLSpace reports whether r is ASCII whitespace other than a line break,
+// as defined in https://infra.spec.whatwg.org/#ascii-whitespace.
+func isHTMLSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\f'
+}
+
 // HTMLConcat returns an HTML which contains, in order, the string representations
 // of the given htmls.
 func HTMLConcat(htmls ...HTML) HTML {
@@ -59,6 +125,14 @@ func (h HTML) String() string {
 	return h.str
 }
 
+// WriteTo writes the string form of the HTML to w. It avoids converting the
+// string to a byte slice if w implements io.StringWriter, as most buffers and
+// http.ResponseWriter implementations do.
+func (h HTML) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, h.str)
+	return int64(n), err
+}
+
 // escapeAndCoerceToInterchangeValid coerces the string to interchange-valid
 // UTF-8 and then HTML-escapes it.
 func escapeAndCoerceToInterchangeValid(str string) string {
@@ -92,8 +166,15 @@ func coerceToUTF8InterchangeValid(s string) string {
 // See http://www.w3.org/TR/html5/syntax.html#preprocessing-the-input-stream
 //
 // safehtml functions do a lot of lookups on these tables, so merging them is probably
-// worth it to avoid comparing against both tables each time.
-var controlAndNonCharacter = rangetable.Merge(unicode.Noncharacter_Code_Point, controlChar)
+// worth it to avoid comparing against both tables each time. The ranges of
+// controlChar all precede those of unicode.Noncharacter_Code_Point, so the
+// tables are merged by concatenating their ranges, without depending on
+// golang.org/x/text/unicode/rangetable.
+var controlAndNonCharacter = &unicode.RangeTable{
+	R16:         append(append([]unicode.Range16(nil), controlChar.R16...), unicode.Noncharacter_Code_Point.R16...),
+	R32:         unicode.Noncharacter_Code_Point.R32,
+	LatinOffset: controlChar.LatinOffset,
+}
 
 // controlChar contains Unicode control characters disallowed in interchange
 // valid UTF-8. This table is slightly different from unicode.Cc:
@@ -7,7 +7,9 @@
 package safehtml
 
 import (
+	"strings"
 	"testing"
+	"unicode"
 )
 
 const (
@@ -21,6 +23,34 @@ func TestHTMLEscaped(t *testing.T) {
 	}
 }
 
+func TestHTMLEscapedBytes(t *testing.T) {
+	for _, in := range [...]string{
+		"",
+		"plain text",
+		rawHTML,
+		"caf\u00e9 \u65e5\u672c",
+		"nul\x00 bell\x07 del\x7f c1\u0085 tab\t newline\n",
+		"nonchar\ufdd0\U0010ffff",
+		"invalid\xff\xfe utf-8\xe2\x82",
+	} {
+		want := HTMLEscaped(in).String()
+		if got := HTMLEscapedBytes([]byte(in)).String(); got != want {
+			t.Errorf("HTMLEscapedBytes(%q) == %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestHTMLWriteTo(t *testing.T) {
+	var b strings.Builder
+	n, err := HTMLEscaped(rawHTML).WriteTo(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != escapedHTML || n != int64(len(escapedHTML)) {
+		t.Errorf("WriteTo wrote %q, returned %d, want %q, %d", got, n, escapedHTML, len(escapedHTML))
+	}
+}
+
 func TestHTMLConcat(t *testing.T) {
 	for _, test := range [...]struct {
 		in   []string
@@ -53,6 +83,28 @@ func TestHTMLConcat(t *testing.T) {
 	}
 }
 
+func TestHTMLFromText(t *testing.T) {
+	for _, test := range [...]struct {
+		in       string
+		collapse bool
+		want     string
+	}{
+		{"", false, ""},
+		{"Hello <world>!", false, "Hello &lt;world&gt;!"},
+		{"one\ntwo", false, "one<br>two"},
+		{"one\r\ntwo\rthree\n", false, "one<br>two<br>three<br>"},
+		{"one\n\ntwo", false, "one<br><br>two"},
+		{"  a \t b  \n c", false, "  a \t b  <br> c"},
+		{"  a \t b  \n c", true, "a b<br>c"},
+		{"a\u00a0\u00a0b", true, "a\u00a0\u00a0b"},
+		{"\x00'\"&", true, "\ufffd&#39;&#34;&amp;"},
+	} {
+		if got := HTMLFromText(test.in, TextOptions{CollapseWhitespace: test.collapse}); got.String() != test.want {
+			t.Errorf("HTMLFromText(%q, CollapseWhitespace: %t) = %q, want %q", test.in, test.collapse, got.String(), test.want)
+		}
+	}
+}
+
 func TestCoerceToInterchangeValid(t *testing.T) {
 	// Single character tests
 	for _, tt := range [...]struct {
@@ -151,3 +203,12 @@ func TestCoerceToInterchangeValid(t *testing.T) {
 		}
 	}
 }
+
+func TestControlAndNonCharacter(t *testing.T) {
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		want := unicode.Is(controlChar, r) || unicode.Is(unicode.Noncharacter_Code_Point, r)
+		if got := unicode.Is(controlAndNonCharacter, r); got != want {
+			t.Fatalf("unicode.Is(controlAndNonCharacter, %U) = %t, want %t", r, got, want)
+		}
+	}
+}
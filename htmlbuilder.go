@@ -0,0 +1,102 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"strings"
+)
+
+// An HTMLBuilder builds HTML from fragments appended to a single buffer, so
+// that a page assembled from many fragments is copied once, rather than once
+// per call as it is by repeated calls to HTMLConcat:
+//
+//	b := NewHTMLBuilder(MaxBytes(1 << 20))
+//	for _, row := range rows {
+//		b.AppendElement("li", nil, row)
+//	}
+//	list, err := b.HTML()
+//
+// The first error of an append, such as a disallowed element or a
+// *SizeLimitError, stops the builder: later appends do nothing, and HTML
+// returns the error. The zero value is an empty HTMLBuilder with no limit.
+// An HTMLBuilder must not be copied after its first use.
+type HTMLBuilder struct {
+	b   strings.Builder
+	c   buildConfig
+	err error
+}
+
+// NewHTMLBuilder returns an empty HTMLBuilder configured by opts.
+func NewHTMLBuilder(opts ...BuildOption) *HTMLBuilder {
+	b := new(HTMLBuilder)
+	for _, opt := range opts {
+		opt(&b.c)
+	}
+	return b
+}
+
+// Grow grows the buffer of b, if necessary, to fit another n bytes without
+// another allocation.
+func (b *HTMLBuilder) Grow(n int) {
+	b.b.Grow(n)
+}
+
+// Len returns the length in bytes of the HTML appended to b.
+func (b *HTMLBuilder) Len() int {
+	return b.b.Len()
+}
+
+// AppendHTML appends htmls to b.
+func (b *HTMLBuilder) AppendHTML(htmls ...HTML) {
+	if b.err != nil {
+		return
+	}
+	if b.err = b.c.check(b.b.Len() + htmlLen(htmls)); b.err != nil {
+		return
+	}
+	for _, h := range htmls {
+		b.b.WriteString(h.str)
+	}
+}
+
+// AppendEscaped appends text to b, HTML-escaped as by HTMLEscaped.
+func (b *HTMLBuilder) AppendEscaped(text string) {
+	b.AppendHTML(HTMLEscaped(text))
+}
+
+// AppendElement appends the element built by HTMLElement from name, attrs,
+// and children to b.
+func (b *HTMLBuilder) AppendElement(name string, attrs []Attr, children ...HTML) {
+	if b.err != nil {
+		return
+	}
+	// Limit the element to the bytes that remain, so that it fails before
+	// copying oversized children.
+	c := b.c
+	if c.maxBytes > 0 {
+		if c.maxBytes -= b.b.Len(); c.maxBytes <= 0 {
+			b.err = &SizeLimitError{b.c.maxBytes}
+			return
+		}
+	}
+	h, err := htmlElement(name, attrs, children, c)
+	if err, ok := err.(*SizeLimitError); ok {
+		err.MaxBytes = b.c.maxBytes
+	}
+	if b.err = err; err != nil {
+		return
+	}
+	b.b.WriteString(h.str)
+}
+
+// HTML returns the HTML appended to b, or the first error of an append.
+func (b *HTMLBuilder) HTML() (HTML, error) {
+	if b.err != nil {
+		return HTML{}, b.err
+	}
+	return HTML{b.b.String()}, nil
+}
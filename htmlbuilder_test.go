@@ -0,0 +1,87 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLBuilder(t *testing.T) {
+	var b HTMLBuilder
+	b.AppendHTML(HTML{"<ul>"})
+	for _, s := range []string{"a", "<b>"} {
+		b.AppendElement("li", []Attr{{"title", s}}, HTMLEscaped(s))
+	}
+	b.AppendHTML(HTML{"</ul>"}, HTML{"<p>"})
+	b.AppendEscaped("Tom & Jerry")
+	b.AppendHTML()
+	got, err := b.HTML()
+	want := `<ul><li title="a">a</li><li title="&lt;b&gt;">&lt;b&gt;</li></ul><p>Tom &amp; Jerry`
+	if err != nil || got.String() != want {
+		t.Errorf("HTML() = %q, %v, want %q", got, err, want)
+	}
+	if b.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", b.Len(), len(want))
+	}
+}
+
+func TestHTMLBuilderErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc  string
+		build func(b *HTMLBuilder)
+		err   string
+	}{
+		{"disallowed element", func(b *HTMLBuilder) {
+			b.AppendElement("script", nil)
+			b.AppendEscaped("x")
+		}, `element "script" is not allowed`},
+		{"html over limit", func(b *HTMLBuilder) {
+			b.AppendEscaped("12345")
+			b.AppendHTML(HTML{"6"}, HTML{"7"})
+		}, "HTML exceeds the limit of 6 bytes"},
+		{"children over limit", func(b *HTMLBuilder) {
+			b.AppendEscaped("1")
+			b.AppendElement("p", nil, HTML{"23456"})
+		}, "HTML exceeds the limit of 6 bytes"},
+		{"element over limit", func(b *HTMLBuilder) {
+			b.AppendEscaped("1")
+			b.AppendElement("p", nil)
+		}, "HTML exceeds the limit of 6 bytes"},
+		{"full", func(b *HTMLBuilder) {
+			b.AppendEscaped("123456")
+			b.AppendElement("br", nil)
+		}, "HTML exceeds the limit of 6 bytes"},
+	} {
+		b := NewHTMLBuilder(MaxBytes(6))
+		test.build(b)
+		h, err := b.HTML()
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: error = %v, want %q", test.desc, err, test.err)
+		}
+		if h.String() != "" {
+			t.Errorf("%s: got %q, want empty HTML", test.desc, h)
+		}
+	}
+	b := NewHTMLBuilder(MaxBytes(9))
+	b.AppendEscaped("1")
+	b.AppendElement("p", nil, HTML{"2"})
+	if h, err := b.HTML(); err != nil || h.String() != "1<p>2</p>" {
+		t.Errorf("HTML() = %q, %v, want %q", h, err, "1<p>2</p>")
+	}
+}
+
+func BenchmarkHTMLBuilder(b *testing.B) {
+	row := HTMLEscaped(strings.Repeat("x", 100))
+	for i := 0; i < b.N; i++ {
+		hb := NewHTMLBuilder()
+		for j := 0; j < 1000; j++ {
+			hb.AppendHTML(row)
+		}
+		hb.HTML()
+	}
+}
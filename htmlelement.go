@@ -0,0 +1,234 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/safehtml/registry"
+)
+
+// An Attr is an attribute of an element built by HTMLElement.
+//
+// The type of Value depends on the attribute, as in safehtml/template:
+//
+//   - URL-valued attributes such as href accept URL values, and strings,
+//     which are sanitized using URLSanitized. Attributes that load resources
+//     as code, such as the src of a script, only accept TrustedResourceURL
+//     values.
+//   - srcset attributes accept URLSet values, and strings, which are
+//     sanitized using URLSetSanitized.
+//   - id and other attributes referring to elements only accept Identifier
+//     values.
+//   - style attributes only accept Style values, and srcdoc attributes only
+//     HTML values.
+//   - dir, target, loading, and async attributes only accept the strings
+//     allowed in their values.
+//   - Other attributes accept strings and integers, and bool values, which
+//     add the attribute without a value if true, and omit it if false.
+type Attr struct {
+	Name  string
+	Value interface{}
+}
+
+// HTMLElement returns the element with the given name, attributes, and
+// children:
+//
+//	HTMLElement("a", []Attr{{"href", u}, {"class", "nav"}}, HTMLEscaped("Home"))
+//	// <a href="/home" class="nav">Home</a>
+//
+// The element and its attributes must be ones that safehtml/template allows
+// untrusted values in, and the values must have the types described for
+// Attr, or HTMLElement returns an error. Elements containing code or style,
+// such as script and style, are not allowed, and void elements such as img
+// and elements containing text such as title cannot have children.
+func HTMLElement(name string, attrs []Attr, children ...HTML) (HTML, error) {
+	return htmlElement(name, attrs, children, buildConfig{})
+}
+
+// HTMLElementWithOptions is like HTMLElement, but takes the children as a
+// slice and is configured by opts:
+//
+//	HTMLElementWithOptions("tbody", nil, rows, MaxBytes(1<<20))
+func HTMLElementWithOptions(name string, attrs []Attr, children []HTML, opts ...BuildOption) (HTML, error) {
+	var c buildConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return htmlElement(name, attrs, children, c)
+}
+
+// ElementRegistry makes the builders check elements and attributes against
+// the contracts in r, rather than the stock contracts returned by
+// registry.Default, so that they build the same elements as templates that
+// use r with template.Template.WithRegistry.
+func ElementRegistry(r *registry.Registry) BuildOption {
+	return func(c *buildConfig) {
+		c.registry = r
+	}
+}
+
+func htmlElement(name string, attrs []Attr, children []HTML, c buildConfig) (HTML, error) {
+	name = strings.ToLower(name)
+	r := c.registry
+	void, htmlContent, err := checkElement(r, name)
+	if err != nil {
+		return HTML{}, err
+	} else if len(children) > 0 && !htmlContent {
+		return HTML{}, fmt.Errorf("element %q cannot have HTML children", name)
+	} else if err := c.check(htmlLen(children)); err != nil {
+		return HTML{}, err
+	}
+	// The rel attribute of a link element determines the context of its
+	// href attribute.
+	linkRel := ""
+	for _, a := range attrs {
+		if strings.EqualFold(a.Name, "rel") {
+			if s, ok := a.Value.(string); ok {
+				linkRel = " " + strings.ToLower(s) + " "
+			}
+		}
+	}
+	var w elementWriter
+	w.open(name)
+	seen := make(map[string]bool, len(attrs))
+	for _, a := range attrs {
+		attr := strings.ToLower(a.Name)
+		if seen[attr] {
+			return HTML{}, fmt.Errorf("duplicate attribute %q", attr)
+		}
+		seen[attr] = true
+		ctx, ok := r.Attribute(name, attr, linkRel)
+		if !ok {
+			return HTML{}, fmt.Errorf("attribute %q is not allowed in element %q", attr, name)
+		}
+		if err := writeTypedAttr(&w, ctx, attr, a.Value); err != nil {
+			return HTML{}, fmt.Errorf("attribute %q of element %q: %v", attr, name, err)
+		}
+	}
+	w.closeTag()
+	if !void {
+		for _, child := range children {
+			w.writeHTML(child)
+		}
+		w.end(name)
+	}
+	if err := c.check(w.b.Len()); err != nil {
+		return HTML{}, err
+	}
+	return w.html(), nil
+}
+
+// checkElement returns an error if HTMLElement does not allow the named
+// element with the contracts in r, or the stock contracts if r is nil, and
+// reports whether it is a void element and whether it can have HTML children.
+func checkElement(r *registry.Registry, name string) (void, htmlContent bool, err error) {
+	for _, v := range r.VoidElements() {
+		if v == name {
+			return true, false, nil
+		}
+	}
+	ctx, ok := r.ElementContent(name)
+	if !ok || ctx != registry.HTML && ctx != registry.RCDATA {
+		return false, false, fmt.Errorf("element %q is not allowed", name)
+	}
+	return false, ctx == registry.HTML, nil
+}
+
+// enumValues contains the values allowed in attributes with enumerated
+// values.
+var enumValues = map[registry.Context][]string{
+	registry.AsyncEnum:   {"async"},
+	registry.DirEnum:     {"auto", "ltr", "rtl"},
+	registry.LoadingEnum: {"eager", "lazy"},
+	registry.TargetEnum:  {"_blank", "_self"},
+}
+
+// writeTypedAttr writes the named attribute, whose value v must have a type
+// allowed in context ctx.
+func writeTypedAttr(w *elementWriter, ctx registry.Context, name string, v interface{}) error {
+	switch ctx {
+	case registry.None:
+		switch v := v.(type) {
+		case string:
+			w.requiredAttr(name, v)
+		case int:
+			w.requiredAttr(name, strconv.Itoa(v))
+		case bool:
+			w.boolAttr(name, v)
+		default:
+			return fmt.Errorf("got value of type %T, want string, int, or bool", v)
+		}
+	case registry.URL, registry.TrustedResourceURLOrURL:
+		switch v := v.(type) {
+		case URL:
+			w.urlAttr(name, v.str)
+		case TrustedResourceURL:
+			if ctx == registry.URL {
+				// As in safehtml/template, a TrustedResourceURL is
+				// sanitized like a string where it is not expected.
+				w.urlAttr(name, URLSanitized(v.str).str)
+			} else {
+				w.urlAttr(name, v.str)
+			}
+		case string:
+			w.urlAttr(name, URLSanitized(v).str)
+		default:
+			return fmt.Errorf("got value of type %T, want URL or string", v)
+		}
+	case registry.TrustedResourceURL:
+		u, ok := v.(TrustedResourceURL)
+		if !ok {
+			return fmt.Errorf("got value of type %T, want TrustedResourceURL", v)
+		}
+		w.urlAttr(name, u.str)
+	case registry.URLSet:
+		switch v := v.(type) {
+		case URLSet:
+			w.requiredAttr(name, v.str)
+		case string:
+			w.requiredAttr(name, URLSetSanitized(v).str)
+		default:
+			return fmt.Errorf("got value of type %T, want URLSet or string", v)
+		}
+	case registry.Identifier:
+		id, ok := v.(Identifier)
+		if !ok {
+			return fmt.Errorf("got value of type %T, want Identifier", v)
+		}
+		w.requiredAttr(name, id.str)
+	case registry.Style:
+		s, ok := v.(Style)
+		if !ok {
+			return fmt.Errorf("got value of type %T, want Style", v)
+		}
+		w.requiredAttr(name, s.str)
+	case registry.HTMLValOnly:
+		h, ok := v.(HTML)
+		if !ok {
+			return fmt.Errorf("got value of type %T, want HTML", v)
+		}
+		w.requiredAttr(name, h.str)
+	case registry.AsyncEnum, registry.DirEnum, registry.LoadingEnum, registry.TargetEnum:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("got value of type %T, want string", v)
+		}
+		for _, allowed := range enumValues[ctx] {
+			if strings.EqualFold(s, allowed) {
+				w.requiredAttr(name, allowed)
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %q", s, enumValues[ctx])
+	default:
+		return fmt.Errorf("values in context %s are not supported", ctx)
+	}
+	return nil
+}
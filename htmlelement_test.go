@@ -0,0 +1,122 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+
+	"github.com/google/safehtml/registry"
+)
+
+func TestHTMLElement(t *testing.T) {
+	for _, test := range [...]struct {
+		desc     string
+		name     string
+		attrs    []Attr
+		children []HTML
+		want     string
+	}{
+		{
+			desc:     "link",
+			name:     "a",
+			attrs:    []Attr{{"href", URLSanitized("/home?a=b&c")}, {"class", `nav "main"`}, {"target", "_BLANK"}},
+			children: []HTML{HTMLEscaped("<Home>")},
+			want:     `<a href="/home?a=b&amp;c" class="nav &#34;main&#34;" target="_blank">&lt;Home&gt;</a>`,
+		},
+		{
+			desc:  "string URL is sanitized",
+			name:  "a",
+			attrs: []Attr{{"href", "javascript:alert(1)"}},
+			want:  `<a href="about:invalid#zGoSafez"></a>`,
+		},
+		{
+			desc:  "trusted resource URL",
+			name:  "img",
+			attrs: []Attr{{"src", TrustedResourceURLFromConstant("/a.png")}, {"alt", ""}, {"width", 10}, {"ismap", true}, {"hidden", false}},
+			want:  `<img src="/a.png" alt="" width="10" ismap>`,
+		},
+		{
+			desc:  "srcset",
+			name:  "img",
+			attrs: []Attr{{"srcset", "/a.png 1x, javascript:x 2x"}},
+			want:  `<img srcset="/a.png 1x">`,
+		},
+		{
+			desc:     "identifier and style",
+			name:     "div",
+			attrs:    []Attr{{"ID", IdentifierFromConstant("main")}, {"style", StyleFromConstant("color:red;")}, {"data-x", "1"}},
+			children: []HTML{HTMLEscaped("a"), HTMLEscaped("b")},
+			want:     `<div id="main" style="color:red;" data-x="1">ab</div>`,
+		},
+		{
+			desc:  "link rel with URL",
+			name:  "link",
+			attrs: []Attr{{"rel", "canonical"}, {"href", URLSanitized("https://example.com/")}},
+			want:  `<link rel="canonical" href="https://example.com/">`,
+		},
+		{
+			desc:  "srcdoc",
+			name:  "iframe",
+			attrs: []Attr{{"srcdoc", HTMLEscaped("<b>")}},
+			want:  `<iframe srcdoc="&amp;lt;b&amp;gt;"></iframe>`,
+		},
+	} {
+		got, err := HTMLElement(test.name, test.attrs, test.children...)
+		if err != nil {
+			t.Errorf("%s: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s:\ngot  %s\nwant %s", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestHTMLElementErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc     string
+		name     string
+		attrs    []Attr
+		children []HTML
+	}{
+		{"script", "script", nil, nil},
+		{"style", "style", nil, nil},
+		{"unknown element", "blink", nil, nil},
+		{"void element children", "br", nil, []HTML{HTMLEscaped("a")}},
+		{"RCDATA element children", "title", nil, []HTML{HTMLEscaped("a")}},
+		{"event handler", "b", []Attr{{"onclick", "alert(1)"}}, nil},
+		{"duplicate attribute", "b", []Attr{{"title", "a"}, {"TITLE", "b"}}, nil},
+		{"string identifier", "div", []Attr{{"id", "main"}}, nil},
+		{"string style", "div", []Attr{{"style", "color:red"}}, nil},
+		{"URL requiring trusted resource URL", "link", []Attr{{"rel", "stylesheet"}, {"href", URLSanitized("/a.css")}}, nil},
+		{"invalid enum", "div", []Attr{{"dir", "sideways"}}, nil},
+		{"unsupported value type", "div", []Attr{{"title", 1.5}}, nil},
+	} {
+		if _, err := HTMLElement(test.name, test.attrs, test.children...); err == nil {
+			t.Errorf("%s: HTMLElement succeeded, want error", test.desc)
+		}
+	}
+}
+
+func TestHTMLElementRegistry(t *testing.T) {
+	r := registry.Default().
+		AllowElement("x-card", registry.HTML).
+		AllowElementAttribute("x-card", "x-link", registry.URL)
+	attrs := []Attr{{"x-link", "javascript:x()"}, {"title", "t"}}
+	if _, err := HTMLElement("x-card", attrs); err == nil {
+		t.Error("HTMLElement succeeded with the stock contracts, want error")
+	}
+	got, err := HTMLElementWithOptions("x-card", attrs, []HTML{HTMLEscaped("a")}, ElementRegistry(r))
+	if want := `<x-card x-link="about:invalid#zGoSafez" title="t">a</x-card>`; err != nil || got.String() != want {
+		t.Errorf("HTMLElementWithOptions = %q, %v, want %q", got, err, want)
+	}
+	b := NewHTMLBuilder(ElementRegistry(r))
+	b.AppendElement("x-card", nil)
+	if got, err := b.HTML(); err != nil || got.String() != "<x-card></x-card>" {
+		t.Errorf("HTMLBuilder = %q, %v, want %q", got, err, "<x-card></x-card>")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package htmltest provides assertions on the structure of HTML values for
+// the tests of applications, so that they need not match the rendered
+// strings with regular expressions. For example,
+//
+//	h, err := tmpl.ExecuteToHTML(data)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	htmltest.MustContainElement(t, h, "a[href^='https://']")
+//	htmltest.MustNotContainScripts(t, h)
+//
+// Elements are selected using a subset of CSS selectors: type selectors such
+// as a and the universal selector *, ID selectors, class selectors, attribute
+// selectors with the operators =, ~=, ^=, $=, and *=, the descendant and
+// child combinators, and selector lists separated by commas.
+package htmltest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/dom"
+	"github.com/google/safehtml/uncheckedconversions"
+)
+
+// Select returns the elements of h that match selector, in document order.
+// It returns an error if selector is invalid.
+func Select(h safehtml.HTML, selector string) ([]dom.Node, error) {
+	sel, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := dom.Parse(h)
+	if err != nil {
+		return nil, err
+	}
+	var ret []dom.Node
+	tree.Walk(func(n dom.Node) bool {
+		if sel.matches(n) {
+			ret = append(ret, n)
+		}
+		return true
+	})
+	return ret, nil
+}
+
+// MustContainElement fails the test immediately unless h contains an element
+// that matches selector.
+func MustContainElement(tb testing.TB, h safehtml.HTML, selector string) {
+	tb.Helper()
+	nodes, err := Select(h, selector)
+	if err != nil {
+		tb.Fatalf("htmltest: %v", err)
+	}
+	if len(nodes) == 0 {
+		tb.Fatalf("htmltest: no element matches %q in %s", selector, snippet(h))
+	}
+}
+
+// MustNotContainElement fails the test immediately if h contains an element
+// that matches selector.
+func MustNotContainElement(tb testing.TB, h safehtml.HTML, selector string) {
+	tb.Helper()
+	nodes, err := Select(h, selector)
+	if err != nil {
+		tb.Fatalf("htmltest: %v", err)
+	}
+	if len(nodes) > 0 {
+		tb.Fatalf("htmltest: %d elements match %q in %s", len(nodes), selector, snippet(h))
+	}
+}
+
+// MustNotContainScripts fails the test immediately if h contains script
+// elements, other than data blocks such as JSON, inline event handlers such
+// as onclick, or javascript: URLs, including in the srcdoc of iframe
+// elements.
+func MustNotContainScripts(tb testing.TB, h safehtml.HTML) {
+	tb.Helper()
+	script, err := findScript(h)
+	if err != nil {
+		tb.Fatalf("htmltest: %v", err)
+	}
+	if script != "" {
+		tb.Fatalf("htmltest: found %s in %s", script, snippet(h))
+	}
+}
+
+// findScript returns a description of the first script found in h, or the
+// empty string if there is none.
+func findScript(h safehtml.HTML) (string, error) {
+	tree, err := dom.Parse(h)
+	if err != nil {
+		return "", err
+	}
+	var script string
+	tree.Walk(func(n dom.Node) bool {
+		if script != "" || !n.IsElement() {
+			return false
+		}
+		if n.Tag() == "script" {
+			if typ, _ := n.Attr("type"); isScriptType(typ) {
+				script = "script element"
+				return false
+			}
+		}
+		for _, a := range n.Attrs() {
+			switch {
+			case strings.HasPrefix(a.Key, "on"):
+				script = fmt.Sprintf("event handler attribute %q", a.Key)
+			case isJavaScriptURL(a.Val):
+				script = fmt.Sprintf("javascript: URL in attribute %q", a.Key)
+			case n.Tag() == "iframe" && a.Key == "srcdoc":
+				// The srcdoc of safe HTML is itself safe HTML, which
+				// is only parsed here.
+				srcdoc := uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(a.Val)
+				if s, err := findScript(srcdoc); err == nil && s != "" {
+					script = s + " in srcdoc"
+				}
+			}
+			if script != "" {
+				return false
+			}
+		}
+		return true
+	})
+	return script, nil
+}
+
+// isScriptType reports whether a script element with the given type attribute
+// value runs script, rather than being a data block.
+func isScriptType(typ string) bool {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	if i := strings.IndexByte(typ, ';'); i != -1 {
+		typ = strings.TrimSpace(typ[:i])
+	}
+	switch typ {
+	case "", "module", "importmap", "speculationrules":
+		return true
+	}
+	return strings.Contains(typ, "javascript") || strings.Contains(typ, "ecmascript") || strings.Contains(typ, "jscript")
+}
+
+// isJavaScriptURL reports whether url has the javascript scheme, as browsers
+// parse it.
+func isJavaScriptURL(url string) bool {
+	url = strings.TrimLeft(url, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\t\n\x0b\x0c\r\x0e\x0f\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f ")
+	url = strings.NewReplacer("\t", "", "\n", "", "\r", "").Replace(url)
+	return len(url) >= len("javascript:") && strings.EqualFold(url[:len("javascript:")], "javascript:")
+}
+
+// snippet returns h for failure messages, shortened if it is long.
+func snippet(h safehtml.HTML) string {
+	const max = 200
+	if s := h.String(); len(s) > max {
+		return fmt.Sprintf("%q...", s[:max])
+	}
+	return fmt.Sprintf("%q", h.String())
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package htmltest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml/testconversions"
+)
+
+const page = `<div id="main" class="content wide">` +
+	`<p class="intro">Hello <a href="https://example.com/a" rel="noopener">secure</a></p>` +
+	`<ul><li><a href="http://example.com/b">plain</a></li><li><a href="/c.pdf">doc</a></li></ul>` +
+	`</div><footer><a href="/about">about</a></footer>`
+
+func TestSelect(t *testing.T) {
+	for _, test := range [...]struct {
+		selector string
+		want     []string
+	}{
+		{"a", []string{"secure", "plain", "doc", "about"}},
+		{"A[href^='https://']", []string{"secure"}},
+		{`a[href$=".pdf"]`, []string{"doc"}},
+		{"a[href*=example]", []string{"secure", "plain"}},
+		{"a[rel]", []string{"secure"}},
+		{"a[rel=noopener]", []string{"secure"}},
+		{"#main a", []string{"secure", "plain", "doc"}},
+		{"div > a", nil},
+		{"p > a", []string{"secure"}},
+		{"div ul > li a", []string{"plain", "doc"}},
+		{".wide .intro", []string{"Hello secure"}},
+		{"div.content.wide > p.intro", []string{"Hello secure"}},
+		{"*[class~=intro]", []string{"Hello secure"}},
+		{"footer a, p a", []string{"secure", "about"}},
+		{"li:first-child", nil},
+	} {
+		nodes, err := Select(testconversions.MakeHTMLForTest(page), test.selector)
+		if test.selector == "li:first-child" {
+			if err == nil {
+				t.Errorf("%s: expected error for unsupported selector", test.selector)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.selector, err)
+			continue
+		}
+		var got []string
+		for _, n := range nodes {
+			got = append(got, n.Text())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("%s: got %q, want %q", test.selector, got, test.want)
+		}
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, s := range [...]string{"", "a,", "> a", "a >", "a[", "a[href", "a[href|=x]", "a[href='x]", "#", "a b["} {
+		if _, err := parseSelector(s); err == nil {
+			t.Errorf("parseSelector(%q): expected error", s)
+		}
+	}
+}
+
+// fakeTB records the failures of a test.
+type fakeTB struct {
+	testing.TB
+	failure string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Fatalf(format string, args ...interface{}) {
+	tb.failure = fmt.Sprintf(format, args...)
+	panic(tb)
+}
+
+// failure returns the failure of f, or the empty string if it succeeds.
+func failure(f func(tb testing.TB)) (msg string) {
+	tb := &fakeTB{}
+	defer func() {
+		if r := recover(); r != nil && r != tb {
+			panic(r)
+		}
+		msg = tb.failure
+	}()
+	f(tb)
+	return ""
+}
+
+func TestMustContainElement(t *testing.T) {
+	h := testconversions.MakeHTMLForTest(page)
+	if msg := failure(func(tb testing.TB) { MustContainElement(tb, h, "a[href^='https://']") }); msg != "" {
+		t.Errorf("unexpected failure: %s", msg)
+	}
+	if msg := failure(func(tb testing.TB) { MustContainElement(tb, h, "form") }); !strings.Contains(msg, `no element matches "form"`) {
+		t.Errorf("got failure %q, want no element matches", msg)
+	}
+	if msg := failure(func(tb testing.TB) { MustNotContainElement(tb, h, "form, iframe") }); msg != "" {
+		t.Errorf("unexpected failure: %s", msg)
+	}
+	if msg := failure(func(tb testing.TB) { MustNotContainElement(tb, h, "li") }); !strings.Contains(msg, `2 elements match "li"`) {
+		t.Errorf("got failure %q, want 2 elements match", msg)
+	}
+	if msg := failure(func(tb testing.TB) { MustContainElement(tb, h, "a[") }); !strings.Contains(msg, "invalid selector") {
+		t.Errorf("got failure %q, want invalid selector", msg)
+	}
+}
+
+func TestMustNotContainScripts(t *testing.T) {
+	for _, test := range [...]struct {
+		in, want string
+	}{
+		{page, ""},
+		{`<script type="application/ld+json">{}</script><a href="javascript-guide.html">x</a>`, ""},
+		{`<p>x</p><script>alert(1)</script>`, "script element"},
+		{`<script type="module" src="/m.js"></script>`, "script element"},
+		{`<script type="text/javascript;charset=utf-8"></script>`, "script element"},
+		{`<img src="x" onerror="alert(1)">`, `event handler attribute "onerror"`},
+		{`<a href=" JavaScript:alert(1)">x</a>`, `javascript: URL in attribute "href"`},
+		{"<a href=\"java\tscript:alert(1)\">x</a>", `javascript: URL in attribute "href"`},
+		{`<iframe srcdoc="<script>alert(1)</script>"></iframe>`, "script element in srcdoc"},
+		{`<iframe srcdoc="<p>ok</p>"></iframe>`, ""},
+	} {
+		msg := failure(func(tb testing.TB) { MustNotContainScripts(tb, testconversions.MakeHTMLForTest(test.in)) })
+		if test.want == "" && msg != "" || !strings.Contains(msg, test.want) {
+			t.Errorf("%s: got failure %q, want %q", test.in, msg, test.want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package htmltest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/safehtml/dom"
+)
+
+// A selector is a compiled CSS selector list.
+type selector [][]step
+
+// A step is a compound selector of a complex selector, with the combinator
+// that relates it to the compound selector before it.
+type step struct {
+	// child reports whether the combinator is the child combinator ">"
+	// rather than the descendant combinator.
+	child bool
+	// tag is the lowercase tag name of the elements matched, or the empty
+	// string for any element.
+	tag   string
+	attrs []attrMatcher
+}
+
+// An attrMatcher matches elements with an attribute selector such as
+// [href^='https:'].
+type attrMatcher struct {
+	name string
+	// op is the operator of the selector, such as "^=", or the empty string
+	// if the selector matches elements having the attribute.
+	op, value string
+}
+
+// parseSelector compiles s, a list of complex selectors separated by commas.
+// Complex selectors consist of compound selectors separated by the descendant
+// or child combinators, and compound selectors of an optional tag name or
+// "*", followed by ID selectors, class selectors, and attribute selectors
+// with the operators =, ~=, ^=, $=, and *=.
+func parseSelector(s string) (selector, error) {
+	p := &selectorParser{s: s}
+	var sel selector
+	for {
+		complex, err := p.complex()
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %v", s, err)
+		}
+		sel = append(sel, complex)
+		if p.done() {
+			return sel, nil
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("invalid selector %q: unexpected %q at offset %d", s, p.s[p.i], p.i)
+		}
+	}
+}
+
+type selectorParser struct {
+	s string
+	i int
+}
+
+func (p *selectorParser) done() bool {
+	p.skipSpace()
+	return p.i == len(p.s)
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.i
+	for p.i < len(p.s) && strings.IndexByte(" \t\n\f\r", p.s[p.i]) != -1 {
+		p.i++
+	}
+	return p.i > start
+}
+
+// consume skips whitespace and c, and reports whether c was found.
+func (p *selectorParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) complex() ([]step, error) {
+	p.skipSpace()
+	var steps []step
+	child := false
+	for {
+		st, err := p.compound()
+		if err != nil {
+			return nil, err
+		}
+		st.child = child
+		steps = append(steps, st)
+		space := p.skipSpace()
+		switch {
+		case p.i == len(p.s) || p.s[p.i] == ',':
+			return steps, nil
+		case p.s[p.i] == '>':
+			p.i++
+			p.skipSpace()
+			child = true
+		case space:
+			child = false
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.s[p.i], p.i)
+		}
+	}
+}
+
+func (p *selectorParser) compound() (step, error) {
+	var st step
+	universal := p.i < len(p.s) && p.s[p.i] == '*'
+	if universal {
+		p.i++
+	} else {
+		st.tag = strings.ToLower(p.ident())
+	}
+loop:
+	for p.i < len(p.s) {
+		switch c := p.s[p.i]; c {
+		case '#', '.':
+			p.i++
+			name := p.ident()
+			if name == "" {
+				return st, fmt.Errorf("missing name after %q at offset %d", c, p.i)
+			}
+			if c == '#' {
+				st.attrs = append(st.attrs, attrMatcher{"id", "=", name})
+			} else {
+				st.attrs = append(st.attrs, attrMatcher{"class", "~=", name})
+			}
+		case '[':
+			p.i++
+			m, err := p.attr()
+			if err != nil {
+				return st, err
+			}
+			st.attrs = append(st.attrs, m)
+		default:
+			break loop
+		}
+	}
+	if st.tag == "" && !universal && len(st.attrs) == 0 {
+		if p.i == len(p.s) {
+			return st, fmt.Errorf("missing selector at offset %d", p.i)
+		}
+		return st, fmt.Errorf("unexpected %q at offset %d", p.s[p.i], p.i)
+	}
+	return st, nil
+}
+
+// attr parses an attribute selector after its opening bracket.
+func (p *selectorParser) attr() (attrMatcher, error) {
+	p.skipSpace()
+	m := attrMatcher{name: strings.ToLower(p.ident())}
+	if m.name == "" {
+		return m, fmt.Errorf("missing attribute name at offset %d", p.i)
+	}
+	p.skipSpace()
+	if p.consume(']') {
+		return m, nil
+	}
+	for _, op := range [...]string{"=", "~=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.s[p.i:], op) {
+			m.op = op
+			p.i += len(op)
+			break
+		}
+	}
+	if m.op == "" {
+		return m, fmt.Errorf("invalid attribute selector operator at offset %d", p.i)
+	}
+	p.skipSpace()
+	if p.i < len(p.s) && (p.s[p.i] == '\'' || p.s[p.i] == '"') {
+		quote := p.s[p.i]
+		end := strings.IndexByte(p.s[p.i+1:], quote)
+		if end == -1 {
+			return m, fmt.Errorf("unterminated string at offset %d", p.i)
+		}
+		m.value = p.s[p.i+1 : p.i+1+end]
+		p.i += end + 2
+	} else {
+		m.value = p.ident()
+	}
+	if !p.consume(']') {
+		return m, fmt.Errorf("missing ] at offset %d", p.i)
+	}
+	return m, nil
+}
+
+// ident parses a name made of letters, digits, hyphens, and underscores.
+func (p *selectorParser) ident() string {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c >= 0x80) {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// matches reports whether the element n matches sel.
+func (sel selector) matches(n dom.Node) bool {
+	for _, steps := range sel {
+		if matchSteps(n, steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSteps reports whether n matches the last of steps, and its ancestors
+// match the others as their combinators require.
+func matchSteps(n dom.Node, steps []step) bool {
+	last := steps[len(steps)-1]
+	if !last.matches(n) {
+		return false
+	}
+	if len(steps) == 1 {
+		return true
+	}
+	for p, ok := n.Parent(); ok; p, ok = p.Parent() {
+		if matchSteps(p, steps[:len(steps)-1]) {
+			return true
+		}
+		if last.child {
+			return false
+		}
+	}
+	return false
+}
+
+func (st step) matches(n dom.Node) bool {
+	if !n.IsElement() || st.tag != "" && n.Tag() != st.tag {
+		return false
+	}
+	for _, m := range st.attrs {
+		if !m.matches(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m attrMatcher) matches(n dom.Node) bool {
+	v, ok := n.Attr(m.name)
+	if !ok {
+		return false
+	}
+	switch m.op {
+	case "=":
+		return v == m.value
+	case "~=":
+		for _, f := range strings.Fields(v) {
+			if f == m.value {
+				return true
+			}
+		}
+		return false
+	case "^=":
+		return m.value != "" && strings.HasPrefix(v, m.value)
+	case "$=":
+		return m.value != "" && strings.HasSuffix(v, m.value)
+	case "*=":
+		return m.value != "" && strings.Contains(v, m.value)
+	}
+	return true
+}
@@ -53,11 +53,21 @@ var onlyAlphanumericsOrHyphenPattern = regexp.MustCompile(`^[-_a-zA-Z0-9]*$`)
 // panics if value does not start with an alphabetic rune or contains any
 // non-alphanumeric runes other than '-' and '_'.
 func IdentifierFromConstant(value stringConstant) Identifier {
+	id, err := TryIdentifierFromConstant(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return id
+}
+
+// TryIdentifierFromConstant is like IdentifierFromConstant, but returns an
+// error instead of panicking if value is not a valid identifier.
+func TryIdentifierFromConstant(value stringConstant) (Identifier, error) {
 	if !startsWithAlphabetPattern.MatchString(string(value)) ||
 		!onlyAlphanumericsOrHyphenPattern.MatchString(string(value)) {
-		panic(fmt.Sprintf("invalid identifier %q", string(value)))
+		return Identifier{}, fmt.Errorf("invalid identifier %q", string(value))
 	}
-	return Identifier{string(value)}
+	return Identifier{string(value)}, nil
 }
 
 // IdentifierFromConstantPrefix constructs an Identifier with its underlying string
@@ -66,15 +76,25 @@ func IdentifierFromConstant(value stringConstant) Identifier {
 // non-alphanumeric runes other than '-' and '_', or if prefix does not start with
 // an alphabetic rune.
 func IdentifierFromConstantPrefix(prefix stringConstant, value string) Identifier {
+	id, err := TryIdentifierFromConstantPrefix(prefix, value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return id
+}
+
+// TryIdentifierFromConstantPrefix is like IdentifierFromConstantPrefix, but
+// returns an error instead of panicking if prefix or value is invalid.
+func TryIdentifierFromConstantPrefix(prefix stringConstant, value string) (Identifier, error) {
 	prefixString := string(prefix)
 	if !startsWithAlphabetPattern.MatchString(string(prefix)) ||
 		!onlyAlphanumericsOrHyphenPattern.MatchString(string(prefix)) {
-		panic(fmt.Sprintf("invalid prefix %q", string(prefix)))
+		return Identifier{}, fmt.Errorf("invalid prefix %q", string(prefix))
 	}
 	if !onlyAlphanumericsOrHyphenPattern.MatchString(value) {
-		panic(fmt.Sprintf("value %q contains non-alphanumeric runes", value))
+		return Identifier{}, fmt.Errorf("value %q contains non-alphanumeric runes", value)
 	}
-	return Identifier{prefixString + "-" + value}
+	return Identifier{prefixString + "-" + value}, nil
 }
 
 // String returns the string form of the Identifier.
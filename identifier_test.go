@@ -7,6 +7,7 @@
 package safehtml
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -42,6 +43,9 @@ func TestIdentifierFromConstant(t *testing.T) {
 		{"4wesome", "invalid identifier"},
 	} {
 		id, panicMsg := tryIdentifierFromConstant(test.value)
+		if tryID, err := TryIdentifierFromConstant(stringConstant(test.value)); fmt.Sprint(err) != fmt.Sprint(errorOrNil(panicMsg)) || tryID != id {
+			t.Errorf("value %q: TryIdentifierFromConstant = %q, %v, want %q and an error matching the panic %q", test.value, tryID, err, id, panicMsg)
+		}
 		if test.panicMsg != "" {
 			if !strings.Contains(panicMsg, test.panicMsg) {
 				t.Errorf("value %q: got panic message:\n\t%q\nwant:\n\t%q", test.value, panicMsg, test.panicMsg)
@@ -97,6 +101,9 @@ func TestIdentifierFromConstantPrefix(t *testing.T) {
 	} {
 		id, panicMsg := tryIdentifierFromConstantPrefix(test.prefix, test.value)
 		inputs := fmt.Sprintf("prefix %q, value %q", test.prefix, test.value)
+		if tryID, err := TryIdentifierFromConstantPrefix(stringConstant(test.prefix), test.value); fmt.Sprint(err) != fmt.Sprint(errorOrNil(panicMsg)) || tryID != id {
+			t.Errorf("%s: TryIdentifierFromConstantPrefix = %q, %v, want %q and an error matching the panic %q", inputs, tryID, err, id, panicMsg)
+		}
 		if test.panicMsg != "" {
 			if !strings.Contains(panicMsg, test.panicMsg) {
 				t.Errorf("%s: got panic message:\n\t%q\nwant:\n\t%q", inputs, panicMsg, test.panicMsg)
@@ -112,3 +119,11 @@ func TestIdentifierFromConstantPrefix(t *testing.T) {
 		}
 	}
 }
+
+// errorOrNil returns an error with the given message, or nil if msg is empty.
+func errorOrNil(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
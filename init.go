@@ -47,6 +47,10 @@ func identifierRaw(s string) Identifier {
 	return Identifier{s}
 }
 
+func svgRaw(s string) SVG {
+	return SVG{s}
+}
+
 func init() {
 	raw.HTML = htmlRaw
 	raw.Script = scriptRaw
@@ -55,4 +59,5 @@ func init() {
 	raw.URL = urlRaw
 	raw.TrustedResourceURL = trustedResourceURLRaw
 	raw.Identifier = identifierRaw
+	raw.SVG = svgRaw
 }
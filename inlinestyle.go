@@ -0,0 +1,45 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// StyleSheetElement returns a style element containing ss:
+//
+//	<style nonce="...">...</style>
+//
+// nonce is the Content-Security-Policy nonce of the style element, and may be
+// empty if the page allows the style element by its hash, as returned by
+// StyleSheetHash, instead.
+func StyleSheetElement(ss StyleSheet, nonce string) HTML {
+	var w elementWriter
+	w.open("style")
+	w.attr("nonce", nonce)
+	w.closeTag()
+	w.b.WriteString(ss.str)
+	w.end("style")
+	return w.html()
+}
+
+// StyleSheetHash returns the Content-Security-Policy hash source of a style
+// element containing ss, such as
+// "'sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU='", for the
+// style-src directive of a policy allowing the element returned by
+// StyleSheetElement(ss, "").
+func StyleSheetHash(ss StyleSheet) string {
+	return CSPHash(ss.str)
+}
+
+// CSPHash returns the Content-Security-Policy hash source of an inline
+// script or style element whose content is s, the SHA-256 hash of s.
+func CSPHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
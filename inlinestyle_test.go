@@ -0,0 +1,36 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestStyleSheetElement(t *testing.T) {
+	ss := StyleSheetFromConstant(`body{margin:0}`)
+	if got, want := StyleSheetElement(ss, "r4nd0m").String(), `<style nonce="r4nd0m">body{margin:0}</style>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := StyleSheetElement(ss, "").String(), `<style>body{margin:0}</style>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestStyleSheetHash(t *testing.T) {
+	for _, test := range [...]struct {
+		ss   StyleSheet
+		want string
+	}{
+		// The hash of the empty string.
+		{StyleSheet{}, `'sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU='`},
+		{StyleSheetFromConstant(`body{margin:0}`), `'sha256-IAdwN3biDCQ3brrgp1m8kBEsPQYyqfREKOEfeoREopc='`},
+	} {
+		if got := StyleSheetHash(test.ss); got != test.want {
+			t.Errorf("StyleSheetHash(%q) = %q, want %q", test.ss, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"container/list"
+	"sync"
+)
+
+// An HTMLInterner deduplicates HTML values, so that servers emitting the same
+// small fragments, such as icons and badges, many times hold a single copy of
+// each and build it only once. It keeps at most a fixed number of values,
+// evicting the least recently used value when it is full. It is safe for
+// concurrent use.
+type HTMLInterner struct {
+	maxEntries, maxSize int
+
+	mu sync.Mutex
+	// entries maps the key of each interned value to its element in lru,
+	// whose Value is an internedHTML.
+	entries map[internKey]*list.Element
+	// lru orders the interned values from the most to the least recently
+	// used.
+	lru   *list.List
+	stats InternerStats
+}
+
+// InternerStats contains metrics of an HTMLInterner.
+type InternerStats struct {
+	// Entries is the number of values interned.
+	Entries int
+	// Hits is the number of lookups that returned an interned value.
+	Hits uint64
+	// Misses is the number of lookups of values that were not interned.
+	Misses uint64
+	// Evictions is the number of values evicted to make room for others.
+	Evictions uint64
+	// Skipped is the number of values that were not interned because they
+	// exceed the maximum size.
+	Skipped uint64
+}
+
+// NewHTMLInterner returns an HTMLInterner that interns at most maxEntries
+// values of at most maxSize bytes each. Larger values are returned without
+// being interned.
+func NewHTMLInterner(maxEntries, maxSize int) *HTMLInterner {
+	return &HTMLInterner{
+		maxEntries: maxEntries,
+		maxSize:    maxSize,
+		entries:    make(map[internKey]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Intern returns the interned HTML value equal to h, interning h if there is
+// none.
+func (in *HTMLInterner) Intern(h HTML) HTML {
+	if len(h.str) > in.maxSize {
+		in.skip()
+		return h
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	key := internKey{s: h.str}
+	if interned, ok := in.lookup(key); ok {
+		return interned
+	}
+	in.add(key, h)
+	return h
+}
+
+// HTMLEscaped returns the interned value of HTMLEscaped(text). Unlike
+// Intern(HTMLEscaped(text)), it escapes text only if the result is not
+// interned yet.
+func (in *HTMLInterner) HTMLEscaped(text string) HTML {
+	if len(text) > in.maxSize {
+		// Escaping rarely shortens text, so do not intern the result.
+		in.skip()
+		return HTMLEscaped(text)
+	}
+	key := internKey{s: text, escaped: true}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if interned, ok := in.lookup(key); ok {
+		return interned
+	}
+	h := HTMLEscaped(text)
+	if len(h.str) > in.maxSize {
+		in.stats.Skipped++
+		return h
+	}
+	in.add(key, h)
+	return h
+}
+
+func (in *HTMLInterner) skip() {
+	in.mu.Lock()
+	in.stats.Skipped++
+	in.mu.Unlock()
+}
+
+// An internKey identifies an interned value.
+type internKey struct {
+	// s is the string form of the value, or the text it was escaped from
+	// if escaped is true.
+	s       string
+	escaped bool
+}
+
+// lookup returns the value interned under key, and records the lookup.
+func (in *HTMLInterner) lookup(key internKey) (HTML, bool) {
+	e, ok := in.entries[key]
+	if !ok {
+		in.stats.Misses++
+		return HTML{}, false
+	}
+	in.stats.Hits++
+	in.lru.MoveToFront(e)
+	return e.Value.(internedHTML).html, true
+}
+
+type internedHTML struct {
+	key  internKey
+	html HTML
+}
+
+// add interns h under key, evicting the least recently used value if in is
+// full.
+func (in *HTMLInterner) add(key internKey, h HTML) {
+	if in.maxEntries <= 0 {
+		return
+	}
+	if in.lru.Len() >= in.maxEntries {
+		oldest := in.lru.Back()
+		in.lru.Remove(oldest)
+		delete(in.entries, oldest.Value.(internedHTML).key)
+		in.stats.Evictions++
+	}
+	in.entries[key] = in.lru.PushFront(internedHTML{key: key, html: h})
+}
+
+// Stats returns the current metrics of in.
+func (in *HTMLInterner) Stats() InternerStats {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	stats := in.stats
+	stats.Entries = in.lru.Len()
+	return stats
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestHTMLInterner(t *testing.T) {
+	in := NewHTMLInterner(2, 16)
+	icon := HTML{`<i class="icon">`}
+	if got := in.Intern(icon); got != icon {
+		t.Errorf("Intern(%q) = %q", icon, got)
+	}
+	if got := in.Intern(HTML{`<i class="icon">`}); got != icon {
+		t.Errorf("Intern of equal value = %q, want %q", got, icon)
+	}
+	if got, want := in.HTMLEscaped("<b>"), "&lt;b&gt;"; got.String() != want {
+		t.Errorf("HTMLEscaped(%q) = %q, want %q", "<b>", got, want)
+	}
+	// An interned value whose string form equals the escaped text is not
+	// returned for it.
+	in.Intern(HTML{"<b>"}) // Evicts icon.
+	if got, want := in.HTMLEscaped("<b>"), "&lt;b&gt;"; got.String() != want {
+		t.Errorf("HTMLEscaped(%q) = %q, want %q", "<b>", got, want)
+	}
+	in.Intern(HTML{"a very long fragment of HTML"})
+	want := InternerStats{Entries: 2, Hits: 2, Misses: 3, Evictions: 1, Skipped: 1}
+	if got := in.Stats(); got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestHTMLInternerNoEntries(t *testing.T) {
+	in := NewHTMLInterner(0, 16)
+	if got, want := in.HTMLEscaped("&"), "&amp;"; got.String() != want {
+		t.Errorf("HTMLEscaped(%q) = %q, want %q", "&", got, want)
+	}
+	if got := in.Stats(); got.Entries != 0 || got.Misses != 1 {
+		t.Errorf("got stats %+v, want no entries and 1 miss", got)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package diag holds the Logger set by safehtml.SetLogger, to which the
+// packages of this module report diagnostics.
+package diag
+
+import (
+	"sync/atomic"
+)
+
+// A Logger receives diagnostics. It has the method set of safehtml.Logger.
+type Logger interface {
+	Log(msg string, keyvals ...interface{})
+}
+
+// loggerHolder wraps the current Logger, since an atomic.Value cannot store
+// nil or values of different types.
+type loggerHolder struct {
+	l Logger
+}
+
+var logger atomic.Value
+
+// SetLogger sets the Logger that Log reports diagnostics to, or disables
+// logging if l is nil.
+func SetLogger(l Logger) {
+	logger.Store(loggerHolder{l})
+}
+
+// Log reports a diagnostic with the given message and alternating keys and
+// values to the current Logger, if any.
+func Log(msg string, keyvals ...interface{}) {
+	if h, ok := logger.Load().(loggerHolder); ok && h.l != nil {
+		h.l.Log(msg, keyvals...)
+	}
+}
@@ -29,3 +29,6 @@ var TrustedResourceURL interface{}
 
 // Identifier is the raw constructor for a safehtml.Identifier.
 var Identifier interface{}
+
+// SVG is the raw constructor for a safehtml.SVG.
+var SVG interface{}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"sync"
+)
+
+// A LazyHTML is an HTML value that is computed when it is used rather than
+// when it is created. safehtml/template accepts LazyHTML values wherever it
+// accepts HTML values, and calls them when the action interpolating them is
+// executed, so that a fragment that is expensive to render, such as a
+// sidebar only shown to some users, is only computed if the branch of the
+// template containing it executes:
+//
+//	data.Sidebar = safehtml.LazyHTML(func() (safehtml.HTML, error) {
+//		return renderSidebar(ctx, user)
+//	})
+//
+// An error returned by a LazyHTML aborts the execution of the template.
+type LazyHTML func() (HTML, error)
+
+// Once returns a LazyHTML that calls f on its first call only, and returns
+// the results of that call on every call, so that a fragment interpolated by
+// several actions is computed at most once. The returned LazyHTML is safe for
+// concurrent use.
+func (f LazyHTML) Once() LazyHTML {
+	var (
+		once sync.Once
+		h    HTML
+		err  error
+	)
+	return func() (HTML, error) {
+		once.Do(func() {
+			h, err = f()
+		})
+		return h, err
+	}
+}
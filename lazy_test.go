@@ -0,0 +1,37 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestLazyHTMLOnce(t *testing.T) {
+	calls := 0
+	errRender := errors.New("render failed")
+	lazy := LazyHTML(func() (HTML, error) {
+		calls++
+		return HTMLEscaped("<b>"), errRender
+	}).Once()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, err := lazy()
+			if h.String() != "&lt;b&gt;" || err != errRender {
+				t.Errorf("got %q, %v, want %q, %v", h, err, "&lt;b&gt;", errRender)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("LazyHTML called %d times, want 1", calls)
+	}
+}
@@ -17,6 +17,7 @@
 package legacyconversions
 
 import (
+	"github.com/google/safehtml/internal/diag"
 	"github.com/google/safehtml/internal/raw"
 	"github.com/google/safehtml"
 )
@@ -28,45 +29,66 @@ var styleSheet = raw.StyleSheet.(func(string) safehtml.StyleSheet)
 var url = raw.URL.(func(string) safehtml.URL)
 var trustedResourceURL = raw.TrustedResourceURL.(func(string) safehtml.TrustedResourceURL)
 var identifier = raw.Identifier.(func(string) safehtml.Identifier)
+var svg = raw.SVG.(func(string) safehtml.SVG)
+
+// logConversion reports a conversion to the Logger set by safehtml.SetLogger,
+// so that the remaining uses of this package can be tracked during an upgrade.
+func logConversion(typ string) {
+	diag.Log("safehtml/legacyconversions: legacy conversion", "type", typ)
+}
 
 // RiskilyAssumeHTML converts a plain string into a HTML.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeHTML(s string) safehtml.HTML {
+	logConversion("HTML")
 	return html(s)
 }
 
 // RiskilyAssumeScript converts a plain string into a Script.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeScript(s string) safehtml.Script {
+	logConversion("Script")
 	return script(s)
 }
 
 // RiskilyAssumeStyle converts a plain string into a Style.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeStyle(s string) safehtml.Style {
+	logConversion("Style")
 	return style(s)
 }
 
 // RiskilyAssumeStyleSheet converts a plain string into a StyleSheet.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeStyleSheet(s string) safehtml.StyleSheet {
+	logConversion("StyleSheet")
 	return styleSheet(s)
 }
 
 // RiskilyAssumeURL converts a plain string into a URL.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeURL(s string) safehtml.URL {
+	logConversion("URL")
 	return url(s)
 }
 
 // RiskilyAssumeTrustedResourceURL converts a plain string into a TrustedResourceURL.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeTrustedResourceURL(s string) safehtml.TrustedResourceURL {
+	logConversion("TrustedResourceURL")
 	return trustedResourceURL(s)
 }
 
 // RiskilyAssumeIdentifier converts a plain string into an Identifier.
 // This function must only be used for refactoring legacy code.
 func RiskilyAssumeIdentifier(s string) safehtml.Identifier {
+	logConversion("Identifier")
 	return identifier(s)
 }
+
+// RiskilyAssumeSVG converts a plain string into an SVG.
+// This function must only be used for refactoring legacy code.
+func RiskilyAssumeSVG(s string) safehtml.SVG {
+	logConversion("SVG")
+	return svg(s)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/safehtml/registry"
+)
+
+// A Rel is the link type of a link element, the value of its rel attribute.
+// It may contain several space-separated link types, such as "preload
+// stylesheet".
+type Rel string
+
+// Common link types.
+const (
+	RelAlternate     Rel = "alternate"
+	RelCanonical     Rel = "canonical"
+	RelDNSPrefetch   Rel = "dns-prefetch"
+	RelIcon          Rel = "icon"
+	RelManifest      Rel = "manifest"
+	RelModulePreload Rel = "modulepreload"
+	RelPreconnect    Rel = "preconnect"
+	RelPrefetch      Rel = "prefetch"
+	RelPreload       Rel = "preload"
+	RelStylesheet    Rel = "stylesheet"
+)
+
+// relPattern matches the values of rel attributes that LinkHTML accepts.
+var relPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*( [a-z][a-z0-9-]*)*$`)
+
+// LinkOptions contains the optional attributes of a link element.
+type LinkOptions struct {
+	// As is the destination of a preloaded resource, such as "script".
+	As string
+	// Type is the MIME type of the resource, such as "text/css".
+	Type string
+	// Media is the media query the resource applies to.
+	Media string
+	// Sizes is the sizes of an icon, such as "32x32".
+	Sizes string
+	// Hreflang is the language of the resource.
+	Hreflang string
+	// Title is the title of the link, such as the name of an alternate style
+	// sheet.
+	Title string
+	// CrossOrigin is the CORS mode of the request, "anonymous" or
+	// "use-credentials", or empty to make no CORS request.
+	CrossOrigin string
+	// Integrity is the Subresource Integrity metadata of the resource.
+	Integrity string
+	// Nonce is the Content-Security-Policy nonce of the resource.
+	Nonce string
+}
+
+// LinkHTML returns a link element with the given link type, which references
+// the trusted resource href:
+//
+//	<link rel="stylesheet" href="/static/app.css">
+//
+// It returns an error if rel is not a lowercase list of link types or an
+// option is invalid.
+func LinkHTML(rel Rel, href TrustedResourceURL, opts LinkOptions) (HTML, error) {
+	return linkHTML(rel, href.str, opts)
+}
+
+// LinkURLHTML is like LinkHTML, but references href, which may be any safe
+// URL. As in safehtml/template, this is only allowed if rel contains a link
+// type that does not load the resource as code or as a style sheet, such as
+// "canonical", "icon", "preconnect", or "dns-prefetch"; for other link types,
+// LinkURLHTML returns an error.
+func LinkURLHTML(rel Rel, href URL, opts LinkOptions) (HTML, error) {
+	if ctx, _ := (*registry.Registry)(nil).Attribute("link", "href", " "+string(rel)+" "); ctx != registry.TrustedResourceURLOrURL {
+		return HTML{}, fmt.Errorf("link type %q requires a TrustedResourceURL", rel)
+	}
+	return linkHTML(rel, href.str, opts)
+}
+
+func linkHTML(rel Rel, href string, opts LinkOptions) (HTML, error) {
+	if !relPattern.MatchString(string(rel)) {
+		return HTML{}, fmt.Errorf("%q is not a valid link type", rel)
+	}
+	switch opts.CrossOrigin {
+	case "", "anonymous", "use-credentials":
+	default:
+		return HTML{}, fmt.Errorf("%q is not a valid CORS mode", opts.CrossOrigin)
+	}
+	if opts.As != "" && !hasLinkType(rel, RelPreload, RelModulePreload, RelPrefetch) {
+		return HTML{}, fmt.Errorf("as is only allowed in preload and prefetch links, not %q", rel)
+	}
+	var w elementWriter
+	w.open("link")
+	w.attr("rel", string(rel))
+	w.urlAttr("href", href)
+	w.attr("as", opts.As)
+	w.attr("type", opts.Type)
+	w.attr("media", opts.Media)
+	w.attr("sizes", opts.Sizes)
+	w.attr("hreflang", opts.Hreflang)
+	w.attr("title", opts.Title)
+	w.attr("crossorigin", opts.CrossOrigin)
+	w.attr("integrity", opts.Integrity)
+	w.attr("nonce", opts.Nonce)
+	w.closeTag()
+	return w.html(), nil
+}
+
+// hasLinkType reports whether rel contains any of the given link types.
+func hasLinkType(rel Rel, types ...Rel) bool {
+	for _, f := range strings.Fields(string(rel)) {
+		for _, t := range types {
+			if Rel(f) == t {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestLinkHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		rel  Rel
+		opts LinkOptions
+		want string
+	}{
+		{RelStylesheet, LinkOptions{}, `<link rel="stylesheet" href="/static/a.css?v=1&amp;x=y">`},
+		{RelStylesheet, LinkOptions{Media: "print", Integrity: "sha384-abc", CrossOrigin: "anonymous", Nonce: "n"},
+			`<link rel="stylesheet" href="/static/a.css?v=1&amp;x=y" media="print" crossorigin="anonymous" integrity="sha384-abc" nonce="n">`},
+		{RelPreload, LinkOptions{As: "style", Type: "text/css"}, `<link rel="preload" href="/static/a.css?v=1&amp;x=y" as="style" type="text/css">`},
+		{"preload stylesheet", LinkOptions{As: "style"}, `<link rel="preload stylesheet" href="/static/a.css?v=1&amp;x=y" as="style">`},
+		{RelAlternate, LinkOptions{Title: `"Dark"`}, `<link rel="alternate" href="/static/a.css?v=1&amp;x=y" title="&#34;Dark&#34;">`},
+	} {
+		got, err := LinkHTML(test.rel, TrustedResourceURLFromConstant(`/static/a.css?v=1&x=y`), test.opts)
+		if err != nil {
+			t.Errorf("LinkHTML(%q): %v", test.rel, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("LinkHTML(%q):\ngot  %s\nwant %s", test.rel, got, test.want)
+		}
+	}
+}
+
+func TestLinkHTMLErrors(t *testing.T) {
+	href := TrustedResourceURLFromConstant(`/a.css`)
+	for _, test := range [...]struct {
+		rel  Rel
+		opts LinkOptions
+	}{
+		{"", LinkOptions{}},
+		{"Stylesheet", LinkOptions{}},
+		{`stylesheet" onload="x`, LinkOptions{}},
+		{RelStylesheet, LinkOptions{CrossOrigin: "yes"}},
+		{RelStylesheet, LinkOptions{As: "style"}},
+	} {
+		if _, err := LinkHTML(test.rel, href, test.opts); err == nil {
+			t.Errorf("LinkHTML(%q, %+v) succeeded, want error", test.rel, test.opts)
+		}
+	}
+}
+
+func TestLinkURLHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		rel     Rel
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{RelPreconnect, "https://fonts.example.com", `<link rel="preconnect" href="https://fonts.example.com">`, false},
+		{RelDNSPrefetch, "//cdn.example.com", `<link rel="dns-prefetch" href="//cdn.example.com">`, false},
+		{RelCanonical, "https://example.com/a b", `<link rel="canonical" href="https://example.com/a%20b">`, false},
+		{RelIcon, "javascript:alert(1)", `<link rel="icon" href="about:invalid#zGoSafez">`, false},
+		{RelStylesheet, "https://evil.example.com/a.css", "", true},
+		{RelPreload, "https://cdn.example.com/a.js", `<link rel="preload" href="https://cdn.example.com/a.js">`, false},
+		{RelManifest, "/manifest.json", "", true},
+		{"", "/a", "", true},
+	} {
+		got, err := LinkURLHTML(test.rel, URLSanitized(test.url), LinkOptions{})
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("LinkURLHTML(%q, %q) succeeded, want error", test.rel, test.url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("LinkURLHTML(%q, %q): %v", test.rel, test.url, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("LinkURLHTML(%q, %q):\ngot  %s\nwant %s", test.rel, test.url, got, test.want)
+		}
+	}
+}
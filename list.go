@@ -0,0 +1,33 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+// ListFromItems returns an unordered list, a ul element, with a list item
+// for each element of items. The contents of the items are included verbatim.
+func ListFromItems(items []HTML) HTML {
+	return listFromItems("ul", items)
+}
+
+// OrderedListFromItems is like ListFromItems, but returns an ordered list,
+// an ol element.
+func OrderedListFromItems(items []HTML) HTML {
+	return listFromItems("ol", items)
+}
+
+func listFromItems(element string, items []HTML) HTML {
+	var w elementWriter
+	w.open(element)
+	w.closeTag()
+	for _, item := range items {
+		w.open("li")
+		w.closeTag()
+		w.writeHTML(item)
+		w.end("li")
+	}
+	w.end(element)
+	return w.html()
+}
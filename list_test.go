@@ -0,0 +1,22 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import "testing"
+
+func TestListFromItems(t *testing.T) {
+	items := []HTML{HTMLEscaped("<one>"), {"<b>two</b>"}}
+	if got, want := ListFromItems(items).String(), "<ul><li>&lt;one&gt;</li><li><b>two</b></li></ul>"; got != want {
+		t.Errorf("ListFromItems(%v) = %q, want %q", items, got, want)
+	}
+	if got, want := OrderedListFromItems(items).String(), "<ol><li>&lt;one&gt;</li><li><b>two</b></li></ol>"; got != want {
+		t.Errorf("OrderedListFromItems(%v) = %q, want %q", items, got, want)
+	}
+	if got, want := ListFromItems(nil).String(), "<ul></ul>"; got != want {
+		t.Errorf("ListFromItems(nil) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package safehtml
+
+import (
+	"unicode"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// NumberHTML returns n formatted with the digits, digit grouping, and decimal
+// separator of the language identified by tag, such as "1,234.5" in English
+// or "١٬٢٣٤٫٥" in Arabic.
+//
+// If the language is written from right to left, or the formatted number
+// contains right-to-left characters, the number is isolated in a bdi element
+// with the direction of the language, so that it is not reordered with the
+// text around it. Otherwise, it is returned as escaped text.
+func NumberHTML(tag language.Tag, n float64) HTML {
+	return bidiIsolated(tag, message.NewPrinter(tag).Sprint(number.Decimal(n)))
+}
+
+// CurrencyHTML returns amount formatted as an amount of the given currency in
+// the language identified by tag: the currency symbol, such as "$" or "€",
+// followed by the amount rounded to the number of decimal places of the
+// currency, such as "$ 1,234.50" in English.
+//
+// Like NumberHTML, the formatted amount is isolated in a bdi element if the
+// language is written from right to left or the result contains right-to-left
+// characters.
+func CurrencyHTML(tag language.Tag, unit currency.Unit, amount float64) HTML {
+	p := message.NewPrinter(tag)
+	scale, _ := currency.Standard.Rounding(unit)
+	// U+00A0 NO-BREAK SPACE keeps the symbol on the same line as the amount.
+	s := p.Sprint(currency.Symbol(unit)) + "\u00a0" + p.Sprint(number.Decimal(amount, number.Scale(scale)))
+	return bidiIsolated(tag, s)
+}
+
+// rtlScripts contains the ISO 15924 codes of the scripts that are written from
+// right to left.
+var rtlScripts = map[string]bool{
+	"Adlm": true,
+	"Arab": true,
+	"Hebr": true,
+	"Mand": true,
+	"Nkoo": true,
+	"Rohg": true,
+	"Samr": true,
+	"Syrc": true,
+	"Thaa": true,
+}
+
+// isRTL reports whether the language identified by tag is written from right
+// to left.
+func isRTL(tag language.Tag) bool {
+	script, _ := tag.Script()
+	return rtlScripts[script.String()]
+}
+
+// containsRTL reports whether s contains strongly right-to-left characters.
+func containsRTL(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko, unicode.Samaritan, unicode.Mandaic, unicode.Adlam) && unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// bidiIsolated returns text, escaped, in a bdi element with the direction of
+// the language identified by tag if the language is written from right to left
+// or text contains right-to-left characters.
+func bidiIsolated(tag language.Tag, text string) HTML {
+	rtl := isRTL(tag)
+	if !rtl && !containsRTL(text) {
+		return HTMLEscaped(text)
+	}
+	var w elementWriter
+	w.open("bdi")
+	if rtl {
+		w.attr("dir", "rtl")
+	} else {
+		w.attr("dir", "ltr")
+	}
+	w.closeTag()
+	w.text(text)
+	w.end("bdi")
+	return w.html()
+}
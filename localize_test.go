@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package safehtml
+
+import (
+	"testing"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+func TestNumberHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		tag  language.Tag
+		n    float64
+		want string
+	}{
+		{language.English, 1234.5, "1,234.5"},
+		{language.German, -1234.5, "-1.234,5"},
+		{language.Arabic, 1234.5, `<bdi dir="rtl">١٬٢٣٤٫٥</bdi>`},
+		{language.Hebrew, -1234.5, "<bdi dir=\"rtl\">\u200e-1,234.5</bdi>"},
+	} {
+		if got := NumberHTML(test.tag, test.n).String(); got != test.want {
+			t.Errorf("NumberHTML(%v, %v) = %q, want %q", test.tag, test.n, got, test.want)
+		}
+	}
+}
+
+func TestCurrencyHTML(t *testing.T) {
+	sar := currency.MustParseISO("SAR")
+	for _, test := range [...]struct {
+		tag    language.Tag
+		unit   currency.Unit
+		amount float64
+		want   string
+	}{
+		{language.English, currency.USD, 1234.5, "$\u00a01,234.50"},
+		{language.English, currency.JPY, 1234.5, "¥\u00a01,234"},
+		{language.German, currency.EUR, 3, "€\u00a03,00"},
+		{language.English, sar, 3, "SAR\u00a03.00"},
+		{language.Arabic, sar, 3, "<bdi dir=\"rtl\">ر.س.\u200f\u00a0٣٫٠٠</bdi>"},
+	} {
+		if got := CurrencyHTML(test.tag, test.unit, test.amount).String(); got != test.want {
+			t.Errorf("CurrencyHTML(%v, %v, %v) = %q, want %q", test.tag, test.unit, test.amount, got, test.want)
+		}
+	}
+}
+
+func TestBidiIsolated(t *testing.T) {
+	for _, test := range [...]struct {
+		tag  language.Tag
+		text string
+		want string
+	}{
+		{language.English, "<1>", "&lt;1&gt;"},
+		{language.English, "ש\"ח 5", `<bdi dir="ltr">ש&#34;ח 5</bdi>`},
+		{language.Persian, "5", `<bdi dir="rtl">5</bdi>`},
+	} {
+		if got := bidiIsolated(test.tag, test.text).String(); got != test.want {
+			t.Errorf("bidiIsolated(%v, %q) = %q, want %q", test.tag, test.text, got, test.want)
+		}
+	}
+}
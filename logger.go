@@ -0,0 +1,43 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"github.com/google/safehtml/internal/diag"
+)
+
+// A Logger receives the diagnostics of the packages of this module, such as
+// unsafe URLs replaced by sanitizers, template execution failures, template
+// reloads, and uses of package legacyconversions. Its method has the
+// signature of the methods of log/slog.Logger, so
+//
+//	safehtml.SetLogger(safehtml.LoggerFunc(slog.Default().Warn))
+//
+// reports diagnostics to the default structured logger.
+//
+// Diagnostics may contain untrusted data, such as the URLs that sanitizers
+// replace. Log must be safe for concurrent use.
+type Logger interface {
+	// Log reports a diagnostic with the given message and alternating keys
+	// and values, such as "url", "javascript:alert(1)".
+	Log(msg string, keyvals ...interface{})
+}
+
+// A LoggerFunc is a function that implements Logger.
+type LoggerFunc func(msg string, keyvals ...interface{})
+
+// Log calls f(msg, keyvals...).
+func (f LoggerFunc) Log(msg string, keyvals ...interface{}) {
+	f(msg, keyvals...)
+}
+
+// SetLogger sets the Logger that the packages of this module report
+// diagnostics to, replacing any previous Logger. Diagnostics are discarded if
+// l is nil, which is the default.
+func SetLogger(l Logger) {
+	diag.SetLogger(l)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"reflect"
+	"testing"
+)
+
+type logEntry struct {
+	msg     string
+	keyvals []interface{}
+}
+
+func TestSetLogger(t *testing.T) {
+	var logged []logEntry
+	SetLogger(LoggerFunc(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, logEntry{msg, keyvals})
+	}))
+	defer SetLogger(nil)
+	URLSanitized("https://example.com/")
+	URLSanitized("javascript:alert(1)")
+	URLSetSanitized("/a.png 1x, javascript:alert(1) 2x")
+	want := []logEntry{
+		{"safehtml: replaced unsafe URL", []interface{}{"url", "javascript:alert(1)"}},
+		{"safehtml: dropped unsafe srcset candidate", []interface{}{"url", "javascript:alert(1)", "descriptor", "2x"}},
+	}
+	if !reflect.DeepEqual(logged, want) {
+		t.Errorf("got %v, want %v", logged, want)
+	}
+	SetLogger(nil)
+	URLSanitized("javascript:alert(1)")
+	if len(logged) != len(want) {
+		t.Error("diagnostics reported after SetLogger(nil)")
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// A LatLng is a geographic location in degrees.
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// validate returns an error if p is not a location on Earth.
+func (p LatLng) validate() error {
+	if !(p.Lat >= -90 && p.Lat <= 90) || !(p.Lng >= -180 && p.Lng <= 180) {
+		return fmt.Errorf("%v is not a valid latitude and longitude", p)
+	}
+	return nil
+}
+
+// String returns p as comma-separated decimal latitude and longitude, such as
+// "51.5,-0.12", which is the form that map providers accept.
+func (p LatLng) String() string {
+	return formatDegrees(p.Lat) + "," + formatDegrees(p.Lng)
+}
+
+func formatDegrees(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// A MapsAPIKey is the API key of an application for a maps provider. Since it
+// is passed to the provider in a URL query parameter, it is escaped as one,
+// and cannot alter the vetted URLs built by the map helpers of this package.
+type MapsAPIKey struct {
+	key string
+}
+
+// MapsAPIKeyFromFlag returns a MapsAPIKey containing the string
+// representation of the retrieved value of the flag.
+//
+// In a server setting, flags are part of the application's deployment
+// configuration and are hence considered application-controlled.
+func MapsAPIKeyFromFlag(value flag.Value) MapsAPIKey {
+	return MapsAPIKey{fmt.Sprint(value.String())}
+}
+
+// MapOptions are the options of a map.
+type MapOptions struct {
+	// Zoom is the zoom level of the map, from 0 (the whole world) to 21
+	// (individual buildings).
+	Zoom int
+	// Width and Height are the dimensions of the map in pixels. They are
+	// optional for embedded maps.
+	Width, Height int
+	// Title is the accessible name of an embedded map's iframe.
+	Title string
+}
+
+// maxZoom is the maximum zoom level of the maps of all providers.
+const maxZoom = 21
+
+// maxStaticMapSize is the maximum width and height of Google static maps.
+const maxStaticMapSize = 640
+
+func (opts MapOptions) validate() error {
+	if opts.Zoom < 0 || opts.Zoom > maxZoom {
+		return fmt.Errorf("zoom level %d is not between 0 and %d", opts.Zoom, maxZoom)
+	}
+	return nil
+}
+
+// GoogleMapsEmbedURL returns the URL of a Google Maps Embed API map centered on
+// center, with a marker at center.
+func GoogleMapsEmbedURL(key MapsAPIKey, center LatLng, opts MapOptions) (TrustedResourceURL, error) {
+	if err := center.validate(); err != nil {
+		return TrustedResourceURL{}, err
+	}
+	if err := opts.validate(); err != nil {
+		return TrustedResourceURL{}, err
+	}
+	return TrustedResourceURLWithParams(TrustedResourceURL{"https://www.google.com/maps/embed/v1/place"}, map[string]string{
+		"key":  key.key,
+		"q":    center.String(),
+		"zoom": strconv.Itoa(opts.Zoom),
+	}), nil
+}
+
+// GoogleMapsEmbedHTML returns an iframe element that embeds a Google Maps
+// Embed API map centered on center, with a marker at center. The iframe is
+// sandboxed, and only allows the features that the map needs.
+func GoogleMapsEmbedHTML(key MapsAPIKey, center LatLng, opts MapOptions) (HTML, error) {
+	u, err := GoogleMapsEmbedURL(key, center, opts)
+	if err != nil {
+		return HTML{}, err
+	}
+	return mapIframe(u, opts), nil
+}
+
+// GoogleStaticMapURL returns the URL of a Google Maps Static API image of a map
+// centered on center, with a marker at center. opts.Width and opts.Height must
+// be between 1 and 640.
+//
+// The result can be used as the src of an img element in templates, or
+// converted for use with ImgHTML with URLSanitized(u.String()).
+func GoogleStaticMapURL(key MapsAPIKey, center LatLng, opts MapOptions) (TrustedResourceURL, error) {
+	if err := center.validate(); err != nil {
+		return TrustedResourceURL{}, err
+	}
+	if err := opts.validate(); err != nil {
+		return TrustedResourceURL{}, err
+	}
+	if opts.Width < 1 || opts.Width > maxStaticMapSize || opts.Height < 1 || opts.Height > maxStaticMapSize {
+		return TrustedResourceURL{}, fmt.Errorf("static map size %dx%d is not between 1x1 and %dx%d", opts.Width, opts.Height, maxStaticMapSize, maxStaticMapSize)
+	}
+	return TrustedResourceURLWithParams(TrustedResourceURL{"https://maps.googleapis.com/maps/api/staticmap"}, map[string]string{
+		"key":     key.key,
+		"center":  center.String(),
+		"markers": center.String(),
+		"zoom":    strconv.Itoa(opts.Zoom),
+		"size":    strconv.Itoa(opts.Width) + "x" + strconv.Itoa(opts.Height),
+	}), nil
+}
+
+// OpenStreetMapEmbedURL returns the URL of an OpenStreetMap map centered on
+// center, with a marker at center. OpenStreetMap does not require an API key.
+func OpenStreetMapEmbedURL(center LatLng, opts MapOptions) (TrustedResourceURL, error) {
+	if err := center.validate(); err != nil {
+		return TrustedResourceURL{}, err
+	}
+	if err := opts.validate(); err != nil {
+		return TrustedResourceURL{}, err
+	}
+	// OpenStreetMap embeds are specified by a bounding box rather than a zoom
+	// level. At zoom level z, a 256 pixel tile spans 360/2^z degrees of
+	// longitude.
+	d := 180 / math.Pow(2, float64(opts.Zoom))
+	bbox := []float64{
+		math.Max(center.Lng-d, -180), math.Max(center.Lat-d/2, -90),
+		math.Min(center.Lng+d, 180), math.Min(center.Lat+d/2, 90),
+	}
+	var bboxStr string
+	for i, f := range bbox {
+		if i > 0 {
+			bboxStr += ","
+		}
+		bboxStr += formatDegrees(f)
+	}
+	return TrustedResourceURLWithParams(TrustedResourceURL{"https://www.openstreetmap.org/export/embed.html"}, map[string]string{
+		"bbox":   bboxStr,
+		"layer":  "mapnik",
+		"marker": center.String(),
+	}), nil
+}
+
+// OpenStreetMapEmbedHTML returns an iframe element that embeds an
+// OpenStreetMap map centered on center, with a marker at center. The iframe is
+// sandboxed, and only allows the features that the map needs.
+func OpenStreetMapEmbedHTML(center LatLng, opts MapOptions) (HTML, error) {
+	u, err := OpenStreetMapEmbedURL(center, opts)
+	if err != nil {
+		return HTML{}, err
+	}
+	return mapIframe(u, opts), nil
+}
+
+func mapIframe(src TrustedResourceURL, opts MapOptions) HTML {
+	return iframeSpec{
+		src:     src,
+		width:   opts.Width,
+		height:  opts.Height,
+		title:   opts.Title,
+		sandbox: "allow-scripts allow-same-origin allow-popups allow-popups-to-escape-sandbox",
+		allow:   "fullscreen",
+	}.html()
+}
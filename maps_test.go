@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"flag"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMapURLs(t *testing.T) {
+	var fs flag.FlagSet
+	keyFlag := fs.String("maps_key", "", "")
+	*keyFlag = "k&ey=x"
+	key := MapsAPIKeyFromFlag(fs.Lookup("maps_key").Value)
+	london := LatLng{51.5, -0.12}
+	for _, test := range [...]struct {
+		desc string
+		f    func() (TrustedResourceURL, error)
+		want string
+		err  string
+	}{
+		{
+			"Google Maps embed",
+			func() (TrustedResourceURL, error) { return GoogleMapsEmbedURL(key, london, MapOptions{Zoom: 12}) },
+			"https://www.google.com/maps/embed/v1/place?key=k%26ey%3dx&q=51.5%2c-0.12&zoom=12", "",
+		},
+		{
+			"Google static map",
+			func() (TrustedResourceURL, error) {
+				return GoogleStaticMapURL(key, london, MapOptions{Zoom: 3, Width: 640, Height: 320})
+			},
+			"https://maps.googleapis.com/maps/api/staticmap?center=51.5%2c-0.12&key=k%26ey%3dx&markers=51.5%2c-0.12&size=640x320&zoom=3", "",
+		},
+		{
+			"OpenStreetMap embed",
+			func() (TrustedResourceURL, error) { return OpenStreetMapEmbedURL(LatLng{0, 0}, MapOptions{Zoom: 1}) },
+			"https://www.openstreetmap.org/export/embed.html?bbox=-90%2c-45%2c90%2c45&layer=mapnik&marker=0%2c0", "",
+		},
+		{
+			"OpenStreetMap embed clamped to the world",
+			func() (TrustedResourceURL, error) { return OpenStreetMapEmbedURL(LatLng{90, 180}, MapOptions{}) },
+			"https://www.openstreetmap.org/export/embed.html?bbox=0%2c0%2c180%2c90&layer=mapnik&marker=90%2c180", "",
+		},
+		{
+			"invalid latitude",
+			func() (TrustedResourceURL, error) { return GoogleMapsEmbedURL(key, LatLng{91, 0}, MapOptions{}) },
+			"", "is not a valid latitude and longitude",
+		},
+		{
+			"NaN longitude",
+			func() (TrustedResourceURL, error) { return OpenStreetMapEmbedURL(LatLng{0, math.NaN()}, MapOptions{}) },
+			"", "is not a valid latitude and longitude",
+		},
+		{
+			"invalid zoom",
+			func() (TrustedResourceURL, error) { return GoogleMapsEmbedURL(key, london, MapOptions{Zoom: 22}) },
+			"", "zoom level 22 is not between 0 and 21",
+		},
+		{
+			"static map too large",
+			func() (TrustedResourceURL, error) {
+				return GoogleStaticMapURL(key, london, MapOptions{Width: 641, Height: 100})
+			},
+			"", "static map size 641x100 is not between 1x1 and 640x640",
+		},
+		{
+			"static map without size",
+			func() (TrustedResourceURL, error) { return GoogleStaticMapURL(key, london, MapOptions{}) },
+			"", "static map size 0x0",
+		},
+	} {
+		got, err := test.f()
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: got error %v, want error containing %q", test.desc, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestMapEmbedHTML(t *testing.T) {
+	got, err := OpenStreetMapEmbedHTML(LatLng{0, 0}, MapOptions{Zoom: 1, Width: 400, Height: 300, Title: "Map"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<iframe src="https://www.openstreetmap.org/export/embed.html?bbox=-90%2c-45%2c90%2c45&amp;layer=mapnik&amp;marker=0%2c0"` +
+		` width="400" height="300" title="Map" sandbox="allow-scripts allow-same-origin allow-popups allow-popups-to-escape-sandbox"` +
+		` allow="fullscreen" referrerpolicy="strict-origin-when-cross-origin" loading="lazy"></iframe>`
+	if got.String() != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+	if _, err := GoogleMapsEmbedHTML(MapsAPIKey{}, LatLng{0, 200}, MapOptions{}); err == nil {
+		t.Error("expected error for invalid longitude")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package markdown converts Markdown content to safehtml.HTML values, by
+// sanitizing the output of a Markdown renderer such as goldmark or
+// blackfriday. Applications can thus display Markdown written by users
+// without converting the renderer's output using legacyconversions.
+//
+// This package does not depend on any renderer. A renderer is adapted with
+// RendererFunc, for example for goldmark:
+//
+//	r := markdown.RendererFunc(func(w io.Writer, source []byte) error {
+//		return goldmark.Convert(source, w)
+//	})
+//	h, err := markdown.Render(r, source)
+//
+// The output of the renderer is sanitized with Policy, so raw HTML in the
+// Markdown source is only kept if it is markup that the policy allows.
+package markdown
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/sanitizer"
+)
+
+// A Renderer converts Markdown source to HTML.
+type Renderer interface {
+	// Render writes the HTML form of source to w.
+	Render(w io.Writer, source []byte) error
+}
+
+// RendererFunc adapts an ordinary function to a Renderer.
+type RendererFunc func(w io.Writer, source []byte) error
+
+// Render calls f(w, source).
+func (f RendererFunc) Render(w io.Writer, source []byte) error {
+	return f(w, source)
+}
+
+// Policy returns a new sanitizer Policy that keeps the markup which Markdown
+// renderers produce, with the extensions of GitHub Flavored Markdown: the
+// elements of sanitizer.UGCPolicy, language classes of code blocks, start
+// numbers of ordered lists, alignments of table cells, heading and footnote
+// ids, and link and image titles.
+//
+// The checkboxes of task lists are removed, since the policy does not allow
+// form controls; the items of the lists are kept. Element ids, and the links
+// to them such as those of footnotes, are prefixed with "user-content-", as
+// described in sanitizer.Policy.AllowAttributes.
+//
+// The returned Policy can be extended before it is passed to
+// RenderWithPolicy.
+func Policy() *sanitizer.Policy {
+	return sanitizer.UGCPolicy().
+		AllowElements("section").
+		AllowAttributes("a", "title", "id").
+		AllowAttributes("img", "title").
+		AllowAttributes("code", "class").
+		AllowAttributes("ol", "start").
+		AllowAttributes("td", "align").
+		AllowAttributes("th", "align").
+		AllowAttributes("h1", "id").
+		AllowAttributes("h2", "id").
+		AllowAttributes("h3", "id").
+		AllowAttributes("h4", "id").
+		AllowAttributes("h5", "id").
+		AllowAttributes("h6", "id").
+		AllowAttributes("li", "id").
+		AllowAttributes("section", "class").
+		AllowAttributes("sup", "id")
+}
+
+// defaultPolicy is the Policy used by Render and Sanitize.
+var defaultPolicy = Policy()
+
+// Render converts source to HTML using r, and sanitizes the result with the
+// policy returned by Policy. It returns an error if r does.
+func Render(r Renderer, source []byte) (safehtml.HTML, error) {
+	return RenderWithPolicy(r, defaultPolicy, source)
+}
+
+// RenderWithPolicy is like Render, but sanitizes the HTML converted by r
+// with p.
+func RenderWithPolicy(r Renderer, p *sanitizer.Policy, source []byte) (safehtml.HTML, error) {
+	var buf bytes.Buffer
+	if err := r.Render(&buf, source); err != nil {
+		return safehtml.HTML{}, err
+	}
+	return p.SanitizeReader(&buf)
+}
+
+// Sanitize sanitizes rendered, the HTML output of a Markdown renderer, with
+// the policy returned by Policy.
+func Sanitize(rendered string) safehtml.HTML {
+	return defaultPolicy.Sanitize(rendered)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package markdown
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// fixedRenderer is a Renderer that writes the same HTML for any source.
+func fixedRenderer(html string) Renderer {
+	return RendererFunc(func(w io.Writer, source []byte) error {
+		_, err := io.WriteString(w, html)
+		return err
+	})
+}
+
+func TestRender(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, rendered, want string
+	}{
+		{
+			"commonmark",
+			"<h1 id=\"intro\">Intro</h1>\n<p>Some <em>text</em> with <a href=\"https://example.com\" title=\"Example\">a link</a>.</p>\n",
+			"<h1 id=\"user-content-intro\">Intro</h1>\n<p>Some <em>text</em> with <a href=\"https://example.com\" title=\"Example\">a link</a>.</p>\n",
+		},
+		{
+			"code block",
+			`<pre><code class="language-go">x := "&lt;b&gt;"</code></pre>`,
+			`<pre><code class="language-go">x := &#34;&lt;b&gt;&#34;</code></pre>`,
+		},
+		{
+			"table",
+			`<table><thead><tr><th align="right">n</th></tr></thead><tbody><tr><td align="right">1</td></tr></tbody></table>`,
+			`<table><thead><tr><th align="right">n</th></tr></thead><tbody><tr><td align="right">1</td></tr></tbody></table>`,
+		},
+		{
+			"task list",
+			`<ul><li><input type="checkbox" checked disabled> done</li></ul>`,
+			`<ul><li> done</li></ul>`,
+		},
+		{
+			"ordered list",
+			`<ol start="3"><li><del>old</del></li></ol>`,
+			`<ol start="3"><li><del>old</del></li></ol>`,
+		},
+		{
+			"heading link",
+			`<h2 id="intro">Intro</h2><p><a href="#intro">Back</a></p>`,
+			`<h2 id="user-content-intro">Intro</h2><p><a href="#user-content-intro">Back</a></p>`,
+		},
+		{
+			"footnote",
+			`<p>Text<sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup></p>` +
+				`<div class="footnotes" role="doc-endnotes"><hr><ol><li id="fn:1"><p>Note&#160;<a href="#fnref:1" class="footnote-backref" role="doc-backlink">&#x21a9;&#xfe0e;</a></p></li></ol></div>`,
+			`<p>Text<sup id="user-content-fnref:1"><a href="#user-content-fn:1">1</a></sup></p>` +
+				"<div><hr><ol><li id=\"user-content-fn:1\"><p>Note\u00a0<a href=\"#user-content-fnref:1\">\u21a9\ufe0e</a></p></li></ol></div>",
+		},
+		{
+			"raw HTML",
+			`<p onclick="alert(1)">hi<script>alert(2)</script></p><a href="javascript:alert(3)">x</a><img src="x.png" onerror="alert(4)">`,
+			`<p>hi</p><a>x</a><img src="x.png">`,
+		},
+	} {
+		got, err := Render(fixedRenderer(test.rendered), []byte("source"))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+		if got := Sanitize(test.rendered); got.String() != test.want {
+			t.Errorf("%s: Sanitize got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestRenderError(t *testing.T) {
+	want := errors.New("bad markdown")
+	r := RendererFunc(func(w io.Writer, source []byte) error {
+		io.WriteString(w, "<p>partial")
+		return want
+	})
+	if h, err := Render(r, nil); err != want || h.String() != "" {
+		t.Errorf("Render() = %q, %v, want empty HTML and %v", h, err, want)
+	}
+}
+
+func TestRenderWithPolicy(t *testing.T) {
+	p := Policy().AllowAttributes("span", "class")
+	got, err := RenderWithPolicy(fixedRenderer(`<p><span class="note">n</span></p>`), p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<p><span class="note">n</span></p>`; got.String() != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := Sanitize(`<p><span class="note">n</span></p>`).String(), `<p><span>n</span></p>`; got != want {
+		t.Errorf("Sanitize: got %q, want %q", got, want)
+	}
+}
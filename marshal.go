@@ -0,0 +1,96 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import "encoding/json"
+
+// The safe types implement encoding.TextMarshaler and json.Marshaler, so that
+// they are marshaled as their string forms rather than as empty objects. They
+// do not implement the corresponding unmarshaling interfaces, since
+// unmarshaled values have not been checked to satisfy their type contracts;
+// the wrapper types of package uncheckedconversions, such as UnmarshaledHTML,
+// opt in to unmarshaling data from trusted sources.
+
+// MarshalText returns the string form of the HTML.
+func (h HTML) MarshalText() ([]byte, error) {
+	return []byte(h.str), nil
+}
+
+// MarshalJSON returns the string form of the HTML as a JSON string.
+func (h HTML) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.str)
+}
+
+// MarshalText returns the string form of the URL.
+func (u URL) MarshalText() ([]byte, error) {
+	return []byte(u.str), nil
+}
+
+// MarshalJSON returns the string form of the URL as a JSON string.
+func (u URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.str)
+}
+
+// MarshalText returns the string form of the TrustedResourceURL.
+func (t TrustedResourceURL) MarshalText() ([]byte, error) {
+	return []byte(t.str), nil
+}
+
+// MarshalJSON returns the string form of the TrustedResourceURL as a JSON string.
+func (t TrustedResourceURL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.str)
+}
+
+// MarshalText returns the string form of the Script.
+func (s Script) MarshalText() ([]byte, error) {
+	return []byte(s.str), nil
+}
+
+// MarshalJSON returns the string form of the Script as a JSON string.
+func (s Script) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.str)
+}
+
+// MarshalText returns the string form of the Style.
+func (s Style) MarshalText() ([]byte, error) {
+	return []byte(s.str), nil
+}
+
+// MarshalJSON returns the string form of the Style as a JSON string.
+func (s Style) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.str)
+}
+
+// MarshalText returns the string form of the StyleSheet.
+func (s StyleSheet) MarshalText() ([]byte, error) {
+	return []byte(s.str), nil
+}
+
+// MarshalJSON returns the string form of the StyleSheet as a JSON string.
+func (s StyleSheet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.str)
+}
+
+// MarshalText returns the string form of the Identifier.
+func (i Identifier) MarshalText() ([]byte, error) {
+	return []byte(i.str), nil
+}
+
+// MarshalJSON returns the string form of the Identifier as a JSON string.
+func (i Identifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.str)
+}
+
+// MarshalText returns the string form of the SVG.
+func (s SVG) MarshalText() ([]byte, error) {
+	return []byte(s.str), nil
+}
+
+// MarshalJSON returns the string form of the SVG as a JSON string.
+func (s SVG) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.str)
+}
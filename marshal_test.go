@@ -0,0 +1,51 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	for _, v := range [...]interface {
+		encoding.TextMarshaler
+		json.Marshaler
+		String() string
+	}{
+		HTML{`<b class="x">&amp;</b>`},
+		URL{"https://example.com/?a=1&b=2"},
+		TrustedResourceURL{"/static/app.js"},
+		Script{`alert("<hi>");`},
+		Style{"color:red;"},
+		StyleSheet{"p{color:red;}"},
+		Identifier{"my-id"},
+		SVG{`<svg><rect width="1"></rect></svg>`},
+	} {
+		text, err := v.MarshalText()
+		if err != nil || string(text) != v.String() {
+			t.Errorf("%T.MarshalText() = %q, %v, want %q", v, text, err, v.String())
+		}
+		b, err := json.Marshal(map[string]interface{}{"v": v})
+		if err != nil {
+			t.Errorf("json.Marshal(%T): %v", v, err)
+			continue
+		}
+		var got map[string]string
+		if err := json.Unmarshal(b, &got); err != nil || got["v"] != v.String() {
+			t.Errorf("json.Marshal(%T) = %s, want the string %q", v, b, v.String())
+		}
+	}
+}
+
+func TestUnmarshalFails(t *testing.T) {
+	var v struct{ H HTML }
+	if err := json.Unmarshal([]byte(`{"H":"<script>alert(1)</script>"}`), &v); err == nil {
+		t.Errorf("json.Unmarshal into HTML succeeded with %q, want error", v.H)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+// A MediaSource is a source element of a video or audio element.
+type MediaSource struct {
+	// URL is the URL of the media resource. TrustedResourceURL values can be
+	// converted with URLSanitized(t.String()).
+	URL URL
+	// Type is the optional MIME type of the resource, such as "video/webm".
+	Type string
+}
+
+// A Track is a timed text track of a video or audio element, such as
+// captions or subtitles.
+type Track struct {
+	// Src is the URL of the WebVTT file of the track. Since the track is
+	// loaded as a resource of the page, it must be a TrustedResourceURL.
+	Src TrustedResourceURL
+	// Kind is the kind of the track, such as "captions" or "subtitles". The
+	// browser treats tracks without a kind as subtitles.
+	Kind string
+	// SrcLang is the language of the track, such as "en".
+	SrcLang string
+	// Label is the title of the track shown to users.
+	Label string
+	// Default reports whether the track is enabled by default.
+	Default bool
+}
+
+// MediaControls are the playback attributes of a video or audio element.
+type MediaControls struct {
+	Controls, Autoplay, Loop, Muted bool
+	// Preload is an optional hint of how much of the media to load before
+	// it is played: "none", "metadata", or "auto".
+	Preload string
+}
+
+// A VideoSpec describes a video element.
+type VideoSpec struct {
+	// Sources are the alternative media resources of the video, in order of
+	// preference.
+	Sources []MediaSource
+	// Poster is the optional URL of an image shown until the video plays.
+	Poster URL
+	// Tracks are the timed text tracks of the video.
+	Tracks []Track
+	// Width and Height are the optional dimensions of the video in pixels.
+	// Dimensions that are 0 or less are omitted.
+	Width, Height int
+	MediaControls
+	// PlaysInline reports whether the video is played inline rather than
+	// fullscreen on mobile browsers.
+	PlaysInline bool
+	// Fallback is the content shown by browsers that do not support video
+	// elements.
+	Fallback HTML
+}
+
+// An AudioSpec describes an audio element.
+type AudioSpec struct {
+	// Sources are the alternative media resources of the audio, in order of
+	// preference.
+	Sources []MediaSource
+	// Tracks are the timed text tracks of the audio.
+	Tracks []Track
+	MediaControls
+	// Fallback is the content shown by browsers that do not support audio
+	// elements.
+	Fallback HTML
+}
+
+// VideoHTML returns a video element described by v, containing a source
+// element for each of its sources and a track element for each of its tracks.
+//
+// Every attribute value is escaped, and text fields may contain arbitrary
+// text. Attributes with empty values are omitted.
+func VideoHTML(v VideoSpec) HTML {
+	var w elementWriter
+	w.open("video")
+	w.urlAttr("poster", v.Poster.String())
+	w.intAttr("width", v.Width)
+	w.intAttr("height", v.Height)
+	writeMediaControls(&w, v.MediaControls)
+	w.boolAttr("playsinline", v.PlaysInline)
+	w.closeTag()
+	writeMediaContent(&w, v.Sources, v.Tracks, v.Fallback)
+	w.end("video")
+	return w.html()
+}
+
+// AudioHTML returns an audio element described by a, containing a source
+// element for each of its sources and a track element for each of its tracks.
+//
+// Every attribute value is escaped, and text fields may contain arbitrary
+// text. Attributes with empty values are omitted.
+func AudioHTML(a AudioSpec) HTML {
+	var w elementWriter
+	w.open("audio")
+	writeMediaControls(&w, a.MediaControls)
+	w.closeTag()
+	writeMediaContent(&w, a.Sources, a.Tracks, a.Fallback)
+	w.end("audio")
+	return w.html()
+}
+
+func writeMediaControls(w *elementWriter, c MediaControls) {
+	w.boolAttr("controls", c.Controls)
+	w.boolAttr("autoplay", c.Autoplay)
+	w.boolAttr("loop", c.Loop)
+	w.boolAttr("muted", c.Muted)
+	w.attr("preload", c.Preload)
+}
+
+func writeMediaContent(w *elementWriter, sources []MediaSource, tracks []Track, fallback HTML) {
+	for _, s := range sources {
+		w.open("source")
+		w.urlAttr("src", s.URL.String())
+		w.attr("type", s.Type)
+		w.closeTag()
+	}
+	for _, t := range tracks {
+		w.open("track")
+		w.urlAttr("src", t.Src.String())
+		w.attr("kind", t.Kind)
+		w.attr("srclang", t.SrcLang)
+		w.attr("label", t.Label)
+		w.boolAttr("default", t.Default)
+		w.closeTag()
+	}
+	w.writeHTML(fallback)
+}
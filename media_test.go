@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestVideoHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		v    VideoSpec
+		want string
+	}{
+		{
+			"empty",
+			VideoSpec{},
+			`<video></video>`,
+		},
+		{
+			"full",
+			VideoSpec{
+				Sources: []MediaSource{
+					{URL: URLSanitized("/v.webm"), Type: "video/webm"},
+					{URL: URLSanitized("javascript:alert(1)"), Type: `video/mp4" onerror="alert(1)`},
+				},
+				Poster: URLSanitized("/poster.jpg"),
+				Tracks: []Track{
+					{Src: TrustedResourceURLFromConstant("/en.vtt"), Kind: "captions", SrcLang: "en", Label: "English", Default: true},
+					{Src: TrustedResourceURLFromConstant("/fr.vtt"), Kind: "subtitles", SrcLang: "fr", Label: "<Français>"},
+				},
+				Width:         640,
+				Height:        360,
+				MediaControls: MediaControls{Controls: true, Muted: true, Preload: "metadata"},
+				PlaysInline:   true,
+				Fallback:      HTMLEscaped("Your browser does not support video."),
+			},
+			`<video poster="/poster.jpg" width="640" height="360" controls muted preload="metadata" playsinline>` +
+				`<source src="/v.webm" type="video/webm">` +
+				`<source src="about:invalid#zGoSafez" type="video/mp4&#34; onerror=&#34;alert(1)">` +
+				`<track src="/en.vtt" kind="captions" srclang="en" label="English" default>` +
+				`<track src="/fr.vtt" kind="subtitles" srclang="fr" label="&lt;Français&gt;">` +
+				`Your browser does not support video.` +
+				`</video>`,
+		},
+	} {
+		if got := VideoHTML(test.v).String(); got != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestAudioHTML(t *testing.T) {
+	a := AudioSpec{
+		Sources:       []MediaSource{{URL: URLSanitized("/a.ogg"), Type: "audio/ogg"}, {URL: URLSanitized("/a b.mp3")}},
+		Tracks:        []Track{{Src: TrustedResourceURLFromConstant("/a.vtt"), Kind: "captions"}},
+		MediaControls: MediaControls{Controls: true, Autoplay: true, Loop: true},
+	}
+	want := `<audio controls autoplay loop>` +
+		`<source src="/a.ogg" type="audio/ogg"><source src="/a%20b.mp3">` +
+		`<track src="/a.vtt" kind="captions">` +
+		`</audio>`
+	if got := AudioHTML(a).String(); got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// messagePlaceholderPattern matches the placeholders of messages, such as
+// {0}, {name}, and the start and end placeholders {link} and {/link} of a
+// link.
+var messagePlaceholderPattern = regexp.MustCompile(`\{(/?)([0-9]+|[A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// MessageHTML returns the translated message msg, such as one read from a
+// translation file, as HTML, substituting each numbered placeholder {0},
+// {1}, ... with the argument of that index:
+//
+//	MessageHTML("{0} commented on {1}.", user.Name, post.TitleHTML)
+//
+// The text of msg is escaped, so translations cannot introduce markup, and
+// so are the arguments, unless they are HTML values or HTMLers, which are
+// included verbatim. URL arguments make the text between a start and an end
+// placeholder, which may itself contain placeholders, a link:
+//
+//	MessageHTML("Read the {0}terms of service{/0}.", termsURL)
+//	// Read the <a href="/terms">terms of service</a>.
+//
+// Other arguments, including URL arguments used as a single placeholder, are
+// formatted as by fmt.Sprint and escaped.
+//
+// MessageHTML returns an error, rather than a partial message, if msg uses a
+// placeholder with no argument, does not use an argument, or uses the start
+// and end placeholders of an argument that is not a URL or that are not
+// properly nested, since such messages are usually mistranslated. Text in
+// braces that is not a placeholder, such as "{ }", is text.
+func MessageHTML(msg string, args ...interface{}) (HTML, error) {
+	values := make(map[string]interface{}, len(args))
+	for i, arg := range args {
+		values[strconv.Itoa(i)] = arg
+	}
+	return messageHTML(msg, values)
+}
+
+// NamedMessageHTML is like MessageHTML, but substitutes named placeholders,
+// such as {name}, with the argument in args with that name:
+//
+//	NamedMessageHTML("{user} commented on {post}.", map[string]interface{}{
+//		"user": user.Name,
+//		"post": post.TitleHTML,
+//	})
+func NamedMessageHTML(msg string, args map[string]interface{}) (HTML, error) {
+	return messageHTML(msg, args)
+}
+
+func messageHTML(msg string, args map[string]interface{}) (HTML, error) {
+	var w elementWriter
+	used := make(map[string]bool, len(args))
+	// links are the names of the links that have been started but not
+	// ended, innermost last.
+	var links []string
+	last := 0
+	for _, m := range messagePlaceholderPattern.FindAllStringSubmatchIndex(msg, -1) {
+		w.text(msg[last:m[0]])
+		last = m[1]
+		end, name := m[3] > m[2], msg[m[4]:m[5]]
+		arg, ok := args[name]
+		if !ok {
+			return HTML{}, fmt.Errorf("message %q: no argument for placeholder %s", msg, msg[m[0]:m[1]])
+		}
+		used[name] = true
+		if end {
+			if len(links) == 0 || links[len(links)-1] != name {
+				return HTML{}, fmt.Errorf("message %q: placeholder {/%s} does not end a link", msg, name)
+			}
+			links = links[:len(links)-1]
+			w.end("a")
+			continue
+		}
+		if u, ok := arg.(URL); ok && strings.Contains(msg[last:], "{/"+name+"}") {
+			links = append(links, name)
+			w.open("a")
+			w.urlAttr("href", u.str)
+			w.closeTag()
+			continue
+		}
+		switch arg := arg.(type) {
+		case HTML:
+			w.writeHTML(arg)
+		case HTMLer:
+			w.writeHTML(arg.HTML())
+		default:
+			w.text(fmt.Sprint(arg))
+		}
+	}
+	w.text(msg[last:])
+	if len(links) > 0 {
+		return HTML{}, fmt.Errorf("message %q: link {%s} is not ended", msg, links[len(links)-1])
+	}
+	for name := range args {
+		if !used[name] {
+			return HTML{}, fmt.Errorf("message %q: argument %s is not used", msg, name)
+		}
+	}
+	return w.html(), nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestMessageHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, msg string
+		args      []interface{}
+		want, err string
+	}{
+		{"text", `Tom & Jerry's <b>`, nil, `Tom &amp; Jerry&#39;s &lt;b&gt;`, ""},
+		{"string", "{0} commented.", []interface{}{"<script>"}, `&lt;script&gt; commented.`, ""},
+		{"html", "{0} by {1}", []interface{}{HTMLEscaped("a<b"), HTML{"<em>Gopher</em>"}}, `a&lt;b by <em>Gopher</em>`, ""},
+		{"reordered", "{1}, {0}", []interface{}{"first", 2}, `2, first`, ""},
+		{"repeated", "{0} and {0}", []interface{}{"x"}, `x and x`, ""},
+		{"link", "Read the {0}terms of {1}{/0}.", []interface{}{URLSanitized("/terms?a=1&b=2"), HTML{"<em>service</em>"}},
+			`Read the <a href="/terms?a=1&amp;b=2">terms of <em>service</em></a>.`, ""},
+		{"nested links", "{0}a{1}b{/1}{/0}", []interface{}{URLSanitized("/a"), URLSanitized("/b")}, `<a href="/a">a<a href="/b">b</a></a>`, ""},
+		{"url as text", "See {0}", []interface{}{URLSanitized("/a?b")}, `See /a?b`, ""},
+		{"unsafe url", "{0}click{/0}", []interface{}{URLSanitized("javascript:alert(1)")}, `<a href="about:invalid#zGoSafez">click</a>`, ""},
+		{"not a placeholder", "{ } {x-y} {{0}}", []interface{}{"z"}, `{ } {x-y} {z}`, ""},
+		{"missing argument", "{0} and {1}", []interface{}{"x"}, "", `message "{0} and {1}": no argument for placeholder {1}`},
+		{"unused argument", "{0}", []interface{}{"x", "y"}, "", `message "{0}": argument 1 is not used`},
+		{"named in numbered", "{name}", nil, "", `message "{name}": no argument for placeholder {name}`},
+		{"end of text", "{0}x{/0}", []interface{}{"y"}, "", `message "{0}x{/0}": placeholder {/0} does not end a link`},
+		{"unstarted link", "x{/0}", []interface{}{URLSanitized("/")}, "", `message "x{/0}": placeholder {/0} does not end a link`},
+		{"crossed links", "{0}{1}{/0}{/1}", []interface{}{URLSanitized("/a"), URLSanitized("/b")}, "", `message "{0}{1}{/0}{/1}": placeholder {/0} does not end a link`},
+		{"unended link", "{0}{0}x{/0}", []interface{}{URLSanitized("/a")}, "", `message "{0}{0}x{/0}": link {0} is not ended`},
+	} {
+		got, err := MessageHTML(test.msg, test.args...)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: error = %v, want %q", test.desc, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+		} else if got.String() != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestNamedMessageHTML(t *testing.T) {
+	got, err := NamedMessageHTML("{user} commented on {post}. {link}Reply{/link}", map[string]interface{}{
+		"user": "Bob & Alice",
+		"post": HTML{"<cite>Gophers</cite>"},
+		"link": URLSanitized("/reply"),
+	})
+	if want := `Bob &amp; Alice commented on <cite>Gophers</cite>. <a href="/reply">Reply</a>`; err != nil || got.String() != want {
+		t.Errorf("NamedMessageHTML = %q, %v, want %q", got, err, want)
+	}
+	_, err = NamedMessageHTML("{user}", map[string]interface{}{"user": "x", "post": "y"})
+	if want := `message "{user}": argument post is not used`; err == nil || err.Error() != want {
+		t.Errorf("NamedMessageHTML error = %v, want %q", err, want)
+	}
+}
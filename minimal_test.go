@@ -0,0 +1,52 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// goCommand returns the go command, skipping the test if it is not
+// available or tests are short.
+func goCommand(t *testing.T) string {
+	if testing.Short() {
+		t.Skip("skipping go command in short mode")
+	}
+	gocmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skipf("go command not found: %v", err)
+	}
+	return gocmd
+}
+
+// TestMinimalBuild checks that every package of the module builds with the
+// safehtml_minimal build tag, which excludes functions from this package and
+// safehtml/template that other packages may use.
+func TestMinimalBuild(t *testing.T) {
+	out, err := exec.Command(goCommand(t), "build", "-tags", "safehtml_minimal", "github.com/google/safehtml/...").CombinedOutput()
+	if err != nil {
+		t.Errorf("go build -tags safehtml_minimal failed: %v\n%s", err, out)
+	}
+}
+
+// TestMinimalDependencies checks that this package and safehtml/template
+// only depend on the standard library when built with the safehtml_minimal
+// build tag, as documented.
+func TestMinimalDependencies(t *testing.T) {
+	out, err := exec.Command(goCommand(t), "list", "-deps", "-tags", "safehtml_minimal", "-f", "{{if not .Standard}}{{.ImportPath}}{{end}}",
+		"github.com/google/safehtml", "github.com/google/safehtml/template").CombinedOutput()
+	if err != nil {
+		t.Fatalf("go list failed: %v\n%s", err, out)
+	}
+	for _, path := range strings.Fields(string(out)) {
+		if !strings.HasPrefix(path, "github.com/google/safehtml") {
+			t.Errorf("package %s is a dependency in builds with the safehtml_minimal tag", path)
+		}
+	}
+}
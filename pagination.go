@@ -0,0 +1,66 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// MaxCursorLength is the maximum length of an encoded pagination cursor, which
+// keeps pagination URLs well within the URL length limits of browsers and
+// servers.
+const MaxCursorLength = 1024
+
+// cursorPattern matches the unpadded base64url encoding of cursors.
+var cursorPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// CursorURL returns QueryURL(current) with the query parameter param set to
+// the unpadded base64url encoding of cursor, an opaque pagination cursor such
+// as a serialized database key. Other query parameters of current, such as
+// filters, are preserved.
+//
+// It returns an error if the encoded cursor is longer than MaxCursorLength.
+func CursorURL(current url.Values, param string, cursor []byte) (URL, error) {
+	encoded := base64.RawURLEncoding.EncodeToString(cursor)
+	if len(encoded) > MaxCursorLength {
+		return URL{}, fmt.Errorf("encoded cursor is %d bytes long, longer than the maximum of %d", len(encoded), MaxCursorLength)
+	}
+	query := make(url.Values, len(current)+1)
+	for k, v := range current {
+		query[k] = v
+	}
+	query.Set(param, encoded)
+	return QueryURL(query), nil
+}
+
+// CursorFromQuery returns the pagination cursor encoded by CursorURL in the
+// query parameter param of query, or nil if the parameter is absent or empty,
+// as on the first page.
+//
+// It returns an error if the parameter is longer than MaxCursorLength or is not
+// unpadded base64url, such as when users edit or truncate the URL.
+func CursorFromQuery(query url.Values, param string) ([]byte, error) {
+	encoded := query.Get(param)
+	if encoded == "" {
+		return nil, nil
+	}
+	if len(encoded) > MaxCursorLength {
+		return nil, fmt.Errorf("cursor parameter %q is %d bytes long, longer than the maximum of %d", param, len(encoded), MaxCursorLength)
+	}
+	// base64 decoding ignores newlines, so check the encoding explicitly.
+	if !cursorPattern.MatchString(encoded) {
+		return nil, fmt.Errorf("cursor parameter %q is not unpadded base64url", param)
+	}
+	cursor, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cursor parameter %q is not unpadded base64url: %v", param, err)
+	}
+	return cursor, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCursorURL(t *testing.T) {
+	current := url.Values{"q": {"a&b"}, "after": {"old"}}
+	got, err := CursorURL(current, "after", []byte{0xfb, 0xff, 'k', 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "?after=-_9rAA&q=a%26b"; got.String() != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if current.Get("after") != "old" {
+		t.Error("CursorURL modified current")
+	}
+	if _, err := CursorURL(nil, "after", make([]byte, MaxCursorLength)); err == nil {
+		t.Error("expected error for long cursor")
+	}
+}
+
+func TestCursorFromQuery(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, value string
+		want        []byte
+		wantErr     bool
+	}{
+		{"absent", "", nil, false},
+		{"valid", "-_9rAA", []byte{0xfb, 0xff, 'k', 0}, false},
+		{"padded", "-_9rAA==", nil, true},
+		{"standard base64", "+/9rAA", nil, true},
+		{"newline", "-_9r\nAA", nil, true},
+		{"truncated", "-_9rA", nil, true},
+		{"too long", strings.Repeat("A", MaxCursorLength+4), nil, true},
+	} {
+		query := url.Values{}
+		if test.value != "" {
+			query.Set("after", test.value)
+		}
+		got, err := CursorFromQuery(query, "after")
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error", test.desc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+		} else if !bytes.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.desc, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+// A PictureSource is a source element of a picture element: a set of image
+// candidates that the browser uses if the media query and image type of the
+// source match.
+type PictureSource struct {
+	// Srcset contains the image candidates of the source, which can be
+	// built with SrcsetFromCandidates.
+	Srcset URLSet
+	// Media is an optional media query, such as "(min-width: 800px)".
+	Media string
+	// Type is the optional MIME type of the images, such as "image/webp".
+	Type string
+	// Sizes optionally describes the display sizes of the images for
+	// width descriptors, such as "(max-width: 600px) 480px, 800px".
+	Sizes string
+}
+
+// An ImgSpec describes an img element.
+type ImgSpec struct {
+	// Src is the URL of the image.
+	Src URL
+	// Srcset optionally contains responsive image candidates, which can be
+	// built with SrcsetFromCandidates.
+	Srcset URLSet
+	// Sizes optionally describes the display sizes of the images in Srcset
+	// for width descriptors.
+	Sizes string
+	// Alt is the text alternative of the image.
+	Alt string
+	// Width and Height are the optional intrinsic dimensions of the image in
+	// pixels. Dimensions that are 0 or less are omitted.
+	Width, Height int
+}
+
+// ImgHTML returns an img element described by img.
+func ImgHTML(img ImgSpec) HTML {
+	var w elementWriter
+	writeImg(&w, img)
+	return w.html()
+}
+
+// PictureHTML returns a picture element containing source elements for the
+// given sources, in order, followed by an img element described by img, which
+// the browser uses if no source matches or picture elements are not
+// supported.
+//
+// Every attribute value is escaped, and Media, Type, Sizes, and Alt fields may
+// contain arbitrary text. Attributes with empty values are omitted.
+func PictureHTML(sources []PictureSource, img ImgSpec) HTML {
+	var w elementWriter
+	w.open("picture")
+	w.closeTag()
+	for _, s := range sources {
+		w.open("source")
+		w.attr("srcset", s.Srcset.String())
+		w.attr("media", s.Media)
+		w.attr("type", s.Type)
+		w.attr("sizes", s.Sizes)
+		w.closeTag()
+	}
+	writeImg(&w, img)
+	w.end("picture")
+	return w.html()
+}
+
+func writeImg(w *elementWriter, img ImgSpec) {
+	w.open("img")
+	w.urlAttr("src", img.Src.String())
+	w.attr("srcset", img.Srcset.String())
+	w.attr("sizes", img.Sizes)
+	// An empty alt attribute marks decorative images, so it is not omitted.
+	w.requiredAttr("alt", img.Alt)
+	w.intAttr("width", img.Width)
+	w.intAttr("height", img.Height)
+	w.closeTag()
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestPictureHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc    string
+		sources []PictureSource
+		img     ImgSpec
+		want    string
+	}{
+		{
+			"img only",
+			nil,
+			ImgSpec{Src: URLSanitized("/a.png"), Alt: `"Logo" & <name>`},
+			`<picture><img src="/a.png" alt="&#34;Logo&#34; &amp; &lt;name&gt;"></picture>`,
+		},
+		{
+			"decorative img",
+			nil,
+			ImgSpec{Src: URLSanitized("/a b.png"), Width: 10, Height: -1},
+			`<picture><img src="/a%20b.png" alt="" width="10"></picture>`,
+		},
+		{
+			"sources",
+			[]PictureSource{
+				{
+					Srcset: SrcsetFromCandidates([]ImageCandidate{
+						{URL: URLSanitized("/a.webp"), Descriptor: "1x"},
+						{URL: URLSanitized("/a@2x.webp"), Descriptor: "2x"},
+					}),
+					Type: "image/webp",
+				},
+				{
+					Srcset: SrcsetFromCandidates([]ImageCandidate{{URL: URLSanitized("/wide.png"), Descriptor: "800w"}}),
+					Media:  `(min-width: 800px)" onload="alert(1)`,
+					Sizes:  "100vw",
+				},
+			},
+			ImgSpec{Src: URLSanitized("javascript:alert(1)"), Alt: "A", Width: 800, Height: 600},
+			`<picture>` +
+				`<source srcset="/a.webp 1x , /a@2x.webp 2x" type="image/webp">` +
+				`<source srcset="/wide.png 800w" media="(min-width: 800px)&#34; onload=&#34;alert(1)" sizes="100vw">` +
+				`<img src="about:invalid#zGoSafez" alt="A" width="800" height="600">` +
+				`</picture>`,
+		},
+	} {
+		if got := PictureHTML(test.sources, test.img).String(); got != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestImgHTML(t *testing.T) {
+	img := ImgSpec{
+		Src:    URLSanitized("/a.png"),
+		Srcset: SrcsetFromCandidates([]ImageCandidate{{URL: URLSanitized("/a@2x.png"), Descriptor: "2x"}}),
+		Alt:    "a",
+	}
+	if got, want := ImgHTML(img).String(), `<img src="/a.png" srcset="/a@2x.png 2x" alt="a">`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
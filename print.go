@@ -0,0 +1,64 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"strings"
+)
+
+// PrintStyleSheet returns the given rules in an @media print rule, so that
+// they only apply when the page is printed or saved as PDF.
+func PrintStyleSheet(rules ...StyleSheet) StyleSheet {
+	s, err := MediaRule("print", rules...)
+	if err != nil {
+		// The query is a valid constant.
+		panic(err)
+	}
+	return s
+}
+
+// PrintHiddenStyleSheet returns an @media print rule hiding the elements
+// matching the given selectors, such as navigation menus and print buttons,
+// when the page is printed. It returns an error if a selector is invalid, as
+// CSSRule does.
+func PrintHiddenStyleSheet(selectors ...string) (StyleSheet, error) {
+	rule, err := CSSRule(strings.Join(selectors, ","), StyleFromConstant(`display:none !important;`))
+	if err != nil {
+		return StyleSheet{}, err
+	}
+	return PrintStyleSheet(rule), nil
+}
+
+// printScript prints the page when the button preceding the script element is
+// clicked.
+const printScript = `document.currentScript.previousElementSibling.addEventListener('click',function(){window.print();});`
+
+// PrintButtonHTML returns a button printing the page, followed by the script
+// handling its clicks:
+//
+//	<button type="button" class="print-button">Print</button>
+//	<script nonce="...">...</script>
+//
+// nonce is the Content-Security-Policy nonce of the script, and may be empty
+// if the page does not have a nonce-based Content-Security-Policy. The button
+// has the class print-button, which PrintHiddenStyleSheet(".print-button")
+// hides from the printed page.
+func PrintButtonHTML(label, nonce string) HTML {
+	var w elementWriter
+	w.open("button")
+	w.attr("type", "button")
+	w.attr("class", "print-button")
+	w.closeTag()
+	w.text(label)
+	w.end("button")
+	w.open("script")
+	w.attr("nonce", nonce)
+	w.closeTag()
+	w.b.WriteString(printScript)
+	w.end("script")
+	return w.html()
+}
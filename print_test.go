@@ -0,0 +1,32 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestPrintHiddenStyleSheet(t *testing.T) {
+	got, err := PrintHiddenStyleSheet("nav", ".print-button")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `@media print{nav,.print-button{display:none !important;}}`; got.String() != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := PrintHiddenStyleSheet("a{"); err == nil {
+		t.Error("expected error for invalid selector")
+	}
+}
+
+func TestPrintButtonHTML(t *testing.T) {
+	want := `<button type="button" class="print-button">Print &lt;now&gt;</button>` +
+		`<script nonce="r4nd0m">document.currentScript.previousElementSibling.addEventListener('click',function(){window.print();});</script>`
+	if got := PrintButtonHTML("Print <now>", "r4nd0m").String(); got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"bytes"
+	"errors"
+)
+
+// pngSignature is the first eight bytes of every PNG image.
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// PNGImgHTML returns an img element that displays the given PNG image, such as
+// a generated QR code, inline as a data URL, so that the image does not have to
+// be served separately. alt is the text alternative of the image, and width and
+// height are its optional dimensions in pixels.
+//
+// It returns an error if png does not start with the PNG signature.
+func PNGImgHTML(png []byte, alt string, width, height int) (HTML, error) {
+	if !bytes.HasPrefix(png, pngSignature) {
+		return HTML{}, errors.New("image data is not a PNG image")
+	}
+	src, err := DataURLFromBytes("image/png", png)
+	if err != nil {
+		return HTML{}, err
+	}
+	return ImgHTML(ImgSpec{Src: src, Alt: alt, Width: width, Height: height}), nil
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestPNGImgHTML(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00")
+	got, err := PNGImgHTML(png, `Scan with "Authenticator" & <sign in>`, 200, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<img src="data:image/png;base64,iVBORw0KGgoA" alt="Scan with &#34;Authenticator&#34; &amp; &lt;sign in&gt;" width="200" height="200">`
+	if got.String() != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+	if _, err := PNGImgHTML([]byte("<svg onload=alert(1)>"), "", 0, 0); err == nil {
+		t.Error("expected error for data that is not a PNG image")
+	}
+}
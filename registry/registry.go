@@ -0,0 +1,416 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package registry defines the contracts of the HTML elements and attributes
+// that untrusted values may be interpolated into, and the context in which
+// each of those values is sanitized.
+//
+// The contracts are shared by every part of safehtml that produces HTML, such
+// as the escaper of package safehtml/template, so that a single Registry
+// extended by an application, for example to allow the hx-target attribute of
+// htmx to hold arbitrary text, applies consistently wherever it is used:
+//
+//	r := registry.Default().AllowAttribute("hx-target", registry.None)
+//	tmpl := template.New("page").WithRegistry(r)
+//
+// A Registry can only be extended with elements and attributes for which it
+// has no contract yet, so extensions never weaken the sanitization of the
+// elements and attributes known to this package.
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A Context determines how a value interpolated into element content or an
+// attribute value is sanitized. The zero value is not a valid Context.
+type Context uint8
+
+const (
+	_ Context = iota
+	// AsyncEnum accepts only the string "async".
+	AsyncEnum
+	// DirEnum accepts only the strings "auto", "ltr" and "rtl".
+	DirEnum
+	// HTML accepts safehtml.HTML values, and escapes other values.
+	HTML
+	// HTMLValOnly accepts only safehtml.HTML values.
+	HTMLValOnly
+	// Identifier accepts only safehtml.Identifier values.
+	Identifier
+	// LoadingEnum accepts only the strings "eager" and "lazy".
+	LoadingEnum
+	// None accepts values of any type without context-specific sanitization.
+	None
+	// RCDATA escapes all values.
+	RCDATA
+	// Script accepts only safehtml.Script values.
+	Script
+	// Style accepts only safehtml.Style values.
+	Style
+	// StyleSheet accepts only safehtml.StyleSheet values.
+	StyleSheet
+	// TargetEnum accepts only the strings "_blank" and "_self".
+	TargetEnum
+	// TrustedResourceURL accepts only safehtml.TrustedResourceURL values.
+	TrustedResourceURL
+	// TrustedResourceURLOrURL accepts safehtml.TrustedResourceURL and
+	// safehtml.URL values, and sanitizes other values as URLs.
+	TrustedResourceURLOrURL
+	// URL accepts safehtml.URL values, and sanitizes other values as URLs.
+	URL
+	// URLSet accepts safehtml.URLSet values, and sanitizes other values as
+	// sets of URLs.
+	URLSet
+)
+
+var contextNames = [...]string{
+	AsyncEnum:               "AsyncEnum",
+	DirEnum:                 "DirEnum",
+	HTML:                    "HTML",
+	HTMLValOnly:             "HTMLValOnly",
+	Identifier:              "Identifier",
+	LoadingEnum:             "LoadingEnum",
+	None:                    "None",
+	RCDATA:                  "RCDATA",
+	Script:                  "Script",
+	Style:                   "Style",
+	StyleSheet:              "StyleSheet",
+	TargetEnum:              "TargetEnum",
+	TrustedResourceURL:      "TrustedResourceURL",
+	TrustedResourceURLOrURL: "TrustedResourceURLOrURL",
+	URL:                     "URL",
+	URLSet:                  "URLSet",
+}
+
+// String returns the name of c, such as "HTML".
+func (c Context) String() string {
+	if !c.valid() {
+		return fmt.Sprintf("invalid sanitization context %d", c)
+	}
+	return contextNames[c]
+}
+
+// MarshalText returns the name of c.
+func (c Context) MarshalText() ([]byte, error) {
+	if !c.valid() {
+		return nil, fmt.Errorf("invalid sanitization context %d", c)
+	}
+	return []byte(contextNames[c]), nil
+}
+
+// UnmarshalText sets c to the Context with the given name.
+func (c *Context) UnmarshalText(text []byte) error {
+	for ctx, name := range contextNames {
+		if ctx != 0 && name == string(text) {
+			*c = Context(ctx)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sanitization context %q", text)
+}
+
+func (c Context) valid() bool {
+	return c != 0 && int(c) < len(contextNames)
+}
+
+// A Registry contains the contracts of the elements and attributes that
+// untrusted values may be interpolated into.
+//
+// A nil *Registry has the contracts returned by Default, and cannot be
+// extended. Methods that extend a Registry return it so that calls can be
+// chained. They panic if the extension is invalid or would change an existing
+// contract, since extensions are expected to be made once, at initialization.
+//
+// Element and attribute names are case-insensitive.
+type Registry struct {
+	// elementContent maps element names to the context of their content.
+	elementContent map[string]Context
+	voidElements   map[string]bool
+	// globalAttrs maps attribute names to the context of their values in
+	// any element in elementContent or voidElements.
+	globalAttrs map[string]Context
+	// elementAttrs[attr][element] is the context of the value of attr in
+	// element. It takes precedence over globalAttrs.
+	elementAttrs   map[string]map[string]Context
+	urlLinkRelVals map[string]bool
+}
+
+// stock is the Registry returned by Default. It is never modified.
+var stock = &Registry{
+	elementContent: elementContentSanitizationContext,
+	voidElements:   allowedVoidElements,
+	globalAttrs:    globalAttrValSanitizationContext,
+	elementAttrs:   elementSpecificAttrValSanitizationContext,
+	urlLinkRelVals: urlLinkRelVals,
+}
+
+// Default returns a new Registry that contains the stock contracts of this
+// package.
+func Default() *Registry {
+	return stock.Clone()
+}
+
+// Clone returns a copy of r, which can be extended without affecting r.
+func (r *Registry) Clone() *Registry {
+	r = r.orStock()
+	c := &Registry{
+		elementContent: make(map[string]Context, len(r.elementContent)),
+		voidElements:   make(map[string]bool, len(r.voidElements)),
+		globalAttrs:    make(map[string]Context, len(r.globalAttrs)),
+		elementAttrs:   make(map[string]map[string]Context, len(r.elementAttrs)),
+		urlLinkRelVals: r.urlLinkRelVals,
+	}
+	for elem, ctx := range r.elementContent {
+		c.elementContent[elem] = ctx
+	}
+	for elem := range r.voidElements {
+		c.voidElements[elem] = true
+	}
+	for attr, ctx := range r.globalAttrs {
+		c.globalAttrs[attr] = ctx
+	}
+	for attr, elems := range r.elementAttrs {
+		c.elementAttrs[attr] = make(map[string]Context, len(elems))
+		for elem, ctx := range elems {
+			c.elementAttrs[attr][elem] = ctx
+		}
+	}
+	return c
+}
+
+func (r *Registry) orStock() *Registry {
+	if r == nil {
+		return stock
+	}
+	return r
+}
+
+// AllowElement allows untrusted values in the content of element, which is
+// sanitized in context ctx, and in the values of the global attributes of
+// element. ctx must be HTML or RCDATA, since the content of elements that are
+// not known to this package is parsed as HTML by browsers.
+func (r *Registry) AllowElement(element string, ctx Context) *Registry {
+	element = strings.ToLower(element)
+	r.checkElement(element)
+	if ctx != HTML && ctx != RCDATA {
+		panic(fmt.Sprintf("registry: the content of %q elements cannot be sanitized in the %s context", element, ctx))
+	}
+	r.elementContent[element] = ctx
+	return r
+}
+
+// AllowVoidElement allows untrusted values in the values of the global
+// attributes of element, which is a void element and so has no content.
+func (r *Registry) AllowVoidElement(element string) *Registry {
+	element = strings.ToLower(element)
+	r.checkElement(element)
+	r.voidElements[element] = true
+	return r
+}
+
+// AllowAttribute allows untrusted values in the value of attr in all elements
+// whose content or global attributes allow untrusted values. The value is
+// sanitized in context ctx, except in elements with a contract for attr of
+// their own.
+func (r *Registry) AllowAttribute(attr string, ctx Context) *Registry {
+	attr = strings.ToLower(attr)
+	r.checkAttr(attr, ctx)
+	if _, ok := r.globalAttrs[attr]; ok {
+		panic(fmt.Sprintf("registry: attribute %q already has a contract", attr))
+	}
+	r.globalAttrs[attr] = ctx
+	return r
+}
+
+// AllowElementAttribute allows untrusted values in the value of attr in
+// element, which are sanitized in context ctx. Unlike AllowAttribute, it
+// applies even if element is not allowed by AllowElement or
+// AllowVoidElement. attr must not have a contract in all elements.
+func (r *Registry) AllowElementAttribute(element, attr string, ctx Context) *Registry {
+	element, attr = strings.ToLower(element), strings.ToLower(attr)
+	checkElementName(element)
+	r.checkAttr(attr, ctx)
+	if _, ok := r.globalAttrs[attr]; ok {
+		panic(fmt.Sprintf("registry: attribute %q already has a contract", attr))
+	}
+	if _, ok := r.elementAttrs[attr][element]; ok {
+		panic(fmt.Sprintf("registry: attribute %q already has a contract in %q elements", attr, element))
+	}
+	if r.elementAttrs[attr] == nil {
+		r.elementAttrs[attr] = make(map[string]Context)
+	}
+	r.elementAttrs[attr][element] = ctx
+	return r
+}
+
+// forbiddenElements contains the names of elements that can never be allowed,
+// since their content or attributes can load or run code regardless of how
+// their values are sanitized.
+var forbiddenElements = map[string]bool{
+	"applet": true,
+	"base":   true,
+	"embed":  true,
+	"math":   true,
+	"meta":   true,
+	"object": true,
+	"svg":    true,
+}
+
+// namePattern matches the element and attribute names that a Registry can be
+// extended with.
+var namePattern = regexp.MustCompile(`^[a-z][-a-z0-9_.]*$`)
+
+// checkElementName panics if element can never be allowed.
+func checkElementName(element string) {
+	if forbiddenElements[element] || !namePattern.MatchString(element) {
+		panic(fmt.Sprintf("registry: element %q cannot be allowed", element))
+	}
+}
+
+// checkElement panics if element cannot be added to r.
+func (r *Registry) checkElement(element string) {
+	if r == nil {
+		panic("registry: the nil Registry cannot be extended")
+	}
+	checkElementName(element)
+	if _, ok := r.elementContent[element]; ok || r.voidElements[element] {
+		panic(fmt.Sprintf("registry: element %q already has a contract", element))
+	}
+}
+
+// checkAttr panics if attr cannot be added to r with context ctx.
+func (r *Registry) checkAttr(attr string, ctx Context) {
+	if r == nil {
+		panic("registry: the nil Registry cannot be extended")
+	}
+	if !ctx.valid() {
+		panic(fmt.Sprintf("registry: invalid sanitization context %d for attribute %q", ctx, attr))
+	}
+	// Event handler attributes run their values as code, and the values of
+	// data-* attributes are always allowed.
+	if strings.HasPrefix(attr, "on") || dataAttributeNamePattern.MatchString(attr) || !namePattern.MatchString(attr) {
+		panic(fmt.Sprintf("registry: attribute %q cannot be allowed", attr))
+	}
+}
+
+// ElementContent returns the context that untrusted values in the content of
+// element are sanitized in, and reports whether they are allowed at all.
+func (r *Registry) ElementContent(element string) (Context, bool) {
+	ctx, ok := r.orStock().elementContent[element]
+	return ctx, ok
+}
+
+// Attribute returns the context that untrusted values in the value of attr
+// are sanitized in when attr appears in element, and reports whether they are
+// allowed at all. linkRel is the value of the rel attribute of element, which
+// determines the context of the href attribute of link elements.
+func (r *Registry) Attribute(element, attr, linkRel string) (Context, bool) {
+	r = r.orStock()
+	if element == "link" && attr == "href" {
+		// Special case: safehtml.URL values are allowed in a link element's href attribute if that element's
+		// rel attribute possesses certain values.
+		for _, val := range strings.Fields(linkRel) {
+			if r.urlLinkRelVals[val] {
+				return TrustedResourceURLOrURL, true
+			}
+		}
+	}
+	if dataAttributeNamePattern.MatchString(attr) {
+		// Special case: data-* attributes are specified by HTML5 to hold custom data private to
+		// the page or application; they should not be interpreted by browsers. Therefore, no
+		// sanitization is required for these attribute values.
+		return None, true
+	}
+	if ctx, ok := r.elementAttrs[attr][element]; ok {
+		return ctx, true
+	}
+	ctx, isAllowedAttr := r.globalAttrs[attr]
+	_, isAllowedElement := r.elementContent[element]
+	if isAllowedAttr && (isAllowedElement || r.voidElements[element]) {
+		// Only sanitize attributes that appear in elements whose semantics are known.
+		// Thes attributes might have different semantics in other standard or custom
+		// elements that our sanitization policy does not handle correctly.
+		return ctx, true
+	}
+	return 0, false
+}
+
+// dataAttributeNamePattern matches valid data attribute names.
+// This pattern is conservative and matches only a subset of the valid names defined in
+// https://html.spec.whatwg.org/multipage/dom.html#embedding-custom-non-visible-data-with-the-data-*-attributes
+var dataAttributeNamePattern = regexp.MustCompile(`^data-[a-z_][-a-z0-9_]*$`)
+
+// IsDataAttribute reports whether attr is the name of a data attribute, such
+// as "data-id", whose values Attribute allows in every element without
+// sanitization.
+func IsDataAttribute(attr string) bool {
+	return dataAttributeNamePattern.MatchString(attr)
+}
+
+// Elements returns a map from the names of the elements whose content allows
+// untrusted values to the context of that content.
+func (r *Registry) Elements() map[string]Context {
+	r = r.orStock()
+	m := make(map[string]Context, len(r.elementContent))
+	for elem, ctx := range r.elementContent {
+		m[elem] = ctx
+	}
+	return m
+}
+
+// VoidElements returns the sorted names of the void elements whose global
+// attributes allow untrusted values.
+func (r *Registry) VoidElements() []string {
+	return sortedKeys(r.orStock().voidElements)
+}
+
+// GlobalAttributes returns a map from the names of the attributes whose values
+// allow untrusted values in any element returned by Elements or VoidElements
+// to the context of those values.
+func (r *Registry) GlobalAttributes() map[string]Context {
+	r = r.orStock()
+	m := make(map[string]Context, len(r.globalAttrs))
+	for attr, ctx := range r.globalAttrs {
+		m[attr] = ctx
+	}
+	return m
+}
+
+// ElementAttributes returns a map from element names to attribute names to the
+// context of the values of those attributes within those elements. These
+// contracts take precedence over those returned by GlobalAttributes.
+func (r *Registry) ElementAttributes() map[string]map[string]Context {
+	m := make(map[string]map[string]Context)
+	for attr, elems := range r.orStock().elementAttrs {
+		for elem, ctx := range elems {
+			if m[elem] == nil {
+				m[elem] = make(map[string]Context)
+			}
+			m[elem][attr] = ctx
+		}
+	}
+	return m
+}
+
+// URLLinkRelValues returns the sorted values of the rel attribute of a link
+// element that cause its href attribute value to be sanitized in the
+// TrustedResourceURLOrURL context instead of the TrustedResourceURL context.
+func (r *Registry) URLLinkRelValues() []string {
+	return sortedKeys(r.orStock().urlLinkRelVals)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
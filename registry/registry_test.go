@@ -0,0 +1,150 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package registry
+
+import (
+	"testing"
+)
+
+func TestDataAttributeNamePattern(t *testing.T) {
+	for _, test := range [...]struct {
+		in   string
+		want bool
+	}{
+		{`data-a`, true},
+		{`data-foo`, true},
+		{`data-foo-bar`, true},
+		{`data-f0o-b4r`, true},
+		{`data-_foo`, true},
+		// Does not begin with "data-".
+		{`data`, false},
+		{`foo`, false},
+		// No characters after hyphen.
+		{`data-`, false},
+		// Suffix starts with a digit.
+		{`data-4oo`, false},
+		// Contains ACSII upper alphas.
+		// Note: this test case isn't strictly necessary, since sanitizerForContext is given
+		// lower-case attribute names.
+		{`data-Foo`, false},
+		// Contains colon characters.
+		{`data-foo:bar`, false},
+		// Contains unicode characters that are allowed in XML names
+		// (https://www.w3.org/TR/xml/#NT-Name), but conservatively rejected
+		// by our regexp pattern.
+		{"data-\u037Fbar", false},
+		{"data-fo\u0300", false},
+	} {
+		if got := IsDataAttribute(test.in); got != test.want {
+			t.Errorf("IsDataAttribute(%q) = %t", test.in, got)
+		}
+	}
+}
+
+func TestRegistryExtensions(t *testing.T) {
+	r := Default().
+		AllowElement("X-Widget", HTML).
+		AllowVoidElement("x-icon").
+		AllowAttribute("HX-Target", None).
+		AllowElementAttribute("x-widget", "hx-get", URL)
+	for _, test := range [...]struct {
+		element, attr string
+		want          Context
+		ok            bool
+	}{
+		{"div", "hx-target", None, true},
+		{"x-widget", "hx-target", None, true},
+		{"x-icon", "hx-target", None, true},
+		{"x-widget", "title", None, true},
+		{"x-widget", "hx-get", URL, true},
+		{"div", "hx-get", 0, false},
+		{"x-other", "hx-target", 0, false},
+		{"a", "href", TrustedResourceURLOrURL, true},
+	} {
+		got, ok := r.Attribute(test.element, test.attr, "")
+		if got != test.want || ok != test.ok {
+			t.Errorf("Attribute(%q, %q) = %v, %t, want %v, %t", test.element, test.attr, got, ok, test.want, test.ok)
+		}
+	}
+	if got, ok := r.ElementContent("x-widget"); got != HTML || !ok {
+		t.Errorf("ElementContent(%q) = %v, %t, want HTML, true", "x-widget", got, ok)
+	}
+	if _, ok := r.ElementContent("x-icon"); ok {
+		t.Errorf("ElementContent(%q) allowed content of a void element", "x-icon")
+	}
+	// Neither the stock contracts nor new registries are affected.
+	var stock *Registry
+	for _, other := range []*Registry{stock, Default()} {
+		if _, ok := other.Attribute("div", "hx-target", ""); ok {
+			t.Error("extension of a Registry affected another Registry")
+		}
+	}
+}
+
+func TestRegistryInvalidExtensions(t *testing.T) {
+	for _, test := range [...]struct {
+		desc   string
+		extend func(r *Registry)
+	}{
+		{"existing element", func(r *Registry) { r.AllowElement("script", HTML) }},
+		{"existing void element", func(r *Registry) { r.AllowVoidElement("img") }},
+		{"forbidden element", func(r *Registry) { r.AllowElement("object", HTML) }},
+		{"invalid element name", func(r *Registry) { r.AllowElement("x widget", HTML) }},
+		{"script content", func(r *Registry) { r.AllowElement("x-widget", Script) }},
+		{"existing attribute", func(r *Registry) { r.AllowAttribute("href", None) }},
+		{"existing element attribute", func(r *Registry) { r.AllowElementAttribute("a", "href", None) }},
+		{"override of global attribute", func(r *Registry) { r.AllowElementAttribute("div", "style", None) }},
+		{"event handler", func(r *Registry) { r.AllowAttribute("onclick", None) }},
+		{"data attribute", func(r *Registry) { r.AllowAttribute("data-foo", URL) }},
+		{"invalid context", func(r *Registry) { r.AllowAttribute("hx-target", 0) }},
+		{"nil registry", func(*Registry) { (*Registry)(nil).AllowAttribute("hx-target", None) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: extension did not panic", test.desc)
+				}
+			}()
+			test.extend(Default())
+		}()
+	}
+}
+
+func TestLinkRelValues(t *testing.T) {
+	for _, test := range [...]struct {
+		rel  string
+		want Context
+	}{
+		{"", TrustedResourceURL},
+		{"stylesheet", TrustedResourceURL},
+		{"canonical", TrustedResourceURLOrURL},
+		{"stylesheet icon", TrustedResourceURLOrURL},
+	} {
+		if got, _ := Default().Attribute("link", "href", test.rel); got != test.want {
+			t.Errorf("rel=%q: got context %v, want %v", test.rel, got, test.want)
+		}
+	}
+}
+
+func TestContextText(t *testing.T) {
+	for c := AsyncEnum; c <= URLSet; c++ {
+		text, err := c.MarshalText()
+		if err != nil {
+			t.Fatalf("%d: %v", c, err)
+		}
+		var got Context
+		if err := got.UnmarshalText(text); err != nil || got != c {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, got, err, c)
+		}
+	}
+	if _, err := Context(0).MarshalText(); err == nil {
+		t.Error("MarshalText of the zero value succeeded")
+	}
+	if _, err := (URLSet + 1).MarshalText(); err == nil {
+		t.Error("MarshalText of an out-of-range value succeeded")
+	}
+}
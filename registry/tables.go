@@ -0,0 +1,345 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package registry
+
+// urlLinkRelVals contains values for a link element's rel attribute that indicate that the same link
+// element's href attribute may contain a safehtml.URL value.
+var urlLinkRelVals = map[string]bool{
+	"alternate":    true,
+	"author":       true,
+	"bookmark":     true,
+	"canonical":    true,
+	"cite":         true,
+	"dns-prefetch": true,
+	"help":         true,
+	"icon":         true,
+	"license":      true,
+	"next":         true,
+	"preconnect":   true,
+	"prefetch":     true,
+	"preload":      true,
+	"prerender":    true,
+	"prev":         true,
+	"search":       true,
+	"subresource":  true,
+}
+
+// elementSpecificAttrValSanitizationContext[x][y] is the sanitization context for
+// attribute x when it appears within element y.
+var elementSpecificAttrValSanitizationContext = map[string]map[string]Context{
+	"accept": {
+		"input": None,
+	},
+	"action": {
+		"form": URL,
+	},
+	"alt": {
+		// The alt attribute of an ESI include is the URL of the resource
+		// included if src cannot be fetched.
+		"esi:include": TrustedResourceURL,
+	},
+	"defer": {
+		"script": None,
+	},
+	"formaction": {
+		"button": URL,
+		"input":  URL,
+	},
+	"formmethod": {
+		"button": None,
+		"input":  None,
+	},
+	"href": {
+		"a":    TrustedResourceURLOrURL,
+		"area": TrustedResourceURLOrURL,
+	},
+	"method": {
+		"form": None,
+	},
+	"pattern": {
+		"input": None,
+	},
+	"readonly": {
+		"input":    None,
+		"textarea": None,
+	},
+	"src": {
+		"audio":       TrustedResourceURLOrURL,
+		"esi:include": TrustedResourceURL,
+		"img":         TrustedResourceURLOrURL,
+		"input":       TrustedResourceURLOrURL,
+		"source":      TrustedResourceURLOrURL,
+		"video":       TrustedResourceURLOrURL,
+	},
+	"srcdoc": {
+		"iframe": HTMLValOnly,
+	},
+	"srcset": {
+		"img":    URLSet,
+		"source": URLSet,
+	},
+}
+
+// globalAttrValSanitizationContext[x] is the sanitization context for attribute x when
+// it appears within any element not in the key set of elementSpecificAttrValSanitizationContext[x].
+var globalAttrValSanitizationContext = map[string]Context{
+	"align":                 None,
+	"alt":                   None,
+	"aria-activedescendant": Identifier,
+	"aria-atomic":           None,
+	"aria-autocomplete":     None,
+	"aria-busy":             None,
+	"aria-checked":          None,
+	"aria-controls":         Identifier,
+	"aria-current":          None,
+	"aria-describedby":      Identifier,
+	"aria-disabled":         None,
+	"aria-dropeffect":       None,
+	"aria-expanded":         None,
+	"aria-haspopup":         None,
+	"aria-hidden":           None,
+	"aria-invalid":          None,
+	"aria-label":            None,
+	"aria-labelledby":       Identifier,
+	"aria-level":            None,
+	"aria-live":             None,
+	"aria-multiline":        None,
+	"aria-multiselectable":  None,
+	"aria-orientation":      None,
+	"aria-owns":             Identifier,
+	"aria-posinset":         None,
+	"aria-pressed":          None,
+	"aria-readonly":         None,
+	"aria-relevant":         None,
+	"aria-required":         None,
+	"aria-selected":         None,
+	"aria-setsize":          None,
+	"aria-sort":             None,
+	"aria-valuemax":         None,
+	"aria-valuemin":         None,
+	"aria-valuenow":         None,
+	"aria-valuetext":        None,
+	"async":                 AsyncEnum,
+	"autocapitalize":        None,
+	"autocomplete":          None,
+	"autocorrect":           None,
+	"autofocus":             None,
+	"autoplay":              None,
+	"bgcolor":               None,
+	"border":                None,
+	"cellpadding":           None,
+	"cellspacing":           None,
+	"checked":               None,
+	"cite":                  None,
+	"class":                 None,
+	"color":                 None,
+	"cols":                  None,
+	"colspan":               None,
+	"contenteditable":       None,
+	"controls":              None,
+	"crossorigin":           None,
+	"datetime":              None,
+	"dir":                   DirEnum,
+	"disabled":              None,
+	"download":              None,
+	"draggable":             None,
+	"enctype":               None,
+	"face":                  None,
+	"for":                   Identifier,
+	"formenctype":           None,
+	"frameborder":           None,
+	"height":                None,
+	"hidden":                None,
+	"href":                  TrustedResourceURL,
+	"hreflang":              None,
+	"id":                    Identifier,
+	"integrity":             None,
+	"ismap":                 None,
+	"itemid":                None,
+	"itemprop":              None,
+	"itemref":               None,
+	"itemscope":             None,
+	"itemtype":              None,
+	"label":                 None,
+	"lang":                  None,
+	"list":                  Identifier,
+	"loading":               LoadingEnum,
+	"loop":                  None,
+	"max":                   None,
+	"maxlength":             None,
+	"media":                 None,
+	"min":                   None,
+	"minlength":             None,
+	"multiple":              None,
+	"muted":                 None,
+	"name":                  Identifier,
+	"nonce":                 None,
+	"open":                  None,
+	"placeholder":           None,
+	"poster":                None,
+	"preload":               None,
+	"rel":                   None,
+	"required":              None,
+	"reversed":              None,
+	"role":                  None,
+	"rows":                  None,
+	"rowspan":               None,
+	"selected":              None,
+	"shape":                 None,
+	"size":                  None,
+	"sizes":                 None,
+	"slot":                  None,
+	"span":                  None,
+	"spellcheck":            None,
+	"src":                   TrustedResourceURL,
+	"start":                 None,
+	"step":                  None,
+	"style":                 Style,
+	"summary":               None,
+	"tabindex":              None,
+	"target":                TargetEnum,
+	"title":                 None,
+	"translate":             None,
+	"type":                  None,
+	"valign":                None,
+	"value":                 None,
+	"width":                 None,
+	"wrap":                  None,
+}
+
+// elementContentSanitizationContext maps element names to element content sanitization contexts.
+var elementContentSanitizationContext = map[string]Context{
+	"a":          HTML,
+	"abbr":       HTML,
+	"acronym":    HTML,
+	"address":    HTML,
+	"article":    HTML,
+	"aside":      HTML,
+	"audio":      HTML,
+	"b":          HTML,
+	"basefont":   HTML,
+	"bdi":        HTML,
+	"bdo":        HTML,
+	"big":        HTML,
+	"blockquote": HTML,
+	"body":       HTML,
+	"button":     HTML,
+	"canvas":     HTML,
+	"caption":    HTML,
+	"center":     HTML,
+	"cite":       HTML,
+	"code":       HTML,
+	"colgroup":   HTML,
+	"command":    HTML,
+	"data":       HTML,
+	"datalist":   HTML,
+	"dd":         HTML,
+	"del":        HTML,
+	"details":    HTML,
+	"dfn":        HTML,
+	"dialog":     HTML,
+	"dir":        HTML,
+	"div":        HTML,
+	"dl":         HTML,
+	"dt":         HTML,
+	"em":         HTML,
+	"fieldset":   HTML,
+	"figcaption": HTML,
+	"figure":     HTML,
+	"font":       HTML,
+	"footer":     HTML,
+	"form":       HTML,
+	"frame":      HTML,
+	"frameset":   HTML,
+	"h1":         HTML,
+	"h2":         HTML,
+	"h3":         HTML,
+	"h4":         HTML,
+	"h5":         HTML,
+	"h6":         HTML,
+	"head":       HTML,
+	"header":     HTML,
+	"hgroup":     HTML,
+	"html":       HTML,
+	"i":          HTML,
+	"iframe":     HTML,
+	"ins":        HTML,
+	"kbd":        HTML,
+	"label":      HTML,
+	"legend":     HTML,
+	"lh":         HTML,
+	"li":         HTML,
+	"main":       HTML,
+	"map":        HTML,
+	"mark":       HTML,
+	"menu":       HTML,
+	"meter":      HTML,
+	"nav":        HTML,
+	"nobr":       HTML,
+	"noscript":   HTML,
+	"ol":         HTML,
+	"optgroup":   HTML,
+	"option":     HTML,
+	"output":     HTML,
+	"p":          HTML,
+	"picture":    HTML,
+	"pre":        HTML,
+	"progress":   HTML,
+	"q":          HTML,
+	"rb":         HTML,
+	"rp":         HTML,
+	"rt":         HTML,
+	"rtc":        HTML,
+	"ruby":       HTML,
+	"s":          HTML,
+	"samp":       HTML,
+	"script":     Script,
+	"section":    HTML,
+	"select":     HTML,
+	"slot":       HTML,
+	"small":      HTML,
+	"span":       HTML,
+	"strike":     HTML,
+	"strong":     HTML,
+	"style":      StyleSheet,
+	"sub":        HTML,
+	"summary":    HTML,
+	"sup":        HTML,
+	"table":      HTML,
+	"tbody":      HTML,
+	"td":         HTML,
+	"textarea":   RCDATA,
+	"tfoot":      HTML,
+	"th":         HTML,
+	"thead":      HTML,
+	"time":       HTML,
+	"title":      RCDATA,
+	"tr":         HTML,
+	"tt":         HTML,
+	"u":          HTML,
+	"ul":         HTML,
+	"var":        HTML,
+	"video":      HTML,
+}
+
+// allowedVoidElements is a set of names of void elements actions may appear in.
+// esi:include is the Edge Side Includes tag, which ESI processors such as CDNs
+// replace with the resource at its src before the page reaches the browser.
+var allowedVoidElements = map[string]bool{
+	"area":        true,
+	"br":          true,
+	"col":         true,
+	"esi:include": true,
+	"hr":          true,
+	"img":         true,
+	"input":       true,
+	"link":        true,
+	"param":       true,
+	"source":      true,
+	"track":       true,
+	"wbr":         true,
+}
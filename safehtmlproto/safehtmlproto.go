@@ -0,0 +1,255 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package safehtmlproto converts the safe types of package safehtml to and
+// from the protocol buffer messages that the safe HTML types of other
+// languages, such as Closure and Java, use to cross RPC boundaries:
+// SafeHtmlProto, SafeUrlProto, TrustedResourceUrlProto, SafeScriptProto,
+// SafeStyleProto, and SafeStyleSheetProto, of the package
+// webutil.html.types.
+//
+// The messages of this package have the same fields and wire format as
+// those messages, and can be marshaled and unmarshaled with their Marshal
+// and Unmarshal methods, or embedded in other messages as bytes fields.
+// Since safe values can only be sent this way within the application's trust
+// domain, the FromProto functions do not check the type contracts of the
+// values they convert. It is the application's responsibility to ensure
+// that the messages originate from within the application itself and not
+// from an external entity outside its trust domain.
+//
+// Unlike the fields of generated code, the fields of the messages are
+// unexported, so that the only messages holding values are those returned
+// by the ToProto functions or unmarshaled from their wire format. Since
+// unmarshaling bytes of any origin creates safe values, the audit package
+// reports uses of this package as escape hatches.
+package safehtmlproto
+
+import (
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+)
+
+// SafeHtmlProto is the webutil.html.types.SafeHtmlProto message, which holds a
+// safehtml.HTML.
+type SafeHtmlProto struct {
+	// privateDoNotAccessOrElseSafeHtmlWrappedValue is the private_do_not_access_or_else_safe_html_wrapped_value field, number 2.
+	privateDoNotAccessOrElseSafeHtmlWrappedValue string
+}
+
+// Marshal returns the wire format of m.
+func (m *SafeHtmlProto) Marshal() ([]byte, error) {
+	return marshalValue(2, m.privateDoNotAccessOrElseSafeHtmlWrappedValue), nil
+}
+
+// Unmarshal sets m to the message in the wire format b. It returns an error
+// if b is not a valid message.
+func (m *SafeHtmlProto) Unmarshal(b []byte) error {
+	v, err := unmarshalValue(b, 2)
+	if err != nil {
+		return err
+	}
+	m.privateDoNotAccessOrElseSafeHtmlWrappedValue = v
+	return nil
+}
+
+// HTMLToProto returns a SafeHtmlProto holding h.
+func HTMLToProto(h safehtml.HTML) *SafeHtmlProto {
+	return &SafeHtmlProto{privateDoNotAccessOrElseSafeHtmlWrappedValue: h.String()}
+}
+
+// HTMLFromProto returns the safehtml.HTML held by m, or the zero value if
+// m is nil.
+func HTMLFromProto(m *SafeHtmlProto) safehtml.HTML {
+	if m == nil {
+		return safehtml.HTML{}
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(m.privateDoNotAccessOrElseSafeHtmlWrappedValue)
+}
+
+// SafeUrlProto is the webutil.html.types.SafeUrlProto message, which holds a
+// safehtml.URL.
+type SafeUrlProto struct {
+	// privateDoNotAccessOrElseSafeUrlWrappedValue is the private_do_not_access_or_else_safe_url_wrapped_value field, number 1.
+	privateDoNotAccessOrElseSafeUrlWrappedValue string
+}
+
+// Marshal returns the wire format of m.
+func (m *SafeUrlProto) Marshal() ([]byte, error) {
+	return marshalValue(1, m.privateDoNotAccessOrElseSafeUrlWrappedValue), nil
+}
+
+// Unmarshal sets m to the message in the wire format b. It returns an error
+// if b is not a valid message.
+func (m *SafeUrlProto) Unmarshal(b []byte) error {
+	v, err := unmarshalValue(b, 1)
+	if err != nil {
+		return err
+	}
+	m.privateDoNotAccessOrElseSafeUrlWrappedValue = v
+	return nil
+}
+
+// URLToProto returns a SafeUrlProto holding u.
+func URLToProto(u safehtml.URL) *SafeUrlProto {
+	return &SafeUrlProto{privateDoNotAccessOrElseSafeUrlWrappedValue: u.String()}
+}
+
+// URLFromProto returns the safehtml.URL held by m, or the zero value if
+// m is nil.
+func URLFromProto(m *SafeUrlProto) safehtml.URL {
+	if m == nil {
+		return safehtml.URL{}
+	}
+	return uncheckedconversions.URLFromStringKnownToSatisfyTypeContract(m.privateDoNotAccessOrElseSafeUrlWrappedValue)
+}
+
+// TrustedResourceUrlProto is the webutil.html.types.TrustedResourceUrlProto message, which holds a
+// safehtml.TrustedResourceURL.
+type TrustedResourceUrlProto struct {
+	// privateDoNotAccessOrElseTrustedResourceUrlWrappedValue is the private_do_not_access_or_else_trusted_resource_url_wrapped_value field, number 1.
+	privateDoNotAccessOrElseTrustedResourceUrlWrappedValue string
+}
+
+// Marshal returns the wire format of m.
+func (m *TrustedResourceUrlProto) Marshal() ([]byte, error) {
+	return marshalValue(1, m.privateDoNotAccessOrElseTrustedResourceUrlWrappedValue), nil
+}
+
+// Unmarshal sets m to the message in the wire format b. It returns an error
+// if b is not a valid message.
+func (m *TrustedResourceUrlProto) Unmarshal(b []byte) error {
+	v, err := unmarshalValue(b, 1)
+	if err != nil {
+		return err
+	}
+	m.privateDoNotAccessOrElseTrustedResourceUrlWrappedValue = v
+	return nil
+}
+
+// TrustedResourceURLToProto returns a TrustedResourceUrlProto holding t.
+func TrustedResourceURLToProto(t safehtml.TrustedResourceURL) *TrustedResourceUrlProto {
+	return &TrustedResourceUrlProto{privateDoNotAccessOrElseTrustedResourceUrlWrappedValue: t.String()}
+}
+
+// TrustedResourceURLFromProto returns the safehtml.TrustedResourceURL held by m, or the zero value if
+// m is nil.
+func TrustedResourceURLFromProto(m *TrustedResourceUrlProto) safehtml.TrustedResourceURL {
+	if m == nil {
+		return safehtml.TrustedResourceURL{}
+	}
+	return uncheckedconversions.TrustedResourceURLFromStringKnownToSatisfyTypeContract(m.privateDoNotAccessOrElseTrustedResourceUrlWrappedValue)
+}
+
+// SafeScriptProto is the webutil.html.types.SafeScriptProto message, which holds a
+// safehtml.Script.
+type SafeScriptProto struct {
+	// privateDoNotAccessOrElseSafeScriptWrappedValue is the private_do_not_access_or_else_safe_script_wrapped_value field, number 1.
+	privateDoNotAccessOrElseSafeScriptWrappedValue string
+}
+
+// Marshal returns the wire format of m.
+func (m *SafeScriptProto) Marshal() ([]byte, error) {
+	return marshalValue(1, m.privateDoNotAccessOrElseSafeScriptWrappedValue), nil
+}
+
+// Unmarshal sets m to the message in the wire format b. It returns an error
+// if b is not a valid message.
+func (m *SafeScriptProto) Unmarshal(b []byte) error {
+	v, err := unmarshalValue(b, 1)
+	if err != nil {
+		return err
+	}
+	m.privateDoNotAccessOrElseSafeScriptWrappedValue = v
+	return nil
+}
+
+// ScriptToProto returns a SafeScriptProto holding s.
+func ScriptToProto(s safehtml.Script) *SafeScriptProto {
+	return &SafeScriptProto{privateDoNotAccessOrElseSafeScriptWrappedValue: s.String()}
+}
+
+// ScriptFromProto returns the safehtml.Script held by m, or the zero value if
+// m is nil.
+func ScriptFromProto(m *SafeScriptProto) safehtml.Script {
+	if m == nil {
+		return safehtml.Script{}
+	}
+	return uncheckedconversions.ScriptFromStringKnownToSatisfyTypeContract(m.privateDoNotAccessOrElseSafeScriptWrappedValue)
+}
+
+// SafeStyleProto is the webutil.html.types.SafeStyleProto message, which holds a
+// safehtml.Style.
+type SafeStyleProto struct {
+	// privateDoNotAccessOrElseSafeStyleWrappedValue is the private_do_not_access_or_else_safe_style_wrapped_value field, number 1.
+	privateDoNotAccessOrElseSafeStyleWrappedValue string
+}
+
+// Marshal returns the wire format of m.
+func (m *SafeStyleProto) Marshal() ([]byte, error) {
+	return marshalValue(1, m.privateDoNotAccessOrElseSafeStyleWrappedValue), nil
+}
+
+// Unmarshal sets m to the message in the wire format b. It returns an error
+// if b is not a valid message.
+func (m *SafeStyleProto) Unmarshal(b []byte) error {
+	v, err := unmarshalValue(b, 1)
+	if err != nil {
+		return err
+	}
+	m.privateDoNotAccessOrElseSafeStyleWrappedValue = v
+	return nil
+}
+
+// StyleToProto returns a SafeStyleProto holding s.
+func StyleToProto(s safehtml.Style) *SafeStyleProto {
+	return &SafeStyleProto{privateDoNotAccessOrElseSafeStyleWrappedValue: s.String()}
+}
+
+// StyleFromProto returns the safehtml.Style held by m, or the zero value if
+// m is nil.
+func StyleFromProto(m *SafeStyleProto) safehtml.Style {
+	if m == nil {
+		return safehtml.Style{}
+	}
+	return uncheckedconversions.StyleFromStringKnownToSatisfyTypeContract(m.privateDoNotAccessOrElseSafeStyleWrappedValue)
+}
+
+// SafeStyleSheetProto is the webutil.html.types.SafeStyleSheetProto message, which holds a
+// safehtml.StyleSheet.
+type SafeStyleSheetProto struct {
+	// privateDoNotAccessOrElseSafeStyleSheetWrappedValue is the private_do_not_access_or_else_safe_style_sheet_wrapped_value field, number 1.
+	privateDoNotAccessOrElseSafeStyleSheetWrappedValue string
+}
+
+// Marshal returns the wire format of m.
+func (m *SafeStyleSheetProto) Marshal() ([]byte, error) {
+	return marshalValue(1, m.privateDoNotAccessOrElseSafeStyleSheetWrappedValue), nil
+}
+
+// Unmarshal sets m to the message in the wire format b. It returns an error
+// if b is not a valid message.
+func (m *SafeStyleSheetProto) Unmarshal(b []byte) error {
+	v, err := unmarshalValue(b, 1)
+	if err != nil {
+		return err
+	}
+	m.privateDoNotAccessOrElseSafeStyleSheetWrappedValue = v
+	return nil
+}
+
+// StyleSheetToProto returns a SafeStyleSheetProto holding s.
+func StyleSheetToProto(s safehtml.StyleSheet) *SafeStyleSheetProto {
+	return &SafeStyleSheetProto{privateDoNotAccessOrElseSafeStyleSheetWrappedValue: s.String()}
+}
+
+// StyleSheetFromProto returns the safehtml.StyleSheet held by m, or the zero value if
+// m is nil.
+func StyleSheetFromProto(m *SafeStyleSheetProto) safehtml.StyleSheet {
+	if m == nil {
+		return safehtml.StyleSheet{}
+	}
+	return uncheckedconversions.StyleSheetFromStringKnownToSatisfyTypeContract(m.privateDoNotAccessOrElseSafeStyleSheetWrappedValue)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtmlproto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestRoundTrip(t *testing.T) {
+	h := safehtml.HTMLEscaped("<b>&</b>")
+	if got := HTMLFromProto(HTMLToProto(h)); got != h {
+		t.Errorf("HTML: got %q, want %q", got, h)
+	}
+	u := safehtml.URLSanitized("https://example.com/?q=a b")
+	if got := URLFromProto(URLToProto(u)); got != u {
+		t.Errorf("URL: got %q, want %q", got, u)
+	}
+	tru := safehtml.TrustedResourceURLFromConstant("/static/app.js")
+	if got := TrustedResourceURLFromProto(TrustedResourceURLToProto(tru)); got != tru {
+		t.Errorf("TrustedResourceURL: got %q, want %q", got, tru)
+	}
+	s := safehtml.ScriptFromConstant("init();")
+	if got := ScriptFromProto(ScriptToProto(s)); got != s {
+		t.Errorf("Script: got %q, want %q", got, s)
+	}
+	st := safehtml.StyleFromConstant("color: red;")
+	if got := StyleFromProto(StyleToProto(st)); got != st {
+		t.Errorf("Style: got %q, want %q", got, st)
+	}
+	ss := safehtml.StyleSheetFromConstant("p { color: red; }")
+	if got := StyleSheetFromProto(StyleSheetToProto(ss)); got != ss {
+		t.Errorf("StyleSheet: got %q, want %q", got, ss)
+	}
+	if got := HTMLFromProto(nil); got != (safehtml.HTML{}) {
+		t.Errorf("HTMLFromProto(nil) = %q, want empty", got)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	b, err := HTMLToProto(safehtml.HTMLEscaped("hi")).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Field 2, wire type 2, length 2.
+	if want := []byte{0x12, 2, 'h', 'i'}; !bytes.Equal(b, want) {
+		t.Errorf("SafeHtmlProto.Marshal() = %x, want %x", b, want)
+	}
+	b, err = URLToProto(safehtml.URLSanitized("/a")).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Field 1, wire type 2, length 2.
+	if want := []byte{0x0a, 2, '/', 'a'}; !bytes.Equal(b, want) {
+		t.Errorf("SafeUrlProto.Marshal() = %x, want %x", b, want)
+	}
+	b, err = new(SafeScriptProto).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 {
+		t.Errorf("SafeScriptProto{}.Marshal() = %x, want empty", b)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		in   []byte
+		want string
+		err  bool
+	}{
+		{"empty", nil, "", false},
+		{"value", []byte{0x12, 2, 'h', 'i'}, "hi", false},
+		{"last value wins", []byte{0x12, 1, 'a', 0x12, 1, 'b'}, "b", false},
+		{"unknown fields", []byte{0x08, 0x96, 0x01, 0x1a, 1, 'x', 0x25, 0, 0, 0, 0, 0x29, 0, 0, 0, 0, 0, 0, 0, 0, 0x12, 1, 'c'}, "c", false},
+		{"truncated tag", []byte{0x80}, "", true},
+		{"truncated value", []byte{0x12, 5, 'a'}, "", true},
+		{"truncated fixed32", []byte{0x25, 0, 0}, "", true},
+		{"wrong wire type", []byte{0x10, 1}, "", true},
+		{"field number 0", []byte{0x02, 0}, "", true},
+		{"group", []byte{0x13}, "", true},
+	} {
+		var m SafeHtmlProto
+		err := m.Unmarshal(test.in)
+		if (err != nil) != test.err {
+			t.Errorf("%s: Unmarshal(%x) error = %v, want error: %t", test.desc, test.in, err, test.err)
+			continue
+		}
+		if got := m.privateDoNotAccessOrElseSafeHtmlWrappedValue; err == nil && got != test.want {
+			t.Errorf("%s: Unmarshal(%x) = %q, want %q", test.desc, test.in, got, test.want)
+		}
+	}
+}
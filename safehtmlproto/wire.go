@@ -0,0 +1,89 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtmlproto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// The wire types of the protocol buffer wire format.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("safehtmlproto: truncated message")
+
+// marshalValue returns the wire format of a message whose string field
+// number field has value v. Empty values are omitted, as in proto3.
+func marshalValue(field int, v string) []byte {
+	if v == "" {
+		return nil
+	}
+	b := make([]byte, 0, 2*binary.MaxVarintLen64+len(v))
+	b = appendVarint(b, uint64(field)<<3|wireBytes)
+	b = appendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// unmarshalValue returns the value of the string field number field of the
+// message in the wire format b. As in generated code, unknown fields are
+// skipped, and the last value of a repeated field wins.
+func unmarshalValue(b []byte, field int) (string, error) {
+	var v string
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return "", errTruncated
+		}
+		b = b[n:]
+		num, typ := tag>>3, tag&7
+		if num == 0 {
+			return "", errors.New("safehtmlproto: invalid field number 0")
+		}
+		switch typ {
+		case wireVarint:
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return "", errTruncated
+			}
+		case wireFixed64:
+			n = 8
+		case wireFixed32:
+			n = 4
+		case wireBytes:
+			l, m := binary.Uvarint(b)
+			if m <= 0 || l > uint64(len(b)-m) {
+				return "", errTruncated
+			}
+			b = b[m:]
+			n = int(l)
+			if num == uint64(field) {
+				v = string(b[:n])
+			}
+		default:
+			return "", fmt.Errorf("safehtmlproto: unsupported wire type %d", typ)
+		}
+		if n > len(b) {
+			return "", errTruncated
+		}
+		if num == uint64(field) && typ != wireBytes {
+			return "", fmt.Errorf("safehtmlproto: field %d has wire type %d, want %d", field, typ, wireBytes)
+		}
+		b = b[n:]
+	}
+	return v, nil
+}
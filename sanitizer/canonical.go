@@ -0,0 +1,201 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+	"golang.org/x/net/html"
+)
+
+// CanonicalizeHTML returns h in a canonical form, so that HTML values that
+// differ only in their serialization, such as values rendered by different
+// versions of a template or of Go, can be compared or used as cache keys:
+//
+//   - Tag and attribute names are lowercase, attributes are sorted by name,
+//     and duplicate attributes, which browsers ignore, are removed.
+//   - Attribute values are double-quoted, attributes with empty values are
+//     written without a value, and void elements such as br have no
+//     trailing slash.
+//   - Text and attribute values are escaped the same way regardless of how
+//     they were escaped in h.
+//   - Runs of whitespace in text are collapsed into a single space, except
+//     in the content of pre, textarea, script, style, and other elements
+//     whose whitespace is significant or not text.
+//
+// CanonicalizeHTML tokenizes h rather than parsing it, so it neither adds
+// implied elements nor moves misnested ones, and never changes the structure
+// of the document. h is not sanitized; it is already safe.
+func CanonicalizeHTML(h safehtml.HTML) safehtml.HTML {
+	return reserialize(h, false)
+}
+
+// reserialize tokenizes h and writes its tokens again, as described in
+// CanonicalizeHTML, or, if minify is true, as described in Minify.
+func reserialize(h safehtml.HTML, minify bool) safehtml.HTML {
+	z := html.NewTokenizer(strings.NewReader(h.String()))
+	var b strings.Builder
+	// raw is the element whose content is being tokenized as raw text or
+	// RCDATA, if any.
+	raw := ""
+	// pre is the number of open elements whose whitespace is preserved.
+	pre := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			// The tokenizer only fails at the end of its input, since reading
+			// from a strings.Reader does not fail.
+			return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(b.String())
+		case html.TextToken:
+			text := string(z.Text())
+			switch {
+			case raw != "" && raw != "textarea" && raw != "title":
+				b.WriteString(text)
+			case raw != "" || pre > 0:
+				b.WriteString(html.EscapeString(text))
+			default:
+				b.WriteString(html.EscapeString(collapseWhitespace(text)))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			b.WriteByte('<')
+			b.WriteString(tag)
+			writeAttrs(&b, z, !minify)
+			if tt == html.SelfClosingTagToken && !voidElements[tag] {
+				// Keep the slash, which closes elements in foreign content
+				// such as SVG.
+				b.WriteString("/>")
+				continue
+			}
+			b.WriteByte('>')
+			if rawTextElements[tag] {
+				raw = tag
+			}
+			if preservedWhitespaceElements[tag] {
+				pre++
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			b.WriteString("</")
+			b.WriteString(tag)
+			b.WriteByte('>')
+			if tag == raw {
+				raw = ""
+			}
+			if preservedWhitespaceElements[tag] && pre > 0 {
+				pre--
+			}
+		case html.CommentToken:
+			text := z.Text()
+			if minify && !isFragmentComment(text) {
+				continue
+			}
+			b.WriteString("<!--")
+			b.Write(text)
+			b.WriteString("-->")
+		case html.DoctypeToken:
+			b.WriteString("<!DOCTYPE ")
+			b.Write(z.Text())
+			b.WriteByte('>')
+		}
+	}
+}
+
+// writeAttrs writes the attributes of the current tag of z, sorted by name if
+// sorted is true.
+func writeAttrs(b *strings.Builder, z *html.Tokenizer, sorted bool) {
+	var attrs []html.Attribute
+	seen := make(map[string]bool)
+	for more := true; more; {
+		var key, val []byte
+		key, val, more = z.TagAttr()
+		if len(key) == 0 || seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		attrs = append(attrs, html.Attribute{Key: string(key), Val: string(val)})
+	}
+	if sorted {
+		sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	}
+	for _, a := range attrs {
+		b.WriteByte(' ')
+		b.WriteString(a.Key)
+		if a.Val != "" {
+			b.WriteString(`="`)
+			b.WriteString(html.EscapeString(a.Val))
+			b.WriteByte('"')
+		}
+	}
+}
+
+// collapseWhitespace replaces each run of ASCII whitespace in s with a single
+// space.
+func collapseWhitespace(s string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\f', '\r':
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+		default:
+			b.WriteByte(c)
+			space = false
+		}
+	}
+	return b.String()
+}
+
+// voidElements contains the elements that have no end tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// rawTextElements contains the elements whose content the tokenizer reads
+// as raw text or, for textarea and title, as RCDATA.
+var rawTextElements = map[string]bool{
+	"iframe":    true,
+	"noembed":   true,
+	"noframes":  true,
+	"noscript":  true,
+	"plaintext": true,
+	"script":    true,
+	"style":     true,
+	"textarea":  true,
+	"title":     true,
+	"xmp":       true,
+}
+
+// preservedWhitespaceElements contains the elements whose whitespace is
+// rendered as is.
+var preservedWhitespaceElements = map[string]bool{
+	"listing":  true,
+	"pre":      true,
+	"textarea": true,
+}
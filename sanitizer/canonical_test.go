@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"testing"
+
+	"github.com/google/safehtml/testconversions"
+)
+
+func TestCanonicalizeHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, in, want string
+	}{
+		{"attribute order", `<a title="t" href="/a" class="c">x</a>`, `<a class="c" href="/a" title="t">x</a>`},
+		{"quoting", `<a href=/a title='it&#39;s "x"'>x</a>`, `<a href="/a" title="it&#39;s &#34;x&#34;">x</a>`},
+		{"case", `<DIV ID="a">x</DIV>`, `<div id="a">x</div>`},
+		{"duplicate attributes", `<b id="a" id="b">x</b>`, `<b id="a">x</b>`},
+		{"empty attributes", `<input disabled="" checked>`, `<input checked disabled>`},
+		{"void elements", `<br/><img src="a.png" />`, `<br><img src="a.png">`},
+		{"foreign self-closing", `<svg><path d="M0"/></svg>`, `<svg><path d="M0"/></svg>`},
+		{"whitespace", "<p>\n  a \t b\n</p>\n\n<p>c</p>", `<p> a b </p> <p>c</p>`},
+		{"entities", `a&nbsp;&lt;&#62;&quot;&amp&#x41;`, "a\u00a0&lt;&gt;&#34;&amp;A"},
+		{"pre", "<pre>\n  a\n   b</pre>  c", "<pre>\n  a\n   b</pre> c"},
+		{"nested pre", "<pre><b>  a</b>  b</pre>  c", "<pre><b>  a</b>  b</pre> c"},
+		{"textarea", "<textarea>  a &lt;b&gt;</textarea>", "<textarea>  a &lt;b&gt;</textarea>"},
+		{"script", "<script>if (a  <  b && c) {}</script>", "<script>if (a  <  b && c) {}</script>"},
+		{"style", "<style>a  >  b { color: red }</style>", "<style>a  >  b { color: red }</style>"},
+		{"comment", "<!--  a  -->", "<!--  a  -->"},
+		{"doctype", "<!doctype html><p>a", "<!DOCTYPE html><p>a"},
+		{"misnested", "<b><i>a</b></i>", "<b><i>a</b></i>"},
+	} {
+		got := CanonicalizeHTML(testconversions.MakeHTMLForTest(test.in)).String()
+		if got != test.want {
+			t.Errorf("%s: CanonicalizeHTML(%q):\ngot  %q\nwant %q", test.desc, test.in, got, test.want)
+			continue
+		}
+		if again := CanonicalizeHTML(testconversions.MakeHTMLForTest(got)).String(); again != got {
+			t.Errorf("%s: CanonicalizeHTML is not idempotent:\ngot  %q\nthen %q", test.desc, got, again)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package interop returns the output of third-party HTML sanitizers, such as
+// a bluemonday.Policy, as safehtml.HTML values.
+//
+// Applications that already sanitize untrusted markup with another library
+// would otherwise convert its output using uncheckedconversions at every call
+// site. A Policy is instead the single, reviewed boundary at which the output
+// is converted, after it has been checked by a safehtml/sanitizer Policy:
+//
+//	p := interop.Wrap(bluemonday.UGCPolicy())
+//	h := p.Sanitize(comment)
+//
+// The check ensures that the output satisfies the safehtml.HTML type
+// contract even if the third-party sanitizer is misconfigured or has a bug.
+// When the check removes markup from the output, a diagnostic is reported to
+// the Logger set with safehtml.SetLogger.
+package interop
+
+import (
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/sanitizer"
+)
+
+// A Sanitizer is a third-party HTML sanitizer. *bluemonday.Policy implements
+// Sanitizer.
+type Sanitizer interface {
+	// Sanitize returns the markup in s that the sanitizer allows.
+	Sanitize(s string) string
+}
+
+// A Policy sanitizes untrusted markup with a Sanitizer and checks its output.
+// A Policy is safe for concurrent use if its Sanitizer is.
+type Policy struct {
+	s     Sanitizer
+	check *sanitizer.Policy
+}
+
+// permissive is the default check of a Policy.
+var permissive = sanitizer.PermissivePolicy()
+
+// Wrap returns a Policy that sanitizes markup with s, and checks its output
+// with sanitizer.PermissivePolicy, which keeps the identifiers of the output
+// unchanged.
+func Wrap(s Sanitizer) *Policy {
+	return WrapWithCheck(s, permissive)
+}
+
+// WrapWithCheck is like Wrap, but checks the output of s with check, which
+// can be stricter than sanitizer.PermissivePolicy.
+func WrapWithCheck(s Sanitizer, check *sanitizer.Policy) *Policy {
+	return &Policy{s: s, check: check}
+}
+
+// Sanitize returns the markup in untrusted that both the Sanitizer and the
+// check of p allow.
+func (p *Policy) Sanitize(untrusted string) safehtml.HTML {
+	out := p.s.Sanitize(untrusted)
+	checked, err := p.check.Check(out)
+	if err == nil {
+		return checked
+	}
+	// Only markup that the check removes is reported, not the normalization
+	// of the markup it keeps, such as the prefixing of identifiers by a strict
+	// check.
+	checked = p.check.Sanitize(out)
+	diag.Log("safehtml/sanitizer/interop: check changed sanitizer output", "output", out, "checked", checked.String(), "error", err.Error())
+	return checked
+}
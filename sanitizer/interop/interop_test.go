@@ -0,0 +1,77 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package interop
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/sanitizer"
+)
+
+// sanitizerFunc adapts a function to a Sanitizer.
+type sanitizerFunc func(string) string
+
+func (f sanitizerFunc) Sanitize(s string) string { return f(s) }
+
+// stripScripts is a deliberately incomplete sanitizer that only removes
+// script elements.
+var stripScripts = sanitizerFunc(func(s string) string {
+	for {
+		i := strings.Index(s, "<script>")
+		j := strings.Index(s, "</script>")
+		if i < 0 || j < i {
+			return s
+		}
+		s = s[:i] + s[j+len("</script>"):]
+	}
+})
+
+func TestSanitize(t *testing.T) {
+	var logged []string
+	safehtml.SetLogger(safehtml.LoggerFunc(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, msg)
+	}))
+	defer safehtml.SetLogger(nil)
+	p := Wrap(stripScripts)
+	for _, test := range [...]struct {
+		in, want string
+		changed  bool
+	}{
+		{`<p>Hello <b>world</b><script>alert(1)</script></p>`, `<p>Hello <b>world</b></p>`, false},
+		{`<p class='x'>a &amp; b</p>`, `<p class="x">a &amp; b</p>`, false},
+		{`<h2 id="intro">a</h2><a href="#intro">b</a><table><tr><td>c</td></tr></table>`, `<h2 id="intro">a</h2><a href="#intro">b</a><table><tbody><tr><td>c</td></tr></tbody></table>`, false},
+		{`<a href="javascript:alert(1)" onclick="alert(2)">x</a>`, `<a>x</a>`, true},
+		{`<iframe src="https://evil.example"></iframe>ok`, `ok`, true},
+	} {
+		logged = nil
+		if got := p.Sanitize(test.in).String(); got != test.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", test.in, got, test.want)
+		}
+		if changed := len(logged) > 0; changed != test.changed {
+			t.Errorf("Sanitize(%q): logged %q, want a diagnostic: %t", test.in, logged, test.changed)
+		}
+	}
+}
+
+func TestWrapWithCheck(t *testing.T) {
+	var logged []string
+	safehtml.SetLogger(safehtml.LoggerFunc(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, msg)
+	}))
+	defer safehtml.SetLogger(nil)
+	p := WrapWithCheck(stripScripts, sanitizer.UGCPolicy())
+	if got, want := p.Sanitize(`<section><p>a</p></section>`).String(), `<p>a</p>`; got != want || len(logged) != 1 {
+		t.Errorf("got %q, logged %q, want %q and a diagnostic", got, logged, want)
+	}
+	logged = nil
+	p = WrapWithCheck(stripScripts, sanitizer.UGCPolicy().AllowAttributes("h2", "id"))
+	if got, want := p.Sanitize(`<h2 id="intro">a</h2>`).String(), `<h2 id="user-content-intro">a</h2>`; got != want || len(logged) != 0 {
+		t.Errorf("got %q, logged %q, want %q and no diagnostic", got, logged, want)
+	}
+}
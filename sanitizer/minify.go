@@ -0,0 +1,41 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"bytes"
+
+	"github.com/google/safehtml"
+)
+
+// Minify returns h with the bytes that browsers ignore removed, so that
+// render pipelines can minify HTML values without converting them to plain
+// strings:
+//
+//   - Comments are removed, except for the comments that
+//     safehtml.VersionedHTML wraps fragments in.
+//   - Runs of whitespace in text are collapsed into a single space, except
+//     in the content of pre, textarea, script, style, and other elements
+//     whose whitespace is significant or not text.
+//   - Attribute values are double-quoted and escaped consistently,
+//     attributes with empty values are written without a value, duplicate
+//     attributes are removed, and void elements have no trailing slash.
+//
+// Like CanonicalizeHTML, Minify tokenizes h rather than parsing it and never
+// changes the structure of the document, and leaves the content of script
+// and style elements unchanged. Whitespace is collapsed rather than removed,
+// since it separates inline content; markup that preserves whitespace with
+// the CSS white-space property may render differently.
+func Minify(h safehtml.HTML) safehtml.HTML {
+	return reserialize(h, true)
+}
+
+// isFragmentComment reports whether text is the text of a comment written by
+// safehtml.VersionedHTML.
+func isFragmentComment(text []byte) bool {
+	return bytes.HasPrefix(text, []byte("safehtml-fragment ")) || bytes.HasPrefix(text, []byte("/safehtml-fragment "))
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/testconversions"
+)
+
+func TestMinify(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, in, want string
+	}{
+		{"attribute order", `<a title="t" href="/a" class="c">x</a>`, `<a title="t" href="/a" class="c">x</a>`},
+		{"quoting", `<a href=/a title='it&#39;s "x"'>x</a>`, `<a href="/a" title="it&#39;s &#34;x&#34;">x</a>`},
+		{"empty attributes", `<input disabled="" checked>`, `<input disabled checked>`},
+		{"duplicate attributes", `<b id="a" id="b">x</b>`, `<b id="a">x</b>`},
+		{"void elements", `<br/><img src="a.png" />`, `<br><img src="a.png">`},
+		{"whitespace", "<ul>\n  <li>a \t b</li>\n\n  <li>c</li>\n</ul>\n", `<ul> <li>a b</li> <li>c</li> </ul> `},
+		{"comments", "a<!-- note -->b<!--[if IE]>c<![endif]-->", "ab"},
+		{"pre", "<pre>\n  a\n   b</pre>  c", "<pre>\n  a\n   b</pre> c"},
+		{"script", "<script>if (a  <  b) {} // <!-- x --></script>", "<script>if (a  <  b) {} // <!-- x --></script>"},
+		{"style", "<style>a  >  b { color: red }</style>", "<style>a  >  b { color: red }</style>"},
+		{"doctype", "<!doctype html>\n<p>a", "<!DOCTYPE html> <p>a"},
+	} {
+		got := Minify(testconversions.MakeHTMLForTest(test.in)).String()
+		if got != test.want {
+			t.Errorf("%s: Minify(%q):\ngot  %q\nwant %q", test.desc, test.in, got, test.want)
+		}
+	}
+}
+
+func TestMinifyKeepsFragmentVersions(t *testing.T) {
+	v := safehtml.FragmentVersion{ID: safehtml.IdentifierFromConstant("header"), Version: "v1"}
+	h, err := safehtml.VersionedHTML(v, testconversions.MakeHTMLForTest("<p>\n  a <!-- x --></p>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, content, ok := safehtml.ParseVersionedHTML(Minify(h))
+	if !ok || got != v || content.String() != "<p> a </p>" {
+		t.Errorf("ParseVersionedHTML(Minify(%q)) = %v, %q, %t", h, got, content, ok)
+	}
+}
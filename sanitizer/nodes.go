@@ -0,0 +1,40 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SanitizeNodes returns the markup of the given node trees that p allows, as
+// Sanitize does for parsed markup. The trees can be the result of parsing
+// untrusted markup with golang.org/x/net/html, or be built or modified by
+// server-side code. Document nodes are replaced by their content, so the html,
+// head, and body elements of a document are never kept.
+//
+// The trees are not modified. If they are not trees that the HTML parser
+// produces, such as a p element containing another, the browser may parse
+// the result into a different tree, which contains only allowed markup.
+func (p *Policy) SanitizeNodes(nodes ...*html.Node) safehtml.HTML {
+	var b strings.Builder
+	for _, n := range nodes {
+		p.render(&b, n, nil)
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(b.String())
+}
+
+// ParseHTML parses h as the content of a div element, as browsers parse it,
+// and returns the resulting node trees. h can be converted back, after
+// server-side changes to the trees, with SanitizeNodes.
+func ParseHTML(h safehtml.HTML) ([]*html.Node, error) {
+	return html.ParseFragment(strings.NewReader(h.String()), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+}
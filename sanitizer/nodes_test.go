@@ -0,0 +1,58 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml/testconversions"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestSanitizeNodes(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<!DOCTYPE html><html><head><title>T</title><script>alert(1)</script></head><body><p onclick="f()">Hi <b>there</b></p><!-- c --></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := UGCPolicy()
+	if got, want := p.SanitizeNodes(doc).String(), `<p>Hi <b>there</b></p>`; got != want {
+		t.Errorf("SanitizeNodes(document) = %q, want %q", got, want)
+	}
+
+	// Nodes built by server-side code.
+	a := &html.Node{Type: html.ElementNode, Data: "a", DataAtom: atom.A, Attr: []html.Attribute{
+		{Key: "href", Val: "javascript:alert(1)"},
+		{Key: "title", Val: `"><script>`},
+	}}
+	a.AppendChild(&html.Node{Type: html.TextNode, Data: "<click>"})
+	img := &html.Node{Type: html.ElementNode, Data: "img", DataAtom: atom.Img, Attr: []html.Attribute{{Key: "src", Val: "/a.png"}, {Key: "onerror", Val: "f()"}}}
+	script := &html.Node{Type: html.ElementNode, Data: "script", DataAtom: atom.Script}
+	script.AppendChild(&html.Node{Type: html.TextNode, Data: "alert(2)"})
+	got := p.SanitizeNodes(a, img, script).String()
+	if want := `<a title="&#34;&gt;&lt;script&gt;">&lt;click&gt;</a><img src="/a.png">`; got != want {
+		t.Errorf("SanitizeNodes(built) = %q, want %q", got, want)
+	}
+	if len(a.Attr) != 2 || a.Attr[0].Val != "javascript:alert(1)" {
+		t.Errorf("SanitizeNodes modified its input: %v", a.Attr)
+	}
+}
+
+func TestParseHTML(t *testing.T) {
+	nodes, err := ParseHTML(testconversions.MakeHTMLForTest(`<ul><li>a<li>b</ul><td>x`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[0].Data != "ul" {
+		t.Fatalf("ParseHTML returned %d nodes, want ul and text nodes", len(nodes))
+	}
+	nodes[0].FirstChild.AppendChild(&html.Node{Type: html.TextNode, Data: "!"})
+	if got, want := UGCPolicy().SanitizeNodes(nodes...).String(), `<ul><li>a!</li><li>b</li></ul>x`; got != want {
+		t.Errorf("round trip = %q, want %q", got, want)
+	}
+}
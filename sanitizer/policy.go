@@ -0,0 +1,270 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/safehtml/registry"
+)
+
+// anyElement is the element name used in a Policy to refer to all elements.
+const anyElement = "*"
+
+// A Policy is an allowlist of the elements, attributes, and URL schemes that
+// Sanitize keeps in untrusted HTML.
+//
+// The zero value is an empty policy that only keeps text. Methods that modify
+// a Policy return it so that calls can be chained:
+//
+//	p := new(sanitizer.Policy).
+//		AllowElements("a", "b", "i", "p").
+//		AllowAttributes("a", "href").
+//		AllowURLSchemes("https", "mailto")
+//
+// Element, attribute, and scheme names are case-insensitive. A Policy must
+// not be modified while it is in use by Sanitize, and is otherwise safe for
+// concurrent use.
+type Policy struct {
+	elements map[string]bool
+	// attrs[element][attr] reports whether attr is allowed in element, or in
+	// all elements if element is "*".
+	attrs map[string]map[string]bool
+	// urlSchemes contains the allowed schemes of absolute URLs, or is nil if
+	// all schemes that are safe in safehtml.URL values are allowed.
+	urlSchemes map[string]bool
+	// dataAttributes reports whether data attributes are allowed in all
+	// allowed elements.
+	dataAttributes bool
+	// keepIdentifiers reports whether identifiers are kept without the
+	// prefix "user-content-".
+	keepIdentifiers bool
+}
+
+// unsafeElements contains elements that Policy never allows, although
+// safehtml/template allows them, because their mere presence in untrusted
+// markup can load or run content, navigate the page, or change how the rest of
+// the document is parsed. esi:include is unsafe because ESI processors such as
+// CDNs replace it with the resource at its src.
+var unsafeElements = map[string]bool{
+	"body":        true,
+	"esi:include": true,
+	"frame":       true,
+	"frameset":    true,
+	"head":        true,
+	"html":        true,
+	"iframe":      true,
+	"link":        true,
+	"noscript":    true,
+	"param":       true,
+}
+
+// AllowElements allows the named elements. It panics if an element is not
+// one that safehtml/template allows untrusted values in, if its content is
+// not HTML, or if it is one of the elements such as iframe that are unsafe to
+// accept from untrusted markup.
+func (p *Policy) AllowElements(names ...string) *Policy {
+	if p.elements == nil {
+		p.elements = make(map[string]bool)
+	}
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if !isAllowableElement(name) {
+			panic(fmt.Sprintf("sanitizer: element %q cannot be allowed", name))
+		}
+		p.elements[name] = true
+	}
+	return p
+}
+
+func isAllowableElement(name string) bool {
+	if unsafeElements[name] {
+		return false
+	}
+	if ctx, ok := (*registry.Registry)(nil).ElementContent(name); ok {
+		return ctx == registry.HTML
+	}
+	for _, v := range (*registry.Registry)(nil).VoidElements() {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowAttributes allows the named attributes in element, which must be
+// allowed using AllowElements for the attributes to be kept. If element is
+// "*", the attributes are allowed in all allowed elements.
+//
+// URL-valued attributes, such as href and src, are kept only if their values
+// are safe URLs with an allowed scheme. Attributes that only accept a few
+// values, such as dir and target, are kept only if they have one of those
+// values. The values of id, name, and other attributes referring to elements
+// are kept only if they are identifiers, which are prefixed with
+// "user-content-" so that untrusted markup cannot clobber the elements and
+// global variables of the page, unless KeepIdentifiers is called. References
+// to the elements of the same markup, such as href="#intro", are prefixed
+// likewise if id or name attributes are allowed. The names of form controls,
+// such as input elements, are not prefixed, since they are the names of the
+// submitted form data.
+//
+// AllowAttributes panics if safehtml/template does not allow untrusted values
+// in an attribute, or if it requires them to have a safe type such as
+// safehtml.Style or safehtml.TrustedResourceURL. Event handler attributes
+// such as onclick can therefore never be allowed.
+func (p *Policy) AllowAttributes(element string, attrs ...string) *Policy {
+	element = strings.ToLower(element)
+	if p.attrs == nil {
+		p.attrs = make(map[string]map[string]bool)
+	}
+	if p.attrs[element] == nil {
+		p.attrs[element] = make(map[string]bool)
+	}
+	for _, attr := range attrs {
+		attr = strings.ToLower(attr)
+		if !isAllowableAttr(element, attr) {
+			panic(fmt.Sprintf("sanitizer: attribute %q cannot be allowed in element %q", attr, element))
+		}
+		p.attrs[element][attr] = true
+	}
+	return p
+}
+
+func isAllowableAttr(element, attr string) bool {
+	var ctx registry.Context
+	if element == anyElement {
+		var ok bool
+		if ctx, ok = (*registry.Registry)(nil).GlobalAttributes()[attr]; !ok && strings.HasPrefix(attr, "data-") {
+			ctx, _ = (*registry.Registry)(nil).Attribute("div", attr, "")
+		}
+	} else {
+		ctx, _ = (*registry.Registry)(nil).Attribute(element, attr, "")
+	}
+	_, ok := attrValidators[ctx]
+	return ok
+}
+
+// AllowURLSchemes restricts the schemes of absolute URLs in URL-valued
+// attributes to the given schemes, such as "https" and "mailto". By default,
+// all schemes that are safe in safehtml.URL values are allowed. If "data" is
+// allowed, only data URLs with the audio, image, and video MIME types returned
+// by urlsafe.DefaultDataURLMIMETypes are kept.
+func (p *Policy) AllowURLSchemes(schemes ...string) *Policy {
+	if p.urlSchemes == nil {
+		p.urlSchemes = make(map[string]bool)
+	}
+	for _, scheme := range schemes {
+		p.urlSchemes[strings.ToLower(scheme)] = true
+	}
+	return p
+}
+
+// AllowDataAttributes allows all custom data attributes, such as data-id, in
+// all allowed elements. Data attributes are kept with any value, as in
+// safehtml/template.
+func (p *Policy) AllowDataAttributes() *Policy {
+	p.dataAttributes = true
+	return p
+}
+
+// KeepIdentifiers keeps identifiers, and the references to them, without the
+// prefix "user-content-" described in AllowAttributes. It is meant for
+// policies that check markup from trusted sources, whose identifiers must be
+// kept for the page's links, scripts, and stylesheets; untrusted markup with
+// unprefixed identifiers can clobber the elements and global variables of the
+// page.
+func (p *Policy) KeepIdentifiers() *Policy {
+	p.keepIdentifiers = true
+	return p
+}
+
+// prefixesReferences reports whether p prefixes the references to the
+// elements of the same markup, which is the case if it prefixes the
+// identifiers of some elements.
+func (p *Policy) prefixesReferences() bool {
+	if p.keepIdentifiers {
+		return false
+	}
+	for _, attrs := range p.attrs {
+		if attrs["id"] || attrs["name"] {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) isElementAllowed(element string) bool {
+	return p != nil && p.elements[element]
+}
+
+func (p *Policy) isAttrAllowed(element, attr string) bool {
+	return p.attrs[element][attr] || p.attrs[anyElement][attr] && isAllowableAttr(element, attr) || p.dataAttributes && registry.IsDataAttribute(attr)
+}
+
+// UGCPolicy returns a Policy for user-generated content such as comments and
+// forum posts. It allows text formatting, headings, lists, quotes, code,
+// tables, links, and images, with http, https, and mailto URLs.
+func UGCPolicy() *Policy {
+	return new(Policy).
+		AllowElements(
+			"a", "abbr", "b", "blockquote", "br", "caption", "cite", "code", "dd", "del",
+			"details", "dfn", "div", "dl", "dt", "em", "figcaption", "figure", "h1", "h2",
+			"h3", "h4", "h5", "h6", "hr", "i", "img", "ins", "kbd", "li", "mark", "ol", "p",
+			"pre", "q", "s", "samp", "small", "span", "strike", "strong", "sub", "summary",
+			"sup", "table", "tbody", "td", "tfoot", "th", "thead", "time", "tr", "u", "ul", "var",
+		).
+		AllowAttributes(anyElement, "dir", "lang", "title").
+		AllowAttributes("a", "href").
+		AllowAttributes("img", "src", "alt", "width", "height").
+		AllowAttributes("td", "colspan", "rowspan").
+		AllowAttributes("th", "colspan", "rowspan").
+		AllowAttributes("time", "datetime").
+		AllowURLSchemes("http", "https", "mailto")
+}
+
+// PermissivePolicy returns a Policy that allows every element, attribute, and
+// URL scheme that a Policy can allow, including data attributes, and keeps
+// identifiers as described in KeepIdentifiers. It is meant to check markup
+// from other sources, such as other sanitizers, rather than to sanitize
+// untrusted markup itself.
+//
+// Although all markup that it keeps is safe in a safehtml.HTML value, it
+// does not keep all markup that templates can produce: it removes style
+// attributes, whose values templates only accept as safehtml.Style values,
+// SVG and MathML content, and elements such as iframe and link that a Policy
+// never allows.
+func PermissivePolicy() *Policy {
+	p := new(Policy).AllowDataAttributes().KeepIdentifiers()
+	r := (*registry.Registry)(nil)
+	for elem := range r.Elements() {
+		if isAllowableElement(elem) {
+			p.AllowElements(elem)
+		}
+	}
+	for _, elem := range r.VoidElements() {
+		if isAllowableElement(elem) {
+			p.AllowElements(elem)
+		}
+	}
+	for attr := range r.GlobalAttributes() {
+		if isAllowableAttr(anyElement, attr) {
+			p.AllowAttributes(anyElement, attr)
+		}
+	}
+	for elem, attrs := range r.ElementAttributes() {
+		if !p.elements[elem] {
+			continue
+		}
+		for attr := range attrs {
+			if isAllowableAttr(elem, attr) {
+				p.AllowAttributes(elem, attr)
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"testing"
+)
+
+func TestPolicyPanics(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		f    func(p *Policy)
+	}{
+		{"script", func(p *Policy) { p.AllowElements("script") }},
+		{"style element", func(p *Policy) { p.AllowElements("STYLE") }},
+		{"iframe", func(p *Policy) { p.AllowElements("iframe") }},
+		{"esi:include", func(p *Policy) { p.AllowElements("esi:include") }},
+		{"RCDATA element", func(p *Policy) { p.AllowElements("textarea") }},
+		{"unknown element", func(p *Policy) { p.AllowElements("blink") }},
+		{"event handler", func(p *Policy) { p.AllowAttributes("*", "onclick") }},
+		{"style attribute", func(p *Policy) { p.AllowAttributes("b", "style") }},
+		{"trusted resource URL", func(p *Policy) { p.AllowAttributes("*", "src") }},
+		{"srcdoc", func(p *Policy) { p.AllowAttributes("iframe", "srcdoc") }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: did not panic", test.desc)
+				}
+			}()
+			test.f(new(Policy))
+		}()
+	}
+}
+
+func TestPolicyGlobalAttributes(t *testing.T) {
+	p := new(Policy).AllowElements("a", "img").AllowAttributes("*", "title", "data-x")
+	got := p.Sanitize(`<a title="a" data-x="1" href="/a">a</a><img title="b" src="/b.png">`).String()
+	if want := `<a title="a" data-x="1">a</a><img title="b">`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPolicyDataURLs(t *testing.T) {
+	p := new(Policy).AllowElements("img").AllowAttributes("img", "src")
+	for in, want := range map[string]string{
+		`<img src="data:image/png;base64,iVBO">`:           `<img src="data:image/png;base64,iVBO">`,
+		`<img src="data:text/html;base64,PHNjcmlwdD4=">`:   `<img>`,
+		`<img src="data:image/svg+xml;base64,PHN2Zz4=">`:   `<img>`,
+		`<img src="data:image/png,<svg onload=alert(1)>">`: `<img>`,
+	} {
+		if got := p.Sanitize(in).String(); got != want {
+			t.Errorf("Sanitize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPolicyDataAttributes(t *testing.T) {
+	p := new(Policy).AllowElements("b").AllowDataAttributes()
+	got := p.Sanitize(`<b data-id="1" data-X="2" data-="3" id="b">a</b><i data-id="4">i</i>`).String()
+	if want := `<b data-id="1" data-x="2">a</b>i`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPermissivePolicy(t *testing.T) {
+	p := PermissivePolicy()
+	for in, want := range map[string]string{
+		`<section><h2 title="t">x</h2><table><tr><td colspan="2">y</td></tr></table></section>`: `<section><h2 title="t">x</h2><table><tbody><tr><td colspan="2">y</td></tr></tbody></table></section>`,
+		`<a href="https://example.com" target="_blank" onclick="f()">a</a>`:                     `<a href="https://example.com" target="_blank">a</a>`,
+		`<a href="javascript:alert(1)">a</a><script>alert(2)</script>`:                          `<a>a</a>`,
+		`<iframe src="https://example.com"></iframe><p style="color:red">p</p>`:                 `<p>p</p>`,
+		`<img src="/a.png" alt="a">`:                            `<img src="/a.png" alt="a">`,
+		`<h2 id="intro" data-n="1"><a href="#intro">x</a></h2>`: `<h2 id="intro" data-n="1"><a href="#intro">x</a></h2>`,
+		`<form name="f"><input name="q"></form>`:                `<form name="f"><input name="q"></form>`,
+	} {
+		if got := p.Sanitize(in).String(); got != want {
+			t.Errorf("Sanitize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
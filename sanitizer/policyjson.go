@@ -0,0 +1,143 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/google/safehtml/template"
+)
+
+// policyJSON is the JSON form of a Policy.
+type policyJSON struct {
+	Elements   []string            `json:"elements,omitempty"`
+	Attributes map[string][]string `json:"attributes,omitempty"`
+	// URLSchemes is a pointer so that policies allowing no absolute URLs,
+	// whose list is empty, can be told from policies allowing all safe
+	// schemes, which have no list.
+	URLSchemes      *[]string `json:"urlSchemes,omitempty"`
+	DataAttributes  bool      `json:"dataAttributes,omitempty"`
+	KeepIdentifiers bool      `json:"keepIdentifiers,omitempty"`
+}
+
+// MarshalJSON returns the JSON form of p, an object whose members list the
+// names passed to the methods of Policy, and are omitted if the methods were
+// not called:
+//
+//	{
+//		"elements": ["a", "b", "p"],
+//		"attributes": {"*": ["title"], "a": ["href"]},
+//		"urlSchemes": ["https", "mailto"],
+//		"dataAttributes": true,
+//		"keepIdentifiers": true
+//	}
+//
+// Names are sorted, so equal policies have the same JSON form.
+func (p *Policy) MarshalJSON() ([]byte, error) {
+	var pj policyJSON
+	if p != nil {
+		pj.Elements = sortedSet(p.elements)
+		for element, attrs := range p.attrs {
+			if len(attrs) == 0 {
+				continue
+			}
+			if pj.Attributes == nil {
+				pj.Attributes = make(map[string][]string)
+			}
+			pj.Attributes[element] = sortedSet(attrs)
+		}
+		if p.urlSchemes != nil {
+			schemes := append([]string{}, sortedSet(p.urlSchemes)...)
+			pj.URLSchemes = &schemes
+		}
+		pj.DataAttributes = p.dataAttributes
+		pj.KeepIdentifiers = p.keepIdentifiers
+	}
+	return json.Marshal(pj)
+}
+
+// UnmarshalJSON sets p to the policy whose JSON form, as described in
+// MarshalJSON, is b. Unlike the methods of Policy, it returns an error rather
+// than panicking if b allows an element or attribute that cannot be allowed.
+// It also returns an error if b has members other than those of the JSON
+// form.
+func (p *Policy) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var pj policyJSON
+	if err := dec.Decode(&pj); err != nil {
+		return fmt.Errorf("invalid policy: %v", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid policy: unexpected data after the policy object")
+	}
+	for _, name := range pj.Elements {
+		if !isAllowableElement(strings.ToLower(name)) {
+			return fmt.Errorf("invalid policy: element %q cannot be allowed", name)
+		}
+	}
+	for element, attrs := range pj.Attributes {
+		for _, attr := range attrs {
+			if !isAllowableAttr(strings.ToLower(element), strings.ToLower(attr)) {
+				return fmt.Errorf("invalid policy: attribute %q cannot be allowed in element %q", attr, element)
+			}
+		}
+	}
+	np := new(Policy)
+	if len(pj.Elements) > 0 {
+		np.AllowElements(pj.Elements...)
+	}
+	for element, attrs := range pj.Attributes {
+		np.AllowAttributes(element, attrs...)
+	}
+	if pj.URLSchemes != nil {
+		for _, scheme := range *pj.URLSchemes {
+			if strings.TrimSpace(scheme) == "" {
+				return fmt.Errorf("invalid policy: urlSchemes contains an empty name")
+			}
+		}
+		np.AllowURLSchemes(*pj.URLSchemes...)
+	}
+	np.dataAttributes = pj.DataAttributes
+	np.keepIdentifiers = pj.KeepIdentifiers
+	*p = *np
+	return nil
+}
+
+// LoadPolicy reads a Policy from the JSON file src, in the form described in
+// Policy.MarshalJSON, so that a vetted policy can be distributed to the
+// applications that must adhere to it.
+func LoadPolicy(src template.TrustedSource) (*Policy, error) {
+	b, err := ioutil.ReadFile(src.String())
+	if err != nil {
+		return nil, err
+	}
+	p := new(Policy)
+	if err := p.UnmarshalJSON(b); err != nil {
+		return nil, fmt.Errorf("policy %s: %v", src, err)
+	}
+	return p, nil
+}
+
+// sortedSet returns the sorted elements of the set m, or nil if it is empty.
+func sortedSet(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
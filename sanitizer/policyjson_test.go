@@ -0,0 +1,104 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/safehtml/template"
+)
+
+func TestPolicyJSON(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		p    *Policy
+		want string
+	}{
+		{"empty", new(Policy), `{}`},
+		{
+			"allowances",
+			new(Policy).
+				AllowElements("P", "a", "b").
+				AllowAttributes("*", "title").
+				AllowAttributes("a", "href").
+				AllowURLSchemes("mailto", "https"),
+			`{"elements":["a","b","p"],"attributes":{"*":["title"],"a":["href"]},"urlSchemes":["https","mailto"]}`,
+		},
+		{"no absolute URLs", new(Policy).AllowURLSchemes(), `{"urlSchemes":[]}`},
+		{"data attributes and identifiers", new(Policy).AllowDataAttributes().KeepIdentifiers(), `{"dataAttributes":true,"keepIdentifiers":true}`},
+	} {
+		b, err := json.Marshal(test.p)
+		if err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		if got := string(b); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.desc, got, test.want)
+		}
+		var p Policy
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		if !reflect.DeepEqual(&p, test.p) {
+			t.Errorf("%s: unmarshaled %+v, want %+v", test.desc, p, *test.p)
+		}
+	}
+	b, err := json.Marshal(UGCPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p Policy
+	if err := json.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&p, UGCPolicy()) {
+		t.Error("UGCPolicy does not survive a JSON round trip")
+	}
+}
+
+func TestPolicyUnmarshalJSONErrors(t *testing.T) {
+	for _, input := range [...]string{
+		`[]`,
+		`{"element": ["a"]}`,
+		`{"elements": ["script"]}`,
+		`{"elements": ["iframe"]}`,
+		`{"elements": ["a"], "attributes": {"a": ["onclick"]}}`,
+		`{"attributes": {"*": ["style"]}}`,
+		`{"urlSchemes": [""]}`,
+		`{} []`,
+	} {
+		if err := new(Policy).UnmarshalJSON([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	contents := `{"elements": ["a", "b"], "attributes": {"a": ["href"]}, "urlSchemes": ["https"]}`
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.PanicOnError)
+	fs.String("policy", path, "")
+	p, err := LoadPolicy(template.TrustedSourceFromFlag(fs.Lookup("policy").Value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := `<b onclick="x()">bold</b> <a href="http://example.com">http</a> <a href="https://example.com">https</a> <i>i</i>`
+	if got, want := p.Sanitize(in).String(), `<b>bold</b> <a>http</a> <a href="https://example.com">https</a> i`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	fs.Set("policy", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := LoadPolicy(template.TrustedSourceFromFlag(fs.Lookup("policy").Value)); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
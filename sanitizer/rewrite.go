@@ -0,0 +1,232 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/safehtml"
+	"golang.org/x/net/html"
+)
+
+// A Rewriter rewrites the URLs in HTML documents proxied from an upstream
+// server, so that the URLs referring to the upstream server refer to the
+// proxy instead:
+//
+//	rw, err := sanitizer.NewRewriter(nil, "https://backend.internal/app/", "/app/")
+//	...
+//	err = rw.Rewrite(w, resp.Body, resp.Request.URL)
+//
+// Unlike Sanitize, a Rewriter keeps all elements and attributes of the
+// document, and only rewrites the values of URL-valued attributes. It is
+// meant to replace the rewriting of upstream markup with regular
+// expressions, which breaks on markup that browsers parse differently, not to
+// sanitize untrusted markup.
+//
+// A Rewriter is safe for concurrent use.
+type Rewriter struct {
+	policy   *Policy
+	upstream *url.URL
+	prefix   string
+}
+
+// rewrittenAttrs contains the attributes whose values are rewritten as URLs.
+var rewrittenAttrs = map[string]bool{
+	"action":     true,
+	"formaction": true,
+	"href":       true,
+	"poster":     true,
+	"src":        true,
+}
+
+// NewRewriter returns a Rewriter that rebases the URLs under upstream, an
+// absolute http or https URL such as "https://backend.internal/app/", onto
+// prefix, an absolute path or http or https URL such as "/app/". Other URLs
+// are made absolute, so that they still refer to the same resources.
+//
+// URLs that are not safe in safehtml.URL values, or whose schemes p does not
+// allow as described in Policy.AllowURLSchemes, are removed with their
+// attributes. If p is nil, all schemes are allowed.
+//
+// NewRewriter returns an error if upstream or prefix are not valid.
+func NewRewriter(p *Policy, upstream, prefix string) (*Rewriter, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("sanitizer: invalid upstream URL: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("sanitizer: upstream URL %q is not an absolute http or https URL", upstream)
+	}
+	pu, err := url.Parse(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("sanitizer: invalid prefix: %v", err)
+	}
+	if pu.Scheme != "" && pu.Scheme != "http" && pu.Scheme != "https" || pu.Scheme == "" && (!strings.HasPrefix(prefix, "/") || strings.HasPrefix(prefix, "//")) {
+		return nil, fmt.Errorf("sanitizer: prefix %q is not an absolute path or http or https URL", prefix)
+	}
+	if pu.RawQuery != "" || pu.Fragment != "" {
+		return nil, fmt.Errorf("sanitizer: prefix %q has a query or fragment", prefix)
+	}
+	if p == nil {
+		p = new(Policy)
+	}
+	// Only rebase the URLs under the directory named by upstream and prefix.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		u.RawPath = ""
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &Rewriter{policy: p, upstream: u, prefix: prefix}, nil
+}
+
+// Rewrite copies the HTML document read from src to w, rewriting its URLs.
+// location is the upstream URL of the document, which relative URLs in it
+// are resolved against, unless the document has a base element. If location
+// is nil, the document is assumed to be at the upstream URL of the Rewriter.
+//
+// Rewrite tokenizes the document rather than parsing it, so it writes the
+// markup it does not rewrite unchanged, and can stream documents of any
+// size. It returns an error if reading from src or writing to w fails.
+func (rw *Rewriter) Rewrite(w io.Writer, src io.Reader, location *url.URL) error {
+	base := rw.upstream
+	if location != nil {
+		base = rw.upstream.ResolveReference(location)
+	}
+	z := html.NewTokenizer(src)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+			return nil
+		}
+		raw := z.Raw()
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+			continue
+		}
+		// Copy the raw bytes, which z overwrites when parsing the attributes
+		// of the tag.
+		raw = append([]byte(nil), raw...)
+		tok := z.Token()
+		if tok.Data == "base" {
+			// The base element changes the URL that the URLs following it
+			// are resolved against.
+			for _, a := range tok.Attr {
+				if a.Key == "href" && a.Namespace == "" {
+					if u, err := base.Parse(strings.TrimSpace(a.Val)); err == nil {
+						base = u
+					}
+					break
+				}
+			}
+		}
+		if !rw.rewriteAttrs(&tok, base) {
+			if _, err := w.Write(raw); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := io.WriteString(w, tok.String()); err != nil {
+			return err
+		}
+	}
+}
+
+// rewriteAttrs rewrites the URL-valued attributes of tok, and reports whether
+// it changed any attributes.
+func (rw *Rewriter) rewriteAttrs(tok *html.Token, base *url.URL) bool {
+	changed := false
+	attrs := tok.Attr[:0]
+	for _, a := range tok.Attr {
+		val, ok := a.Val, true
+		switch {
+		case a.Namespace != "":
+		case rewrittenAttrs[a.Key]:
+			val, ok = rw.rebase(base, a.Val)
+		case a.Key == "srcset":
+			val, ok = rw.rebaseSet(base, a.Val)
+		}
+		if !ok {
+			changed = true
+			continue
+		}
+		if val != a.Val {
+			a.Val = val
+			changed = true
+		}
+		attrs = append(attrs, a)
+	}
+	tok.Attr = attrs
+	return changed
+}
+
+// rebase returns the rewritten value of the URL val, and reports whether p
+// allows it.
+func (rw *Rewriter) rebase(base *url.URL, val string) (string, bool) {
+	val, ok := rw.policy.validateURL(strings.TrimSpace(val))
+	if !ok {
+		return "", false
+	}
+	if val == "" || strings.HasPrefix(val, "#") {
+		// Keep references to the document itself.
+		return val, true
+	}
+	u, err := base.Parse(val)
+	if err != nil {
+		return "", false
+	}
+	path := u.EscapedPath()
+	if u.Scheme != rw.upstream.Scheme || u.Host != rw.upstream.Host || !strings.HasPrefix(path, rw.upstream.EscapedPath()) {
+		return u.String(), true
+	}
+	var b strings.Builder
+	b.WriteString(rw.prefix)
+	b.WriteString(strings.TrimPrefix(path, rw.upstream.EscapedPath()))
+	if u.RawQuery != "" || u.ForceQuery {
+		b.WriteByte('?')
+		b.WriteString(u.RawQuery)
+	}
+	if u.Fragment != "" {
+		b.WriteByte('#')
+		b.WriteString(u.EscapedFragment())
+	}
+	return b.String(), true
+}
+
+// rebaseSet returns the rewritten value of the set of URLs val, such as the
+// value of a srcset attribute, and reports whether p allows all its URLs.
+func (rw *Rewriter) rebaseSet(base *url.URL, val string) (string, bool) {
+	set := safehtml.URLSetSanitized(val).String()
+	if set == "" {
+		return "", false
+	}
+	// Candidates in the output of URLSetSanitized are separated by " , ",
+	// and consist of a URL optionally followed by descriptors.
+	candidates := strings.Split(set, " , ")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		u, ok := rw.rebase(base, fields[0])
+		if !ok {
+			return "", false
+		}
+		fields[0] = u
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, " , "), true
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	rw, err := NewRewriter(nil, "https://backend.internal/app", "/proxy/app/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	location, _ := url.Parse("https://backend.internal/app/docs/index.html")
+	for _, test := range [...]struct {
+		in, want string
+	}{
+		{`<a href="guide.html">Guide</a>`, `<a href="/proxy/app/docs/guide.html">Guide</a>`},
+		{`<a href="/app/x?q=1&amp;r=2#top">x</a>`, `<a href="/proxy/app/x?q=1&amp;r=2#top">x</a>`},
+		{`<a href="https://backend.internal/app/">home</a>`, `<a href="/proxy/app/">home</a>`},
+		{`<a href="//backend.internal/app/a">a</a>`, `<a href="/proxy/app/a">a</a>`},
+		{`<a href="/other/page">other</a>`, `<a href="https://backend.internal/other/page">other</a>`},
+		{`<a href="/apple">apple</a>`, `<a href="https://backend.internal/apple">apple</a>`},
+		{`<a href="https://example.com/">ext</a>`, `<a href="https://example.com/">ext</a>`},
+		{`<a href="#section">s</a>`, `<a href="#section">s</a>`},
+		{`<a href="javascript:alert(1)" class=c>bad</a>`, `<a class="c">bad</a>`},
+		{`<form action="../submit"><button formaction="save">Save</button></form>`, `<form action="/proxy/app/submit"><button formaction="/proxy/app/docs/save">Save</button></form>`},
+		{`<img src="a.png" srcset="a.png 1x, /app/b.png 2x">`, `<img src="/proxy/app/docs/a.png" srcset="/proxy/app/docs/a.png 1x , /proxy/app/b.png 2x">`},
+		{`<base href="/app/other/"><img src="c.png">`, `<base href="/proxy/app/other/"><img src="/proxy/app/other/c.png">`},
+		// Markup without URLs is copied unchanged.
+		{`<!DOCTYPE html><P CLASS=x>a &amp b<!-- c --></P>`, `<!DOCTYPE html><P CLASS=x>a &amp b<!-- c --></P>`},
+		{`<script>var s = '<a href="x">';</script>`, `<script>var s = '<a href="x">';</script>`},
+	} {
+		var b strings.Builder
+		if err := rw.Rewrite(&b, strings.NewReader(test.in), location); err != nil {
+			t.Errorf("Rewrite(%q) failed: %v", test.in, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("Rewrite(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestRewritePolicy(t *testing.T) {
+	rw, err := NewRewriter(new(Policy).AllowURLSchemes("https"), "https://backend.internal/", "https://gateway.example/b/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := rw.Rewrite(&b, strings.NewReader(`<a href="/x">x</a><a href="mailto:a@example.com">m</a>`), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<a href="https://gateway.example/b/x">x</a><a>m</a>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestNewRewriterErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		upstream, prefix string
+	}{
+		{"/app/", "/proxy/"},
+		{"ftp://backend.internal/", "/proxy/"},
+		{"https://backend.internal/", "proxy/"},
+		{"https://backend.internal/", "//evil.example/"},
+		{"https://backend.internal/", "javascript:alert(1)"},
+		{"https://backend.internal/", "/proxy/?a=b"},
+	} {
+		if _, err := NewRewriter(nil, test.upstream, test.prefix); err == nil {
+			t.Errorf("NewRewriter(nil, %q, %q) succeeded, want error", test.upstream, test.prefix)
+		}
+	}
+}
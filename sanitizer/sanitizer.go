@@ -0,0 +1,328 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package sanitizer sanitizes untrusted HTML, such as user comments and CMS
+// content, into safehtml.HTML values according to an allowlist Policy.
+//
+// The markup is parsed as the content of a div element, as browsers parse it,
+// and rendered again from the allowed elements and attributes, escaping all
+// text. Disallowed elements are replaced by their content, except for
+// elements such as script and style whose content is not markup or must not
+// be shown, which are removed with their content. Comments, processing
+// instructions, and SVG and MathML content are always removed.
+//
+// The allowable elements and attributes, and the checks applied to attribute
+// values, are those of the registry used by safehtml/template, so markup
+// kept by a Policy is as safe as the output of a template. Check rejects,
+// rather than removes, the markup that a Policy does not allow, to validate
+// markup from trusted sources.
+//
+// SanitizeSVG sanitizes untrusted SVG images into safehtml.SVG values, which
+// can be embedded inline in HTML.
+//
+// SanitizeNodes and ParseHTML convert between HTML values and the node trees
+// of golang.org/x/net/html, for server-side manipulation of markup.
+//
+// Rewriter rewrites the URLs of proxied HTML documents onto a new prefix.
+//
+// CanonicalizeHTML normalizes the serialization of HTML values, such as
+// sanitized markup, for comparisons and cache keys, and Minify removes the
+// whitespace and comments that browsers ignore.
+package sanitizer
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/registry"
+	"github.com/google/safehtml/uncheckedconversions"
+	"github.com/google/safehtml/urlsafe"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Sanitize returns the markup in s that p allows. Sanitizing sanitized
+// markup again does not change it.
+func (p *Policy) Sanitize(s string) safehtml.HTML {
+	h, err := p.SanitizeReader(strings.NewReader(s))
+	if err != nil {
+		// Reading from a strings.Reader does not fail.
+		panic(err)
+	}
+	return h
+}
+
+// SanitizeReader is like Sanitize, but reads the markup from r. It returns
+// an error if reading from r fails.
+func (p *Policy) SanitizeReader(r io.Reader) (safehtml.HTML, error) {
+	nodes, err := html.ParseFragment(r, &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return safehtml.HTML{}, err
+	}
+	var b strings.Builder
+	for _, n := range nodes {
+		p.render(&b, n, nil)
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(b.String()), nil
+}
+
+// Check is like Sanitize, but returns an error rather than removing the
+// elements and attributes in s that p does not allow, so that markup from a
+// trusted source, such as a column that only the application writes, is
+// either kept as is or rejected. Comments are removed without an error. Check
+// may still normalize the values of the attributes it keeps, for example by
+// percent-encoding URLs, or prefixing identifiers as described in
+// AllowAttributes.
+func (p *Policy) Check(s string) (safehtml.HTML, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return safehtml.HTML{}, err
+	}
+	var (
+		b       strings.Builder
+		removed string
+	)
+	for _, n := range nodes {
+		p.render(&b, n, func(what string) {
+			if removed == "" {
+				removed = what
+			}
+		})
+	}
+	if removed != "" {
+		return safehtml.HTML{}, fmt.Errorf("sanitizer: %s is not allowed", removed)
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(b.String()), nil
+}
+
+// droppedElements contains elements that are removed with their content when
+// they are not allowed, because their content is code, style, or text that
+// is not meant to be shown, or is parsed differently than HTML.
+var droppedElements = map[string]bool{
+	"applet":    true,
+	"embed":     true,
+	"frameset":  true,
+	"head":      true,
+	"iframe":    true,
+	"noembed":   true,
+	"noframes":  true,
+	"noscript":  true,
+	"object":    true,
+	"plaintext": true,
+	"script":    true,
+	"select":    true,
+	"style":     true,
+	"template":  true,
+	"textarea":  true,
+	"title":     true,
+	"xmp":       true,
+}
+
+// render writes the markup of n that p allows to b. If removed is not nil, it
+// is called with a description of each element and attribute that is
+// removed.
+func (p *Policy) render(b *strings.Builder, n *html.Node, removed func(string)) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		p.renderChildren(b, n, removed)
+		return
+	case html.DoctypeNode:
+		if removed != nil {
+			removed("doctype")
+		}
+		return
+	default:
+		// Drop comments.
+		return
+	}
+	if !p.isElementAllowed(n.Data) || n.Namespace != "" {
+		if removed != nil {
+			removed(fmt.Sprintf("element %q", n.Data))
+		}
+		if n.Namespace != "" || droppedElements[n.Data] {
+			return
+		}
+		p.renderChildren(b, n, removed)
+		return
+	}
+	b.WriteByte('<')
+	b.WriteString(n.Data)
+	for _, a := range n.Attr {
+		if a.Namespace != "" || !p.isAttrAllowed(n.Data, a.Key) {
+			if removed != nil {
+				removed(fmt.Sprintf("attribute %q of element %q", a.Key, n.Data))
+			}
+			continue
+		}
+		ctx, _ := (*registry.Registry)(nil).Attribute(n.Data, a.Key, "")
+		validate := attrValidators[ctx]
+		if a.Key == "name" && formControlElements[n.Data] {
+			validate = validateFieldName
+		}
+		val, ok := validate(p, a.Val)
+		if !ok {
+			if removed != nil {
+				removed(fmt.Sprintf("value %q of attribute %q of element %q", a.Val, a.Key, n.Data))
+			}
+			continue
+		}
+		if (ctx == registry.URL || ctx == registry.TrustedResourceURLOrURL) && strings.HasPrefix(val, "#") && p.prefixesReferences() {
+			val = prefixReference(val)
+		}
+		b.WriteByte(' ')
+		b.WriteString(a.Key)
+		b.WriteString(`="`)
+		b.WriteString(html.EscapeString(val))
+		b.WriteByte('"')
+	}
+	b.WriteByte('>')
+	if voidElements[n.Data] {
+		return
+	}
+	if n.Data == "pre" && n.FirstChild != nil && n.FirstChild.Type == html.TextNode && strings.HasPrefix(n.FirstChild.Data, "\n") {
+		// The parser drops a newline immediately following a pre start tag,
+		// so preserve the newline that the content starts with.
+		b.WriteByte('\n')
+	}
+	p.renderChildren(b, n, removed)
+	b.WriteString("</")
+	b.WriteString(n.Data)
+	b.WriteByte('>')
+}
+
+func (p *Policy) renderChildren(b *strings.Builder, n *html.Node, removed func(string)) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.render(b, c, removed)
+	}
+}
+
+// An attrValidator returns the value of an attribute to keep, and reports
+// whether to keep the attribute at all.
+type attrValidator func(p *Policy, val string) (string, bool)
+
+// attrValidators contains the validators of the values of the attributes
+// that a Policy can allow, by the context that safehtml/template sanitizes
+// them in.
+var attrValidators = map[registry.Context]attrValidator{
+	registry.None:                    func(_ *Policy, val string) (string, bool) { return val, true },
+	registry.URL:                     (*Policy).validateURL,
+	registry.TrustedResourceURLOrURL: (*Policy).validateURL,
+	registry.URLSet:                  (*Policy).validateURLSet,
+	registry.Identifier:              validateIdentifier,
+	registry.DirEnum:                 enumValidator("auto", "ltr", "rtl"),
+	registry.LoadingEnum:             enumValidator("eager", "lazy"),
+	registry.TargetEnum:              enumValidator("_blank", "_self"),
+}
+
+// schemePattern matches the scheme of absolute URLs.
+var schemePattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*):`)
+
+func (p *Policy) validateURL(val string) (string, bool) {
+	if !urlsafe.IsSafeURL(val) {
+		return "", false
+	}
+	if m := schemePattern.FindStringSubmatch(val); m != nil {
+		scheme := strings.ToLower(m[1])
+		if p.urlSchemes != nil && !p.urlSchemes[scheme] {
+			return "", false
+		}
+		if scheme == "data" && !isDefaultDataURL(val) {
+			return "", false
+		}
+	}
+	return urlsafe.NormalizeURL(val), true
+}
+
+func isDefaultDataURL(url string) bool {
+	mimeType, ok := urlsafe.DataURLMIMEType(url)
+	if !ok {
+		return false
+	}
+	for _, t := range urlsafe.DefaultDataURLMIMETypes() {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) validateURLSet(val string) (string, bool) {
+	set := safehtml.URLSetSanitized(val).String()
+	// Candidates in the output of URLSetSanitized are separated by " , ",
+	// and consist of a URL optionally followed by descriptors.
+	for _, candidate := range strings.Split(set, " , ") {
+		if fields := strings.Fields(candidate); len(fields) > 0 {
+			if _, ok := p.validateURL(fields[0]); !ok {
+				return "", false
+			}
+		}
+	}
+	return set, set != ""
+}
+
+// userContentPrefix is prepended to the identifiers in untrusted markup, so
+// that they cannot clobber the elements and global variables of the page.
+const userContentPrefix = "user-content-"
+
+// identifierPattern matches the identifiers kept in untrusted markup, which
+// include those that Markdown renderers write for footnotes, such as "fn:1".
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z0-9_.:-]+$`)
+
+func validateIdentifier(p *Policy, val string) (string, bool) {
+	if !identifierPattern.MatchString(val) {
+		return "", false
+	}
+	if p != nil && p.keepIdentifiers || strings.HasPrefix(val, userContentPrefix) {
+		// Keep sanitized identifiers unchanged.
+		return val, true
+	}
+	return userContentPrefix + val, true
+}
+
+// formControlElements contains the elements whose name attributes are the
+// names of submitted form data, which validateFieldName keeps unprefixed.
+var formControlElements = map[string]bool{
+	"button":   true,
+	"fieldset": true,
+	"input":    true,
+	"output":   true,
+	"select":   true,
+	"textarea": true,
+}
+
+func validateFieldName(_ *Policy, val string) (string, bool) {
+	return val, identifierPattern.MatchString(val)
+}
+
+// prefixReference returns ref, a reference to an element of the same markup
+// such as "#intro", with the identifier prefixed as in validateIdentifier.
+// References that are not to identifiers, such as "#", are returned
+// unchanged.
+func prefixReference(ref string) string {
+	if id, ok := validateIdentifier(nil, ref[1:]); ok {
+		return "#" + id
+	}
+	return ref
+}
+
+func enumValidator(values ...string) attrValidator {
+	return func(_ *Policy, val string) (string, bool) {
+		val = strings.ToLower(val)
+		for _, v := range values {
+			if v == val {
+				return val, true
+			}
+		}
+		return "", false
+	}
+}
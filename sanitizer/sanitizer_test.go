@@ -0,0 +1,104 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"errors"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSanitize(t *testing.T) {
+	p := UGCPolicy().
+		AllowElements("section", "input").
+		AllowAttributes("section", "id", "data-kind", "dir").
+		AllowAttributes("input", "name").
+		AllowAttributes("img", "srcset", "loading")
+	for _, test := range [...]struct {
+		desc, in, want string
+	}{
+		{"text", `a < b & "c"`, `a &lt; b &amp; &#34;c&#34;`},
+		{"formatting", `<p>Hello <b>world</b><br>!</p>`, `<p>Hello <b>world</b><br>!</p>`},
+		{"unclosed elements", `<p><i>a<p>b`, `<p><i>a</i></p><p><i>b</i></p>`},
+		{"uppercase", `<B TITLE="x">a</B>`, `<b title="x">a</b>`},
+		{"disallowed element keeps content", `<font color="red">a</font>`, `a`},
+		{"script", `<script>alert(1)</script>a`, `a`},
+		{"style", `<style>*{color:red}</style>a`, `a`},
+		{"iframe", `<iframe src="https://evil.example.com">a</iframe>b`, `b`},
+		{"svg", `<svg><script>alert(1)</script><text>a</text></svg>b`, `b`},
+		{"math", `<math><mi xlink:href="javascript:alert(1)">a</mi></math>b`, `b`},
+		{"comment", `a<!-- <script>alert(1)</script> -->b`, `ab`},
+		{"event handler", `<b onclick="alert(1)">a</b>`, `<b>a</b>`},
+		{"disallowed attribute", `<b class="x" style="color:red">a</b>`, `<b>a</b>`},
+		{"link", `<a href="https://example.com/a b?c=d&e" rel="x">a</a>`, `<a href="https://example.com/a%20b?c=d&amp;e">a</a>`},
+		{"javascript URL", `<a href="javascript:alert(1)">a</a>`, `<a>a</a>`},
+		{"encoded javascript URL", `<a href="jav&#x09;ascript:alert(1)">a</a>`, `<a>a</a>`},
+		{"disallowed scheme", `<a href="ftp://example.com">a</a>`, `<a>a</a>`},
+		{"relative URL", `<a href="/a?b#c">a</a>`, `<a href="/a?b#c">a</a>`},
+		{"mailto", `<a href="MAILTO:a@example.com">a</a>`, `<a href="MAILTO:a@example.com">a</a>`},
+		{"image", `<img src="https://example.com/a.png" alt="a" onerror="alert(1)">`, `<img src="https://example.com/a.png" alt="a">`},
+		{"srcset", `<img srcset="https://example.com/a.png 2x, /b.png 1x">`, `<img srcset="https://example.com/a.png 2x , /b.png 1x">`},
+		{"srcset with disallowed scheme", `<img srcset="https://example.com/a.png 2x, ftp://example.com/b.png 1x">`, `<img>`},
+		{"enum", `<img loading="LAZY"><section dir="sideways">a</section>`, `<img loading="lazy"><section>a</section>`},
+		{"identifier", `<section id="intro">a</section>`, `<section id="user-content-intro">a</section>`},
+		{"reference", `<a href="#intro">a</a><a href="#">b</a><a href="/p#intro">c</a>`, `<a href="#user-content-intro">a</a><a href="#">b</a><a href="/p#intro">c</a>`},
+		{"form control name", `<input name="q"><section name="s">a</section>`, `<input name="q"><section>a</section>`},
+		{"invalid identifier", `<section id="a b">a</section>`, `<section>a</section>`},
+		{"data attribute", `<section data-kind="note">a</section>`, `<section data-kind="note">a</section>`},
+		{"pre newline", "<pre>\n\nx</pre>", "<pre>\n\nx</pre>"},
+		{"document", `<html><head><title>t</title></head><body><p>a</p></body></html>`, `<p>a</p>`},
+		{"attribute breakout", `<b title='"><script>alert(1)</script>'>a</b>`, `<b title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;">a</b>`},
+		{"textarea", `<textarea></textarea><script>alert(1)</script></textarea>a`, `a`},
+	} {
+		got := p.Sanitize(test.in).String()
+		if got != test.want {
+			t.Errorf("%s: Sanitize(%q):\ngot  %q\nwant %q", test.desc, test.in, got, test.want)
+			continue
+		}
+		if again := p.Sanitize(got).String(); again != got {
+			t.Errorf("%s: Sanitize is not idempotent:\ngot  %q\nthen %q", test.desc, got, again)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := UGCPolicy().AllowAttributes("a", "id")
+	for _, test := range [...]struct {
+		desc, in, want, err string
+	}{
+		{"allowed", `<p><a id="a" href="/a b">a</a><!-- c --></p>`, `<p><a id="user-content-a" href="/a%20b">a</a></p>`, ""},
+		{"element", `<p>a<font>b</font></p>`, "", `sanitizer: element "font" is not allowed`},
+		{"attribute", `<p class="x">a</p>`, "", `sanitizer: attribute "class" of element "p" is not allowed`},
+		{"value", `<a href="javascript:x()">a</a>`, "", `sanitizer: value "javascript:x()" of attribute "href" of element "a" is not allowed`},
+		{"svg", `<svg><circle r="1"></circle></svg>`, "", `sanitizer: element "svg" is not allowed`},
+	} {
+		got, err := p.Check(test.in)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%s: Check(%q) error = %v, want %q", test.desc, test.in, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.String() != test.want {
+			t.Errorf("%s: Check(%q) = %q, %v, want %q", test.desc, test.in, got, err, test.want)
+		}
+	}
+}
+
+func TestSanitizeZeroPolicy(t *testing.T) {
+	var p Policy
+	if got, want := p.Sanitize(`<p>a <b>b</b></p><script>c</script>`).String(), `a b`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSanitizeReaderError(t *testing.T) {
+	errRead := errors.New("read failed")
+	if _, err := UGCPolicy().SanitizeReader(iotest.ErrReader(errRead)); !errors.Is(err, errRead) {
+		t.Errorf("got error %v, want %v", err, errRead)
+	}
+}
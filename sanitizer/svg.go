@@ -0,0 +1,230 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SanitizeSVG returns the svg element in the untrusted markup s, such as an
+// uploaded icon or a chart rendered by another service, as a safehtml.SVG
+// that can be embedded inline in HTML documents.
+//
+// Only drawing, text, gradient, clipping, masking, marker, and filter
+// elements are kept. Scripts, style elements, foreignObject elements,
+// animations, and elements outside the SVG namespace are removed with their
+// content, and other elements, such as links, are replaced by their content.
+// Only presentation and geometry attributes are kept, so event handlers and
+// style attributes are removed. References to other resources are limited
+// to references to elements of the same image, such as href="#icon" and
+// fill="url(#gradient)", except for the safe URLs of image elements.
+//
+// As in Sanitize, id attributes, and the references to them, are prefixed
+// with "user-content-", so that the image cannot clobber the elements and
+// global variables of the page.
+//
+// SanitizeSVG returns an error if s is not a single svg element, optionally
+// surrounded by whitespace and comments.
+func SanitizeSVG(s string) (safehtml.SVG, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+	if err != nil {
+		return safehtml.SVG{}, err
+	}
+	var root *html.Node
+	for _, n := range nodes {
+		switch {
+		case n.Type == html.CommentNode:
+		case n.Type == html.TextNode && strings.TrimSpace(n.Data) == "":
+		case root == nil && n.Type == html.ElementNode && n.Namespace == "svg" && n.Data == "svg":
+			root = n
+		default:
+			return safehtml.SVG{}, errors.New("sanitizer: markup is not a single svg element")
+		}
+	}
+	if root == nil {
+		return safehtml.SVG{}, errors.New("sanitizer: markup has no svg element")
+	}
+	var b strings.Builder
+	renderSVG(&b, root)
+	return uncheckedconversions.SVGFromStringKnownToSatisfyTypeContract(b.String()), nil
+}
+
+// svgElements contains the SVG elements that SanitizeSVG keeps.
+var svgElements = map[string]bool{
+	"circle":         true,
+	"clipPath":       true,
+	"defs":           true,
+	"desc":           true,
+	"ellipse":        true,
+	"feBlend":        true,
+	"feColorMatrix":  true,
+	"feComposite":    true,
+	"feDropShadow":   true,
+	"feFlood":        true,
+	"feGaussianBlur": true,
+	"feMerge":        true,
+	"feMergeNode":    true,
+	"feOffset":       true,
+	"filter":         true,
+	"g":              true,
+	"image":          true,
+	"line":           true,
+	"linearGradient": true,
+	"marker":         true,
+	"mask":           true,
+	"path":           true,
+	"pattern":        true,
+	"polygon":        true,
+	"polyline":       true,
+	"radialGradient": true,
+	"rect":           true,
+	"stop":           true,
+	"svg":            true,
+	"symbol":         true,
+	"text":           true,
+	"textPath":       true,
+	"title":          true,
+	"tspan":          true,
+	"use":            true,
+}
+
+// droppedSVGElements contains the SVG elements that SanitizeSVG removes with
+// their content, because their content is code, style, or markup in other
+// namespaces, or because they can change the attributes of other elements.
+var droppedSVGElements = map[string]bool{
+	"animate":          true,
+	"animateMotion":    true,
+	"animateTransform": true,
+	"discard":          true,
+	"feImage":          true,
+	"foreignObject":    true,
+	"script":           true,
+	"set":              true,
+	"style":            true,
+}
+
+// svgAttrs contains the attributes that SanitizeSVG keeps, other than id and
+// href.
+var svgAttrs = map[string]bool{}
+
+func init() {
+	for _, attr := range strings.Fields(`
+		alignment-baseline aria-hidden aria-label clip-path clip-rule
+		clipPathUnits class color cx cy d display dominant-baseline dx dy
+		fill fill-opacity fill-rule filter flood-color flood-opacity
+		focusable font-family font-size font-style font-weight fx fy
+		gradientTransform gradientUnits height in in2 k1 k2 k3 k4
+		lengthAdjust letter-spacing marker-end marker-mid marker-start
+		markerHeight markerUnits markerWidth mask maskContentUnits
+		maskUnits mode offset opacity operator orient pathLength
+		patternContentUnits patternTransform patternUnits points
+		preserveAspectRatio r refX refY result role rotate rx ry
+		shape-rendering spreadMethod stdDeviation stop-color stop-opacity
+		stroke stroke-dasharray stroke-dashoffset stroke-linecap
+		stroke-linejoin stroke-miterlimit stroke-opacity stroke-width
+		text-anchor text-decoration textLength transform type values
+		vector-effect version viewBox visibility width word-spacing x x1
+		x2 y y1 y2`) {
+		svgAttrs[attr] = true
+	}
+}
+
+// svgURLPattern matches the url() functions in the values of SVG
+// attributes, and captures their arguments.
+var svgURLPattern = regexp.MustCompile(`(?i)url\(\s*([^)]*?)\s*\)`)
+
+func renderSVG(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		// Drop comments.
+		return
+	}
+	if n.Namespace != "svg" || droppedSVGElements[n.Data] {
+		return
+	}
+	if !svgElements[n.Data] {
+		renderSVGChildren(b, n)
+		return
+	}
+	b.WriteByte('<')
+	b.WriteString(n.Data)
+	for _, a := range n.Attr {
+		val, ok := svgAttr(n.Data, a)
+		if !ok {
+			continue
+		}
+		b.WriteByte(' ')
+		b.WriteString(a.Key)
+		b.WriteString(`="`)
+		b.WriteString(html.EscapeString(val))
+		b.WriteByte('"')
+	}
+	b.WriteByte('>')
+	renderSVGChildren(b, n)
+	b.WriteString("</")
+	b.WriteString(n.Data)
+	b.WriteByte('>')
+}
+
+func renderSVGChildren(b *strings.Builder, n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderSVG(b, c)
+	}
+}
+
+// svgAttr returns the value of the attribute a of element to keep, and
+// reports whether to keep the attribute at all. xlink:href attributes are
+// kept as href attributes, which browsers support in inline SVG.
+func svgAttr(element string, a html.Attribute) (string, bool) {
+	switch {
+	case a.Key == "href" && (a.Namespace == "" || a.Namespace == "xlink"):
+		if element == "image" && !strings.HasPrefix(a.Val, "#") {
+			return new(Policy).validateURL(a.Val)
+		}
+		return svgReference(a.Val)
+	case a.Namespace != "":
+		return "", false
+	case a.Key == "id":
+		return validateIdentifier(nil, a.Val)
+	case !svgAttrs[a.Key]:
+		return "", false
+	}
+	ok := true
+	val := svgURLPattern.ReplaceAllStringFunc(a.Val, func(m string) string {
+		ref, valid := svgReference(strings.Trim(svgURLPattern.FindStringSubmatch(m)[1], `"'`))
+		ok = ok && valid
+		return "url(" + ref + ")"
+	})
+	if !ok || strings.ContainsAny(val, `\`) || strings.Contains(strings.ToLower(svgURLPattern.ReplaceAllString(val, "")), "url(") {
+		// Drop values with references to other resources, and values that
+		// could hide them from svgURLPattern, such as CSS escapes.
+		return "", false
+	}
+	return val, true
+}
+
+// svgReference returns the reference to an element of the same image ref,
+// with the identifier prefixed as in validateIdentifier, and reports whether
+// ref is such a reference.
+func svgReference(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	id, ok := validateIdentifier(nil, ref[1:])
+	return "#" + id, ok
+}
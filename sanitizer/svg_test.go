@@ -0,0 +1,97 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sanitizer
+
+import "testing"
+
+func TestSanitizeSVG(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, in, want string
+	}{
+		{
+			"icon",
+			`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" width="24"><path d="M0 0h24v24H0z" fill="none"/><circle cx="12" cy="12" r="5"/></svg>`,
+			`<svg viewBox="0 0 24 24" width="24"><path d="M0 0h24v24H0z" fill="none"></path><circle cx="12" cy="12" r="5"></circle></svg>`,
+		},
+		{
+			"surrounding whitespace and comments",
+			"\n<!-- icon -->\n<svg><rect width=\"1\"/></svg>\n",
+			`<svg><rect width="1"></rect></svg>`,
+		},
+		{
+			"scripts",
+			`<svg onload="alert(1)"><script>alert(2)</script><g onclick="alert(3)"><rect/></g></svg>`,
+			`<svg><g><rect></rect></g></svg>`,
+		},
+		{
+			"foreignObject",
+			`<svg><foreignObject><iframe src="https://evil.example"></iframe></foreignObject><text>a &lt; b</text></svg>`,
+			`<svg><text>a &lt; b</text></svg>`,
+		},
+		{
+			"animation",
+			`<svg><a href="#x"><set attributeName="href" to="javascript:alert(1)"/><text>x</text></a></svg>`,
+			`<svg><text>x</text></svg>`,
+		},
+		{
+			"style",
+			`<svg><style>rect { fill: url(https://evil.example/) }</style><rect style="fill: red"/></svg>`,
+			`<svg><rect></rect></svg>`,
+		},
+		{
+			"javascript href",
+			`<svg xmlns:xlink="http://www.w3.org/1999/xlink"><use xlink:href="javascript:alert(1)"/><use href="https://evil.example/a.svg#x"/></svg>`,
+			`<svg><use></use><use></use></svg>`,
+		},
+		{
+			"internal references",
+			`<svg><defs><linearGradient id="g"><stop offset="0" stop-color="red"/></linearGradient><symbol id="s"/></defs>` +
+				`<rect fill="url(#g)" clip-path="url( '#g' )"/><use xlink:href="#s"/></svg>`,
+			`<svg><defs><linearGradient id="user-content-g"><stop offset="0" stop-color="red"></stop></linearGradient><symbol id="user-content-s"></symbol></defs>` +
+				`<rect fill="url(#user-content-g)" clip-path="url(#user-content-g)"></rect><use href="#user-content-s"></use></svg>`,
+		},
+		{
+			"external references",
+			`<svg><rect fill="url(https://evil.example/x)" stroke="u\rl(x)" filter="URL(data:x)"/></svg>`,
+			`<svg><rect></rect></svg>`,
+		},
+		{
+			"images",
+			`<svg><image href="https://example.com/a.png"/><image href="javascript:alert(1)"/></svg>`,
+			`<svg><image href="https://example.com/a.png"></image><image></image></svg>`,
+		},
+		{
+			"HTML in SVG",
+			`<svg><desc><b>bold</b> text</desc></svg>`,
+			`<svg><desc> text</desc></svg>`,
+		},
+	} {
+		got, err := SanitizeSVG(test.in)
+		if err != nil {
+			t.Errorf("%s: SanitizeSVG(%q) failed: %v", test.desc, test.in, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: SanitizeSVG(%q):\ngot  %q\nwant %q", test.desc, test.in, got, test.want)
+		}
+	}
+}
+
+func TestSanitizeSVGErrors(t *testing.T) {
+	for _, in := range []string{
+		``,
+		`text`,
+		`<p>a</p>`,
+		`<svg></svg><svg></svg>`,
+		`<svg></svg>trailing`,
+		`<math></math>`,
+	} {
+		if _, err := SanitizeSVG(in); err == nil {
+			t.Errorf("SanitizeSVG(%q) succeeded, want error", in)
+		}
+	}
+}
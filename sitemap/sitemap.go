@@ -0,0 +1,173 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package sitemap writes sitemaps and sitemap indexes, as described at
+// https://www.sitemaps.org/protocol.html, from safehtml.URL values.
+//
+// Sitemaps are written with encoding/xml, which escapes the URLs and other
+// values of their entries. URLs are normalized as safehtml/template normalizes
+// the URLs of links, so the URLs that crawlers read are the same safe URLs
+// that the application's pages link to:
+//
+//	err := sitemap.Write(w, []sitemap.Entry{{
+//		Loc:        safehtml.URLSanitized("https://example.com/"),
+//		LastMod:    updated,
+//		ChangeFreq: sitemap.Daily,
+//	}})
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/urlsafe"
+)
+
+// MaxEntries is the maximum number of entries of a sitemap or sitemap index.
+const MaxEntries = 50000
+
+// A ChangeFreq is how frequently the page at a URL is likely to change.
+type ChangeFreq string
+
+// The values of ChangeFreq.
+const (
+	Always  ChangeFreq = "always"
+	Hourly  ChangeFreq = "hourly"
+	Daily   ChangeFreq = "daily"
+	Weekly  ChangeFreq = "weekly"
+	Monthly ChangeFreq = "monthly"
+	Yearly  ChangeFreq = "yearly"
+	Never   ChangeFreq = "never"
+)
+
+// An Entry is the URL of a page in a sitemap.
+type Entry struct {
+	// Loc is the absolute http or https URL of the page.
+	Loc safehtml.URL
+	// LastMod is the optional time the page last changed.
+	LastMod time.Time
+	// ChangeFreq is the optional frequency of changes of the page.
+	ChangeFreq ChangeFreq
+	// Priority is the optional priority of the page relative to the other
+	// pages of the site, between 0 and 1. It is omitted if it is 0, and
+	// crawlers then assume 0.5.
+	Priority float64
+}
+
+// A Sitemap is the URL of a sitemap in a sitemap index.
+type Sitemap struct {
+	// Loc is the absolute http or https URL of the sitemap.
+	Loc safehtml.URL
+	// LastMod is the optional time the sitemap last changed.
+	LastMod time.Time
+}
+
+type urlset struct {
+	XMLName xml.Name   `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Write writes a sitemap of entries to w. It returns an error if there are
+// more than MaxEntries entries, if an entry is not valid, or if writing to w
+// fails.
+func Write(w io.Writer, entries []Entry) error {
+	if len(entries) > MaxEntries {
+		return fmt.Errorf("sitemap: %d entries exceed the maximum of %d", len(entries), MaxEntries)
+	}
+	doc := urlset{URLs: make([]urlEntry, 0, len(entries))}
+	for i, e := range entries {
+		if err := checkLoc(e.Loc); err != nil {
+			return fmt.Errorf("sitemap: entry %d: %v", i, err)
+		}
+		switch e.ChangeFreq {
+		case "", Always, Hourly, Daily, Weekly, Monthly, Yearly, Never:
+		default:
+			return fmt.Errorf("sitemap: entry %d: invalid change frequency %q", i, e.ChangeFreq)
+		}
+		if e.Priority < 0 || e.Priority > 1 {
+			return fmt.Errorf("sitemap: entry %d: priority %v is not between 0 and 1", i, e.Priority)
+		}
+		u := urlEntry{
+			Loc:        urlsafe.NormalizeURL(e.Loc.String()),
+			LastMod:    lastMod(e.LastMod),
+			ChangeFreq: string(e.ChangeFreq),
+		}
+		if e.Priority != 0 {
+			u.Priority = strconv.FormatFloat(e.Priority, 'f', -1, 64)
+		}
+		doc.URLs = append(doc.URLs, u)
+	}
+	return writeXML(w, doc)
+}
+
+// WriteIndex writes a sitemap index of sitemaps to w. It returns an error if
+// there are more than MaxEntries sitemaps, if the URL of a sitemap is not an
+// absolute http or https URL, or if writing to w fails.
+func WriteIndex(w io.Writer, sitemaps []Sitemap) error {
+	if len(sitemaps) > MaxEntries {
+		return fmt.Errorf("sitemap: %d sitemaps exceed the maximum of %d", len(sitemaps), MaxEntries)
+	}
+	doc := sitemapIndex{Sitemaps: make([]sitemapEntry, 0, len(sitemaps))}
+	for i, s := range sitemaps {
+		if err := checkLoc(s.Loc); err != nil {
+			return fmt.Errorf("sitemap: sitemap %d: %v", i, err)
+		}
+		doc.Sitemaps = append(doc.Sitemaps, sitemapEntry{Loc: urlsafe.NormalizeURL(s.Loc.String()), LastMod: lastMod(s.LastMod)})
+	}
+	return writeXML(w, doc)
+}
+
+// checkLoc returns an error if loc is not an absolute http or https URL, which
+// sitemaps require.
+func checkLoc(loc safehtml.URL) error {
+	u, err := url.Parse(loc.String())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute http or https URL", loc)
+	}
+	return nil
+}
+
+// lastMod formats t as a W3C datetime, or returns "" if t is zero.
+func lastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// writeXML writes v to w as an XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if err := xml.NewEncoder(w).Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sitemap
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/safehtml"
+)
+
+var testTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestWrite(t *testing.T) {
+	var b strings.Builder
+	err := Write(&b, []Entry{
+		{Loc: safehtml.URLSanitized("https://example.com/")},
+		{
+			Loc:        safehtml.URLSanitized("https://example.com/search?q=a b&lang=en"),
+			LastMod:    testTime,
+			ChangeFreq: Daily,
+			Priority:   0.85,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+		`<url><loc>https://example.com/</loc></url>` +
+		`<url><loc>https://example.com/search?q=a%20b&amp;lang=en</loc><lastmod>2026-01-02T03:04:05Z</lastmod>` +
+		`<changefreq>daily</changefreq><priority>0.85</priority></url>` +
+		"</urlset>\n"
+	if got := b.String(); got != want {
+		t.Errorf("Write:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestWriteIndex(t *testing.T) {
+	var b strings.Builder
+	err := WriteIndex(&b, []Sitemap{
+		{Loc: safehtml.URLSanitized("https://example.com/sitemap-1.xml"), LastMod: testTime},
+		{Loc: safehtml.URLSanitized("https://example.com/sitemap-2.xml")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+		`<sitemap><loc>https://example.com/sitemap-1.xml</loc><lastmod>2026-01-02T03:04:05Z</lastmod></sitemap>` +
+		`<sitemap><loc>https://example.com/sitemap-2.xml</loc></sitemap>` +
+		"</sitemapindex>\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteIndex:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc  string
+		entry Entry
+	}{
+		{"relative URL", Entry{Loc: safehtml.URLSanitized("/page")}},
+		{"mailto URL", Entry{Loc: safehtml.URLSanitized("mailto:a@example.com")}},
+		{"unsafe URL", Entry{Loc: safehtml.URLSanitized("javascript:alert(1)")}},
+		{"invalid change frequency", Entry{Loc: safehtml.URLSanitized("https://example.com/"), ChangeFreq: "often"}},
+		{"negative priority", Entry{Loc: safehtml.URLSanitized("https://example.com/"), Priority: -0.1}},
+		{"priority above 1", Entry{Loc: safehtml.URLSanitized("https://example.com/"), Priority: 1.5}},
+	} {
+		if err := Write(new(strings.Builder), []Entry{test.entry}); err == nil {
+			t.Errorf("%s: Write succeeded, want error", test.desc)
+		}
+	}
+	if err := Write(new(strings.Builder), make([]Entry, MaxEntries+1)); err == nil {
+		t.Errorf("Write of %d entries succeeded, want error", MaxEntries+1)
+	}
+	if err := WriteIndex(new(strings.Builder), []Sitemap{{Loc: safehtml.URLSanitized("sitemap.xml")}}); err == nil {
+		t.Errorf("WriteIndex of relative URL succeeded, want error")
+	}
+}
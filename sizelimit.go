@@ -0,0 +1,81 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/safehtml/registry"
+)
+
+// A SizeLimitError is returned by HTMLConcatWithLimit, and by the builders
+// given the MaxBytes option, when the HTML they build would exceed the limit.
+type SizeLimitError struct {
+	// MaxBytes is the limit that the HTML would exceed.
+	MaxBytes int
+}
+
+func (e *SizeLimitError) Error() string {
+	return fmt.Sprintf("HTML exceeds the limit of %d bytes", e.MaxBytes)
+}
+
+// A BuildOption configures a builder of this package, such as
+// HTMLElementWithOptions or NewHTMLBuilder.
+type BuildOption func(*buildConfig)
+
+type buildConfig struct {
+	maxBytes int
+	// registry, if not nil, contains the element and attribute contracts
+	// that elements are checked against, in place of the stock contracts.
+	registry *registry.Registry
+}
+
+// MaxBytes limits the HTML returned by a builder to n bytes, so that a
+// service composing a number of fragments chosen by its users, such as the
+// rows of a table, fails with a *SizeLimitError rather than building HTML of
+// any size. The builder checks the size of the fragments before copying
+// them. A limit of 0 or less means no limit, which is the default.
+func MaxBytes(n int) BuildOption {
+	return func(c *buildConfig) {
+		c.maxBytes = n
+	}
+}
+
+// check returns a *SizeLimitError if n bytes exceed the limit of c.
+func (c *buildConfig) check(n int) error {
+	if c.maxBytes > 0 && n > c.maxBytes {
+		return &SizeLimitError{c.maxBytes}
+	}
+	return nil
+}
+
+// HTMLConcatWithLimit is like HTMLConcat, but returns a *SizeLimitError,
+// without concatenating htmls, if the result would be longer than maxBytes
+// bytes.
+func HTMLConcatWithLimit(maxBytes int, htmls ...HTML) (HTML, error) {
+	c := buildConfig{maxBytes: maxBytes}
+	n := htmlLen(htmls)
+	if err := c.check(n); err != nil {
+		return HTML{}, err
+	}
+	var b strings.Builder
+	b.Grow(n)
+	for _, h := range htmls {
+		b.WriteString(h.str)
+	}
+	return HTML{b.String()}, nil
+}
+
+// htmlLen returns the total length of htmls.
+func htmlLen(htmls []HTML) int {
+	n := 0
+	for _, h := range htmls {
+		n += len(h.str)
+	}
+	return n
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHTMLConcatWithLimit(t *testing.T) {
+	htmls := []HTML{{"<b>"}, {"Hello"}, {"</b>"}}
+	for _, test := range [...]struct {
+		maxBytes int
+		want     string
+		err      bool
+	}{
+		{0, "<b>Hello</b>", false},
+		{12, "<b>Hello</b>", false},
+		{11, "", true},
+		{-1, "<b>Hello</b>", false},
+	} {
+		got, err := HTMLConcatWithLimit(test.maxBytes, htmls...)
+		if test.err {
+			var e *SizeLimitError
+			if !errors.As(err, &e) || e.MaxBytes != test.maxBytes {
+				t.Errorf("HTMLConcatWithLimit(%d) error = %v, want *SizeLimitError", test.maxBytes, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("HTMLConcatWithLimit(%d) failed: %v", test.maxBytes, err)
+		} else if got.String() != test.want {
+			t.Errorf("HTMLConcatWithLimit(%d) = %q, want %q", test.maxBytes, got, test.want)
+		}
+	}
+}
+
+func TestHTMLElementWithOptions(t *testing.T) {
+	rows := []HTML{{"<tr><td>1</td></tr>"}, {"<tr><td>2</td></tr>"}}
+	got, err := HTMLElementWithOptions("tbody", nil, rows, MaxBytes(53))
+	if want := "<tbody><tr><td>1</td></tr><tr><td>2</td></tr></tbody>"; err != nil || got.String() != want {
+		t.Errorf("HTMLElementWithOptions = %q, %v, want %q", got, err, want)
+	}
+	for _, test := range [...]struct {
+		desc     string
+		name     string
+		attrs    []Attr
+		children []HTML
+		maxBytes int
+	}{
+		{"children", "tbody", nil, rows, 37},
+		{"tags", "tbody", nil, rows, 52},
+		{"void element", "img", []Attr{{"alt", "a long description"}}, nil, 20},
+	} {
+		_, err := HTMLElementWithOptions(test.name, test.attrs, test.children, MaxBytes(test.maxBytes))
+		if want := (&SizeLimitError{test.maxBytes}).Error(); err == nil || err.Error() != want {
+			t.Errorf("%s: got error %v, want %q", test.desc, err, want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package sqltypes provides wrappers of the safe types of package safehtml
+// that can be stored in and loaded from databases with database/sql.
+//
+// Each wrapper implements driver.Valuer, storing the string form of its
+// value, and sql.Scanner. Since the contents of a database are not under
+// application control, values are validated again when they are scanned,
+// where possible:
+//   - HTML values are checked with sanitizer.PermissivePolicy, as described
+//     in sanitizer.Policy.Check. Values with markup that the policy would
+//     remove, such as style attributes and SVG content, cannot be scanned,
+//     so they are never changed without notice.
+//   - URL values must be safe URLs, as described in safehtml.URLSanitized.
+//   - Identifier values must be valid identifiers, as described in
+//     safehtml.IdentifierFromConstant.
+//
+// The contracts of the other types cannot be checked, so their values are
+// converted without validation, and each conversion is reported to the
+// Logger set with safehtml.SetLogger so that it can be audited, and the
+// audit package reports their uses as unchecked conversions. Only use those
+// wrappers for columns that only the application itself writes.
+//
+// A NULL value is scanned as the zero value of the safe type.
+package sqltypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/sanitizer"
+	"github.com/google/safehtml/uncheckedconversions"
+	"github.com/google/safehtml/urlsafe"
+)
+
+// scanString returns the string form of src, a value scanned from a
+// database into a value of type typ.
+func scanString(typ string, src interface{}) (string, error) {
+	switch src := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return src, nil
+	case []byte:
+		return string(src), nil
+	}
+	return "", fmt.Errorf("sqltypes: cannot scan %T into %s", src, typ)
+}
+
+// logConversion reports an unchecked conversion to the Logger set by
+// safehtml.SetLogger.
+func logConversion(typ string) {
+	diag.Log("safehtml/sqltypes: unchecked conversion", "type", typ)
+}
+
+// permissive checks scanned HTML values.
+var permissive = sanitizer.PermissivePolicy()
+
+// identifierPattern matches valid Identifier values.
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z][-_a-zA-Z0-9]*$`)
+
+// HTML is a safehtml.HTML that can be stored in a database.
+type HTML struct {
+	safehtml.HTML
+}
+
+// Value returns the string form of h.
+func (h HTML) Value() (driver.Value, error) {
+	return h.HTML.String(), nil
+}
+
+// Scan sets h to the value src, after checking that it only contains markup
+// that sanitizer.PermissivePolicy allows.
+func (h *HTML) Scan(src interface{}) error {
+	s, err := scanString("HTML", src)
+	if err != nil {
+		return err
+	}
+	checked, err := permissive.Check(s)
+	if err != nil {
+		return fmt.Errorf("sqltypes: cannot scan HTML: %v", err)
+	}
+	h.HTML = checked
+	return nil
+}
+
+// URL is a safehtml.URL that can be stored in a database.
+type URL struct {
+	safehtml.URL
+}
+
+// Value returns the string form of u.
+func (u URL) Value() (driver.Value, error) {
+	return u.URL.String(), nil
+}
+
+// Scan sets u to the value src, after checking that it is a safe URL.
+func (u *URL) Scan(src interface{}) error {
+	s, err := scanString("URL", src)
+	if err != nil {
+		return err
+	}
+	if !urlsafe.IsSafeURL(s) {
+		return fmt.Errorf("sqltypes: %q is not a safe URL", s)
+	}
+	u.URL = uncheckedconversions.URLFromStringKnownToSatisfyTypeContract(s)
+	return nil
+}
+
+// Identifier is a safehtml.Identifier that can be stored in a database.
+type Identifier struct {
+	safehtml.Identifier
+}
+
+// Value returns the string form of i.
+func (i Identifier) Value() (driver.Value, error) {
+	return i.Identifier.String(), nil
+}
+
+// Scan sets i to the value src, after checking that it is a valid identifier.
+func (i *Identifier) Scan(src interface{}) error {
+	s, err := scanString("Identifier", src)
+	if err != nil {
+		return err
+	}
+	if s != "" && !identifierPattern.MatchString(s) {
+		return fmt.Errorf("sqltypes: %q is not a valid identifier", s)
+	}
+	i.Identifier = uncheckedconversions.IdentifierFromStringKnownToSatisfyTypeContract(s)
+	return nil
+}
+
+// TrustedResourceURL is a safehtml.TrustedResourceURL that can be stored in a database.
+type TrustedResourceURL struct {
+	safehtml.TrustedResourceURL
+}
+
+// Value returns the string form of t.
+func (t TrustedResourceURL) Value() (driver.Value, error) {
+	return t.TrustedResourceURL.String(), nil
+}
+
+// Scan sets t to the value src without checking its type contract, and
+// reports the conversion.
+func (t *TrustedResourceURL) Scan(src interface{}) error {
+	s, err := scanString("TrustedResourceURL", src)
+	if err != nil {
+		return err
+	}
+	logConversion("TrustedResourceURL")
+	t.TrustedResourceURL = uncheckedconversions.TrustedResourceURLFromStringKnownToSatisfyTypeContract(s)
+	return nil
+}
+
+// Script is a safehtml.Script that can be stored in a database.
+type Script struct {
+	safehtml.Script
+}
+
+// Value returns the string form of s.
+func (s Script) Value() (driver.Value, error) {
+	return s.Script.String(), nil
+}
+
+// Scan sets s to the value src without checking its type contract, and
+// reports the conversion.
+func (s *Script) Scan(src interface{}) error {
+	str, err := scanString("Script", src)
+	if err != nil {
+		return err
+	}
+	logConversion("Script")
+	s.Script = uncheckedconversions.ScriptFromStringKnownToSatisfyTypeContract(str)
+	return nil
+}
+
+// Style is a safehtml.Style that can be stored in a database.
+type Style struct {
+	safehtml.Style
+}
+
+// Value returns the string form of s.
+func (s Style) Value() (driver.Value, error) {
+	return s.Style.String(), nil
+}
+
+// Scan sets s to the value src without checking its type contract, and
+// reports the conversion.
+func (s *Style) Scan(src interface{}) error {
+	str, err := scanString("Style", src)
+	if err != nil {
+		return err
+	}
+	logConversion("Style")
+	s.Style = uncheckedconversions.StyleFromStringKnownToSatisfyTypeContract(str)
+	return nil
+}
+
+// StyleSheet is a safehtml.StyleSheet that can be stored in a database.
+type StyleSheet struct {
+	safehtml.StyleSheet
+}
+
+// Value returns the string form of s.
+func (s StyleSheet) Value() (driver.Value, error) {
+	return s.StyleSheet.String(), nil
+}
+
+// Scan sets s to the value src without checking its type contract, and
+// reports the conversion.
+func (s *StyleSheet) Scan(src interface{}) error {
+	str, err := scanString("StyleSheet", src)
+	if err != nil {
+		return err
+	}
+	logConversion("StyleSheet")
+	s.StyleSheet = uncheckedconversions.StyleSheetFromStringKnownToSatisfyTypeContract(str)
+	return nil
+}
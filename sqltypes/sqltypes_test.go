@@ -0,0 +1,83 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package sqltypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+// A scanValuer is one of the wrappers of this package.
+type scanValuer interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+func TestScan(t *testing.T) {
+	var logged []interface{}
+	safehtml.SetLogger(safehtml.LoggerFunc(func(msg string, keyvals ...interface{}) {
+		logged = append(logged, keyvals...)
+	}))
+	defer safehtml.SetLogger(nil)
+	for _, test := range [...]struct {
+		desc   string
+		dst    scanValuer
+		src    interface{}
+		want   string
+		err    bool
+		logged bool
+	}{
+		{"HTML", new(HTML), `<b>bold</b>`, `<b>bold</b>`, false, false},
+		{"HTML template output", new(HTML), `<form><input id="q" name="q" data-kind="search"><a href="#q">q</a></form>`, `<form><input id="q" name="q" data-kind="search"><a href="#q">q</a></form>`, false, false},
+		{"HTML unsafe", new(HTML), []byte(`<p onclick="x()">a<script>alert(1)</script></p>`), "", true, false},
+		{"HTML style", new(HTML), `<p style="color: red">a</p>`, "", true, false},
+		{"HTML NULL", new(HTML), nil, ``, false, false},
+		{"URL", new(URL), "https://example.com/?q=1", "https://example.com/?q=1", false, false},
+		{"URL unsafe", new(URL), "javascript:alert(1)", "", true, false},
+		{"Identifier", new(Identifier), []byte("main-1"), "main-1", false, false},
+		{"Identifier invalid", new(Identifier), "1 main", "", true, false},
+		{"TrustedResourceURL", new(TrustedResourceURL), "/static/app.js", "/static/app.js", false, true},
+		{"Script", new(Script), "init();", "init();", false, true},
+		{"Style", new(Style), "color: red;", "color: red;", false, true},
+		{"StyleSheet", new(StyleSheet), "p { color: red; }", "p { color: red; }", false, true},
+		{"unsupported type", new(Script), 42, "", true, false},
+	} {
+		logged = nil
+		err := test.dst.Scan(test.src)
+		if (err != nil) != test.err {
+			t.Errorf("%s: Scan(%v) error = %v, want error: %t", test.desc, test.src, err, test.err)
+			continue
+		}
+		if (len(logged) > 0) != test.logged {
+			t.Errorf("%s: Scan(%v) logged %v, want a diagnostic: %t", test.desc, test.src, logged, test.logged)
+		}
+		if err != nil {
+			continue
+		}
+		v, err := test.dst.Value()
+		if err != nil {
+			t.Errorf("%s: Value() error = %v", test.desc, err)
+			continue
+		}
+		if v != test.want {
+			t.Errorf("%s: Value() = %q, want %q", test.desc, v, test.want)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	v, err := HTML{safehtml.HTMLEscaped("<a & b>")}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "&lt;a &amp; b&gt;"; v != want {
+		t.Errorf("Value() = %q, want %q", v, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+// A RedirectPage describes the interstitial page redirecting a user to an
+// identity provider, such as an OAuth authorization endpoint, during sign-in.
+type RedirectPage struct {
+	// Endpoint is the URL of the identity provider, typically from the
+	// configuration of the application.
+	Endpoint TrustedResourceURL
+	// Params are query parameters added to Endpoint, such as client_id and
+	// redirect_uri.
+	Params map[string]string
+	// State and Nonce are added to Endpoint as the state and nonce query
+	// parameters, if not empty.
+	State, Nonce string
+	// Title is the title of the page. It defaults to "Redirecting".
+	Title string
+	// Message is the text displayed while the page redirects. It defaults
+	// to "Redirecting you to your identity provider…".
+	Message string
+	// LinkLabel is the text of the link users without script follow to
+	// continue. It defaults to "Continue".
+	LinkLabel string
+	// ScriptNonce is the Content-Security-Policy nonce of the script
+	// redirecting the page. It may be empty if the page does not have a
+	// nonce-based Content-Security-Policy.
+	ScriptNonce string
+}
+
+// redirectScript navigates to the link preceding the script element, without
+// adding the interstitial page to the session history.
+const redirectScript = `location.replace(document.currentScript.previousElementSibling.href);`
+
+// URL returns the URL the page redirects to: p.Endpoint with p.Params, p.State,
+// and p.Nonce added as query parameters, as by TrustedResourceURLWithParams.
+func (p RedirectPage) URL() TrustedResourceURL {
+	params := make(map[string]string, len(p.Params)+2)
+	for k, v := range p.Params {
+		params[k] = v
+	}
+	if p.State != "" {
+		params["state"] = p.State
+	}
+	if p.Nonce != "" {
+		params["nonce"] = p.Nonce
+	}
+	return TrustedResourceURLWithParams(p.Endpoint, params)
+}
+
+// RedirectPageHTML returns the HTML document of p:
+//
+//	<!DOCTYPE html>
+//	<html><head><meta charset="utf-8"><meta name="referrer" content="no-referrer">
+//	<title>Redirecting</title></head>
+//	<body><p>Redirecting you to your identity provider…</p>
+//	<a href="https://idp.example.com/authorize?...">Continue</a>
+//	<script>...</script></body></html>
+//
+// The page redirects using a script from a constant, rather than a meta
+// refresh, and falls back to a link for users without script. It does not
+// send a referrer, so the URL of the page is not disclosed to the identity
+// provider.
+func RedirectPageHTML(p RedirectPage) HTML {
+	title := p.Title
+	if title == "" {
+		title = "Redirecting"
+	}
+	message := p.Message
+	if message == "" {
+		message = "Redirecting you to your identity provider…"
+	}
+	label := p.LinkLabel
+	if label == "" {
+		label = "Continue"
+	}
+	var w elementWriter
+	w.b.WriteString("<!DOCTYPE html>")
+	w.b.WriteString(`<html><head><meta charset="utf-8"><meta name="referrer" content="no-referrer">`)
+	w.open("title")
+	w.closeTag()
+	w.text(title)
+	w.end("title")
+	w.b.WriteString("</head><body>")
+	w.open("p")
+	w.closeTag()
+	w.text(message)
+	w.end("p")
+	w.open("a")
+	w.urlAttr("href", p.URL().String())
+	w.closeTag()
+	w.text(label)
+	w.end("a")
+	w.open("script")
+	w.attr("nonce", p.ScriptNonce)
+	w.closeTag()
+	w.b.WriteString(redirectScript)
+	w.end("script")
+	w.b.WriteString("</body></html>")
+	return w.html()
+}
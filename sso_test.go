@@ -0,0 +1,38 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestRedirectPageHTML(t *testing.T) {
+	p := RedirectPage{
+		Endpoint: TrustedResourceURLFromConstant("https://idp.example.com/authorize"),
+		Params: map[string]string{
+			"client_id":    "app",
+			"redirect_uri": "https://app.example.com/callback",
+		},
+		State:       `s&"t`,
+		Nonce:       "n0nce",
+		Title:       "<Sign in>",
+		ScriptNonce: "r4nd0m",
+	}
+	const wantURL = "https://idp.example.com/authorize?client_id=app&nonce=n0nce&redirect_uri=https%3a%2f%2fapp.example.com%2fcallback&state=s%26%22t"
+	if got := p.URL().String(); got != wantURL {
+		t.Errorf("URL: got %q, want %q", got, wantURL)
+	}
+	want := `<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="referrer" content="no-referrer">` +
+		`<title>&lt;Sign in&gt;</title></head><body>` +
+		`<p>Redirecting you to your identity provider…</p>` +
+		`<a href="https://idp.example.com/authorize?client_id=app&amp;nonce=n0nce&amp;redirect_uri=https%3a%2f%2fapp.example.com%2fcallback&amp;state=s%26%22t">Continue</a>` +
+		`<script nonce="r4nd0m">location.replace(document.currentScript.previousElementSibling.href);</script>` +
+		`</body></html>`
+	if got := RedirectPageHTML(p).String(); got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"encoding/json"
+)
+
+// StructuredDataHTML returns a script element of type application/ld+json
+// containing data encoded as JSON using encoding/json.Marshal, such as a
+// schema.org description of the page for search engines.
+//
+// encoding/json escapes '<', '>', and '&' in strings, so the encoded data
+// cannot end the script element or be interpreted as HTML. It returns an
+// error if JSON encoding fails.
+func StructuredDataHTML(data interface{}) (HTML, error) {
+	return jsonScript("application/ld+json", Identifier{}, data)
+}
+
+// JSONScriptHTML returns a script element of type application/json with the
+// given id, containing data encoded as JSON using encoding/json.Marshal. Such
+// elements pass data to the scripts of a page, which can read it with
+// JSON.parse(document.getElementById(id).textContent), without the data
+// being executed as script.
+//
+// Like StructuredDataHTML, it returns an error if JSON encoding fails.
+func JSONScriptHTML(id Identifier, data interface{}) (HTML, error) {
+	return jsonScript("application/json", id, data)
+}
+
+func jsonScript(typ string, id Identifier, data interface{}) (HTML, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return HTML{}, err
+	}
+	var w elementWriter
+	w.open("script")
+	w.attr("type", typ)
+	w.attr("id", id.String())
+	w.closeTag()
+	// Marshal also escapes these characters in the output of custom
+	// marshalers and json.RawMessage values, so b cannot contain "</script".
+	w.b.Write(b)
+	w.end("script")
+	return w.html(), nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStructuredDataHTML(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		data interface{}
+		want string
+		err  bool
+	}{
+		{
+			"map",
+			map[string]string{"@type": "Organization", "name": "</script><script>alert(1)</script>"},
+			`<script type="application/ld+json">{"@type":"Organization","name":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"}</script>`,
+			false,
+		},
+		{
+			"raw message",
+			json.RawMessage(`{"a":"</script>"}`),
+			`<script type="application/ld+json">{"a":"\u003c/script\u003e"}</script>`,
+			false,
+		},
+		{
+			"unsupported value",
+			func() {},
+			"",
+			true,
+		},
+	} {
+		got, err := StructuredDataHTML(test.data)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected error", test.desc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
@@ -81,19 +81,29 @@ type Style struct {
 //
 // See also http://www.w3.org/TR/css3-syntax/.
 func StyleFromConstant(style stringConstant) Style {
+	s, err := TryStyleFromConstant(style)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// TryStyleFromConstant is like StyleFromConstant, but returns an error
+// instead of panicking if style does not comply with the Style type contract.
+func TryStyleFromConstant(style stringConstant) (Style, error) {
 	// TODO: implement UTF-8 interchange-validity checks and blocking of newlines
 	// (including Unicode ones) and other whitespace characters (\t, \f) for Style and other safe types
 	// in this package.
 	if strings.ContainsAny(string(style), "<>") {
-		panic(fmt.Sprintf("style string %q contains angle brackets", style))
+		return Style{}, fmt.Errorf("style string %q contains angle brackets", style)
 	}
 	if !strings.HasSuffix(string(style), ";") {
-		panic(fmt.Sprintf("style string %q must end with ';'", style))
+		return Style{}, fmt.Errorf("style string %q must end with ';'", style)
 	}
 	if !strings.Contains(string(style), ":") {
-		panic(fmt.Sprintf("style string %q must contain at least one ':' to specify a property-value pair", style))
+		return Style{}, fmt.Errorf("style string %q must contain at least one ':' to specify a property-value pair", style)
 	}
-	return Style{string(style)}
+	return Style{string(style)}, nil
 }
 
 // String returns the string form of the Style.
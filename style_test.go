@@ -63,6 +63,9 @@ func TestStyleFromConstantPanic(t *testing.T) {
 		if !strings.Contains(errMsg, test.want) {
 			t.Errorf("%s: error message does not contain\n\t%q\ngot:\n\t%q", test.desc, test.want, errMsg)
 		}
+		if _, err := TryStyleFromConstant(stringConstant(test.input)); err == nil || err.Error() != errMsg {
+			t.Errorf("%s: TryStyleFromConstant returned error %v, want %q", test.desc, err, errMsg)
+		}
 	}
 }
 
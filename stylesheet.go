@@ -61,6 +61,37 @@ func CSSRule(selector string, style Style) (StyleSheet, error) {
 	return StyleSheet{fmt.Sprintf("%s{%s}", selector, style.String())}, nil
 }
 
+// MediaRule constructs a StyleSheet containing the given rules in a CSS media
+// rule of the form:
+//
+//	@media query{rules}
+//
+// such as MediaRule("print", rule) to apply rule only when printing. It returns
+// an error if query contains characters other than those of media types,
+// features, and values, or has unbalanced () brackets.
+func MediaRule(query string, rules ...StyleSheet) (StyleSheet, error) {
+	if !mediaQueryPattern.MatchString(query) {
+		return StyleSheet{}, fmt.Errorf("media query %q contains disallowed characters", query)
+	}
+	if !hasBalancedBrackets(query) {
+		return StyleSheet{}, fmt.Errorf("media query %q contains unbalanced () brackets", query)
+	}
+	var b strings.Builder
+	b.WriteString("@media ")
+	b.WriteString(query)
+	b.WriteByte('{')
+	for _, r := range rules {
+		b.WriteString(r.str)
+	}
+	b.WriteByte('}')
+	return StyleSheet{b.String()}, nil
+}
+
+// mediaQueryPattern matches media queries, such as
+// "screen and (min-width: 40em), print". It does not allow '<', which could
+// end the style element, so range features must be written with '>'.
+var mediaQueryPattern = regexp.MustCompile(`^[-a-zA-Z0-9 (),:./>=]+$`)
+
 var (
 	// cssStringPattern matches a single- or double-quoted CSS string.
 	cssStringPattern = regexp.MustCompile(
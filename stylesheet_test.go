@@ -103,3 +103,35 @@ func TestCSSRule(t *testing.T) {
 		}
 	}
 }
+
+func TestMediaRule(t *testing.T) {
+	a := StyleSheetFromConstant(`a{color:black;}`)
+	b := StyleSheetFromConstant(`p{margin:0;}`)
+	for _, test := range [...]struct {
+		query     string
+		want, err string
+	}{
+		{`print`, `@media print{a{color:black;}p{margin:0;}}`, ``},
+		{`screen and (min-width: 40em), print`, `@media screen and (min-width: 40em), print{a{color:black;}p{margin:0;}}`, ``},
+		{`(aspect-ratio: 16/9)`, `@media (aspect-ratio: 16/9){a{color:black;}p{margin:0;}}`, ``},
+		{`(width >= 600px)`, `@media (width >= 600px){a{color:black;}p{margin:0;}}`, ``},
+		{`(width < 600px)`, ``, `media query "(width < 600px)" contains disallowed characters`},
+		{`print{}a`, ``, `media query "print{}a" contains disallowed characters`},
+		{`print;`, ``, `media query "print;" contains disallowed characters`},
+		{``, ``, `media query "" contains disallowed characters`},
+		{`(min-width: 40em`, ``, `media query "(min-width: 40em" contains unbalanced () brackets`},
+	} {
+		got, err := MediaRule(test.query, a, b)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("%q: got error %v, want %q", test.query, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.query, err)
+		} else if got.String() != test.want {
+			t.Errorf("%q: got %q, want %q", test.query, got, test.want)
+		}
+	}
+}
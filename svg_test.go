@@ -0,0 +1,21 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import "testing"
+
+func TestSVG(t *testing.T) {
+	const icon = `<svg viewBox="0 0 1 1"><rect width="1" height="1"></rect></svg>`
+	s := SVGFromConstant(icon)
+	if got := s.String(); got != icon {
+		t.Errorf("SVGFromConstant(%q).String() = %q", icon, got)
+	}
+	var h HTMLer = s
+	if got, want := HTMLConcat(HTMLEscaped("<icon>"), h.HTML()).String(), "&lt;icon&gt;"+icon; got != want {
+		t.Errorf("HTMLConcat = %q, want %q", got, want)
+	}
+}
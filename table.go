@@ -0,0 +1,184 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"net/url"
+)
+
+// QueryURL returns a relative URL consisting of the given query parameters,
+// such as "?page=2&sort=name", which refers to the current page with a
+// different query. The parameters are encoded with url.Values.Encode, so the
+// URL is well-formed and has no other components regardless of the keys and
+// values of query.
+func QueryURL(query url.Values) URL {
+	return URL{"?" + query.Encode()}
+}
+
+// A TableColumn is a column of a table.
+type TableColumn struct {
+	// Label is the text of the column header.
+	Label string
+	// SortKey is the value of the sort parameter that sorts the table by the
+	// column. Columns without a sort key are not sortable.
+	SortKey string
+}
+
+// A TableSort describes the sort order of a table, and the query parameters
+// that encode it.
+type TableSort struct {
+	// Key is the sort key of the column that the table is sorted by.
+	Key string
+	// Descending reports whether the table is sorted in descending order.
+	Descending bool
+	// KeyParam and OrderParam are the names of the query parameters of the
+	// sort key and order. They default to "sort" and "order". The order
+	// parameter has the value "asc" or "desc".
+	KeyParam, OrderParam string
+}
+
+// TableSortFromQuery returns the sort order encoded in query by the headers
+// of TableHeaderHTML, with the given parameter names. Empty names default to
+// "sort" and "order".
+func TableSortFromQuery(query url.Values, keyParam, orderParam string) TableSort {
+	s := TableSort{KeyParam: keyParam, OrderParam: orderParam}
+	s.Key = query.Get(s.keyParam())
+	s.Descending = query.Get(s.orderParam()) == "desc"
+	return s
+}
+
+func (s TableSort) keyParam() string {
+	if s.KeyParam == "" {
+		return "sort"
+	}
+	return s.KeyParam
+}
+
+func (s TableSort) orderParam() string {
+	if s.OrderParam == "" {
+		return "order"
+	}
+	return s.OrderParam
+}
+
+// TableHeaderHTML returns a thead element with a header cell for each of the
+// given columns. The header of each sortable column links to the current page
+// with the query parameters in current, typically the query of the request
+// URL, updated to sort the table by the column: in ascending order, or in the
+// opposite order if the table is already sorted by the column. The header of
+// the column that the table is sorted by has an aria-sort attribute.
+//
+// Other query parameters, such as filters, are preserved. The thead element
+// can be combined with the rows of the table with TableFromHeaderAndRows.
+func TableHeaderHTML(current url.Values, columns []TableColumn, sort TableSort) HTML {
+	var w elementWriter
+	w.open("thead")
+	w.closeTag()
+	w.open("tr")
+	w.closeTag()
+	for _, col := range columns {
+		w.open("th")
+		w.attr("scope", "col")
+		sorted := col.SortKey != "" && col.SortKey == sort.Key
+		if sorted {
+			if sort.Descending {
+				w.attr("aria-sort", "descending")
+			} else {
+				w.attr("aria-sort", "ascending")
+			}
+		}
+		w.closeTag()
+		if col.SortKey == "" {
+			w.text(col.Label)
+		} else {
+			query := make(url.Values, len(current)+2)
+			for k, v := range current {
+				query[k] = v
+			}
+			query.Set(sort.keyParam(), col.SortKey)
+			if sorted && !sort.Descending {
+				query.Set(sort.orderParam(), "desc")
+			} else {
+				query.Set(sort.orderParam(), "asc")
+			}
+			w.open("a")
+			w.urlAttr("href", QueryURL(query).String())
+			w.closeTag()
+			w.text(col.Label)
+			w.end("a")
+		}
+		w.end("th")
+	}
+	w.end("tr")
+	w.end("thead")
+	return w.html()
+}
+
+// TableFromRows returns a table element with a header row containing the
+// cells in header, if any, followed by a row for each element of rows. Header
+// cells are th elements with scope="col", and other cells are td elements.
+// The contents of the cells are included verbatim.
+func TableFromRows(header []HTML, rows [][]HTML) HTML {
+	var w elementWriter
+	w.open("table")
+	w.closeTag()
+	if len(header) > 0 {
+		w.open("thead")
+		w.closeTag()
+		w.open("tr")
+		w.closeTag()
+		for _, cell := range header {
+			w.open("th")
+			w.attr("scope", "col")
+			w.closeTag()
+			w.writeHTML(cell)
+			w.end("th")
+		}
+		w.end("tr")
+		w.end("thead")
+	}
+	writeTableBody(&w, rows)
+	w.end("table")
+	return w.html()
+}
+
+// TableFromHeaderAndRows is like TableFromRows, but starts the table with
+// head, typically the thead element returned by TableHeaderHTML, which is
+// included verbatim:
+//
+//	TableFromHeaderAndRows(TableHeaderHTML(r.URL.Query(), columns, sort), rows)
+func TableFromHeaderAndRows(head HTML, rows [][]HTML) HTML {
+	var w elementWriter
+	w.open("table")
+	w.closeTag()
+	w.writeHTML(head)
+	writeTableBody(&w, rows)
+	w.end("table")
+	return w.html()
+}
+
+// writeTableBody writes a tbody element with a row for each element of rows
+// to w, unless rows is empty.
+func writeTableBody(w *elementWriter, rows [][]HTML) {
+	if len(rows) == 0 {
+		return
+	}
+	w.open("tbody")
+	w.closeTag()
+	for _, row := range rows {
+		w.open("tr")
+		w.closeTag()
+		for _, cell := range row {
+			w.open("td")
+			w.closeTag()
+			w.writeHTML(cell)
+			w.end("td")
+		}
+		w.end("tr")
+	}
+	w.end("tbody")
+}
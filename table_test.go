@@ -0,0 +1,128 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestQueryURL(t *testing.T) {
+	for _, test := range [...]struct {
+		query url.Values
+		want  string
+	}{
+		{nil, "?"},
+		{url.Values{"sort": {"name"}, "page": {"2"}}, "?page=2&sort=name"},
+		{url.Values{"q": {`"><script>`}, "x#y": {"javascript:alert(1)"}}, "?q=%22%3E%3Cscript%3E&x%23y=javascript%3Aalert%281%29"},
+	} {
+		if got := QueryURL(test.query).String(); got != test.want {
+			t.Errorf("QueryURL(%v) = %q, want %q", test.query, got, test.want)
+		}
+	}
+}
+
+func TestTableHeaderHTML(t *testing.T) {
+	columns := []TableColumn{
+		{Label: "Name", SortKey: "name"},
+		{Label: "E-mail <address>", SortKey: "email"},
+		{Label: "Actions"},
+	}
+	for _, test := range [...]struct {
+		desc  string
+		query string
+		want  string
+	}{
+		{
+			"unsorted",
+			"filter=active",
+			`<thead><tr>` +
+				`<th scope="col"><a href="?filter=active&amp;order=asc&amp;sort=name">Name</a></th>` +
+				`<th scope="col"><a href="?filter=active&amp;order=asc&amp;sort=email">E-mail &lt;address&gt;</a></th>` +
+				`<th scope="col">Actions</th>` +
+				`</tr></thead>`,
+		},
+		{
+			"sorted ascending",
+			"sort=name&order=asc",
+			`<thead><tr>` +
+				`<th scope="col" aria-sort="ascending"><a href="?order=desc&amp;sort=name">Name</a></th>` +
+				`<th scope="col"><a href="?order=asc&amp;sort=email">E-mail &lt;address&gt;</a></th>` +
+				`<th scope="col">Actions</th>` +
+				`</tr></thead>`,
+		},
+		{
+			"sorted descending",
+			"sort=email&order=desc",
+			`<thead><tr>` +
+				`<th scope="col"><a href="?order=asc&amp;sort=name">Name</a></th>` +
+				`<th scope="col" aria-sort="descending"><a href="?order=asc&amp;sort=email">E-mail &lt;address&gt;</a></th>` +
+				`<th scope="col">Actions</th>` +
+				`</tr></thead>`,
+		},
+	} {
+		current, err := url.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := TableHeaderHTML(current, columns, TableSortFromQuery(current, "", "")).String()
+		if got != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestTableHeaderHTMLCustomParams(t *testing.T) {
+	current := url.Values{"s": {"name"}}
+	sort := TableSortFromQuery(current, "s", "dir")
+	if sort.Key != "name" || sort.Descending {
+		t.Errorf("got sort %+v, want ascending by name", sort)
+	}
+	got := TableHeaderHTML(current, []TableColumn{{Label: "Name", SortKey: "name"}}, sort).String()
+	want := `<thead><tr><th scope="col" aria-sort="ascending"><a href="?dir=desc&amp;s=name">Name</a></th></tr></thead>`
+	if got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+	if len(current) != 1 {
+		t.Errorf("TableHeaderHTML modified the current query: %v", current)
+	}
+}
+
+func TestTableFromRows(t *testing.T) {
+	h := HTMLEscaped
+	for _, test := range [...]struct {
+		desc   string
+		header []HTML
+		rows   [][]HTML
+		want   string
+	}{
+		{"empty", nil, nil, "<table></table>"},
+		{"header only", []HTML{h("Name"), h("<Age>")}, nil,
+			`<table><thead><tr><th scope="col">Name</th><th scope="col">&lt;Age&gt;</th></tr></thead></table>`},
+		{"rows only", nil, [][]HTML{{h("a"), h("b")}, {h("c")}},
+			"<table><tbody><tr><td>a</td><td>b</td></tr><tr><td>c</td></tr></tbody></table>"},
+		{"header and rows", []HTML{h("Link")}, [][]HTML{{HTML{`<a href="/x">x</a>`}}},
+			`<table><thead><tr><th scope="col">Link</th></tr></thead><tbody><tr><td><a href="/x">x</a></td></tr></tbody></table>`},
+	} {
+		if got := TableFromRows(test.header, test.rows).String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestTableFromHeaderAndRows(t *testing.T) {
+	head := TableHeaderHTML(url.Values{}, []TableColumn{{Label: "Name", SortKey: "name"}}, TableSort{Key: "name"})
+	got := TableFromHeaderAndRows(head, [][]HTML{{HTMLEscaped("<a>")}}).String()
+	want := `<table><thead><tr><th scope="col" aria-sort="ascending"><a href="?order=desc&amp;sort=name">Name</a></th></tr></thead>` +
+		`<tbody><tr><td>&lt;a&gt;</td></tr></tbody></table>`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := TableFromHeaderAndRows(HTML{}, nil).String(), "<table></table>"; got != want {
+		t.Errorf("empty: got %q, want %q", got, want)
+	}
+}
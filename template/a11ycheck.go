@@ -0,0 +1,172 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// WithAccessibilityCheck returns an ExecuteOption that checks the output of a
+// template for elements that assistive technologies such as screen readers
+// cannot describe, and passes each problem found to report:
+//
+//   - img elements, and input elements of type image, without an alt
+//     attribute, which may be empty for decorative images;
+//   - input, select, and textarea elements without a label, that is,
+//     neither in a label element nor referred to by the for attribute of
+//     one, and without an aria-label, aria-labelledby, or title attribute;
+//   - button elements, and input elements of type button, without an
+//     accessible name, such as text or an image with an alt attribute.
+//
+// Like WithHTMLCheck, which it can be combined with, WithAccessibilityCheck
+// buffers the output until the execution completes, and is meant for
+// development and tests rather than production. Like WithHTMLCheck, it is
+// excluded from builds with the safehtml_minimal tag.
+func WithAccessibilityCheck(report func(HTMLProblem)) ExecuteOption {
+	return func(c *executeConfig) {
+		c.checkAccessibility = report
+	}
+}
+
+// unlabeledInputTypes contains the types of input elements that do not need
+// a label, because they are not shown or the browser labels them.
+var unlabeledInputTypes = map[string]bool{
+	"button": true,
+	"hidden": true,
+	"image":  true,
+	"reset":  true,
+	"submit": true,
+}
+
+// checkAccessibility passes the accessibility problems of the markup b to
+// report. templateAt is as in checkHTML.
+func checkAccessibility(b []byte, templateAt func(int) string, report func(HTMLProblem)) {
+	type control struct {
+		desc, id string
+		line     int
+		template string
+	}
+	var (
+		// controls are the form controls without a label so far, which may
+		// still be labeled by a later label element.
+		controls []control
+		labeled  = make(map[string]bool)
+		labels   int
+		// button is the open button element, if any.
+		button *control
+		named  bool
+	)
+	scanHTML(b, templateAt, func(tt html.TokenType, tok html.Token, line int, tmpl string) {
+		problem := func(format string, args ...interface{}) {
+			report(HTMLProblem{line, []string{tmpl}, fmt.Sprintf(format, args...)})
+		}
+		switch tt {
+		case html.TextToken:
+			if button != nil && strings.TrimSpace(tok.Data) != "" {
+				named = true
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if tokenAttrValue(tok, "aria-hidden") == "true" {
+				return
+			}
+			switch tok.Data {
+			case "img":
+				alt, ok := tokenAttr(tok, "alt")
+				switch {
+				case ok:
+					named = named || alt != ""
+				case hasAccessibleName(tok):
+					named = true
+				case tokenAttrValue(tok, "role") != "presentation" && tokenAttrValue(tok, "role") != "none":
+					problem("<img> has no alt attribute")
+				}
+			case "input", "select", "textarea":
+				typ := strings.ToLower(tokenAttrValue(tok, "type"))
+				if typ == "" {
+					typ = "text"
+				}
+				desc := "<" + tok.Data + ">"
+				if tok.Data == "input" {
+					desc = fmt.Sprintf("<input type=%q>", typ)
+				}
+				switch {
+				case tok.Data == "input" && typ == "image":
+					if _, ok := tokenAttr(tok, "alt"); !ok && !hasAccessibleName(tok) {
+						problem("%s has no alt attribute", desc)
+					}
+				case tok.Data == "input" && typ == "button":
+					if tokenAttrValue(tok, "value") == "" && !hasAccessibleName(tok) {
+						problem("%s has no accessible name", desc)
+					}
+				case tok.Data == "input" && unlabeledInputTypes[typ]:
+				case labels == 0 && !hasAccessibleName(tok):
+					controls = append(controls, control{desc, tokenAttrValue(tok, "id"), line, tmpl})
+				}
+			case "label":
+				if id := tokenAttrValue(tok, "for"); id != "" {
+					labeled[id] = true
+				}
+				if tt == html.StartTagToken {
+					labels++
+				}
+			case "button":
+				button = &control{"<button>", "", line, tmpl}
+				named = hasAccessibleName(tok)
+			}
+		case html.EndTagToken:
+			switch tok.Data {
+			case "label":
+				if labels > 0 {
+					labels--
+				}
+			case "button":
+				if button != nil && !named {
+					report(HTMLProblem{button.line, []string{button.template}, "<button> has no accessible name"})
+				}
+				button = nil
+			}
+		}
+	})
+	if button != nil && !named {
+		report(HTMLProblem{button.line, []string{button.template}, "<button> has no accessible name"})
+	}
+	for _, c := range controls {
+		if c.id == "" || !labeled[c.id] {
+			report(HTMLProblem{c.line, []string{c.template}, c.desc + " has no label"})
+		}
+	}
+}
+
+// hasAccessibleName reports whether tok has an attribute naming the element
+// for assistive technologies.
+func hasAccessibleName(tok html.Token) bool {
+	return tokenAttrValue(tok, "aria-label") != "" || tokenAttrValue(tok, "aria-labelledby") != "" || tokenAttrValue(tok, "title") != ""
+}
+
+// tokenAttr returns the value of the named attribute of tok, and reports
+// whether tok has the attribute.
+func tokenAttr(tok html.Token, name string) (string, bool) {
+	for _, a := range tok.Attr {
+		if a.Namespace == "" && a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// tokenAttrValue returns the trimmed value of the named attribute of tok, or
+// "" if tok does not have the attribute.
+func tokenAttrValue(tok html.Token, name string) string {
+	val, _ := tokenAttr(tok, name)
+	return strings.TrimSpace(val)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithAccessibilityCheck(t *testing.T) {
+	for _, test := range [...]struct {
+		desc  string
+		input string
+		want  []string
+	}{
+		{"images", `<img src="/a.png" alt="{{.}}"><img src="/b.png" alt=""><img src="/c.png" role="presentation"><img src="/d.png" aria-hidden="true">`, nil},
+		{"image without alt", "<p>\n<img src=\"/a.png\" title=\"\">{{.}}", []string{"line 2: <img> has no alt attribute"}},
+		{"labeled controls", `<label>Name <input name="n"></label><label for="e">Email</label><input id="e" type="email">` +
+			`<select aria-label="{{.}}"></select><textarea title="Notes"></textarea><input type="hidden" name="t"><input type="submit">`, nil},
+		{"label after control", `<input id="q" type="search"><label for="q">{{.}}</label>`, nil},
+		{"unlabeled controls", "<input name=\"n\">\n<select id=\"s\"></select><label for=\"x\">{{.}}</label><textarea></textarea>",
+			[]string{`line 1: <input type="text"> has no label`, "line 2: <select> has no label", "line 2: <textarea> has no label"}},
+		{"input buttons", `<input type="image" src="/go.png"><input type="button" value="{{.}}"><input type="button">`,
+			[]string{`line 1: <input type="image"> has no alt attribute`, `line 1: <input type="button"> has no accessible name`}},
+		{"buttons", `<button>{{.}}</button><button aria-label="Close">×</button><button><img src="/x.png" alt="Close"></button>`, nil},
+		{"button without name", "<button>\n  <img src=\"/x.png\" alt=\"\">\n</button>{{.}}", []string{"line 1: <button> has no accessible name"}},
+	} {
+		var got []string
+		var b strings.Builder
+		tmpl := Must(New("").ParseFromTrustedTemplate(TrustedTemplate{test.input}))
+		err := tmpl.ExecuteWithOptions(&b, "x", WithAccessibilityCheck(func(p HTMLProblem) {
+			got = append(got, p.String())
+		}))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got problems %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestWithAccessibilityAndHTMLCheck(t *testing.T) {
+	tmpl := Must(New("form").ParseFromTrustedTemplate(TrustedTemplate{`<div><input value="{{.}}">`}))
+	var got []HTMLProblem
+	report := func(p HTMLProblem) { got = append(got, p) }
+	if err := tmpl.ExecuteWithOptions(new(strings.Builder), "q", WithHTMLCheck(report), WithAccessibilityCheck(report)); err != nil {
+		t.Fatal(err)
+	}
+	want := []HTMLProblem{
+		{1, []string{"form"}, "<div> is not closed"},
+		{1, []string{"form"}, `<input type="text"> has no label`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got problems %q, want %q", got, want)
+	}
+}
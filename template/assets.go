@@ -0,0 +1,93 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build go1.16
+// +build go1.16
+
+package template
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+	"github.com/google/safehtml/urlsafe"
+)
+
+// An Asset is a static asset, such as a script or a style sheet, served from
+// a TrustedFS.
+type Asset struct {
+	// URL is the URL of the asset. Its query parameter v changes whenever
+	// the content of the asset changes, so that the asset can be cached
+	// indefinitely.
+	URL safehtml.TrustedResourceURL
+	// Integrity is the Subresource Integrity metadata of the asset, such as
+	// "sha384-oqVuAfXRKap7fdgcCY5uykM6+R9GqQ8K/uxy9rx7HNQlGYl1kPzQho1wx4JwY8wC",
+	// for the integrity attribute of elements that load it.
+	Integrity string
+}
+
+// An AssetHasher computes the URLs and Subresource Integrity metadata of the
+// assets in a TrustedFS. It reads and hashes each asset once, so assets must
+// not change while the AssetHasher is in use. It is safe for concurrent use.
+type AssetHasher struct {
+	tfs    TrustedFS
+	prefix string
+
+	mu     sync.Mutex
+	assets map[string]Asset
+}
+
+// NewAssetHasher returns an AssetHasher for the assets in tfs, which are
+// served at the URLs that are the paths of the assets appended to prefix, such
+// as "/static/". prefix must have one of the prefixes allowed by
+// safehtml.TrustedResourceURLAppend and must not have a query or fragment.
+func NewAssetHasher(tfs TrustedFS, prefix safehtml.TrustedResourceURL) (*AssetHasher, error) {
+	p := prefix.String()
+	if !urlsafe.IsSafeTrustedResourceURLPrefix(p) || strings.ContainsAny(p, "?#") {
+		return nil, fmt.Errorf("%q is not an allowed asset URL prefix", p)
+	}
+	return &AssetHasher{tfs: tfs, prefix: p, assets: make(map[string]Asset)}, nil
+}
+
+// Asset returns the URL and integrity metadata of the asset at name, a
+// slash-separated path in the TrustedFS, such as "js/app.js".
+func (h *AssetHasher) Asset(name string) (Asset, error) {
+	h.mu.Lock()
+	a, ok := h.assets[name]
+	h.mu.Unlock()
+	if ok {
+		return a, nil
+	}
+	if !fs.ValidPath(name) || name == "." {
+		return Asset{}, fmt.Errorf("%q is not a valid asset path", name)
+	}
+	b, err := fs.ReadFile(h.tfs.fsys, name)
+	if err != nil {
+		return Asset{}, err
+	}
+	sum := sha512.Sum384(b)
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	u := uncheckedconversions.TrustedResourceURLFromStringKnownToSatisfyTypeContract(h.prefix + strings.Join(segments, "/"))
+	a = Asset{
+		URL:       safehtml.TrustedResourceURLWithParams(u, map[string]string{"v": hex.EncodeToString(sum[:8])}),
+		Integrity: "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+	}
+	h.mu.Lock()
+	h.assets[name] = a
+	h.mu.Unlock()
+	return a, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build go1.16
+// +build go1.16
+
+package template
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestAssetHasher(t *testing.T) {
+	h, err := NewAssetHasher(TrustedFSFromEmbed(testFS), safehtml.TrustedResourceURLFromConstant("/static/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const name = "testdata/dir1/parsefiles_t1.tmpl"
+	a, err := h.Asset(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha512.Sum384(b)
+	if got, want := a.URL.String(), "/static/"+name+"?v="+hex.EncodeToString(sum[:8]); got != want {
+		t.Errorf("got URL %q, want %q", got, want)
+	}
+	if got, want := a.Integrity, "sha384-"+base64.StdEncoding.EncodeToString(sum[:]); got != want {
+		t.Errorf("got integrity %q, want %q", got, want)
+	}
+	if cached, _ := h.Asset(name); cached != a {
+		t.Errorf("got %+v on second call, want %+v", cached, a)
+	}
+
+	tmpl := Must(New("script").Parse(`<script src="{{.URL}}" integrity="{{.Integrity}}" crossorigin="anonymous"></script>`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, a); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `integrity="sha384-`) {
+		t.Errorf("got %q, want integrity attribute", buf.String())
+	}
+}
+
+func TestAssetHasherErrors(t *testing.T) {
+	tfs := TrustedFSFromEmbed(testFS)
+	for _, prefix := range []safehtml.TrustedResourceURL{
+		safehtml.TrustedResourceURLFromConstant("javascript:"),
+		safehtml.TrustedResourceURLFromConstant("/static/?a=b"),
+		safehtml.TrustedResourceURLFromConstant("about:blank#"),
+	} {
+		if _, err := NewAssetHasher(tfs, prefix); err == nil {
+			t.Errorf("NewAssetHasher(%q) succeeded, want error", prefix)
+		}
+	}
+	h, err := NewAssetHasher(tfs, safehtml.TrustedResourceURLFromConstant("https://static.example.com/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"", ".", "../x", "/testdata/glob_t0.tmpl", "testdata/missing.js"} {
+		if _, err := h.Asset(name); err == nil {
+			t.Errorf("Asset(%q) succeeded, want error", name)
+		}
+	}
+}
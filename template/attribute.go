@@ -0,0 +1,159 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strconv"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/safehtmlutil"
+	"github.com/google/safehtml/registry"
+)
+
+// sanitizeAttributeFuncName is the name of the sanitizer of actions that emit
+// a safehtml.Attribute in a start tag, such as {{.}} in `<input {{.}}>`.
+const sanitizeAttributeFuncName = "_sanitizeAttribute"
+
+// contextAttrs contains the attributes of elements that change the
+// sanitization contexts of the other attributes or of the content of the
+// element, which the escaper determines from the template text, so actions
+// must not emit them.
+var contextAttrs = map[string]string{
+	"link":   "rel",
+	"script": "type",
+}
+
+// escapeAttributeAction escapes the action n in context c, the start tag of
+// an element before an attribute, such as {{.}} in `<input {{.}}>`. The
+// action must emit a safehtml.Attribute, which is checked against the
+// element and sanitized when the template is executed.
+func (e *escaper) escapeAttributeAction(c context, n *parse.ActionNode) context {
+	var err error
+	switch {
+	case c.element.name == "" || len(c.element.names) > 0:
+		err = codedErrorf(ErrActionInName, "actions must not affect element names, or emit attributes of elements whose name depends on a condition")
+	case e.policy.isElementForbidden(c.element.name):
+		err = policyErrorf("actions must not occur in the start tag of a %q element, which is forbidden by the template policy", c.element.name)
+	}
+	if err != nil {
+		return context{state: stateError, err: errorf(errorCode(err, ErrEscapeAction), n, n.Line, "cannot escape action %v: %s", n, err)}
+	}
+	name := sanitizeAttributeFuncName
+	if e.subPolicy != -1 {
+		name = subPolicyFuncName(name, e.subPolicy)
+	}
+	if _, ok := e.attributeActionEdits[n]; ok {
+		panic("node " + n.String() + " shared between templates")
+	}
+	// Separate the attribute from the element name or attribute value before
+	// it, unless the template text already does.
+	sep := " "
+	if c.afterSpace || c.state == stateAfterName {
+		sep = ""
+	}
+	e.attributeActionEdits[n] = &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      n.Pos,
+		Args: []parse.Node{
+			parse.NewIdentifier(name).SetPos(n.Pos),
+			&parse.StringNode{NodeType: parse.NodeString, Pos: n.Pos, Quoted: strconv.Quote(c.element.name), Text: c.element.name},
+			&parse.StringNode{NodeType: parse.NodeString, Pos: n.Pos, Quoted: strconv.Quote(c.linkRel), Text: c.linkRel},
+			&parse.StringNode{NodeType: parse.NodeString, Pos: n.Pos, Quoted: strconv.Quote(sep), Text: sep},
+		},
+	}
+	return context{
+		state:      stateTag,
+		element:    c.element,
+		scriptType: c.scriptType,
+		linkRel:    c.linkRel,
+	}
+}
+
+// attributeFuncs returns the sanitizers of actions that emit attributes in
+// templates with the policy of ns and with each of its sub-policies.
+func (ns *nameSpace) attributeFuncs() template.FuncMap {
+	ret := template.FuncMap{sanitizeAttributeFuncName: attributeSanitizer(ns.registry, ns.policy)}
+	for i, sp := range ns.subPolicies {
+		ret[subPolicyFuncName(sanitizeAttributeFuncName, i)] = attributeSanitizer(ns.registry, sp.policy)
+	}
+	return ret
+}
+
+// attributeSanitizer returns the sanitizer of actions that emit attributes in
+// templates with registry r and policy p. The sanitizer returns the HTML of
+// the attribute given as its last argument, a safehtml.Attribute, in the
+// start tag of element, whose link types are linkRel if it is a link,
+// preceded by sep.
+func attributeSanitizer(r *registry.Registry, p *Policy) func(element, linkRel, sep string, v interface{}) (string, error) {
+	policyFuncs := p.funcs()
+	return func(element, linkRel, sep string, v interface{}) (string, error) {
+		a, ok := safehtmlutil.Indirect(v).(safehtml.Attribute)
+		if !ok {
+			return "", sanitizationErrorf(SanitizationContextHTML, ErrUnexpectedValue, "expected a safehtml.Attribute value in the start tag of <%s>", element)
+		}
+		name := a.Name()
+		if name == "" || a.Value() == false {
+			return "", nil
+		}
+		if contextAttrs[element] == name {
+			return "", codedErrorf(ErrDisallowedAttr, "actions must not emit the %q attribute of a %q element, which determines how the element is sanitized", name, element)
+		}
+		sc, err := sanitizationContextForAttrVal(r, element, name, linkRel)
+		if err == nil {
+			sc, err = p.attrValContext(element, name, sc)
+		}
+		if err != nil {
+			return "", err
+		}
+		if a.Value() == true {
+			if sc != SanitizationContextNone && sc != SanitizationContextAsyncEnum {
+				return "", sanitizationErrorf(sc, ErrUnexpectedValue, "the %q attribute of a %q element must have a value", name, element)
+			}
+			// Give boolean attributes an empty value, rather than none, so
+			// that template text directly after the action, as in
+			// `<input {{.}}hidden>`, cannot extend the attribute name.
+			return sep + name + `=""`, nil
+		}
+		chain := []string{sc.sanitizerName()}
+		if sc.isURLorTrustedResourceURL() {
+			chain = append(chain, normalizeURLFuncName)
+		}
+		val := a.Value()
+		for _, fname := range chain {
+			if fname == "" {
+				continue
+			}
+			f, ok := policyFuncs[fname]
+			if !ok {
+				f = funcs[fname]
+			}
+			var out string
+			switch f := f.(type) {
+			case func(...interface{}) (string, error):
+				if out, err = f(val); err != nil {
+					return "", err
+				}
+			case func(...interface{}) string:
+				out = f(val)
+			}
+			val = out
+		}
+		// Unlike sanitizeHTML, escape safehtml.HTML values too, which are
+		// only safe as the content of elements.
+		return sep + name + `="` + safehtml.HTMLEscaped(safehtmlutil.Stringify(val)).String() + `"`, nil
+	}
+}
+
+// appendAttributeSanitizer appends cmd, the command calling the sanitizer of
+// an action that emits an attribute, to the pipeline p of the action.
+func appendAttributeSanitizer(p *parse.PipeNode, cmd *parse.CommandNode) {
+	cmds := make([]*parse.CommandNode, len(p.Cmds), len(p.Cmds)+1)
+	copy(cmds, p.Cmds)
+	p.Cmds = append(cmds, cmd)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestAttributeAction(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, input string
+		data        interface{}
+		want        string
+	}{
+		{"boolean", `<button {{.}}>Save</button>`, safehtml.AttributeFromConstant("disabled", true), `<button disabled="">Save</button>`},
+		{"false", `<button {{.}}>Save</button>`, safehtml.AttributeFromConstant("disabled", false), `<button >Save</button>`},
+		{"zero", `<button {{.}}>Save</button>`, safehtml.Attribute{}, `<button >Save</button>`},
+		{"pointer", `<button {{.}}>Save</button>`, &[]safehtml.Attribute{safehtml.AttributeFromConstant("hidden", true)}[0], `<button hidden="">Save</button>`},
+		{"string", `<button type="button" {{.}}>×</button>`, safehtml.AttributeFromConstant("aria-label", `Close "dialog"`),
+			`<button type="button" aria-label="Close &#34;dialog&#34;">×</button>`},
+		{"int", `<div {{.}}>`, safehtml.AttributeFromConstant("tabindex", -1), `<div tabindex="-1">`},
+		{"before other attributes", `<input {{.}} name="q">`, safehtml.AttributeFromConstant("required", true), `<input required="" name="q">`},
+		{"before text", `<input {{.}}hidden>`, safehtml.AttributeFromConstant("required", true), `<input required=""hidden>`},
+		{"after element name", `<input{{.}}>`, safehtml.AttributeFromConstant("required", true), `<input required="">`},
+		{"after attribute value", `<input type="text"{{.}}>`, safehtml.AttributeFromConstant("required", true), `<input type="text" required="">`},
+		{"after boolean attribute", `<input hidden {{.}}>`, safehtml.AttributeFromConstant("required", true), `<input hidden required="">`},
+		{"template calls", `{{define "a"}}{{.}}{{end}}<input {{template "a" .}}><input{{template "a" .}}>`, safehtml.AttributeFromConstant("required", true),
+			`<input required=""><input required="">`},
+		{"url", `<a {{.}}>Link</a>`, safehtml.AttributeFromConstant("href", "javascript:alert(1)"), `<a href="about:invalid#zGoSafez">Link</a>`},
+		{"safe url", `<a {{.}}>Link</a>`, safehtml.AttributeFromConstant("href", safehtml.URLSanitized("/a?b=c&d")), `<a href="/a?b=c&amp;d">Link</a>`},
+		{"html value", `<div {{.}}>`, safehtml.AttributeFromConstant("title", safehtml.HTMLEscaped("<b>")), `<div title="&amp;lt;b&amp;gt;">`},
+		{"conditional", `<input {{if .}}{{.}}{{end}}>`, safehtml.AttributeFromConstant("checked", true), `<input checked="">`},
+	} {
+		var b strings.Builder
+		tmpl := Must(New("").ParseFromTrustedTemplate(TrustedTemplate{test.input}))
+		if err := tmpl.Execute(&b, test.data); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestAttributeActionErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, input string
+		policy      *Policy
+		data        interface{}
+		want        string
+	}{
+		{"not an attribute", `<button {{.}}>`, nil, "disabled", `expected a safehtml.Attribute value in the start tag of <button>`},
+		{"unknown attribute", `<button {{.}}>`, nil, safehtml.AttributeFromConstant("foo", "bar"), `actions must not occur in the "foo" attribute value context of a "button" element`},
+		{"boolean with value", `<a {{.}}>`, nil, safehtml.AttributeFromConstant("href", true), `the "href" attribute of a "a" element must have a value`},
+		{"link rel", `<link {{.}} href="/a.css">`, nil, safehtml.AttributeFromConstant("rel", "stylesheet"), `actions must not emit the "rel" attribute of a "link" element`},
+		{"script type", `<script {{.}}></script>`, nil, safehtml.AttributeFromConstant("type", "module"), `actions must not emit the "type" attribute of a "script" element`},
+		{"forbidden attribute", `<a {{.}}>`, new(Policy).ForbidAttributes("a", "target"), safehtml.AttributeFromConstant("target", "_blank"), `forbidden by the template policy`},
+		{"forbidden element", `<img {{.}}>`, new(Policy).ForbidElements("img"), safehtml.AttributeFromConstant("alt", ""), `forbidden by the template policy`},
+		{"element name", `<a{{.}}>`, nil, "b", `expected a safehtml.Attribute value in the start tag of <a>`},
+		{"conditional element", `{{if .}}<a{{else}}<b{{end}} {{.}}>`, nil, safehtml.AttributeFromConstant("title", "x"), `emit attributes of elements whose name depends on a condition`},
+	} {
+		tmpl := New("")
+		if test.policy != nil {
+			tmpl = tmpl.WithPolicy(test.policy)
+		}
+		tmpl, err := tmpl.ParseFromTrustedTemplate(TrustedTemplate{test.input})
+		if err == nil {
+			err = tmpl.Execute(&strings.Builder{}, test.data)
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", test.desc)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: error\n\t%q\ndoes not contain expected string\n\t%q", test.desc, err, test.want)
+		}
+	}
+}
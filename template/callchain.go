@@ -0,0 +1,220 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// A TemplateCall is an invocation of a template in a template call chain.
+type TemplateCall struct {
+	// Name is the name of the invoked template.
+	Name string
+	// Pos is the position of the {{template}} action that invoked the
+	// template, such as "page.html:12:5", or the empty string for the
+	// executed template.
+	Pos string
+}
+
+// An ExecError is returned by an execution that fails in a template invoked
+// by another template. It wraps the error that caused the execution to fail.
+type ExecError struct {
+	// Chain is the template call chain at the time of the failure, starting
+	// with the executed template and ending with the template in which the
+	// execution failed.
+	Chain []TemplateCall
+	// Err is the error that caused the execution to fail.
+	Err error
+}
+
+func (e *ExecError) Error() string {
+	msg := e.Err.Error()
+	var execErr template.ExecError
+	if errors.As(e.Err, &execErr) {
+		// Report the name of the template in which the failure occurred
+		// rather than the name of the template derived from it by the escaper.
+		msg = strings.ReplaceAll(msg, strconv.Quote(execErr.Name), strconv.Quote(demangle(execErr.Name)))
+	}
+	callers := make([]string, 0, len(e.Chain))
+	for i := len(e.Chain) - 1; i > 0; i-- {
+		callers = append(callers, fmt.Sprintf("called from %q at %s", e.Chain[i-1].Name, e.Chain[i].Pos))
+	}
+	const maxCallers = 10
+	if len(callers) > maxCallers {
+		callers = append(append(callers[:maxCallers/2:maxCallers/2], "..."), callers[len(callers)-maxCallers/2:]...)
+	}
+	return strings.Join(append([]string{msg}, callers...), "; ")
+}
+
+// Unwrap returns e.Err.
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// demangle returns the name of the template that the template with the given
+// name was derived from by the escaper, or name if it was not derived.
+func demangle(name string) string {
+	if i := strings.Index(name, "$htmltemplate_"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// annotate returns err annotated with the template call chain of st, if err
+// occurred in a template invoked by another template.
+func (st *execState) annotate(err error) error {
+	if err == nil || len(st.calls) < 2 {
+		return err
+	}
+	return &ExecError{Chain: append([]TemplateCall(nil), st.calls...), Err: err}
+}
+
+const (
+	enterTemplateFuncName = "_enterTemplate"
+	exitTemplateFuncName  = "_exitTemplate"
+	callTemplateFuncName  = "_callTemplate"
+)
+
+// instrumentationFuncs are the default implementations of the functions
+// called by the actions that instrument inserts. They are replaced by the
+// functions of an execState in each execution.
+var instrumentationFuncs = template.FuncMap{
+	enterTemplateFuncName: func(string) string { return "" },
+	exitTemplateFuncName:  func() string { return "" },
+	callTemplateFuncName:  func(string) string { return "" },
+}
+
+// instrumentationFuncs returns the functions that record the template call
+// chain in st.
+func (st *execState) instrumentationFuncs() template.FuncMap {
+	return template.FuncMap{
+		enterTemplateFuncName: st.enterTemplate,
+		exitTemplateFuncName:  st.exitTemplate,
+		callTemplateFuncName:  st.callTemplate,
+	}
+}
+
+func (st *execState) enterTemplate(name string) (string, error) {
+	st.calls = append(st.calls, TemplateCall{Name: name, Pos: st.callSite})
+	st.callSite = ""
+	st.markOutput()
+	if st.maxDepth > 0 && len(st.calls) > st.maxDepth {
+		chain := make([]string, len(st.calls))
+		for i, call := range st.calls {
+			chain[i] = call.Name
+		}
+		return "", &DepthError{MaxDepth: st.maxDepth, Chain: chain}
+	}
+	return "", nil
+}
+
+func (st *execState) exitTemplate() string {
+	st.calls = st.calls[:len(st.calls)-1]
+	st.markOutput()
+	return ""
+}
+
+func (st *execState) callTemplate(pos string) string {
+	st.callSite = pos
+	return ""
+}
+
+// instrument inserts actions that record the template call chain into the
+// body of the template with the given name (which may be mangled), unless
+// they have already been inserted. These actions record the invocation of the
+// template at the start and end of its body, and the position of each
+// {{template}} action before it is executed.
+func instrument(tree *parse.Tree, name string) {
+	if tree == nil || tree.Root == nil {
+		return
+	}
+	root := tree.Root
+	if len(root.Nodes) > 0 && isInstrumentationAction(root.Nodes[0]) {
+		return
+	}
+	instrumentCalls(tree, root)
+	name = demangle(name)
+	enter := newInstrumentationAction(tree, root.Pos, enterTemplateFuncName, name)
+	exit := newInstrumentationAction(tree, root.Pos, exitTemplateFuncName)
+	nodes := make([]parse.Node, 0, len(root.Nodes)+2)
+	nodes = append(nodes, enter)
+	nodes = append(nodes, root.Nodes...)
+	root.Nodes = append(nodes, exit)
+}
+
+// instrumentCalls inserts an action that records the position of each
+// {{template}} action in n before it.
+func instrumentCalls(tree *parse.Tree, n parse.Node) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		nodes := make([]parse.Node, 0, len(n.Nodes))
+		for _, c := range n.Nodes {
+			if tn, ok := c.(*parse.TemplateNode); ok {
+				pos, _ := tree.ErrorContext(tn)
+				nodes = append(nodes, newInstrumentationAction(tree, tn.Pos, callTemplateFuncName, pos))
+			}
+			instrumentCalls(tree, c)
+			nodes = append(nodes, c)
+		}
+		n.Nodes = nodes
+	case *parse.IfNode:
+		instrumentCalls(tree, n.List)
+		instrumentCalls(tree, n.ElseList)
+	case *parse.RangeNode:
+		instrumentCalls(tree, n.List)
+		instrumentCalls(tree, n.ElseList)
+	case *parse.WithNode:
+		instrumentCalls(tree, n.List)
+		instrumentCalls(tree, n.ElseList)
+	}
+}
+
+// newInstrumentationAction returns an action that calls the named function
+// with the given string arguments.
+func newInstrumentationAction(tree *parse.Tree, pos parse.Pos, funcName string, strs ...string) *parse.ActionNode {
+	args := []parse.Node{parse.NewIdentifier(funcName).SetTree(tree).SetPos(pos)}
+	for _, arg := range strs {
+		args = append(args, &parse.StringNode{
+			NodeType: parse.NodeString,
+			Pos:      pos,
+			Quoted:   strconv.Quote(arg),
+			Text:     arg,
+		})
+	}
+	return &parse.ActionNode{
+		NodeType: parse.NodeAction,
+		Pos:      pos,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Pos:      pos,
+			Cmds: []*parse.CommandNode{{
+				NodeType: parse.NodeCommand,
+				Pos:      pos,
+				Args:     args,
+			}},
+		},
+	}
+}
+
+// isInstrumentationAction reports whether n is an action inserted by
+// instrument.
+func isInstrumentationAction(n parse.Node) bool {
+	a, ok := n.(*parse.ActionNode)
+	if !ok || len(a.Pipe.Decl) != 0 || len(a.Pipe.Cmds) != 1 {
+		return false
+	}
+	id, ok := a.Pipe.Cmds[0].Args[0].(*parse.IdentifierNode)
+	return ok && instrumentationFuncs[id.Ident] != nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExecErrorCallChain(t *testing.T) {
+	tmpl := Must(New("page.html").Parse("" +
+		`{{define "page"}}<html>` + "\n" +
+		`{{range .}}{{template "item" .}}{{end}}</html>{{end}}` +
+		`{{define "item"}}<p>{{if .}}{{template "style" .}}{{end}}</p>{{end}}` +
+		`{{define "style"}}<style>{{.}}</style>{{end}}`))
+	err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "page", []string{"css"})
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("got error %v, want *ExecError", err)
+	}
+	want := []TemplateCall{
+		{Name: "page"},
+		{Name: "item", Pos: "page.html:2:22"},
+		{Name: "style", Pos: "page.html:2:92"},
+	}
+	if !reflect.DeepEqual(execErr.Chain, want) {
+		t.Errorf("got chain %+v, want %+v", execErr.Chain, want)
+	}
+	wantMsg := `template: page.html:2:148: executing "style" at <_sanitizeStyleSheet>: ` +
+		`error calling _sanitizeStyleSheet: expected a safehtml.StyleSheet value; ` +
+		`called from "item" at page.html:2:92; called from "page" at page.html:2:22`
+	if got := err.Error(); got != wantMsg {
+		t.Errorf("got error\n\t%s\nwant\n\t%s", got, wantMsg)
+	}
+}
+
+func TestExecErrorNotNested(t *testing.T) {
+	tmpl := Must(New("").Parse(`{{define "a"}}<script>{{.}}</script>{{end}}{{template "a" .}}`))
+	err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "a", "x")
+	var execErr *ExecError
+	if err == nil || errors.As(err, &execErr) {
+		t.Errorf("got error %#v, want error other than *ExecError", err)
+	}
+	err = tmpl.Execute(&bytes.Buffer{}, "x")
+	if !errors.As(err, &execErr) || len(execErr.Chain) != 2 {
+		t.Errorf("got error %#v, want *ExecError with chain of length 2", err)
+	}
+}
+
+func TestExecErrorElidesLongChains(t *testing.T) {
+	tmpl := Must(New("r").Parse(`{{define "r"}}{{if .}}{{template "r" slice . 1}}{{else}}<script>{{"x"}}</script>{{end}}{{end}}`))
+	err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "r", make([]int, 20))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got, want := strings.Count(err.Error(), "called from"), 10; got != want {
+		t.Errorf("got %d callers in error message, want %d: %s", got, want, err)
+	}
+	if !strings.Contains(err.Error(), "; ...; ") {
+		t.Errorf("error does not elide callers: %s", err)
+	}
+}
+
+func TestExecStatesInvalidated(t *testing.T) {
+	tmpl := Must(New("").Parse(`{{define "a"}}a{{end}}{{define "b"}}<p title="{{template "a"}}">{{end}}`))
+	if err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "a", nil); err != nil {
+		t.Fatal(err)
+	}
+	// Escaping b derives a new template from a, which must be visible to
+	// subsequent executions.
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, "b", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<p title="a">`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestConcurrentExecutions(t *testing.T) {
+	tmpl := Must(New("").Parse(`{{define "a"}}<b>{{template "b" .}}</b>{{end}}{{define "b"}}{{.}}{{end}}`))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				var b bytes.Buffer
+				if err := tmpl.ExecuteTemplateWithOptions(&b, "a", "x", WithMaxTemplateDepth(2)); err != nil {
+					t.Error(err)
+					return
+				}
+				if got, want := b.String(), "<b>x</b>"; got != want {
+					t.Errorf("got %q, want %q", got, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
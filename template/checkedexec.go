@@ -0,0 +1,93 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// An HTMLProblem is a structural problem in the output of a template, found
+// by an execution with WithHTMLCheck.
+type HTMLProblem struct {
+	// Line is the line of the output that the problem is on, starting at 1.
+	Line int
+	// Templates are the names of the templates that wrote the markup with
+	// the problem, such as the templates of both elements with a duplicate
+	// id when a page is composed from several templates.
+	Templates []string
+	// Description describes the problem, such as "<div> is not allowed in
+	// <p>".
+	Description string
+}
+
+func (p HTMLProblem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Description)
+}
+
+// executeChecked is like execute, but checks the output of st with
+// st.checkHTML, st.checkAccessibility, and st.checkSecrets before writing it
+// to wr.
+func (st *execState) executeChecked(wr io.Writer, name string, data interface{}) error {
+	var b bytes.Buffer
+	st.output = &b
+	if err := st.annotate(st.text.Lookup(name).Execute(&b, data)); err != nil {
+		return err
+	}
+	if st.checkHTML != nil {
+		checkHTML(b.Bytes(), st.templateAt, st.checkHTML)
+	}
+	if st.checkAccessibility != nil {
+		checkAccessibility(b.Bytes(), st.templateAt, st.checkAccessibility)
+	}
+	if st.checkSecrets != nil {
+		checkSecrets(b.Bytes(), st.templateAt, st.checkSecrets)
+	}
+	_, err := wr.Write(b.Bytes())
+	return err
+}
+
+// An outputSpan records that the output of an execution from offset on was
+// written by the named template, until the next outputSpan.
+type outputSpan struct {
+	offset int
+	name   string
+}
+
+// markOutput records the template that writes the output of a checked
+// execution from now on, after a template is entered or exited.
+func (st *execState) markOutput() {
+	if st.output == nil {
+		return
+	}
+	name := ""
+	if len(st.calls) > 0 {
+		name = st.calls[len(st.calls)-1].Name
+	}
+	st.outputSpans = append(st.outputSpans, outputSpan{st.output.Len(), name})
+}
+
+// templateAt returns the name of the template that wrote the output of a
+// checked execution at offset.
+func (st *execState) templateAt(offset int) string {
+	i := sort.Search(len(st.outputSpans), func(i int) bool { return st.outputSpans[i].offset > offset })
+	if i == 0 {
+		return ""
+	}
+	return st.outputSpans[i-1].name
+}
+
+// templateNames returns the names of templates a and b, or only a if they
+// are the same template.
+func templateNames(a, b string) []string {
+	if a == b {
+		return []string{a}
+	}
+	return []string{a, b}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build safehtml_minimal
+// +build safehtml_minimal
+
+package template
+
+// The structural and accessibility checks depend on golang.org/x/net/html,
+// so builds with the safehtml_minimal tag exclude WithHTMLCheck and
+// WithAccessibilityCheck, and the check functions that executeChecked calls
+// for them do nothing.
+
+func checkHTML(b []byte, templateAt func(int) string, report func(HTMLProblem)) {}
+
+func checkAccessibility(b []byte, templateAt func(int) string, report func(HTMLProblem)) {}
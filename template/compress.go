@@ -0,0 +1,199 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// A ContentEncoder compresses HTTP responses with a content coding.
+//
+// Encoders for content codings that are not supported by the standard
+// library, such as brotli, can be provided by other packages:
+//
+//	br := template.ContentEncoder{
+//		Name: "br",
+//		NewWriter: func(w io.Writer) template.EncoderWriter {
+//			return brotli.NewWriter(w)
+//		},
+//	}
+type ContentEncoder struct {
+	// Name is the name of the content coding in the Accept-Encoding and
+	// Content-Encoding headers, such as "gzip".
+	Name string
+	// NewWriter returns a writer that compresses the data written to it and
+	// writes the result to w.
+	NewWriter func(w io.Writer) EncoderWriter
+}
+
+// An EncoderWriter compresses the data written to it. Flush writes all
+// buffered data to the underlying writer, and Close flushes and finishes the
+// compressed stream.
+type EncoderWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// GzipEncoder compresses responses with gzip.
+var GzipEncoder = ContentEncoder{
+	Name: "gzip",
+	NewWriter: func(w io.Writer) EncoderWriter {
+		return gzip.NewWriter(w)
+	},
+}
+
+// A CompressedResponseWriter is an http.ResponseWriter that compresses the
+// body of a response as it is written, using the content coding negotiated
+// with the client. It implements http.Flusher, so that a response can still
+// be streamed in parts: Flush writes all the data compressed so far to the
+// client.
+type CompressedResponseWriter struct {
+	w       http.ResponseWriter
+	encoder *ContentEncoder
+	// enc compresses the body. It is created when the body is first written.
+	enc         EncoderWriter
+	wroteHeader bool
+	// noBody reports whether the response has no body to compress.
+	noBody bool
+}
+
+// CompressResponse returns a CompressedResponseWriter that writes the response
+// to r to w, compressed with the content coding of the first of encoders that
+// is most preferred by the Accept-Encoding header of r. If encoders is empty,
+// only GzipEncoder is considered. If r accepts none of the encoders, the
+// response is not compressed. Close must be called when the response is
+// complete.
+func CompressResponse(w http.ResponseWriter, r *http.Request, encoders ...ContentEncoder) *CompressedResponseWriter {
+	if len(encoders) == 0 {
+		encoders = []ContentEncoder{GzipEncoder}
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+	cw := &CompressedResponseWriter{w: w, noBody: r.Method == http.MethodHead}
+	accepted := parseAcceptEncoding(r.Header.Get("Accept-Encoding"))
+	best := 0.0
+	for i := range encoders {
+		q, ok := accepted[encoders[i].Name]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > best {
+			cw.encoder, best = &encoders[i], q
+		}
+	}
+	return cw
+}
+
+// parseAcceptEncoding returns the quality values of the content codings in the
+// value of an Accept-Encoding header.
+func parseAcceptEncoding(header string) map[string]float64 {
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[len("q="):], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		accepted[name] = q
+	}
+	return accepted
+}
+
+// Header returns the header map of the underlying ResponseWriter.
+func (cw *CompressedResponseWriter) Header() http.Header {
+	return cw.w.Header()
+}
+
+// WriteHeader sends the response header with statusCode, along with a
+// Content-Encoding header if the body is compressed.
+func (cw *CompressedResponseWriter) WriteHeader(statusCode int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	if statusCode == http.StatusNoContent || statusCode == http.StatusNotModified || statusCode < 200 {
+		cw.noBody = true
+	}
+	if cw.encoder != nil && !cw.noBody && cw.Header().Get("Content-Encoding") == "" {
+		cw.Header().Set("Content-Encoding", cw.encoder.Name)
+		// The length of the compressed body is not known in advance.
+		cw.Header().Del("Content-Length")
+	} else {
+		cw.encoder = nil
+	}
+	cw.w.WriteHeader(statusCode)
+}
+
+// Write compresses b and writes it to the underlying ResponseWriter.
+func (cw *CompressedResponseWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.encoder == nil {
+		return cw.w.Write(b)
+	}
+	if cw.enc == nil {
+		cw.enc = cw.encoder.NewWriter(cw.w)
+	}
+	return cw.enc.Write(b)
+}
+
+// Flush writes the data compressed so far to the underlying ResponseWriter,
+// and flushes it if it implements http.Flusher.
+func (cw *CompressedResponseWriter) Flush() {
+	if cw.enc != nil {
+		cw.enc.Flush()
+	}
+	if f, ok := cw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close finishes the compressed body. It does not close the underlying
+// ResponseWriter.
+func (cw *CompressedResponseWriter) Close() error {
+	if cw.enc == nil {
+		return nil
+	}
+	return cw.enc.Close()
+}
+
+// ExecuteHTTP executes t with data and opts, and writes the output to w as
+// the response to r. The output is compressed with gzip if r accepts it. It is
+// compressed and written as it is produced, rather than after the whole page
+// has been rendered into memory. The Content-Type header is set to
+// "text/html; charset=utf-8" unless it has already been set.
+//
+// To compress with other content codings, or to flush parts of the response
+// to the client while it is being rendered, use CompressResponse and one of
+// the Execute methods instead.
+//
+// If an error occurs, part of the response may already have been written to
+// w.
+func (t *Template) ExecuteHTTP(w http.ResponseWriter, r *http.Request, data interface{}, opts ...ExecuteOption) error {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	cw := CompressResponse(w, r)
+	err := t.ExecuteWithOptions(cw, data, opts...)
+	if closeErr := cw.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExecuteHTTP(t *testing.T) {
+	tmpl := Must(New("page").Parse(`<p>{{.}}</p>`))
+	const want = `<p>&lt;x&gt;</p>`
+	for _, test := range [...]struct {
+		acceptEncoding, wantEncoding string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"br, gzip;q=0.5", "gzip"},
+		{"gzip;q=0", ""},
+		{"*", "gzip"},
+		{"identity", ""},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", test.acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		if err := tmpl.ExecuteHTTP(w, r, "<x>"); err != nil {
+			t.Fatalf("%q: %v", test.acceptEncoding, err)
+		}
+		if got := w.Header().Get("Content-Encoding"); got != test.wantEncoding {
+			t.Errorf("%q: got Content-Encoding %q, want %q", test.acceptEncoding, got, test.wantEncoding)
+		}
+		if got, want := w.Header().Get("Content-Type"), "text/html; charset=utf-8"; got != want {
+			t.Errorf("%q: got Content-Type %q, want %q", test.acceptEncoding, got, want)
+		}
+		if got, want := w.Header().Get("Vary"), "Accept-Encoding"; got != want {
+			t.Errorf("%q: got Vary %q, want %q", test.acceptEncoding, got, want)
+		}
+		body := io.Reader(w.Body)
+		if test.wantEncoding == "gzip" {
+			zr, err := gzip.NewReader(w.Body)
+			if err != nil {
+				t.Fatalf("%q: %v", test.acceptEncoding, err)
+			}
+			body = zr
+		}
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			t.Fatalf("%q: %v", test.acceptEncoding, err)
+		}
+		if got := string(b); got != want {
+			t.Errorf("%q: got body %q, want %q", test.acceptEncoding, got, want)
+		}
+	}
+}
+
+func TestCompressResponseFlush(t *testing.T) {
+	deflate := ContentEncoder{
+		Name: "deflate",
+		NewWriter: func(w io.Writer) EncoderWriter {
+			zw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return zw
+		},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip;q=0.8, deflate")
+	w := httptest.NewRecorder()
+	cw := CompressResponse(w, r, GzipEncoder, deflate)
+	head := Must(New("head").Parse(`<head><title>{{.}}</title></head>`))
+	body := Must(New("body").Parse(`<body>{{.}}</body>`))
+	if err := head.Execute(cw, "Title"); err != nil {
+		t.Fatal(err)
+	}
+	cw.Flush()
+	if !w.Flushed {
+		t.Error("Flush did not flush the underlying ResponseWriter")
+	}
+	flushed := w.Body.Len()
+	if flushed == 0 {
+		t.Error("Flush did not write the compressed data")
+	}
+	if err := body.Execute(cw, "Body"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "deflate" {
+		t.Errorf("got Content-Encoding %q, want deflate", got)
+	}
+	b, err := ioutil.ReadAll(flate.NewReader(w.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `<head><title>Title</title></head><body>Body</body>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCompressResponseNoBody(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	cw := CompressResponse(w, r)
+	cw.WriteHeader(http.StatusNotModified)
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("got Content-Encoding %q for response without a body", got)
+	}
+	if !strings.Contains(w.Header().Get("Vary"), "Accept-Encoding") {
+		t.Error("Vary header does not contain Accept-Encoding")
+	}
+}
@@ -36,6 +36,10 @@ type context struct {
 	// the rel attribute has not already been parsed in the current element, or if the
 	// value of the rel attribute cannot be determined at parse time.
 	linkRel string
+	// afterSpace is true in stateTag after whitespace at the end of the
+	// template text, as in `<input {{.}}`, where an action emitting an
+	// attribute need not separate it from the preceding text.
+	afterSpace bool
 }
 
 // eq returns whether Context c is equal to Context d.
@@ -46,7 +50,8 @@ func (c context) eq(d context) bool {
 		c.attr.eq(d.attr) &&
 		c.err == d.err &&
 		c.scriptType == d.scriptType &&
-		c.linkRel == d.linkRel
+		c.linkRel == d.linkRel &&
+		c.afterSpace == d.afterSpace
 }
 
 // state describes a high-level HTML parser state.
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	gocontext "context"
+	"fmt"
+	"reflect"
+	"text/template"
+)
+
+// A FuncError is returned by an execution in which a function added using
+// ContextFuncs panics or does not return before the execution context is done.
+// The error returned by the execution identifies the template and the
+// position of the action that called the function, and wraps the FuncError.
+type FuncError struct {
+	// Name is the name of the function.
+	Name string
+	// Panic is the value the function panicked with, or nil if the function
+	// did not panic.
+	Panic interface{}
+	// Err is the error of the execution context if the function did not
+	// return before the context was done, or nil otherwise.
+	Err error
+}
+
+func (e *FuncError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("html/template: function %q did not return: %v", e.Name, e.Err)
+	}
+	return fmt.Sprintf("html/template: function %q panicked: %v", e.Name, e.Panic)
+}
+
+// Unwrap returns e.Err, so that errors.Is reports whether an execution failed
+// because of context.DeadlineExceeded or context.Canceled.
+func (e *FuncError) Unwrap() error {
+	return e.Err
+}
+
+// WithContext returns an ExecuteOption that bounds calls to the functions
+// added using ContextFuncs by ctx.
+func WithContext(ctx gocontext.Context) ExecuteOption {
+	return func(c *executeConfig) {
+		c.ctx = ctx
+	}
+}
+
+// ContextFuncs is like Funcs, but hardens the execution of templates against
+// misbehaving functions in funcMap:
+//
+//   - If a function panics, execution stops with a *FuncError instead of
+//     propagating the panic.
+//   - In executions with WithContext, each call to a function returns as soon
+//     as the context is done, and execution stops with a *FuncError that wraps
+//     the error of the context. The function itself keeps running until it
+//     returns, so functions should observe the context if possible.
+//
+// If the first parameter of a function has type context.Context, the
+// execution context is passed as that argument, and the remaining parameters
+// are supplied by the template. Executions without WithContext pass
+// context.Background().
+//
+// Like Funcs, ContextFuncs must be called before the template is parsed. The
+// return value is the template, so calls can be chained.
+func (t *Template) ContextFuncs(funcMap FuncMap) *Template {
+	t.nameSpace.mu.Lock()
+	defer t.nameSpace.mu.Unlock()
+	if t.nameSpace.contextFuncs == nil {
+		t.nameSpace.contextFuncs = make(template.FuncMap)
+	}
+	for name, fn := range funcMap {
+		t.nameSpace.contextFuncs[name] = fn
+	}
+	t.nameSpace.gen++
+	t.text.Funcs(bindContext(gocontext.Background, template.FuncMap(funcMap)))
+	return t
+}
+
+var contextType = reflect.TypeOf((*gocontext.Context)(nil)).Elem()
+
+// bindContext returns wrappers for the functions in funcMap whose calls are
+// bounded by the context that ctx returns at the time of each call.
+func bindContext(ctx func() gocontext.Context, funcMap template.FuncMap) template.FuncMap {
+	ret := make(template.FuncMap, len(funcMap))
+	for name, fn := range funcMap {
+		ret[name] = bindFuncContext(ctx, name, fn)
+	}
+	return ret
+}
+
+func bindFuncContext(ctx func() gocontext.Context, name string, fn interface{}) interface{} {
+	v := reflect.ValueOf(fn)
+	typ := v.Type()
+	if typ.Kind() != reflect.Func {
+		panic(fmt.Sprintf("value for %s not a function", name))
+	}
+	var out reflect.Type
+	switch {
+	case typ.NumOut() == 1:
+		out = typ.Out(0)
+	case typ.NumOut() == 2 && typ.Out(1) == errorType:
+		out = typ.Out(0)
+	default:
+		panic(fmt.Sprintf("function %s has %d return values; should be 1 or 2", name, typ.NumOut()))
+	}
+	takesContext := typ.NumIn() > 0 && typ.In(0) == contextType
+	var in []reflect.Type
+	for i := 0; i < typ.NumIn(); i++ {
+		if i > 0 || !takesContext {
+			in = append(in, typ.In(i))
+		}
+	}
+	wrapped := reflect.FuncOf(in, []reflect.Type{out, errorType}, typ.IsVariadic())
+	return reflect.MakeFunc(wrapped, func(args []reflect.Value) []reflect.Value {
+		ctx := ctx()
+		if takesContext {
+			args = append([]reflect.Value{reflect.ValueOf(&ctx).Elem()}, args...)
+		}
+		results, err := callWithContext(ctx, name, v, args)
+		if err != nil {
+			return []reflect.Value{reflect.Zero(out), reflect.ValueOf(&err).Elem()}
+		}
+		if len(results) == 1 {
+			results = append(results, reflect.Zero(errorType))
+		}
+		return results
+	}).Interface()
+}
+
+// callWithContext calls fn with args, and returns early if ctx is done
+// before fn returns.
+func callWithContext(ctx gocontext.Context, name string, fn reflect.Value, args []reflect.Value) ([]reflect.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &FuncError{Name: name, Err: err}
+	}
+	type result struct {
+		values []reflect.Value
+		err    error
+	}
+	call := func() (r result) {
+		defer func() {
+			if p := recover(); p != nil {
+				r.err = &FuncError{Name: name, Panic: p}
+			}
+		}()
+		if fn.Type().IsVariadic() {
+			return result{values: fn.CallSlice(args)}
+		}
+		return result{values: fn.Call(args)}
+	}
+	if ctx.Done() == nil {
+		// ctx can never be done, so avoid starting a goroutine.
+		r := call()
+		return r.values, r.err
+	}
+	done := make(chan result, 1)
+	go func() {
+		done <- call()
+	}()
+	select {
+	case r := <-done:
+		return r.values, r.err
+	case <-ctx.Done():
+		return nil, &FuncError{Name: name, Err: ctx.Err()}
+	}
+}
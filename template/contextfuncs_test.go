@@ -0,0 +1,105 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	gocontext "context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContextFuncs(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+	tmpl := Must(New("page").ContextFuncs(FuncMap{
+		"upper": strings.ToUpper,
+		"join":  func(sep string, s ...string) string { return strings.Join(s, sep) },
+		"fail":  func() (string, error) { return "", errors.New("failed") },
+		"boom":  func() string { panic("boom") },
+		"hang":  func() string { <-hang; return "" },
+		"deadline": func(ctx gocontext.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		},
+	}).Parse(`{{define "upper"}}{{upper .}}{{end}}` +
+		`{{define "join"}}{{join "," "a" "b"}}{{end}}` +
+		`{{define "fail"}}{{fail}}{{end}}` +
+		`{{define "boom"}}<p>{{boom}}</p>{{end}}` +
+		`{{define "hang"}}{{hang}}{{end}}` +
+		`{{define "deadline"}}{{deadline}}{{end}}`))
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), 20*time.Millisecond)
+	defer cancel()
+	for _, test := range [...]struct {
+		name string
+		opts []ExecuteOption
+		want string
+		err  string
+	}{
+		{name: "upper", want: "FOO"},
+		{name: "join", want: "a,b"},
+		{name: "fail", err: "error calling fail: failed"},
+		{name: "boom", err: `template: page:1:134: executing "boom" at <boom>: error calling boom: html/template: function "boom" panicked: boom`},
+		{name: "deadline", want: "false"},
+		{name: "deadline", opts: []ExecuteOption{WithContext(ctx)}, want: "true"},
+		{name: "hang", opts: []ExecuteOption{WithContext(ctx)}, err: `function "hang" did not return: context deadline exceeded`},
+	} {
+		var b bytes.Buffer
+		err := tmpl.ExecuteTemplateWithOptions(&b, test.name, "foo", test.opts...)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: got error %v, want error containing %q", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestContextFuncsErrors(t *testing.T) {
+	tmpl := Must(New("").ContextFuncs(FuncMap{
+		"boom":  func() string { panic(errors.New("boom")) },
+		"sleep": func() string { time.Sleep(time.Second); return "" },
+	}).Parse(`{{define "boom"}}{{boom}}{{end}}{{define "sleep"}}{{sleep}}{{end}}`))
+
+	err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "boom", nil)
+	var funcErr *FuncError
+	if !errors.As(err, &funcErr) || funcErr.Name != "boom" || funcErr.Panic == nil {
+		t.Errorf("got error %#v, want *FuncError for panic of boom", err)
+	}
+
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+	err = tmpl.ExecuteTemplateWithOptions(&bytes.Buffer{}, "sleep", nil, WithContext(ctx))
+	if !errors.Is(err, gocontext.Canceled) {
+		t.Errorf("got error %v, want error wrapping context.Canceled", err)
+	}
+	if !errors.As(err, &funcErr) || funcErr.Name != "sleep" {
+		t.Errorf("got error %#v, want *FuncError for sleep", err)
+	}
+}
+
+func TestFuncsReplacesContextFuncs(t *testing.T) {
+	tmpl := New("").ContextFuncs(FuncMap{"f": func() string { return "context" }})
+	tmpl.Funcs(FuncMap{"f": func() string { return "plain" }})
+	Must(tmpl.Parse(`{{f}}`))
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	defer cancel()
+	var b bytes.Buffer
+	if err := tmpl.ExecuteWithOptions(&b, nil, WithContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "plain"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A DepthError is returned by an execution whose template invocations exceed
+// the maximum template call depth set using MaxTemplateDepth or
+// WithMaxTemplateDepth.
+type DepthError struct {
+	// MaxDepth is the maximum template call depth that was exceeded.
+	MaxDepth int
+	// Chain contains the names of the templates in the invocation chain,
+	// starting with the executed template and ending with the template whose
+	// invocation exceeded MaxDepth.
+	Chain []string
+}
+
+func (e *DepthError) Error() string {
+	chain := e.Chain
+	const elided = 4
+	if len(chain) > 2*elided+1 {
+		chain = append(append(append([]string(nil), chain[:elided]...), "..."), chain[len(chain)-elided:]...)
+	}
+	return fmt.Sprintf("html/template: exceeded maximum template call depth (%d): %s", e.MaxDepth, strings.Join(chain, " -> "))
+}
+
+// MaxTemplateDepth sets the maximum depth of nested template invocations
+// during executions of t and all templates associated with it, counting the
+// executed template itself. Executions that would exceed depth fail with a
+// *DepthError instead of recursing further. A depth of 0 or less removes the
+// limit. The return value is the template, so calls can be chained.
+func (t *Template) MaxTemplateDepth(depth int) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.maxTemplateDepth = depth
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// WithMaxTemplateDepth returns an ExecuteOption that overrides the maximum
+// template call depth set using MaxTemplateDepth for a single execution.
+func WithMaxTemplateDepth(depth int) ExecuteOption {
+	return func(c *executeConfig) {
+		c.maxDepth = depth
+	}
+}
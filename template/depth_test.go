@@ -0,0 +1,122 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const recursiveTemplates = `` +
+	`{{define "list"}}<ul>{{range .}}{{template "item" .}}{{end}}</ul>{{end}}` +
+	`{{define "item"}}<li title="{{template "title" .}}">{{template "list" .Children}}</li>{{end}}` +
+	`{{define "title"}}{{.Name}}{{end}}`
+
+type node struct {
+	Name     string
+	Children []*node
+}
+
+func chain(depth int) []*node {
+	n := &node{Name: "leaf"}
+	for i := 1; i < depth; i++ {
+		n = &node{Name: "n", Children: []*node{n}}
+	}
+	return []*node{n}
+}
+
+func TestMaxTemplateDepth(t *testing.T) {
+	for _, test := range [...]struct {
+		desc      string
+		max       int
+		opts      []ExecuteOption
+		data      []*node
+		wantChain []string
+	}{
+		{
+			desc: "within limit",
+			max:  5,
+			data: chain(2),
+		},
+		{
+			desc: "siblings do not accumulate depth",
+			max:  3,
+			data: []*node{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}},
+		},
+		{
+			desc:      "limit exceeded",
+			max:       5,
+			data:      chain(3),
+			wantChain: []string{"list", "item", "list", "item", "list", "item"},
+		},
+		{
+			desc:      "limit exceeded in attribute context",
+			max:       2,
+			data:      chain(1),
+			wantChain: []string{"list", "item", "title"},
+		},
+		{
+			desc: "limit removed by option",
+			max:  2,
+			opts: []ExecuteOption{WithMaxTemplateDepth(0)},
+			data: chain(10),
+		},
+		{
+			desc:      "limit set by option",
+			opts:      []ExecuteOption{WithMaxTemplateDepth(1)},
+			data:      chain(1),
+			wantChain: []string{"list", "item"},
+		},
+	} {
+		tmpl := Must(New("root").Parse(recursiveTemplates)).MaxTemplateDepth(test.max)
+		err := tmpl.ExecuteTemplateWithOptions(&bytes.Buffer{}, "list", test.data, test.opts...)
+		if test.wantChain == nil {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.desc, err)
+			}
+			continue
+		}
+		var depthErr *DepthError
+		if !errors.As(err, &depthErr) {
+			t.Errorf("%s: got error %v, want *DepthError", test.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(depthErr.Chain, test.wantChain) {
+			t.Errorf("%s: got chain %q, want %q", test.desc, depthErr.Chain, test.wantChain)
+		}
+	}
+}
+
+func TestMaxTemplateDepthMutualRecursion(t *testing.T) {
+	tmpl := Must(New("a").Parse(`{{define "a"}}a{{template "b" .}}{{end}}{{define "b"}}b{{template "a" .}}{{end}}`)).MaxTemplateDepth(20)
+	var b bytes.Buffer
+	err := tmpl.ExecuteTemplate(&b, "a", nil)
+	var depthErr *DepthError
+	if !errors.As(err, &depthErr) {
+		t.Fatalf("got error %v, want *DepthError", err)
+	}
+	if depthErr.MaxDepth != 20 || len(depthErr.Chain) != 21 {
+		t.Errorf("got MaxDepth %d and chain of length %d, want 20 and 21", depthErr.MaxDepth, len(depthErr.Chain))
+	}
+	if want := "exceeded maximum template call depth (20): a -> b -> a -> b -> ... -> b -> a -> b -> a"; !strings.Contains(err.Error(), want) {
+		t.Errorf("got error\n\t%s\nwant error containing\n\t%s", err, want)
+	}
+}
+
+func TestMaxTemplateDepthOutput(t *testing.T) {
+	tmpl := Must(New("root").Parse(recursiveTemplates)).MaxTemplateDepth(10)
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, "list", chain(2)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<ul><li title="n"><ul><li title="leaf"><ul></ul></li></ul></li></ul>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
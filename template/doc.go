@@ -204,6 +204,13 @@ template's HTML output. Note that in attribute value contexts, HTML escaping
 will always take place, whether or not context-specific sanitization is
 performed. More details can be found at the end of this section.
 
+Wherever safehtml.HTML values are accepted, values implementing safehtml.HTMLer
+are accepted too: the autosanitizer calls their HTML method and uses the
+resulting safehtml.HTML value. This lets types define how they render
+themselves, without every action wrapping them in a function call. Likewise,
+safehtml.LazyHTML values are called when the action interpolating them is
+executed, so that fragments in branches that do not execute are not computed.
+
 In certain contexts, the autosanitizer allows values only of that context's
 "Safe types". Any other values will trigger an error and abort template
 processing. For example, the template
@@ -238,6 +245,26 @@ remains unchanged after _sanitizeTrustedResourceURLOrURL, X will still be
 URL-normalized and HTML-escaped. Likewise, Y will still be HTML-escaped even if
 its string form is left unchanged by _sanitizeIdentifier.
 
+# Attributes
+
+An action in the start tag of an element, outside any attribute, emits a
+whole attribute, and must output a safehtml.Attribute value:
+
+	<button type="submit" {{ .Disabled }}>Save</button>
+
+Since the name of the attribute is only known at run time, the template checks
+when it is executed that the element and the template policy allow the
+attribute, and sanitizes its value as it would in the attribute's value context.
+For example, if .Disabled is safehtml.AttributeFromConstant("disabled", true),
+the output is
+
+	<button type="submit" disabled="">Save</button>
+
+while a false value or the zero safehtml.Attribute emits nothing. Actions
+must not emit the rel attribute of link elements or the type attribute of
+script elements, which determine how the rest of the element is sanitized, or
+occur in the start tags of elements whose name depends on a condition.
+
 # Substitutions in URLs
 
 Values of any type may be substituted into attribute values in URL and
@@ -268,7 +295,8 @@ percent-encoding character triplet (e.g. /fo%6), does not contain whitespace or
 characters, and one of the following is true:
   - The prefix has a safe scheme (i.e. http, https, mailto, or ftp).
   - The prefix has the data scheme with base64 encoding and an allowed audio, image,
-    or video MIME type (e.g. data:img/jpeg;base64, data:video/mp4;base64).
+    or video MIME type (e.g. data:img/jpeg;base64, data:video/mp4;base64). The
+    allowed MIME types can be configured with Policy.AllowDataURLMIMETypes.
   - The prefix has no scheme at all, and cannot be interpreted as a scheme prefix (e.g. /path).
 
 A URL prefix is considered safe in a TrustedResourceURL sanitization context if it does
@@ -5,11 +5,14 @@
 package template
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"text/template/parse"
 )
 
-// Error describes a problem encountered during template Escaping.
+// Error describes a problem encountered while parsing, escaping, or executing
+// a template.
 type Error struct {
 	// ErrorCode describes the kind of error.
 	ErrorCode ErrorCode
@@ -22,6 +25,17 @@ type Error struct {
 	Line int
 	// Description is a human-readable description of the problem.
 	Description string
+	// Snippet is the line of template source containing Node followed by a
+	// line with a caret marking the position of Node, or the empty string if
+	// the source is unknown or snippets are disabled using
+	// Template.ErrorSnippets.
+	Snippet string
+	// SanitizationContext is the sanitization context of the action that
+	// caused the problem, or 0 if it is unknown.
+	SanitizationContext SanitizationContext
+	// Err is the underlying error for problems reported by package
+	// text/template while parsing or executing a template, or nil.
+	Err error
 }
 
 // ErrorCode is a code for a kind of error.
@@ -258,13 +272,163 @@ const (
 	//     });
 	//     </script>
 	ErrCSPCompatibility
-	// All JS templates inside script literals have to be balanced; otherwise a concatenation such as
-	// <script>alert(`x{{.data}}`</script> can contain XSS if data contains user-controlled escaped strings (e.g. as JSON).
+
+	// ErrUnbalancedJsTemplate: "... there can be no safehtml/template insertion
+	//   points or actions inside an ES6 template, and all ES6 templates must be closed"
+	// Example:
+	//   <script>alert(`x{{.data}}`</script>
+	// Discussion:
+	//   All JS templates inside script literals have to be balanced; otherwise
+	//   a concatenation such as the example can contain XSS if data contains
+	//   user-controlled escaped strings (e.g. as JSON).
 	ErrUnbalancedJsTemplate
+
+	// ErrPolicyViolation: "... is forbidden by the template policy"
+	// Examples:
+	//   <iframe src="/embed"></iframe>
+	//   where the Policy forbids the iframe element
+	//   <img src="data:image/png;base64,{{.}}">
+	//   where the Policy forbids the data URL scheme
+	// Discussion:
+	//   The template contains an element, attribute, or action that is
+	//   allowed by default but is forbidden by the Policy set using
+	//   Template.WithPolicy.
+	ErrPolicyViolation
+
+	// ErrActionInName: "actions must not affect element or attribute names"
+	// Example:
+	//   <a {{.X}}>
+	//   <a {{.X}}="/">
+	// Discussion:
+	//   The names of elements and attributes determine how their content is
+	//   sanitized, so they cannot be computed at runtime.
+	ErrActionInName
+
+	// ErrUnquotedAttr: "unquoted attribute values disallowed"
+	// Example:
+	//   <a title={{.X}}>
+	// Discussion:
+	//   Quote the attribute value:
+	//   <a title="{{.X}}">
+	ErrUnquotedAttr
+
+	// ErrDisallowedAttr: "actions must not occur in the ... attribute value
+	//   context of a ... element"
+	// Example:
+	//   <a onclick="{{.X}}">
+	//   <custom-element title="{{.X}}">
+	// Discussion:
+	//   Only attributes whose semantics are known, in elements whose
+	//   semantics are known, may contain actions. Event handler attributes
+	//   may never contain actions.
+	ErrDisallowedAttr
+
+	// ErrDisallowedElementContent: "actions must not occur in the element
+	//   content context of a ... element"
+	// Example:
+	//   <custom-element>{{.X}}</custom-element>
+	// Discussion:
+	//   Only elements whose semantics are known may contain actions.
+	ErrDisallowedElementContent
+
+	// ErrBranchSanitization: "conditional branches end in different ...
+	//   sanitization contexts"
+	// Example:
+	//   <a {{if .C}}href{{else}}title{{end}}="{{.X}}">
+	// Discussion:
+	//   The value of {{.X}} would require a different sanitizer depending on
+	//   the branch taken. Move the action into each branch.
+	ErrBranchSanitization
+
+	// ErrPartialSubstitution: "partial substitutions are disallowed in the
+	//   ... attribute value context"
+	// Example:
+	//   <a target="_{{.X}}">
+	// Discussion:
+	//   Attributes that accept only a fixed set of values, such as target and
+	//   dir, must be set entirely by a single action.
+	ErrPartialSubstitution
+
+	// ErrIncompleteCharRef: "prefix ... ends with an incomplete HTML character
+	//   reference"
+	// Example:
+	//   <a href="/search?a=b&{{.X}}">
+	// Discussion:
+	//   The action could complete a character reference, changing the
+	//   meaning of the prefix. Use "&amp;" instead of "&".
+	ErrIncompleteCharRef
+
+	// ErrAmbigURLPrefix: "actions must not occur after an ambiguous URL prefix"
+	// Example:
+	//   <a href="{{if .C}}/path/{{else}}/search?q={{end}}{{.X}}">
+	// Discussion:
+	//   The sanitization of {{.X}} depends on the URL prefix it follows,
+	//   which cannot be determined at escaping time. As with
+	//   ErrAmbigContext, move {{.X}} into the condition.
+	ErrAmbigURLPrefix
+
+	// ErrUnsafeURLPrefix: "URL prefix ... contains an unsafe scheme",
+	//   "... might be interpreted as part of a scheme",
+	//   "... is a disallowed TrustedResourceURL prefix"
+	// Example:
+	//   <a href="javascript:{{.X}}">
+	//   <a href="java{{.X}}">
+	//   <script src="http://{{.X}}"></script>
+	// Discussion:
+	//   An action cannot follow a URL prefix that is unsafe on its own, or
+	//   that the action could turn into an unsafe one.
+	ErrUnsafeURLPrefix
+
+	// ErrExpectedSafeType: "expected a safehtml.... value"
+	// Example:
+	//   <script>{{.X}}</script>
+	//   where {{.X}} evaluates to a string rather than a safehtml.Script
+	// Discussion:
+	//   This error occurs at runtime. Some contexts cannot be sanitized, so
+	//   values in them must have one of the safe types in package safehtml.
+	ErrExpectedSafeType
+
+	// ErrUnexpectedValue: "expected one of the following strings: ...",
+	//   `cannot substitute ... after TrustedResourceURL prefix: ".." is disallowed`
+	// Example:
+	//   <a target="{{.X}}">
+	//   where {{.X}} evaluates to "_top"
+	// Discussion:
+	//   This error occurs at runtime. The value of the action is a string
+	//   that is not allowed in its context.
+	ErrUnexpectedValue
+
+	// ErrParse: "template: ...", "cannot Parse after Execute"
+	// Example:
+	//   {{if .C}}
+	// Discussion:
+	//   The template text is not well-formed, or the template has already
+	//   been executed. Err is the error reported by package text/template,
+	//   if any.
+	ErrParse
+
+	// ErrExec: "template: ...: executing ..."
+	// Discussion:
+	//   This error occurs at runtime for problems that are not caused by
+	//   sanitization, such as a failed function call or write. Err is the
+	//   underlying error; use errors.As to test for *ExecError, *DepthError,
+	//   *FallbackError, or *FuncError.
+	ErrExec
+
+	// ErrParseLimit: "template ... has ... bytes, exceeding the limit of ..."
+	// Discussion:
+	//   The text passed to Parse exceeds a limit set using MaxParseSize or
+	//   MaxParseNodes. Err is a *ParseLimitError.
+	ErrParseLimit
 )
 
 func (e *Error) Error() string {
 	switch {
+	case e.Err != nil:
+		return e.Err.Error()
+	case e.Node != nil && e.Snippet != "":
+		loc, _ := (*parse.Tree)(nil).ErrorContext(e.Node)
+		return fmt.Sprintf("html/template:%s: %s\n\t%s", loc, e.Description, strings.ReplaceAll(e.Snippet, "\n", "\n\t"))
 	case e.Node != nil:
 		loc, _ := (*parse.Tree)(nil).ErrorContext(e.Node)
 		return fmt.Sprintf("html/template:%s: %s", loc, e.Description)
@@ -279,5 +443,86 @@ func (e *Error) Error() string {
 // errorf creates an error given a format string f and args.
 // The template Name still needs to be supplied.
 func errorf(k ErrorCode, node parse.Node, line int, f string, args ...interface{}) *Error {
-	return &Error{k, node, "", line, fmt.Sprintf(f, args...)}
+	return &Error{ErrorCode: k, Node: node, Line: line, Description: fmt.Sprintf(f, args...)}
+}
+
+// Unwrap returns e.Err.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// A codedError is an error reported by a sanitizer or URL prefix validator
+// that carries the ErrorCode of the problem, and its sanitization context if
+// known.
+type codedError struct {
+	code ErrorCode
+	sc   SanitizationContext
+	msg  string
+}
+
+func (e *codedError) Error() string {
+	return e.msg
+}
+
+func codedErrorf(k ErrorCode, f string, args ...interface{}) error {
+	return &codedError{code: k, msg: fmt.Sprintf(f, args...)}
+}
+
+// sanitizationErrorf is like codedErrorf, but records that the problem
+// occurred in sanitization context sc.
+func sanitizationErrorf(sc SanitizationContext, k ErrorCode, f string, args ...interface{}) error {
+	return &codedError{code: k, sc: sc, msg: fmt.Sprintf(f, args...)}
+}
+
+// errorContext returns the sanitization context carried by err, or 0 if
+// there is none.
+func errorContext(err error) SanitizationContext {
+	var cerr *codedError
+	if errors.As(err, &cerr) {
+		return cerr.sc
+	}
+	return 0
+}
+
+// errorCode returns the ErrorCode carried by err, or def if there is none.
+func errorCode(err error, def ErrorCode) ErrorCode {
+	var cerr *codedError
+	if errors.As(err, &cerr) {
+		return cerr.code
+	}
+	return def
+}
+
+// parseError returns err, which was returned by package text/template while
+// parsing the template with the given name, as an *Error.
+func parseError(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	code := ErrParse
+	if _, ok := err.(*ParseLimitError); ok {
+		code = ErrParseLimit
+	}
+	return &Error{ErrorCode: code, Name: name, Description: err.Error(), Err: err}
+}
+
+// execError returns err, which was returned by an execution of the template
+// with the given name, as an *Error.
+func execError(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	return &Error{
+		ErrorCode:           errorCode(err, ErrExec),
+		Name:                name,
+		Description:         err.Error(),
+		SanitizationContext: errorContext(err),
+		Err:                 err,
+	}
 }
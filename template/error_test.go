@@ -0,0 +1,107 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestErrorCodes(t *testing.T) {
+	for _, test := range [...]struct {
+		input string
+		data  interface{}
+		want  ErrorCode
+	}{
+		{`{{if .C}}`, nil, ErrParse},
+		{`<a t{{.X}}>`, nil, ErrActionInName},
+		{`<a {{.}}>`, "title", ErrUnexpectedValue},
+		{`<a title={{.X}}>`, nil, ErrUnquotedAttr},
+		{`<a onclick="{{.X}}">`, nil, ErrDisallowedAttr},
+		{`<custom-element>{{.X}}</custom-element>`, nil, ErrDisallowedElementContent},
+		{`<a {{if .C}}href{{else}}title{{end}}="{{.X}}">`, nil, ErrBranchSanitization},
+		{`<a target="_{{.X}}">`, nil, ErrPartialSubstitution},
+		{`<a href="/search?a=b&{{.X}}">`, nil, ErrIncompleteCharRef},
+		{`<a href="{{if .C}}/path/{{else}}/search?q={{end}}{{.X}}">`, nil, ErrAmbigURLPrefix},
+		{`<a href="javascript:{{.X}}">`, nil, ErrUnsafeURLPrefix},
+		{`<a href="java{{.X}}">`, nil, ErrUnsafeURLPrefix},
+		{`<script src="http://{{.X}}"></script>`, nil, ErrUnsafeURLPrefix},
+		{`<script>{{.}}</script>`, "alert(1)", ErrExpectedSafeType},
+		{`<a target="{{.}}">`, "_top", ErrUnexpectedValue},
+		{`<script src="/path/{{.}}"></script>`, "../secret", ErrUnexpectedValue},
+		{`{{.X}}`, 1, ErrExec},
+	} {
+		tmpl, err := New("page").Parse(stringConstant(test.input))
+		if err == nil {
+			err = tmpl.Execute(ioutil.Discard, test.data)
+		}
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%q: got error %v, want *Error", test.input, err)
+			continue
+		}
+		if e.ErrorCode != test.want {
+			t.Errorf("%q: got error code %d, want %d (error: %v)", test.input, e.ErrorCode, test.want, err)
+		}
+	}
+}
+
+func TestErrorWrapsTextTemplateError(t *testing.T) {
+	_, err := New("page").Parse(`{{end}}`)
+	var e *Error
+	if !errors.As(err, &e) || e.ErrorCode != ErrParse || e.Err == nil {
+		t.Fatalf("got parse error %#v, want *Error with code ErrParse wrapping the text/template error", err)
+	}
+	if got, want := err.Error(), e.Err.Error(); got != want {
+		t.Errorf("got message %q, want message of text/template error %q", got, want)
+	}
+
+	tmpl := Must(New("page").Parse(`<p>{{.X}}</p>`))
+	err = tmpl.Execute(ioutil.Discard, 1)
+	var execErr template.ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("got execution error %#v, want error wrapping template.ExecError", err)
+	}
+	if !strings.Contains(err.Error(), `executing "page"`) {
+		t.Errorf("got message %q, want message of template.ExecError", err)
+	}
+
+	err = tmpl.ExecuteTemplate(ioutil.Discard, "missing", nil)
+	if !errors.As(err, &e) || e.ErrorCode != ErrNoSuchTemplate {
+		t.Errorf("got error %#v, want *Error with code ErrNoSuchTemplate", err)
+	}
+	if _, err := tmpl.Parse(`x`); !errors.As(err, &e) || e.ErrorCode != ErrParse {
+		t.Errorf("got error %#v for Parse after Execute, want *Error with code ErrParse", err)
+	}
+}
+
+func TestErrorSanitizationContext(t *testing.T) {
+	for _, test := range [...]struct {
+		input string
+		data  interface{}
+		want  SanitizationContext
+	}{
+		{`<a target="_{{.}}">`, nil, SanitizationContextTargetEnum},
+		{`<a href="javascript:{{.}}">`, nil, SanitizationContextTrustedResourceURLOrURL},
+		{`<script>{{.}}</script>`, "alert(1)", SanitizationContextScript},
+		{`<a dir="{{.}}">`, "up", SanitizationContextDirEnum},
+		{`<a title={{.}}>`, nil, 0},
+	} {
+		err := Must(New("page").Parse(stringConstant(test.input))).Execute(ioutil.Discard, test.data)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Errorf("%q: got error %v, want *Error", test.input, err)
+			continue
+		}
+		if e.SanitizationContext != test.want {
+			t.Errorf("%q: got sanitization context %v, want %v", test.input, e.SanitizationContext, test.want)
+		}
+	}
+}
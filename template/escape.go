@@ -27,8 +27,11 @@ func escapeTemplate(tmpl *Template, node parse.Node, name string) error {
 	var err error
 	if c.err != nil {
 		err, c.err.Name = c.err, name
+		if c.err.Node != nil && !tmpl.nameSpace.noErrorSnippets {
+			c.err.Snippet = tmpl.nameSpace.snippet(c.err.Node)
+		}
 	} else if c.state != stateText {
-		err = &Error{ErrEndContext, nil, name, 0, fmt.Sprintf("ends in a non-text context: %+v", c)}
+		err = &Error{ErrorCode: ErrEndContext, Name: name, Description: fmt.Sprintf("ends in a non-text context: %+v", c)}
 	}
 	if err != nil {
 		// Prevent execution of unsafe templates.
@@ -81,6 +84,13 @@ type escaper struct {
 	actionNodeEdits   map[*parse.ActionNode][]string
 	templateNodeEdits map[*parse.TemplateNode]string
 	textNodeEdits     map[*parse.TextNode][]byte
+	// attributeActionEdits contains the sanitizer commands of actions that
+	// emit attributes, which take the element as arguments.
+	attributeActionEdits map[*parse.ActionNode]*parse.CommandNode
+	// policy is the policy of the template being escaped, and subPolicy
+	// the index of its sub-policy, or -1 if it has the policy of ns.
+	policy    *Policy
+	subPolicy int
 }
 
 // makeEscaper creates a blank escaper for the given set.
@@ -93,6 +103,9 @@ func makeEscaper(n *nameSpace) escaper {
 		map[*parse.ActionNode][]string{},
 		map[*parse.TemplateNode]string{},
 		map[*parse.TextNode][]byte{},
+		map[*parse.ActionNode]*parse.CommandNode{},
+		nil,
+		-1,
 	}
 }
 
@@ -123,6 +136,16 @@ func (e *escaper) escapeAction(c context, n *parse.ActionNode) context {
 		// A local variable assignment, not an interpolation.
 		return c
 	}
+	if isInstrumentationAction(n) {
+		// Inserted by an earlier commit into a template that is now being
+		// escaped in a different context; produces no output.
+		return c
+	}
+	if c.state == stateTag || c.state == stateAfterName {
+		// In `<input {{.}}>` or `<input hidden {{.}}>`, the action emits a
+		// safehtml.Attribute.
+		return e.escapeAttributeAction(c, n)
+	}
 	c = nudge(c)
 	// Check for disallowed use of predefined escapers in the pipeline.
 	for pos, idNode := range n.Pipe.Cmds {
@@ -155,12 +178,19 @@ func (e *escaper) escapeAction(c context, n *parse.ActionNode) context {
 		c.state = stateAttrName
 	}
 	// TODO: integrate sanitizerForContext into escapeAction.
-	s, err := sanitizerForContext(c)
+	s, err := sanitizerForContext(c, e.ns.registry, e.policy)
 	if err != nil {
-		return context{
-			state: stateError,
-			// TODO: return sanitization-specific errors.
-			err: errorf(ErrEscapeAction, n, n.Line, "cannot escape action %v: %s", n, err),
+		escErr := errorf(errorCode(err, ErrEscapeAction), n, n.Line, "cannot escape action %v: %s", n, err)
+		escErr.SanitizationContext = errorContext(err)
+		return context{state: stateError, err: escErr}
+	}
+	if e.subPolicy != -1 {
+		for i, name := range s {
+			for _, spName := range subPolicyFuncNames {
+				if name == spName {
+					s[i] = subPolicyFuncName(name, e.subPolicy)
+				}
+			}
 		}
 	}
 	e.editActionNode(n, s)
@@ -344,6 +374,14 @@ func join(a, b context, node parse.Node, nodeName string) context {
 		return c
 	}
 
+	c, d := a, b
+	c.afterSpace, d.afterSpace = false, false
+	if c.eq(d) {
+		// The contexts differ only by whether the tag ends in whitespace, so
+		// actions emitting attributes must precede them with whitespace.
+		return c
+	}
+
 	// Allow a nudged context to join with an unnudged one.
 	// This means that
 	//   <p title={{if .C}}{{.}}{{end}}
@@ -420,6 +458,7 @@ func (e *escaper) escapeList(c context, n *parse.ListNode) context {
 // which is the same as whether e was updated.
 func (e *escaper) escapeListConditionally(c context, n *parse.ListNode, filter func(*escaper, context) bool) (context, bool) {
 	e1 := makeEscaper(e.ns)
+	e1.policy, e1.subPolicy = e.policy, e.subPolicy
 	// Make type inferences available to f.
 	for k, v := range e.output {
 		e1.output[k] = v
@@ -446,6 +485,9 @@ func (e *escaper) escapeListConditionally(c context, n *parse.ListNode, filter f
 		for k, v := range e1.textNodeEdits {
 			e.editTextNode(k, v)
 		}
+		for k, v := range e1.attributeActionEdits {
+			e.attributeActionEdits[k] = v
+		}
 	}
 	return c, ok
 }
@@ -476,6 +518,9 @@ func mangle(c context, templateName string) string {
 	if c.element.name != "" {
 		s += "_" + c.element.String()
 	}
+	if c.afterSpace {
+		s += "_afterSpace"
+	}
 	return s
 }
 
@@ -491,6 +536,19 @@ func (e *escaper) escapeTree(c context, node parse.Node, name string, line int)
 		return out, dname
 	}
 	t := e.template(name)
+	if t != nil && t.Tree == nil {
+		// The template could not be escaped when it was executed earlier.
+		if c.state == stateError {
+			return c, dname
+		}
+		if tmpl := e.ns.set[name]; tmpl != nil {
+			if err, ok := tmpl.escapeErr.(*Error); ok {
+				errCopy := *err
+				return context{state: stateError, err: &errCopy}, dname
+			}
+		}
+		t = nil
+	}
 	if t == nil {
 		// Two cases: The template exists but is empty, or has never been mentioned at
 		// all. Distinguish the cases in the error messages.
@@ -517,6 +575,10 @@ func (e *escaper) escapeTree(c context, node parse.Node, name string, line int)
 		}
 		t = dt
 	}
+	// Escape the template with its own policy, and restore the policy of
+	// the caller afterwards.
+	defer func(p *Policy, sp int) { e.policy, e.subPolicy = p, sp }(e.policy, e.subPolicy)
+	e.policy, e.subPolicy = e.ns.policyFor(name)
 	return e.computeOutCtx(c, t), dname
 }
 
@@ -601,9 +663,15 @@ func (e *escaper) escapeText(c context, n *parse.TextNode) context {
 			}
 		}
 		c1, nread := contextAfterText(c, s[i:])
+		if err := e.policy.checkTransition(c1); err != nil {
+			return context{
+				state: stateError,
+				err:   errorf(ErrPolicyViolation, n, 0, "%s", err),
+			}
+		}
 		i1 := i + nread
-		sc, err := sanitizationContextForElementContent(c.element.name)
-		if c.state == stateText || err == nil && sc == sanitizationContextRCDATA {
+		sc, err := sanitizationContextForElementContent(e.ns.registry, c.element.name)
+		if c.state == stateText || err == nil && sc == SanitizationContextRCDATA {
 			end := i1
 			if c1.state != c.state {
 				for j := end - 1; j >= i; j-- {
@@ -755,8 +823,10 @@ func (e *escaper) editTextNode(n *parse.TextNode, text []byte) {
 // commit applies changes to actions and template calls needed to contextually
 // autoescape content and adds any derived templates to the set.
 func (e *escaper) commit() {
+	policyFuncs := e.ns.policyFuncs()
 	for name := range e.output {
-		e.template(name).Funcs(funcs)
+		t := e.template(name).Funcs(funcs).Funcs(policyFuncs).Funcs(e.ns.attributeFuncs()).Funcs(instrumentationFuncs)
+		instrument(t.Tree, name)
 	}
 	// Any template from the name space associated with this escaper can be used
 	// to add derived templates to the underlying text/template name space.
@@ -769,6 +839,9 @@ func (e *escaper) commit() {
 	for n, s := range e.actionNodeEdits {
 		ensurePipelineContains(n.Pipe, s)
 	}
+	for n, cmd := range e.attributeActionEdits {
+		appendAttributeSanitizer(n.Pipe, cmd)
+	}
 	for n, name := range e.templateNodeEdits {
 		n.Name = name
 	}
@@ -781,6 +854,9 @@ func (e *escaper) commit() {
 	e.actionNodeEdits = make(map[*parse.ActionNode][]string)
 	e.templateNodeEdits = make(map[*parse.TemplateNode]string)
 	e.textNodeEdits = make(map[*parse.TextNode][]byte)
+	e.attributeActionEdits = make(map[*parse.ActionNode]*parse.CommandNode)
+	// Executions must not reuse clones of the templates before this commit.
+	e.ns.gen++
 }
 
 // template returns the named template given a mangled template name.
@@ -233,7 +233,7 @@ func TestEscapeText(t *testing.T) {
 		},
 		{
 			`<a `,
-			context{state: stateTag, element: element{name: "a"}},
+			context{state: stateTag, element: element{name: "a"}, afterSpace: true},
 		},
 		{
 			`<a>`,
@@ -261,7 +261,7 @@ func TestEscapeText(t *testing.T) {
 		},
 		{
 			`<a href=x `,
-			context{state: stateTag, element: element{name: "a"}},
+			context{state: stateTag, element: element{name: "a"}, afterSpace: true},
 		},
 		{
 			`<a href=>`,
@@ -321,19 +321,19 @@ func TestEscapeText(t *testing.T) {
 		},
 		{
 			`<script `,
-			context{state: stateTag, element: element{name: "script"}},
+			context{state: stateTag, element: element{name: "script"}, afterSpace: true},
 		},
 		{
 			`<script src="foo.js" `,
-			context{state: stateTag, element: element{name: "script"}},
+			context{state: stateTag, element: element{name: "script"}, afterSpace: true},
 		},
 		{
 			`<script src='foo.js' `,
-			context{state: stateTag, element: element{name: "script"}},
+			context{state: stateTag, element: element{name: "script"}, afterSpace: true},
 		},
 		{
 			`<script type=text/javascript `,
-			context{state: stateTag, element: element{name: "script"}, scriptType: "text/javascript"},
+			context{state: stateTag, element: element{name: "script"}, scriptType: "text/javascript", afterSpace: true},
 		},
 		{
 			`<script>`,
@@ -935,3 +935,14 @@ func TestOrphanedTemplate(t *testing.T) {
 		t.Fatalf("t2 rendered %q, want %q", got, want)
 	}
 }
+
+func TestCallTemplateThatFailedToEscape(t *testing.T) {
+	tmpl := Must(New("page").Parse(`{{define "page"}}{{template "link" .}}{{end}}{{define "link"}}<a title={{.}}>{{end}}`))
+	err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "link", nil)
+	if err == nil {
+		t.Fatal("expected error executing link")
+	}
+	if got := tmpl.ExecuteTemplate(&bytes.Buffer{}, "page", nil); got == nil || got.Error() != err.Error() {
+		t.Errorf("got error %v executing page, want %v", got, err)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/uncheckedconversions"
+)
+
+// A Hash is the SHA-256 hash of the output of a template execution.
+type Hash [sha256.Size]byte
+
+// ETag returns a strong HTTP entity tag for the output with hash h, including
+// the surrounding double quotes.
+func (h Hash) ETag() string {
+	return `"` + base64.RawURLEncoding.EncodeToString(h[:]) + `"`
+}
+
+// ExecuteToHTMLWithHash is like ExecuteToHTML, but also returns the hash of the
+// output, which is computed as the output is written rather than in a second
+// pass over it.
+func (t *Template) ExecuteToHTMLWithHash(data interface{}) (safehtml.HTML, Hash, error) {
+	return executeToHTMLWithHash(func(wr io.Writer) error {
+		return t.Execute(wr, data)
+	})
+}
+
+// ExecuteTemplateToHTMLWithHash is like ExecuteTemplateToHTML, but also
+// returns the hash of the output, which is computed as the output is written
+// rather than in a second pass over it.
+func (t *Template) ExecuteTemplateToHTMLWithHash(name string, data interface{}) (safehtml.HTML, Hash, error) {
+	return executeToHTMLWithHash(func(wr io.Writer) error {
+		return t.ExecuteTemplate(wr, name, data)
+	})
+}
+
+func executeToHTMLWithHash(execute func(io.Writer) error) (safehtml.HTML, Hash, error) {
+	var buf bytes.Buffer
+	h := sha256.New()
+	if err := execute(io.MultiWriter(&buf, h)); err != nil {
+		return safehtml.HTML{}, Hash{}, err
+	}
+	var sum Hash
+	h.Sum(sum[:0])
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(buf.String()), sum, nil
+}
+
+// WriteHTMLWithETag writes html, whose hash is h, to w as the response to r,
+// with an ETag header derived from h. If r is a GET or HEAD request with an
+// If-None-Match header that matches the ETag, it responds with 304 Not
+// Modified and no body instead. The Content-Type header is set to
+// "text/html; charset=utf-8" unless it has already been set.
+//
+// For example, a handler can respond to requests for a page that has not
+// changed without transferring it again:
+//
+//	html, h, err := tmpl.ExecuteToHTMLWithHash(data)
+//	if err != nil {
+//		// Handle the error.
+//	}
+//	err = template.WriteHTMLWithETag(w, r, html, h)
+func WriteHTMLWithETag(w http.ResponseWriter, r *http.Request, html safehtml.HTML, h Hash) error {
+	etag := h.ETag()
+	header := w.Header()
+	header.Set("ETag", etag)
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	s := html.String()
+	header.Set("Content-Length", strconv.Itoa(len(s)))
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// etagMatches reports whether the value of an If-None-Match header matches
+// etag using the weak comparison required by RFC 9110, section 13.1.2.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
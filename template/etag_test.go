@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExecuteToHTMLWithHash(t *testing.T) {
+	tmpl := Must(New("page").Parse(`<p>{{.}}</p>{{define "item"}}<li>{{.}}</li>{{end}}`))
+	html, h, err := tmpl.ExecuteToHTMLWithHash("<x>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := html.String(), `<p>&lt;x&gt;</p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if want := Hash(sha256.Sum256([]byte(html.String()))); h != want {
+		t.Errorf("got hash %x, want %x", h, want)
+	}
+	itemHTML, itemHash, err := tmpl.ExecuteTemplateToHTMLWithHash("item", "<x>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Hash(sha256.Sum256([]byte(itemHTML.String()))); itemHash != want {
+		t.Errorf("got hash %x, want %x", itemHash, want)
+	}
+	if _, h, err := Must(New("bad").Parse(`<a title={{.}}>`)).ExecuteToHTMLWithHash(nil); err == nil || h != (Hash{}) {
+		t.Errorf("got hash %x, error %v, want zero hash and error", h, err)
+	}
+}
+
+func TestWriteHTMLWithETag(t *testing.T) {
+	html, h, err := Must(New("page").Parse(`<p>{{.}}</p>`)).ExecuteToHTMLWithHash("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	etag := h.ETag()
+	for _, test := range [...]struct {
+		desc, method, ifNoneMatch string
+		wantStatus                int
+	}{
+		{"no validator", http.MethodGet, "", http.StatusOK},
+		{"match", http.MethodGet, etag, http.StatusNotModified},
+		{"weak match", http.MethodHead, "W/" + etag, http.StatusNotModified},
+		{"match in list", http.MethodGet, `"other", ` + etag, http.StatusNotModified},
+		{"wildcard", http.MethodGet, "*", http.StatusNotModified},
+		{"mismatch", http.MethodGet, `"other"`, http.StatusOK},
+		{"unsafe method", http.MethodPost, etag, http.StatusOK},
+	} {
+		r := httptest.NewRequest(test.method, "/", nil)
+		if test.ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", test.ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		if err := WriteHTMLWithETag(w, r, html, h); err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		if w.Code != test.wantStatus {
+			t.Errorf("%s: got status %d, want %d", test.desc, w.Code, test.wantStatus)
+		}
+		if got := w.Header().Get("ETag"); got != etag {
+			t.Errorf("%s: got ETag %q, want %q", test.desc, got, etag)
+		}
+		wantBody := ""
+		if test.wantStatus == http.StatusOK {
+			wantBody = html.String()
+		}
+		if got := w.Body.String(); got != wantBody {
+			t.Errorf("%s: got body %q, want %q", test.desc, got, wantBody)
+		}
+	}
+}
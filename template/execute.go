@@ -0,0 +1,328 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	gocontext "context"
+	"fmt"
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/internal/safehtmlutil"
+	"github.com/google/safehtml/uncheckedconversions"
+)
+
+// An ExecuteOption configures a single execution of a template by
+// ExecuteWithOptions or ExecuteTemplateWithOptions.
+type ExecuteOption func(*executeConfig)
+
+// executeConfig holds the settings for a single execution of a template.
+type executeConfig struct {
+	// redact, if not nil, is applied to string data before it is sanitized.
+	redact func(string) string
+	// maxDepth is the maximum template call depth, or 0 if there is no limit.
+	maxDepth int
+	// maxFallbacks is the maximum number of sanitizer fallbacks, or 0 if
+	// there is no limit.
+	maxFallbacks int
+	// ctx, if not nil, bounds calls to the functions added using ContextFuncs.
+	ctx gocontext.Context
+	// report, if not nil, is called with the sanitization errors of the
+	// execution, which then proceeds with html/template behavior.
+	report func(*Error)
+	// checkHTML, if not nil, is called with the structural problems of the
+	// output of the execution.
+	checkHTML func(HTMLProblem)
+	// checkAccessibility, if not nil, is called with the accessibility
+	// problems of the output of the execution.
+	checkAccessibility func(HTMLProblem)
+	// checkSecrets, if not nil, is called with the possible secrets in the
+	// output of the execution.
+	checkSecrets func(HTMLProblem)
+	// parentCalls and parentFallbacks are the template invocation chain and
+	// the number of sanitizer fallbacks of the enclosing execution, which an
+	// execution rendering a fragment of another starts from.
+	parentCalls     []TemplateCall
+	parentFallbacks int
+}
+
+// WithRedactor returns an ExecuteOption that applies redact to every string
+// value interpolated by an action before the value is sanitized for the
+// context in which it occurs. For example, redact can mask email addresses or
+// access tokens when rendering a page for a log or a preview.
+//
+// The output of redact is untrusted data that is sanitized like any other
+// string. Values of the safe types in package safehtml, and non-string values,
+// are not passed to redact.
+func WithRedactor(redact func(string) string) ExecuteOption {
+	return func(c *executeConfig) {
+		c.redact = redact
+	}
+}
+
+// ExecuteWithOptions is like Execute, but configures this execution of t
+// with opts.
+func (t *Template) ExecuteWithOptions(wr io.Writer, data interface{}, opts ...ExecuteOption) error {
+	return t.nameSpace.trace(wr, t.Name(), opts, func(ctx gocontext.Context, wr io.Writer, opts []ExecuteOption) error {
+		if err := t.tracedEscape(ctx); err != nil {
+			return err
+		}
+		return logExecError(execError(t.Name(), t.execute(wr, data, opts)))
+	})
+}
+
+// ExecuteTemplateWithOptions is like ExecuteTemplate, but configures this
+// execution of the named template with opts.
+func (t *Template) ExecuteTemplateWithOptions(wr io.Writer, name string, data interface{}, opts ...ExecuteOption) error {
+	return t.nameSpace.trace(wr, name, opts, func(ctx gocontext.Context, wr io.Writer, opts []ExecuteOption) error {
+		tmpl, err := t.lookupAndEscapeTemplate(ctx, name)
+		if err != nil {
+			return err
+		}
+		return logExecError(execError(name, tmpl.execute(wr, data, opts)))
+	})
+}
+
+// logExecError reports err, the error of an execution, to the Logger set by
+// safehtml.SetLogger, and returns it.
+func logExecError(err error) error {
+	if e, ok := err.(*Error); ok {
+		diag.Log("safehtml/template: execution failed", "template", e.Name, "code", e.ErrorCode, "error", e.Description)
+	}
+	return err
+}
+
+// execute applies the escaped template t to data with opts.
+func (t *Template) execute(wr io.Writer, data interface{}, opts []ExecuteOption) error {
+	st, err := t.nameSpace.acquireExecState(t, opts)
+	if err != nil {
+		return err
+	}
+	defer t.nameSpace.releaseExecState(st)
+	if st.checkHTML != nil || st.checkAccessibility != nil || st.checkSecrets != nil {
+		return st.executeChecked(wr, t.Name(), data)
+	}
+	return st.annotate(st.text.Lookup(t.Name()).Execute(wr, data))
+}
+
+// An execState holds the state of a single execution of a template.
+//
+// Executing a template requires a clone of the underlying text/template
+// namespace whose functions refer to the execState, so that concurrent
+// executions do not interfere with each other. Since cloning is expensive,
+// execStates are pooled and reset after each execution.
+type execState struct {
+	executeConfig
+	// text is the clone of the underlying namespace that is executed.
+	text *template.Template
+	// gen is the generation of the namespace that text was cloned from.
+	gen int
+	// calls is the template invocation chain, starting with the executed
+	// template.
+	calls []TemplateCall
+	// callSite is the position of the {{template}} action that is about to
+	// invoke a template.
+	callSite string
+	// fallbacks is the number of values that the sanitizers replaced with
+	// safehtml.InnocuousURL.
+	fallbacks int
+	// output, if not nil, is the buffered output of an execution with
+	// WithHTMLCheck or WithAccessibilityCheck, and outputSpans record the
+	// templates that wrote it.
+	output      *bytes.Buffer
+	outputSpans []outputSpan
+}
+
+// execStatePool returns the pool of execStates for executions with c.
+// Executions with a redactor, in report-only mode, or with a limit on
+// sanitizer fallbacks use a separate pool
+// because they require wrapped sanitizers, which would otherwise slow down all
+// executions.
+func (ns *nameSpace) execStatePool(c *executeConfig) *sync.Pool {
+	if c.wrapsSanitizers() {
+		return &ns.execStates[1]
+	}
+	return &ns.execStates[0]
+}
+
+// acquireExecState returns an execState for an execution of t with opts.
+// It must be released using releaseExecState.
+func (ns *nameSpace) acquireExecState(t *Template, opts []ExecuteOption) (*execState, error) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	c := executeConfig{maxDepth: ns.maxTemplateDepth, maxFallbacks: ns.maxFallbacks}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	pool := ns.execStatePool(&c)
+	for {
+		st, ok := pool.Get().(*execState)
+		if !ok {
+			break
+		}
+		if st.gen == ns.gen {
+			st.executeConfig = c
+			st.calls = append(st.calls, c.parentCalls...)
+			st.fallbacks = c.parentFallbacks
+			return st, nil
+		}
+		// Drop states cloned before the namespace last changed.
+	}
+	text, err := t.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	st := &execState{executeConfig: c, text: text, gen: ns.gen}
+	st.calls = append(st.calls, c.parentCalls...)
+	st.fallbacks = c.parentFallbacks
+	text.Funcs(st.instrumentationFuncs())
+	if c.wrapsSanitizers() {
+		text.Funcs(st.sanitizers(ns.policyFuncs()))
+	}
+	if len(ns.contextFuncs) > 0 {
+		text.Funcs(bindContext(st.context, ns.contextFuncs))
+	}
+	if ns.fragmentCache != nil {
+		// Render cached templates from this namespace, which may be a clone
+		// of the one the FragmentCache was added to.
+		text.Funcs(template.FuncMap{cachedFuncName: ns.fragmentCache.renderFunc(t, st)})
+	}
+	if ns.parallelFragments {
+		// Likewise, render parallel templates from this namespace.
+		text.Funcs(template.FuncMap{parallelFuncName: parallelFunc(t, st)})
+	}
+	return st, nil
+}
+
+// releaseExecState resets st and returns it to its pool.
+func (ns *nameSpace) releaseExecState(st *execState) {
+	pool := ns.execStatePool(&st.executeConfig)
+	st.executeConfig = executeConfig{}
+	st.calls = st.calls[:0]
+	st.callSite = ""
+	st.fallbacks = 0
+	st.output = nil
+	st.outputSpans = st.outputSpans[:0]
+	pool.Put(st)
+}
+
+// fragmentOptions returns the ExecuteOptions of an execution that renders a
+// fragment of the execution st with {{parallel}} or {{cached}}. The fragment
+// is executed with the redactor, context, report-only mode, and limits of st,
+// and starts from its template invocation chain and number of sanitizer
+// fallbacks, so that its template invocations count towards the maximum
+// template call depth of st.
+func (st *execState) fragmentOptions() []ExecuteOption {
+	c := executeConfig{
+		redact:          st.redact,
+		maxDepth:        st.maxDepth,
+		maxFallbacks:    st.maxFallbacks,
+		ctx:             st.ctx,
+		report:          st.report,
+		parentCalls:     append([]TemplateCall(nil), st.calls...),
+		parentFallbacks: st.fallbacks,
+	}
+	return []ExecuteOption{func(fc *executeConfig) {
+		*fc = c
+	}}
+}
+
+// executeFragment executes the named template of the set of t to HTML with
+// opts, as a fragment of another execution.
+func (t *Template) executeFragment(name string, data interface{}, opts []ExecuteOption) (safehtml.HTML, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplateWithOptions(&buf, name, data, opts...); err != nil {
+		return safehtml.HTML{}, err
+	}
+	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(buf.String()), nil
+}
+
+// wrapsSanitizers reports whether executions with c require the wrapped
+// sanitizers returned by execState.sanitizers.
+func (c *executeConfig) wrapsSanitizers() bool {
+	return c.redact != nil || c.report != nil || c.maxFallbacks > 0
+}
+
+// context returns the context that bounds calls to the functions added using
+// ContextFuncs in this execution.
+func (st *execState) context() gocontext.Context {
+	if st.ctx == nil {
+		return gocontext.Background()
+	}
+	return st.ctx
+}
+
+// sanitizedString is the type of the values returned by the sanitizers that
+// execState.sanitizers returns. It distinguishes the output of one
+// sanitizer, which may be the input of another, from string data.
+type sanitizedString string
+
+// sanitizers returns wrappers for the sanitizers inserted into escaped
+// templates and the predefined escapers that replace them. The wrappers apply
+// the redactor of st to the data before passing it to the sanitizers, which
+// policyFuncs overrides, and report their errors in report-only mode.
+func (st *execState) sanitizers(policyFuncs template.FuncMap) template.FuncMap {
+	base := template.FuncMap{
+		"html":     template.HTMLEscaper,
+		"urlquery": template.URLQueryEscaper,
+	}
+	for name, f := range funcs {
+		base[name] = f
+	}
+	for name, f := range policyFuncs {
+		base[name] = f
+	}
+	ret := make(template.FuncMap, len(base))
+	for name, f := range base {
+		switch f := f.(type) {
+		case func(...interface{}) (string, error):
+			ret[name] = st.wrap(baseFuncName(name), f)
+		case func(...interface{}) string:
+			ret[name] = st.wrap(baseFuncName(name), func(args ...interface{}) (string, error) {
+				return f(args...), nil
+			})
+		default:
+			panic(fmt.Sprintf("unexpected type %T of sanitizer %s", f, name))
+		}
+	}
+	return ret
+}
+
+// wrap returns a sanitizer that applies the redactor of st to the arguments
+// of sanitize, the sanitizer with the given name, that have not been sanitized
+// yet. In report-only mode, it reports the errors of sanitize and returns the
+// output of html/template instead. It also counts the fallbacks of sanitize
+// against the limit of st.
+func (st *execState) wrap(name string, sanitize func(...interface{}) (string, error)) func(...interface{}) (sanitizedString, error) {
+	return func(args ...interface{}) (sanitizedString, error) {
+		for i, arg := range args {
+			switch v := safehtmlutil.Indirect(arg).(type) {
+			case sanitizedString:
+				args[i] = string(v)
+			case string:
+				if st.redact != nil {
+					args[i] = st.redact(v)
+				}
+			}
+		}
+		out, err := sanitize(args...)
+		if err != nil && st.report != nil {
+			if fallback, ok := reportOnlyFallbacks[name]; ok {
+				st.report(st.reportedError(err))
+				return sanitizedString(fallback(args)), nil
+			}
+		}
+		if err == nil {
+			err = st.countFallback(args, out)
+		}
+		return sanitizedString(out), err
+	}
+}
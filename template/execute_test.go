@@ -0,0 +1,154 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+var emailPattern = regexp.MustCompile(`[[:alnum:].]+@[[:alnum:].]+`)
+
+func redactEmails(s string) string {
+	return emailPattern.ReplaceAllString(s, "<redacted>")
+}
+
+func TestWithRedactor(t *testing.T) {
+	email := "alice@example.com"
+	for _, test := range [...]struct {
+		desc  string
+		input stringConstant
+		data  interface{}
+		want  string
+	}{
+		{
+			"element content",
+			`<p>{{.}}</p>`,
+			"Contact alice@example.com",
+			`<p>Contact &lt;redacted&gt;</p>`,
+		},
+		{
+			"pointer to string",
+			`<p>{{.}}</p>`,
+			&email,
+			`<p>&lt;redacted&gt;</p>`,
+		},
+		{
+			"attribute value",
+			`<a title="{{.}}" href="/users?q={{.}}">`,
+			"alice@example.com",
+			`<a title="&lt;redacted&gt;" href="/users?q=%3credacted%3e">`,
+		},
+		{
+			"predefined escaper",
+			`<p>{{. | html}}</p>{{html "bob@example.com" .}}`,
+			"alice@example.com",
+			`<p>&lt;redacted&gt;</p>&lt;redacted&gt;&lt;redacted&gt;`,
+		},
+		{
+			"redaction applied once",
+			`<a href="{{.}}">`,
+			"mailto:alice@example.com",
+			`<a href="mailto:%3credacted%3e">`,
+		},
+		{
+			"safe types not redacted",
+			`<p>{{.}}</p>`,
+			safehtml.HTMLEscaped("alice@example.com"),
+			`<p>alice@example.com</p>`,
+		},
+		{
+			"nested template",
+			`{{define "inner"}}<b>{{.}}</b>{{end}}<p>{{template "inner" .}}</p>`,
+			"alice@example.com",
+			`<p><b>&lt;redacted&gt;</b></p>`,
+		},
+	} {
+		tmpl := Must(New("").Parse(test.input))
+		var b bytes.Buffer
+		if err := tmpl.ExecuteWithOptions(&b, test.data, WithRedactor(redactEmails)); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestWithRedactorDoesNotAffectOtherExecutions(t *testing.T) {
+	tmpl := Must(New("page").Parse(`{{define "body"}}<p>{{.}}</p>{{end}}{{template "body" .}}`))
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplateWithOptions(&b, "body", "alice@example.com", WithRedactor(redactEmails)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<p>&lt;redacted&gt;</p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	b.Reset()
+	if err := tmpl.Execute(&b, "alice@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<p>alice@example.com</p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWithRedactorSanitizesOutput(t *testing.T) {
+	tmpl := Must(New("").Parse(`<a href="{{.}}">`))
+	var b bytes.Buffer
+	err := tmpl.ExecuteWithOptions(&b, "https://example.com/", WithRedactor(func(string) string {
+		return "javascript:alert(1)"
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); strings.Contains(got, "javascript") {
+		t.Errorf("output of redactor was not sanitized: %q", got)
+	}
+}
+
+func TestWithRedactorAndPolicy(t *testing.T) {
+	tmpl := Must(New("").WithPolicy(new(Policy).ForbidURLSchemes("data")).Parse(`<a href="{{.}}">`))
+	var b bytes.Buffer
+	err := tmpl.ExecuteWithOptions(&b, "https://example.com/", WithRedactor(func(string) string {
+		return "data:text/plain,foo"
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<a href="about:invalid#zGoSafez">`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExecutionLogging(t *testing.T) {
+	var msgs []string
+	safehtml.SetLogger(safehtml.LoggerFunc(func(msg string, keyvals ...interface{}) {
+		msgs = append(msgs, msg)
+	}))
+	defer safehtml.SetLogger(nil)
+	p := new(Policy).ForbidURLSchemes("mailto")
+	tmpl := Must(New("page").WithPolicy(p).Parse(`<a href="{{.}}"></a>`))
+	if err := tmpl.Execute(&bytes.Buffer{}, "mailto:a@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Must(New("script").Parse(`<script>{{.}}</script>`)).Execute(&bytes.Buffer{}, "x"); err == nil {
+		t.Fatal("expected execution error")
+	}
+	want := []string{
+		"safehtml/template: replaced URL forbidden by policy",
+		"safehtml/template: execution failed",
+	}
+	if strings.Join(msgs, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got messages %q, want %q", msgs, want)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/safehtmlutil"
+)
+
+// A FallbackError is returned by an execution whose sanitizers substitute
+// safehtml.InnocuousURL for more values than the limit set using
+// MaxSanitizerFallbacks or WithMaxSanitizerFallbacks. Many substitutions in
+// a single execution usually indicate an attack or a bug in the data, rather
+// than the occasional malformed link.
+type FallbackError struct {
+	// MaxFallbacks is the limit that was exceeded.
+	MaxFallbacks int
+	// Template is the name of the template whose action exceeded the limit.
+	Template string
+	// Value is the value whose substitution exceeded the limit.
+	Value string
+}
+
+func (e *FallbackError) Error() string {
+	return fmt.Sprintf("html/template: exceeded maximum number of sanitizer fallbacks (%d) in template %q, replacing %q", e.MaxFallbacks, e.Template, e.Value)
+}
+
+// MaxSanitizerFallbacks sets the maximum number of values that the sanitizers
+// may replace with safehtml.InnocuousURL during an execution of t or any
+// template associated with it, for example because they are javascript: URLs
+// or URLs forbidden by the Policy. Executions that would exceed max fail with
+// a *FallbackError instead. A max of 0 or less removes the limit. The return
+// value is the template, so calls can be chained.
+func (t *Template) MaxSanitizerFallbacks(max int) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.maxFallbacks = max
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// WithMaxSanitizerFallbacks returns an ExecuteOption that overrides the
+// maximum number of sanitizer fallbacks set using MaxSanitizerFallbacks for a
+// single execution.
+func WithMaxSanitizerFallbacks(max int) ExecuteOption {
+	return func(c *executeConfig) {
+		c.maxFallbacks = max
+	}
+}
+
+// countFallback records a fallback in st if out, the output of a sanitizer
+// applied to args, contains more substitutions of safehtml.InnocuousURL than
+// args. It returns a *FallbackError if st exceeds its maximum.
+func (st *execState) countFallback(args []interface{}, out string) error {
+	if st.maxFallbacks <= 0 || !strings.Contains(out, safehtml.InnocuousURL) {
+		return nil
+	}
+	in := safehtmlutil.Stringify(args...)
+	if strings.Count(out, safehtml.InnocuousURL) <= strings.Count(in, safehtml.InnocuousURL) {
+		return nil
+	}
+	st.fallbacks++
+	if st.fallbacks <= st.maxFallbacks {
+		return nil
+	}
+	name := ""
+	if len(st.calls) > 0 {
+		name = st.calls[len(st.calls)-1].Name
+	}
+	return &FallbackError{MaxFallbacks: st.maxFallbacks, Template: name, Value: in}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestMaxSanitizerFallbacks(t *testing.T) {
+	const text = `{{range .}}<a href="{{.}}">x</a>{{end}}`
+	for _, test := range [...]struct {
+		desc    string
+		max     int
+		opts    []ExecuteOption
+		data    []interface{}
+		wantErr bool
+	}{
+		{
+			desc: "no limit",
+			data: []interface{}{"javascript:a", "javascript:b", "javascript:c"},
+		},
+		{
+			desc: "within limit",
+			max:  2,
+			data: []interface{}{"javascript:a", "/ok", "javascript:b"},
+		},
+		{
+			desc:    "limit exceeded",
+			max:     2,
+			data:    []interface{}{"javascript:a", "javascript:b", "javascript:c"},
+			wantErr: true,
+		},
+		{
+			desc: "innocuous input values are not fallbacks",
+			max:  1,
+			data: []interface{}{safehtml.InnocuousURL, safehtml.URLSanitized("javascript:a"), "javascript:b"},
+		},
+		{
+			desc: "option removes limit",
+			max:  1,
+			opts: []ExecuteOption{WithMaxSanitizerFallbacks(0)},
+			data: []interface{}{"javascript:a", "javascript:b"},
+		},
+		{
+			desc:    "option sets limit",
+			opts:    []ExecuteOption{WithMaxSanitizerFallbacks(1)},
+			data:    []interface{}{"javascript:a", "javascript:b"},
+			wantErr: true,
+		},
+	} {
+		tmpl := Must(New("links").Parse(text)).MaxSanitizerFallbacks(test.max)
+		// Execute twice to check that the count is reset between executions.
+		for i := 0; i < 2; i++ {
+			err := tmpl.ExecuteWithOptions(&bytes.Buffer{}, test.data, test.opts...)
+			var fe *FallbackError
+			if !test.wantErr {
+				if err != nil {
+					t.Errorf("%s: unexpected error: %v", test.desc, err)
+				}
+				continue
+			}
+			if !errors.As(err, &fe) {
+				t.Errorf("%s: got error %v, want *FallbackError", test.desc, err)
+				continue
+			}
+			if fe.Template != "links" || fe.Value != "javascript:c" && fe.Value != "javascript:b" {
+				t.Errorf("%s: got %+v", test.desc, fe)
+			}
+		}
+	}
+}
+
+func TestMaxSanitizerFallbacksPolicy(t *testing.T) {
+	tmpl := Must(New("links").WithPolicy(new(Policy).ForbidURLSchemes("http")).Parse(`{{range .}}<a href="{{.}}">x</a>{{end}}`))
+	tmpl.MaxSanitizerFallbacks(1)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, []string{"https://a", "http://b"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `<a href="https://a">x</a><a href="about:invalid#zGoSafez">x</a>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	var fe *FallbackError
+	if err := tmpl.Execute(&bytes.Buffer{}, []string{"http://a", "http://b"}); !errors.As(err, &fe) {
+		t.Errorf("got error %v, want *FallbackError", err)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/safehtml"
+)
+
+// cachedFuncName is the name of the function that renders cached fragments.
+const cachedFuncName = "cached"
+
+// A FragmentCache memoizes the output of the templates invoked by {{cached}}
+// actions in the templates it is added to using WithFragmentCache. It is safe
+// for concurrent use.
+type FragmentCache struct {
+	ttl time.Duration
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[fragmentKey]*list.Element
+	// lru holds the *fragment entries of c, from the most to the least
+	// recently used.
+	lru list.List
+	// nextSweep is the time after which put next removes expired entries.
+	nextSweep time.Time
+}
+
+// defaultMaxFragments is the default maximum number of entries of a
+// FragmentCache.
+const defaultMaxFragments = 10000
+
+// A fragmentKey identifies the output of a template for a cache key.
+type fragmentKey struct {
+	// ns distinguishes templates with the same name in different template
+	// sets that share a FragmentCache.
+	ns   *nameSpace
+	name string
+	key  interface{}
+}
+
+type fragment struct {
+	key     fragmentKey
+	html    safehtml.HTML
+	expires time.Time
+}
+
+// NewFragmentCache returns a FragmentCache whose entries expire ttl after they
+// are rendered. If ttl is 0 or less, entries expire only when they are
+// invalidated or evicted. The cache holds at most 10000 entries, unless
+// changed using MaxEntries.
+func NewFragmentCache(ttl time.Duration) *FragmentCache {
+	return &FragmentCache{ttl: ttl, now: time.Now, maxEntries: defaultMaxFragments, entries: make(map[fragmentKey]*list.Element)}
+}
+
+// MaxEntries sets the maximum number of entries of c. Rendering a fragment
+// for a new key when c is full evicts the least recently used entry, so that
+// caching fragments for keys without bound, such as user or request IDs,
+// does not use memory without bound. A maximum of 0 or less removes the
+// limit. The return value is c, so calls can be chained.
+func (c *FragmentCache) MaxEntries(max int) *FragmentCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxEntries = max
+	c.evict()
+	return c
+}
+
+// WithFragmentCache adds the function cached to t and all templates associated
+// with it, which renders templates of the set as HTML and memoizes the output
+// in c, so that expensive fragments such as navigation bars and sidebars of
+// otherwise dynamic pages are rendered only once per key:
+//
+//	{{cached "sidebar" .User.ID}}
+//
+// renders the template named "sidebar" with the data .User.ID, unless the
+// output for that key is cached and has not expired. An optional third
+// argument is passed to the template as its data instead of the key:
+//
+//	{{cached "sidebar" .User.ID .User}}
+//
+// The key must be comparable, and must identify the output of the template
+// for the data, since the cached output is returned for any data with the same
+// key. Cached templates may themselves contain {{cached}} actions.
+//
+// Each cached template is escaped as if it were executed on its own, and its
+// output is a safehtml.HTML value that is interpolated into the output of the
+// enclosing template like any other. Cached templates are rendered with the
+// context and limits of the enclosing execution, and their template
+// invocations count towards its maximum template call depth. Since cached
+// output is shared between executions, executions with a redactor neither
+// use nor fill the cache, and output rendered in report-only mode is only
+// cached if no errors were reported.
+//
+// Like Funcs, WithFragmentCache must be called before the template is parsed.
+// The return value is the template, so calls can be chained.
+func (t *Template) WithFragmentCache(c *FragmentCache) *Template {
+	t.nameSpace.mu.Lock()
+	defer t.nameSpace.mu.Unlock()
+	t.nameSpace.fragmentCache = c
+	t.nameSpace.gen++
+	t.text.Funcs(template.FuncMap{cachedFuncName: c.renderFunc(t, nil)})
+	return t
+}
+
+// renderFunc returns the implementation of the cached function for the
+// template set of t in the execution st, or for parsing if st is nil.
+func (c *FragmentCache) renderFunc(t *Template, st *execState) func(string, interface{}, ...interface{}) (safehtml.HTML, error) {
+	return func(name string, key interface{}, data ...interface{}) (safehtml.HTML, error) {
+		if err := checkKey(name, key); err != nil {
+			return safehtml.HTML{}, err
+		}
+		var arg interface{}
+		switch len(data) {
+		case 0:
+			arg = key
+		case 1:
+			arg = data[0]
+		default:
+			return safehtml.HTML{}, fmt.Errorf("wrong number of arguments for cached template %q: want at most 3, got %d", name, len(data)+2)
+		}
+		opts := st.fragmentOptions()
+		if st.redact != nil {
+			// The output depends on the redactor of this execution.
+			return t.executeFragment(name, arg, opts)
+		}
+		k := fragmentKey{ns: t.nameSpace, name: name, key: key}
+		if html, ok := c.get(k); ok {
+			return html, nil
+		}
+		reported := false
+		if report := st.report; report != nil {
+			opts = append(opts, WithReportOnly(func(e *Error) {
+				reported = true
+				report(e)
+			}))
+		}
+		html, err := t.executeFragment(name, arg, opts)
+		if err != nil {
+			return safehtml.HTML{}, err
+		}
+		if !reported {
+			c.put(k, html)
+		}
+		return html, nil
+	}
+}
+
+// checkKey returns an error if key, a cache key for the named template, is not
+// comparable. Keys of comparable types, such as interface types, may hold
+// values that are not, which panic when they are compared.
+func checkKey(name string, key interface{}) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = fmt.Errorf("cache key of type %T for template %q is not comparable", key, name)
+		}
+	}()
+	_ = map[interface{}]bool{key: true}
+	return nil
+}
+
+func (c *FragmentCache) get(k fragmentKey) (safehtml.HTML, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	if !ok {
+		return safehtml.HTML{}, false
+	}
+	f := e.Value.(*fragment)
+	if c.ttl > 0 && !c.now().Before(f.expires) {
+		c.remove(e)
+		return safehtml.HTML{}, false
+	}
+	c.lru.MoveToFront(e)
+	return f.html, true
+}
+
+func (c *FragmentCache) put(k fragmentKey, html safehtml.HTML) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f := &fragment{key: k, html: html}
+	if c.ttl > 0 {
+		now := c.now()
+		f.expires = now.Add(c.ttl)
+		if !now.Before(c.nextSweep) {
+			// Remove the expired entries, at most once per TTL, so that
+			// entries whose keys are not used again do not stay in c until
+			// they are evicted.
+			for e := c.lru.Front(); e != nil; {
+				next := e.Next()
+				if !now.Before(e.Value.(*fragment).expires) {
+					c.remove(e)
+				}
+				e = next
+			}
+			c.nextSweep = f.expires
+		}
+	}
+	if e, ok := c.entries[k]; ok {
+		e.Value = f
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[k] = c.lru.PushFront(f)
+	c.evict()
+}
+
+// evict removes the least recently used entries of c until it has at most
+// c.maxEntries entries.
+func (c *FragmentCache) evict() {
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *FragmentCache) remove(e *list.Element) {
+	c.lru.Remove(e)
+	delete(c.entries, e.Value.(*fragment).key)
+}
+
+// Invalidate removes the cached output of the named template for key from c
+// in every template set that c has been added to.
+func (c *FragmentCache) Invalidate(name string, key interface{}) {
+	if checkKey(name, key) != nil {
+		// No output is cached for such keys.
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if k.name == name && k.key == key {
+			c.remove(e)
+		}
+	}
+}
+
+// Purge removes all cached output from c.
+func (c *FragmentCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[fragmentKey]*list.Element)
+	c.lru.Init()
+}
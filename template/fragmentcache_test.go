@@ -0,0 +1,197 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFragmentCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewFragmentCache(time.Minute)
+	c.now = func() time.Time { return now }
+	renders := 0
+	tmpl := Must(New("page").WithFragmentCache(c).Funcs(FuncMap{
+		"render": func() int { renders++; return renders },
+	}).Parse(`<p>{{cached "nav" .}}</p>` +
+		`{{define "nav"}}<nav>{{.}} #{{render}}</nav>{{end}}`))
+	execute := func(data interface{}) string {
+		t.Helper()
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+	if got, want := execute("<a>"), `<p><nav>&lt;a&gt; #1</nav></p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := execute("b"), `<p><nav>b #2</nav></p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	now = now.Add(59 * time.Second)
+	execute("<a>")
+	if renders != 2 {
+		t.Errorf("got %d renders before the TTL elapsed, want 2", renders)
+	}
+	now = now.Add(time.Second)
+	execute("<a>")
+	if renders != 3 {
+		t.Errorf("got %d renders after the TTL elapsed, want 3", renders)
+	}
+	c.Invalidate("nav", "b")
+	execute("b")
+	if renders != 4 {
+		t.Errorf("got %d renders after Invalidate, want 4", renders)
+	}
+	c.Purge()
+	execute("<a>")
+	if renders != 5 {
+		t.Errorf("got %d renders after Purge, want 5", renders)
+	}
+}
+
+func TestFragmentCacheData(t *testing.T) {
+	type user struct{ ID, Name string }
+	tmpl := Must(New("page").WithFragmentCache(NewFragmentCache(0)).Parse(
+		`{{cached "greeting" .ID .}}{{define "greeting"}}Hello, {{.Name}}!{{end}}`))
+	for _, u := range []user{{"1", "Alice"}, {"1", "Mallory"}, {"2", "Bob"}} {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, u); err != nil {
+			t.Fatal(err)
+		}
+		want := "Hello, Alice!"
+		if u.ID == "2" {
+			want = "Hello, Bob!"
+		}
+		if got := b.String(); got != want {
+			t.Errorf("%+v: got %q, want %q", u, got, want)
+		}
+	}
+}
+
+func TestFragmentCacheErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc  string
+		input stringConstant
+		data  interface{}
+		want  string
+	}{
+		{"key not comparable", `{{cached "a" .}}{{define "a"}}a{{end}}`, []string{"x"}, "is not comparable"},
+		{"key holding a value that is not comparable", `{{cached "a" .}}{{define "a"}}a{{end}}`, struct{ V interface{} }{[]string{"x"}}, "is not comparable"},
+		{"too many arguments", `{{cached "a" . . .}}{{define "a"}}a{{end}}`, "x", "want at most 3, got 4"},
+		{"no such template", `{{cached "b" .}}{{define "a"}}a{{end}}`, "x", `"b" is undefined`},
+		{"escaping error", `{{cached "a" .}}{{define "a"}}<a title={{.}}>{{end}}`, "x", "unquoted attribute values disallowed"},
+	} {
+		tmpl := Must(New("page").WithFragmentCache(NewFragmentCache(0)).Parse(test.input))
+		err := tmpl.Execute(&strings.Builder{}, test.data)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: got error %v, want error containing %q", test.desc, err, test.want)
+		}
+	}
+}
+
+func TestFragmentCacheClone(t *testing.T) {
+	c := NewFragmentCache(0)
+	base := Must(New("page").WithFragmentCache(c).Parse(`{{cached "nav" 1}}{{define "nav"}}base{{end}}`))
+	clone := Must(Must(base.Clone()).Parse(`{{define "nav"}}clone{{end}}`))
+	for _, test := range []struct {
+		tmpl *Template
+		want string
+	}{{base, "base"}, {clone, "clone"}} {
+		var b strings.Builder
+		if err := test.tmpl.Execute(&b, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestFragmentCacheEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := NewFragmentCache(time.Minute).MaxEntries(2)
+	c.now = func() time.Time { return now }
+	renders := 0
+	tmpl := Must(New("page").WithFragmentCache(c).Funcs(FuncMap{
+		"render": func() int { renders++; return renders },
+	}).Parse(`{{cached "f" .}}{{define "f"}}{{render}}{{end}}`))
+	execute := func(key int) {
+		t.Helper()
+		if err := tmpl.Execute(&strings.Builder{}, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	execute(1)
+	execute(2)
+	execute(1)
+	execute(3) // evicts 2, the least recently used
+	if renders != 3 || c.lru.Len() != 2 {
+		t.Fatalf("got %d renders and %d entries, want 3 and 2", renders, c.lru.Len())
+	}
+	execute(1)
+	if renders != 3 {
+		t.Errorf("got %d renders of a recently used key, want 3", renders)
+	}
+	execute(2)
+	if renders != 4 {
+		t.Errorf("got %d renders of an evicted key, want 4", renders)
+	}
+
+	// Expired entries are removed when another is added.
+	now = now.Add(time.Minute)
+	c.MaxEntries(0)
+	execute(4)
+	if got := len(c.entries); got != 1 {
+		t.Errorf("got %d entries after the TTL elapsed, want 1", got)
+	}
+}
+
+func TestFragmentCacheOptions(t *testing.T) {
+	c := NewFragmentCache(0)
+	tmpl := Must(New("page").WithFragmentCache(c).Parse(`{{define "page"}}{{cached "p" .}}{{end}}` +
+		`{{define "p"}}<p>{{.}}</p>{{end}}` +
+		`{{define "recurse"}}{{cached "recurse" .}}{{end}}` +
+		`{{define "script"}}{{cached "s" .}}{{end}}` +
+		`{{define "s"}}<script>var x = {{.}};</script>{{end}}`))
+	execute := func(name string, opts ...ExecuteOption) (string, error) {
+		var b strings.Builder
+		err := tmpl.ExecuteTemplateWithOptions(&b, name, "secret", opts...)
+		return b.String(), err
+	}
+	redact := WithRedactor(func(string) string { return "<redacted>" })
+	if got, err := execute("page", redact); err != nil || got != "<p>&lt;redacted&gt;</p>" {
+		t.Errorf("redactor: got %q, %v, want %q", got, err, "<p>&lt;redacted&gt;</p>")
+	}
+	if got, err := execute("page"); err != nil || got != "<p>secret</p>" {
+		t.Errorf("after redactor: got %q, %v, want %q", got, err, "<p>secret</p>")
+	}
+	if got, err := execute("page", redact); err != nil || got != "<p>&lt;redacted&gt;</p>" {
+		t.Errorf("redactor after caching: got %q, %v, want %q", got, err, "<p>&lt;redacted&gt;</p>")
+	}
+
+	var reported int
+	report := WithReportOnly(func(*Error) { reported++ })
+	for i := 0; i < 2; i++ {
+		if _, err := execute("script", report); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reported != 2 {
+		t.Errorf("report-only: got %d reported errors, want 2, since output with errors is not cached", reported)
+	}
+
+	_, err := execute("recurse", WithMaxTemplateDepth(3))
+	var depthErr *DepthError
+	if !errors.As(err, &depthErr) {
+		t.Errorf("depth: got error %v, want a *DepthError", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+// WithHTMLCheck returns an ExecuteOption that checks the structure of the
+// output of a template, and passes each problem found to report: elements
+// that are not closed, end tags that do not close an element, duplicate id
+// attribute values, and elements such as a div in a p or an a in an a that
+// browsers do not nest as written. These problems are not unsafe, so
+// templates are escaped and executed regardless of them, but browsers repair
+// them in ways that break layouts, styles, and scripts.
+//
+// Problems name the templates that wrote the markup they concern, so that an
+// id reused by the components a page is composed of, a common accessibility
+// bug, is reported with the templates of both elements.
+//
+// The output is buffered until the execution completes, then checked and
+// written, so that problems are only reported for executions that succeed.
+// WithHTMLCheck is meant for development and tests, and should not be used
+// in production. It depends on golang.org/x/net/html, so builds with the
+// safehtml_minimal tag exclude it.
+func WithHTMLCheck(report func(HTMLProblem)) ExecuteOption {
+	return func(c *executeConfig) {
+		c.checkHTML = report
+	}
+}
+
+// optionalEndTags contains the elements whose end tags may be omitted.
+var optionalEndTags = map[string]bool{
+	"body":     true,
+	"colgroup": true,
+	"dd":       true,
+	"dt":       true,
+	"head":     true,
+	"html":     true,
+	"li":       true,
+	"optgroup": true,
+	"option":   true,
+	"p":        true,
+	"rp":       true,
+	"rt":       true,
+	"tbody":    true,
+	"td":       true,
+	"tfoot":    true,
+	"th":       true,
+	"thead":    true,
+	"tr":       true,
+}
+
+// closesP contains the elements whose start tags close an open p element.
+var closesP = map[string]bool{
+	"address":    true,
+	"article":    true,
+	"aside":      true,
+	"blockquote": true,
+	"details":    true,
+	"div":        true,
+	"dl":         true,
+	"fieldset":   true,
+	"figcaption": true,
+	"figure":     true,
+	"footer":     true,
+	"form":       true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"header":     true,
+	"hgroup":     true,
+	"hr":         true,
+	"main":       true,
+	"menu":       true,
+	"nav":        true,
+	"ol":         true,
+	"pre":        true,
+	"section":    true,
+	"table":      true,
+	"ul":         true,
+}
+
+// notNested contains the elements that browsers do not nest in themselves.
+var notNested = map[string]bool{
+	"a":      true,
+	"button": true,
+	"form":   true,
+}
+
+// checkHTML passes the structural problems of the markup b to report.
+// templateAt returns the name of the template that wrote the markup at an
+// offset of b.
+func checkHTML(b []byte, templateAt func(int) string, report func(HTMLProblem)) {
+	type element struct {
+		name     string
+		line     int
+		template string
+	}
+	var open []element
+	ids := make(map[string]element)
+	isOpen := func(name string) bool {
+		for _, e := range open {
+			if e.name == name {
+				return true
+			}
+		}
+		return false
+	}
+	scanHTML(b, templateAt, func(tt html.TokenType, tok html.Token, tokLine int, tmpl string) {
+		problem := func(format string, args ...interface{}) {
+			report(HTMLProblem{tokLine, []string{tmpl}, fmt.Sprintf(format, args...)})
+		}
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			for _, a := range tok.Attr {
+				if a.Namespace != "" || a.Key != "id" || a.Val == "" {
+					continue
+				}
+				first, ok := ids[a.Val]
+				switch {
+				case !ok:
+					ids[a.Val] = element{tok.Data, tokLine, tmpl}
+				case first.template != tmpl:
+					report(HTMLProblem{tokLine, templateNames(first.template, tmpl),
+						fmt.Sprintf("duplicate id %q, first used on line %d by template %q", a.Val, first.line, first.template)})
+				default:
+					problem("duplicate id %q, first used on line %d", a.Val, first.line)
+				}
+			}
+			if closesP[tok.Data] && len(open) > 0 && open[len(open)-1].name == "p" {
+				problem("<%s> is not allowed in <p>", tok.Data)
+			}
+			if notNested[tok.Data] && isOpen(tok.Data) {
+				problem("<%s> is not allowed in <%s>", tok.Data, tok.Data)
+			}
+			if tt == html.StartTagToken && !voidElements[tok.Data] {
+				open = append(open, element{tok.Data, tokLine, tmpl})
+			}
+		case html.EndTagToken:
+			i := len(open) - 1
+			for i >= 0 && open[i].name != tok.Data {
+				i--
+			}
+			if i < 0 {
+				problem("</%s> does not close an element", tok.Data)
+				return
+			}
+			for _, e := range open[i+1:] {
+				if !optionalEndTags[e.name] {
+					report(HTMLProblem{e.line, templateNames(e.template, tmpl), fmt.Sprintf("<%s> is not closed before </%s>", e.name, tok.Data)})
+				}
+			}
+			open = open[:i]
+		}
+	})
+	for _, e := range open {
+		if !optionalEndTags[e.name] {
+			report(HTMLProblem{e.line, []string{e.template}, fmt.Sprintf("<%s> is not closed", e.name)})
+		}
+	}
+}
+
+// scanHTML calls f with each token of the markup b, its type, the line it
+// starts on, which problems are reported on, and the name of the template
+// that wrote it, as returned by templateAt for its offset in b.
+func scanHTML(b []byte, templateAt func(int) string, f func(tt html.TokenType, tok html.Token, line int, tmpl string)) {
+	z := html.NewTokenizer(bytes.NewReader(b))
+	line, offset := 1, 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return
+		}
+		tokLine, tmpl := line, templateAt(offset)
+		line += bytes.Count(z.Raw(), []byte("\n"))
+		offset += len(z.Raw())
+		f(tt, z.Token(), tokLine, tmpl)
+	}
+}
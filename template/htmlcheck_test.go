@@ -0,0 +1,90 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package template
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithHTMLCheck(t *testing.T) {
+	for _, test := range [...]struct {
+		desc  string
+		input string
+		want  []string
+	}{
+		{"valid", "<ul>\n<li>{{.}}\n<li>b\n</ul><p>a<p>b<br><img alt=x>", nil},
+		{"unclosed", "<div>\n<span>{{.}}", []string{"line 1: <div> is not closed", "line 2: <span> is not closed"}},
+		{"unclosed before end tag", "<div>\n<b>{{.}}</div>", []string{"line 2: <b> is not closed before </div>"}},
+		{"stray end tag", "<p>{{.}}</p></div>", []string{"line 1: </div> does not close an element"}},
+		{"void end tag", "a<br></br>", []string{"line 1: </br> does not close an element"}},
+		{"duplicate id", "<p id=a>\n<span id=\"a\">{{.}}</span>", []string{`line 2: duplicate id "a", first used on line 1`}},
+		{"div in p", "<p><div>{{.}}</div></p>", []string{"line 1: <div> is not allowed in <p>"}},
+		{"a in a", `<a href="/x"><b><a href="/y">{{.}}</a></b></a>`, []string{"line 1: <a> is not allowed in <a>"}},
+		{"raw text", "<script>var x = '</div>';</script><textarea><p></textarea>", nil},
+		{"svg", `<svg><path d="M0"/><circle r="1"></circle></svg>`, nil},
+	} {
+		var got []string
+		var b strings.Builder
+		tmpl := Must(New("").ParseFromTrustedTemplate(TrustedTemplate{test.input}))
+		err := tmpl.ExecuteWithOptions(&b, "x", WithHTMLCheck(func(p HTMLProblem) {
+			got = append(got, p.String())
+		}))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got problems %q, want %q", test.desc, got, test.want)
+		}
+		if b.Len() == 0 {
+			t.Errorf("%s: no output", test.desc)
+		}
+	}
+}
+
+func TestWithHTMLCheckFailedExecution(t *testing.T) {
+	tmpl := Must(New("").Funcs(FuncMap{"fail": func() (string, error) { return "", errors.New("failed") }}).
+		ParseFromTrustedTemplate(TrustedTemplate{"<div>{{fail}}"}))
+	var b strings.Builder
+	called := false
+	err := tmpl.ExecuteWithOptions(&b, nil, WithHTMLCheck(func(HTMLProblem) { called = true }))
+	if err == nil {
+		t.Fatal("unexpected success")
+	}
+	if called || b.Len() != 0 {
+		t.Errorf("failed execution was checked or written: %q", b.String())
+	}
+}
+
+func TestWithHTMLCheckTemplates(t *testing.T) {
+	tmpl := Must(New("page").ParseFromTrustedTemplate(TrustedTemplate{
+		`{{define "header"}}<nav id="menu"><a href="/">Home</a></nav>{{end}}` +
+			`{{define "sidebar"}}<div id="menu">{{.}}</div><div id="ad"></div><div id="ad"></div>{{end}}` +
+			`{{define "footer"}}<footer><p>{{.}}{{end}}` +
+			"{{template `header`}}\n<main>{{template `sidebar` .}}\n{{template `footer` .}}</main>",
+	}))
+	var got []HTMLProblem
+	if err := tmpl.ExecuteWithOptions(new(strings.Builder), "x", WithHTMLCheck(func(p HTMLProblem) {
+		got = append(got, p)
+	})); err != nil {
+		t.Fatal(err)
+	}
+	want := []HTMLProblem{
+		{2, []string{"header", "sidebar"}, `duplicate id "menu", first used on line 1 by template "header"`},
+		{2, []string{"sidebar"}, `duplicate id "ad", first used on line 2`},
+		{3, []string{"footer", "page"}, "<footer> is not closed before </main>"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got problems\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,548 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package htmlcompat mirrors the exported API of package html/template, backed
+// by the escaper of package safehtml/template, so that replacing the import of
+// html/template with
+//
+//	import template "github.com/google/safehtml/template/htmlcompat"
+//
+// compiles without further changes. It is intended only as the first step of
+// a migration to safehtml/template.
+//
+// Templates are escaped and executed with the semantics of safehtml/template.
+// Features of html/template that safehtml/template does not support fail with
+// an *UnsupportedError rather than being silently ignored:
+//   - Template.AddParseTree always fails.
+//   - The content types CSS, HTML, HTMLAttr, JS, JSStr, Srcset, and URL are
+//     not trusted. Executing a template with data containing values of these
+//     types, or calling functions returning them, fails. Such values should
+//     be replaced with the safe types of package safehtml.
+//
+// Unlike safehtml/template, this package parses template text and files from
+// arbitrary strings, so it does not guarantee that templates are under the
+// control of the application. Code using it should be migrated to
+// safehtml/template, and new code must not use it.
+package htmlcompat
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"reflect"
+	"sync"
+	"text/template/parse"
+
+	"github.com/google/safehtml/template"
+	"github.com/google/safehtml/template/uncheckedconversions"
+)
+
+// The content types of html/template. Values of these types are not trusted by
+// this package; templates executed with them fail with an *UnsupportedError.
+type (
+	CSS      string
+	HTML     string
+	HTMLAttr string
+	JS       string
+	JSStr    string
+	Srcset   string
+	URL      string
+)
+
+// contentTypes maps the content types of this package to their names.
+var contentTypes = map[reflect.Type]string{
+	reflect.TypeOf(CSS("")):      "CSS",
+	reflect.TypeOf(HTML("")):     "HTML",
+	reflect.TypeOf(HTMLAttr("")): "HTMLAttr",
+	reflect.TypeOf(JS("")):       "JS",
+	reflect.TypeOf(JSStr("")):    "JSStr",
+	reflect.TypeOf(Srcset("")):   "Srcset",
+	reflect.TypeOf(URL("")):      "URL",
+}
+
+// Error describes a problem encountered during template escaping or execution.
+type Error = template.Error
+
+// ErrorCode is a code for a kind of error.
+type ErrorCode = template.ErrorCode
+
+// The error codes of html/template, which have the same meaning in
+// safehtml/template.
+const (
+	OK                   = template.OK
+	ErrAmbigContext      = template.ErrAmbigContext
+	ErrBadHTML           = template.ErrBadHTML
+	ErrBranchEnd         = template.ErrBranchEnd
+	ErrEndContext        = template.ErrEndContext
+	ErrNoSuchTemplate    = template.ErrNoSuchTemplate
+	ErrOutputContext     = template.ErrOutputContext
+	ErrPartialCharset    = template.ErrPartialCharset
+	ErrPartialEscape     = template.ErrPartialEscape
+	ErrRangeLoopReentry  = template.ErrRangeLoopReentry
+	ErrSlashAmbig        = template.ErrSlashAmbig
+	ErrPredefinedEscaper = template.ErrPredefinedEscaper
+	ErrJSTemplate        = template.ErrUnbalancedJsTemplate
+)
+
+// An UnsupportedError is returned when a template uses a feature of
+// html/template that safehtml/template does not support.
+type UnsupportedError struct {
+	// Feature describes the unsupported feature, such as "AddParseTree".
+	Feature string
+	// Reason explains why the feature is not supported, and what to use
+	// instead.
+	Reason string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("htmlcompat: %s is not supported: %s", e.Feature, e.Reason)
+}
+
+// FuncMap is the type of the map defining the mapping from names to functions.
+type FuncMap = template.FuncMap
+
+// Template is a template produced by the escaper of safehtml/template, with
+// the methods of the Template of html/template.
+type Template struct {
+	// Tree is the parse tree of the template. Unlike in html/template, it is
+	// not updated when the template is escaped.
+	Tree *parse.Tree
+
+	text   *template.Template
+	shared *shared
+}
+
+// shared is the state shared by all associated templates.
+type shared struct {
+	mu sync.Mutex
+	// templates maps the templates of safehtml/template to the Templates
+	// wrapping them.
+	templates map[*template.Template]*Template
+}
+
+func newShared() *shared {
+	return &shared{templates: make(map[*template.Template]*Template)}
+}
+
+// wrap returns the Template wrapping t, or nil if t is nil.
+func (s *shared) wrap(t *template.Template) *Template {
+	if t == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.templates[t]
+	if !ok {
+		w = &Template{Tree: t.Tree, text: t, shared: s}
+		s.templates[t] = w
+	}
+	return w
+}
+
+// parsed updates the parse trees of the Templates wrapping t and its
+// associated templates after t parsed template text, and returns the Template
+// wrapping t.
+func (s *shared) parsed(t *template.Template) *Template {
+	s.wrap(t)
+	for _, x := range t.Templates() {
+		s.wrap(x)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for x, w := range s.templates {
+		w.Tree = x.Tree
+	}
+	return s.templates[t]
+}
+
+// New allocates a new HTML template with the given name.
+func New(name string) *Template {
+	return newShared().wrap(template.New(name))
+}
+
+// Must is a helper that wraps a call to a function returning (*Template, error)
+// and panics if the error is non-nil.
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// IsTrue reports whether the value is 'true', in the sense of not the zero of
+// its type, and whether the value has a meaningful truth value.
+func IsTrue(val interface{}) (truth, ok bool) {
+	return template.IsTrue(val)
+}
+
+// ParseFiles creates a new Template and parses the template definitions from
+// the named files, as html/template.ParseFiles does.
+func ParseFiles(filenames ...string) (*Template, error) {
+	t, err := template.ParseFilesFromTrustedSources(trustedSources(filenames)...)
+	if err != nil {
+		return nil, err
+	}
+	return newShared().parsed(t), nil
+}
+
+// ParseGlob creates a new Template and parses the template definitions from
+// the files identified by the pattern, as html/template.ParseGlob does.
+func ParseGlob(pattern string) (*Template, error) {
+	t, err := template.ParseGlobFromTrustedSource(uncheckedconversions.TrustedSourceFromStringKnownToSatisfyTypeContract(pattern))
+	if err != nil {
+		return nil, err
+	}
+	return newShared().parsed(t), nil
+}
+
+// ParseFS is like ParseFiles or ParseGlob but reads from the file system fsys
+// instead of the host operating system's file system, as html/template.ParseFS
+// does.
+func ParseFS(fsys fs.FS, patterns ...string) (*Template, error) {
+	return parseFS(nil, fsys, patterns)
+}
+
+func trustedSources(filenames []string) []template.TrustedSource {
+	ret := make([]template.TrustedSource, len(filenames))
+	for i, filename := range filenames {
+		ret[i] = uncheckedconversions.TrustedSourceFromStringKnownToSatisfyTypeContract(filename)
+	}
+	return ret
+}
+
+// parseFS parses the files in fsys matching patterns into t, or into a new
+// Template named after the first file if t is nil.
+func parseFS(t *Template, fsys fs.FS, patterns []string) (*Template, error) {
+	var filenames []string
+	for _, pattern := range patterns {
+		list, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) == 0 {
+			return nil, fmt.Errorf("htmlcompat: pattern matches no files: %#q", pattern)
+		}
+		filenames = append(filenames, list...)
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("htmlcompat: no files named in call to ParseFS")
+	}
+	for _, filename := range filenames {
+		b, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, err
+		}
+		name := path.Base(filename)
+		if t == nil {
+			t = New(name)
+		}
+		tmpl := t
+		if name != t.Name() {
+			tmpl = t.New(name)
+		}
+		if _, err := tmpl.Parse(string(b)); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// AddParseTree always returns an *UnsupportedError, since safehtml/template
+// only escapes templates parsed from template text.
+func (t *Template) AddParseTree(name string, tree *parse.Tree) (*Template, error) {
+	return nil, &UnsupportedError{
+		Feature: "AddParseTree",
+		Reason:  "templates must be parsed from template text under the control of the application",
+	}
+}
+
+// Clone returns a duplicate of the template, including all associated
+// templates.
+func (t *Template) Clone() (*Template, error) {
+	c, err := t.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return newShared().parsed(c), nil
+}
+
+// DefinedTemplates returns a string listing the defined templates, prefixed
+// by the string "; defined templates are: ".
+func (t *Template) DefinedTemplates() string {
+	return t.text.DefinedTemplates()
+}
+
+// Delims sets the action delimiters to the specified strings, to be used in
+// subsequent calls to Parse, ParseFiles, or ParseGlob.
+func (t *Template) Delims(left, right string) *Template {
+	t.text.Delims(left, right)
+	return t
+}
+
+// Execute applies a parsed template to the specified data object, writing the
+// output to wr.
+//
+// It returns an *UnsupportedError if t calls a function that returns content
+// types, or if data contains values of content types.
+func (t *Template) Execute(wr io.Writer, data interface{}) error {
+	if err := t.checkExecute(data); err != nil {
+		return err
+	}
+	return t.text.Execute(wr, data)
+}
+
+// ExecuteTemplate applies the template associated with t that has the given
+// name to the specified data object and writes the output to wr.
+//
+// It returns an *UnsupportedError in the same cases as Execute.
+func (t *Template) ExecuteTemplate(wr io.Writer, name string, data interface{}) error {
+	if err := t.checkExecute(data); err != nil {
+		return err
+	}
+	return t.text.ExecuteTemplate(wr, name, data)
+}
+
+func (t *Template) checkExecute(data interface{}) error {
+	return checkData(reflect.ValueOf(data), 0)
+}
+
+// Funcs adds the elements of the argument map to the template's function map.
+// Functions returning content types are replaced by functions that fail with
+// an *UnsupportedError, so that only the executions of templates calling them
+// fail.
+func (t *Template) Funcs(funcMap FuncMap) *Template {
+	funcs := make(FuncMap, len(funcMap))
+	for name, fn := range funcMap {
+		funcs[name] = fn
+		v := reflect.ValueOf(fn)
+		if v.Kind() != reflect.Func || v.Type().NumOut() == 0 {
+			continue
+		}
+		if typ, ok := contentTypes[v.Type().Out(0)]; ok {
+			funcs[name] = unsupportedFunc(v.Type(), contentTypeError(fmt.Sprintf("function %q returning %s", name, typ)))
+		}
+	}
+	t.text.Funcs(funcs)
+	return t
+}
+
+// errorType is the type of the error results of template functions.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// unsupportedFunc returns a function with the parameters and first result of
+// the function type typ that returns err.
+func unsupportedFunc(typ reflect.Type, err error) interface{} {
+	in := make([]reflect.Type, typ.NumIn())
+	for i := range in {
+		in[i] = typ.In(i)
+	}
+	out := []reflect.Type{typ.Out(0), errorType}
+	errVal := reflect.ValueOf(&err).Elem()
+	return reflect.MakeFunc(reflect.FuncOf(in, out, typ.IsVariadic()), func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.Zero(typ.Out(0)), errVal}
+	}).Interface()
+}
+
+// Lookup returns the template with the given name that is associated with t,
+// or nil if there is no such template.
+func (t *Template) Lookup(name string) *Template {
+	return t.shared.wrap(t.text.Lookup(name))
+}
+
+// Name returns the name of the template.
+func (t *Template) Name() string {
+	return t.text.Name()
+}
+
+// New allocates a new HTML template associated with the given one and with the
+// same delimiters.
+func (t *Template) New(name string) *Template {
+	return t.shared.wrap(t.text.New(name))
+}
+
+// Option sets options for the template, as html/template's Template.Option
+// does.
+func (t *Template) Option(opt ...string) *Template {
+	t.text.Option(opt...)
+	return t
+}
+
+// Parse parses text as a template body for t.
+func (t *Template) Parse(text string) (*Template, error) {
+	if _, err := t.text.ParseFromTrustedTemplate(uncheckedconversions.TrustedTemplateFromStringKnownToSatisfyTypeContract(text)); err != nil {
+		return nil, err
+	}
+	return t.shared.parsed(t.text), nil
+}
+
+// ParseFiles parses the named files and associates the resulting templates
+// with t.
+func (t *Template) ParseFiles(filenames ...string) (*Template, error) {
+	if _, err := t.text.ParseFilesFromTrustedSources(trustedSources(filenames)...); err != nil {
+		return nil, err
+	}
+	return t.shared.parsed(t.text), nil
+}
+
+// ParseGlob parses the template definitions in the files identified by the
+// pattern and associates the resulting templates with t.
+func (t *Template) ParseGlob(pattern string) (*Template, error) {
+	if _, err := t.text.ParseGlobFromTrustedSource(uncheckedconversions.TrustedSourceFromStringKnownToSatisfyTypeContract(pattern)); err != nil {
+		return nil, err
+	}
+	return t.shared.parsed(t.text), nil
+}
+
+// ParseFS is like ParseFiles or ParseGlob but reads from the file system fsys
+// instead of the host operating system's file system.
+func (t *Template) ParseFS(fsys fs.FS, patterns ...string) (*Template, error) {
+	return parseFS(t, fsys, patterns)
+}
+
+// Templates returns a slice of the templates associated with t, including t
+// itself.
+func (t *Template) Templates() []*Template {
+	ts := t.text.Templates()
+	ret := make([]*Template, len(ts))
+	for i, x := range ts {
+		ret[i] = t.shared.wrap(x)
+	}
+	return ret
+}
+
+func contentTypeError(use string) error {
+	return &UnsupportedError{
+		Feature: use,
+		Reason:  "html/template content types are not trusted; use the types of package safehtml instead",
+	}
+}
+
+// maxDataDepth bounds the depth to which checkData inspects data, so that
+// cyclic data terminates.
+const maxDataDepth = 32
+
+// checkData returns an *UnsupportedError if v contains a value of a content
+// type in a place that a template could access. Only values whose types can
+// hold content types are inspected, so the cost of checking data of other
+// types, such as structs of strings and numbers, does not depend on its size.
+func checkData(v reflect.Value, depth int) error {
+	if !v.IsValid() || depth > maxDataDepth || !mayHoldContentTypes(v.Type()) {
+		return nil
+	}
+	if typ, ok := contentTypes[v.Type()]; ok {
+		return contentTypeError(fmt.Sprintf("data of type %s", typ))
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		return checkData(v.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				// Templates cannot access unexported fields.
+				continue
+			}
+			if err := checkData(v.Field(i), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkData(iter.Value(), depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := checkData(v.Index(i), depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// holdsContentTypes caches the results of mayHoldContentTypes by type.
+var holdsContentTypes sync.Map
+
+// mayHoldContentTypes reports whether values of type typ can contain values
+// of content types that checkData finds, either because a content type or an
+// interface type is reachable from typ through the elements, exported fields,
+// and map values that checkData inspects.
+func mayHoldContentTypes(typ reflect.Type) bool {
+	if may, ok := holdsContentTypes.Load(typ); ok {
+		return may.(bool)
+	}
+	seen := make(map[reflect.Type]bool)
+	var reachable func(t reflect.Type) bool
+	reachable = func(t reflect.Type) bool {
+		if seen[t] {
+			return false
+		}
+		seen[t] = true
+		if _, ok := contentTypes[t]; ok {
+			return true
+		}
+		switch t.Kind() {
+		case reflect.Interface:
+			return true
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array:
+			return reachable(t.Elem())
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				if f := t.Field(i); f.PkgPath == "" && reachable(f.Type) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	may := reachable(typ)
+	holdsContentTypes.Store(typ, may)
+	return may
+}
+
+// HTMLEscape writes to w the escaped HTML equivalent of the plain text data b.
+func HTMLEscape(w io.Writer, b []byte) {
+	htmltemplate.HTMLEscape(w, b)
+}
+
+// HTMLEscapeString returns the escaped HTML equivalent of the plain text data s.
+func HTMLEscapeString(s string) string {
+	return htmltemplate.HTMLEscapeString(s)
+}
+
+// HTMLEscaper returns the escaped HTML equivalent of the textual
+// representation of its arguments.
+func HTMLEscaper(args ...interface{}) string {
+	return htmltemplate.HTMLEscaper(args...)
+}
+
+// JSEscape writes to w the escaped JavaScript equivalent of the plain text
+// data b.
+func JSEscape(w io.Writer, b []byte) {
+	htmltemplate.JSEscape(w, b)
+}
+
+// JSEscapeString returns the escaped JavaScript equivalent of the plain text
+// data s.
+func JSEscapeString(s string) string {
+	return htmltemplate.JSEscapeString(s)
+}
+
+// JSEscaper returns the escaped JavaScript equivalent of the textual
+// representation of its arguments.
+func JSEscaper(args ...interface{}) string {
+	return htmltemplate.JSEscaper(args...)
+}
+
+// URLQueryEscaper returns the escaped value of the textual representation of
+// its arguments in a form suitable for embedding in a URL query.
+func URLQueryEscaper(args ...interface{}) string {
+	return htmltemplate.URLQueryEscaper(args...)
+}
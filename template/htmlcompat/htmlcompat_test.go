@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package htmlcompat
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/safehtml"
+)
+
+func TestParseAndExecute(t *testing.T) {
+	tmpl := Must(New("page").Funcs(FuncMap{"upper": strings.ToUpper}).Parse(
+		`{{define "link"}}<a href="{{.}}">link</a>{{end}}<p>{{upper .Text}}</p>{{template "link" .URL}}`))
+	if tmpl.Tree == nil || tmpl.Lookup("link") == nil || tmpl.Lookup("link").Tree == nil {
+		t.Fatal("parse trees not set")
+	}
+	if tmpl.Lookup("link") != tmpl.Lookup("link") {
+		t.Error("Lookup returned different Templates for the same template")
+	}
+	var b strings.Builder
+	data := map[string]string{"Text": "<page>", "URL": "javascript:alert(1)"}
+	if err := tmpl.Execute(&b, data); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<p>&lt;PAGE&gt;</p><a href="about:invalid#zGoSafez">link</a>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := len(tmpl.Templates()); got != 2 {
+		t.Errorf("got %d templates, want 2", got)
+	}
+}
+
+func TestSafehtmlSemantics(t *testing.T) {
+	tmpl := Must(New("t").Parse(`<script>{{.}}</script>`))
+	var b strings.Builder
+	err := tmpl.Execute(&b, "alert(1)")
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("got error %v, want an *Error", err)
+	}
+	b.Reset()
+	if err := tmpl.Execute(&b, safehtml.ScriptFromConstant(`alert(1)`)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<script>alert(1)</script>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	tmpl := Must(New("t").Parse(`<p>{{.}}</p>`))
+	var b strings.Builder
+	for _, test := range [...]struct {
+		desc string
+		data interface{}
+	}{
+		{"content type", HTML("<b>")},
+		{"content type field", struct{ X JS }{"x"}},
+		{"content type in map", map[string]interface{}{"x": []interface{}{URL("/")}}},
+	} {
+		var u *UnsupportedError
+		if err := tmpl.Execute(&b, test.data); !errors.As(err, &u) {
+			t.Errorf("%s: got error %v, want an *UnsupportedError", test.desc, err)
+		}
+	}
+	if err := tmpl.Execute(&b, struct{ x HTML }{"<b>"}); err != nil {
+		t.Errorf("unexported field: unexpected error: %v", err)
+	}
+
+	type node struct {
+		Next  *node
+		Items []struct{ A, B string }
+	}
+	if err := tmpl.Execute(&b, &node{Next: &node{}, Items: make([]struct{ A, B string }, 3)}); err != nil {
+		t.Errorf("recursive type: unexpected error: %v", err)
+	}
+	if err := tmpl.Execute(&b, []interface{}{&node{}, []JSStr{"x"}}); !errors.As(err, new(*UnsupportedError)) {
+		t.Errorf("content type after recursive type: got error %v, want an *UnsupportedError", err)
+	}
+
+	withFunc := Must(New("t").Funcs(FuncMap{"safe": func(s string) HTML { return HTML(s) }}).Parse(`{{safe .}}{{define "other"}}<p>{{.}}</p>{{end}}`))
+	var u *UnsupportedError
+	if err := withFunc.Execute(&b, "<b>"); !errors.As(err, &u) {
+		t.Errorf("function returning content type: got error %v, want an *UnsupportedError", err)
+	}
+	if err := withFunc.ExecuteTemplate(&b, "other", "<b>"); err != nil {
+		t.Errorf("template not calling the function: unexpected error: %v", err)
+	}
+	if _, err := tmpl.AddParseTree("x", tmpl.Tree); !errors.As(err, &u) {
+		t.Errorf("AddParseTree: got error %v, want an *UnsupportedError", err)
+	}
+}
+
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.html": {Data: []byte(`<p>{{template "b.html" .}}</p>`)},
+		"b.html": {Data: []byte(`{{.}}`)},
+	}
+	tmpl, err := ParseFS(fsys, "*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Name() != "a.html" {
+		t.Errorf("got name %q, want a.html", tmpl.Name())
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "<x>"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<p>&lt;x&gt;</p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := ParseFS(fsys, "*.txt"); err == nil {
+		t.Error("expected error for pattern matching no files")
+	}
+}
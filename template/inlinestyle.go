@@ -0,0 +1,168 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"sort"
+	"text/template/parse"
+
+	"github.com/google/safehtml"
+)
+
+// InlineStyleHashes returns the Content-Security-Policy hash sources, such as
+// "'sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU='", of the style
+// elements with static content in the output of t, including the templates
+// that t invokes, for the style-src directive of a policy that allows them
+// without a nonce. The hashes are sorted and unique.
+//
+// Style elements whose content contains actions have no fixed hash, and must
+// be allowed using a nonce attribute or interpolate a safehtml.StyleSheet
+// rendered by safehtml.StyleSheetElement instead. Actions in the start tag of
+// a style element, such as a nonce attribute, do not prevent hashing its
+// content.
+//
+// InlineStyleHashes escapes t, and returns an error if escaping fails.
+func (t *Template) InlineStyleHashes() ([]string, error) {
+	if err := t.escape(); err != nil {
+		return nil, err
+	}
+	t.nameSpace.mu.Lock()
+	defer t.nameSpace.mu.Unlock()
+	hashes := make(map[string]bool)
+	visited := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		tmpl := t.text.Lookup(name)
+		if tmpl == nil || tmpl.Tree == nil {
+			return
+		}
+		s := styleScanner{hashes: hashes}
+		s.scan(tmpl.Root, visit)
+	}
+	visit(t.Name())
+	ret := make([]string, 0, len(hashes))
+	for h := range hashes {
+		ret = append(ret, h)
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// A styleScanner finds the style elements with static content in a sequence
+// of template nodes.
+type styleScanner struct {
+	state   styleScannerState
+	content []byte
+	hashes  map[string]bool
+}
+
+type styleScannerState uint8
+
+const (
+	// styleOutside is the state outside style elements.
+	styleOutside styleScannerState = iota
+	// styleTag is the state in the start tag of a style element.
+	styleTag
+	// styleContent is the state in the static content of a style element.
+	styleContent
+)
+
+// scan scans the nodes in n, and calls visit with the names of the templates
+// that they invoke.
+func (s *styleScanner) scan(n parse.Node, visit func(name string)) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			s.scan(c, visit)
+		}
+	case *parse.TextNode:
+		s.text(n.Text)
+	case *parse.TemplateNode:
+		s.interrupt()
+		visit(n.Name)
+	case *parse.IfNode:
+		s.branches(visit, n.List, n.ElseList)
+	case *parse.RangeNode:
+		s.branches(visit, n.List, n.ElseList)
+	case *parse.WithNode:
+		s.branches(visit, n.List, n.ElseList)
+	default:
+		s.interrupt()
+	}
+}
+
+// branches scans the branches of a conditional, each with a new scanner,
+// since the content of a style element that spans a conditional is not
+// static.
+func (s *styleScanner) branches(visit func(name string), lists ...*parse.ListNode) {
+	s.interrupt()
+	for _, l := range lists {
+		b := styleScanner{hashes: s.hashes}
+		b.scan(l, visit)
+	}
+}
+
+// interrupt records that a node with dynamic output follows.
+func (s *styleScanner) interrupt() {
+	if s.state == styleContent {
+		s.state = styleOutside
+	}
+}
+
+func (s *styleScanner) text(b []byte) {
+	for len(b) > 0 {
+		switch s.state {
+		case styleOutside:
+			i := indexTag(b, "<style")
+			if i < 0 {
+				return
+			}
+			b = b[i+len("<style"):]
+			s.state = styleTag
+		case styleTag:
+			i := bytes.IndexByte(b, '>')
+			if i < 0 {
+				return
+			}
+			b = b[i+1:]
+			s.state = styleContent
+			s.content = s.content[:0]
+		case styleContent:
+			i := indexTag(b, "</style")
+			if i < 0 {
+				s.content = append(s.content, b...)
+				return
+			}
+			s.content = append(s.content, b[:i]...)
+			s.hashes[safehtml.CSPHash(string(s.content))] = true
+			b = b[i+len("</style"):]
+			s.state = styleOutside
+		}
+	}
+}
+
+// indexTag returns the index of the first case-insensitive occurrence of tag,
+// such as "<style", in b followed by the end of a tag name, or -1.
+func indexTag(b []byte, tag string) int {
+	for i := 0; i+len(tag) <= len(b); i++ {
+		if !bytes.EqualFold(b[i:i+len(tag)], []byte(tag)) {
+			continue
+		}
+		if j := i + len(tag); j == len(b) || bytes.IndexByte([]byte("\t\n\f\r />"), b[j]) >= 0 {
+			return i
+		}
+	}
+	return -1
+}
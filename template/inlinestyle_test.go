@@ -0,0 +1,77 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestInlineStyleHashes(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, text string
+		want       []string
+	}{
+		{
+			desc: "static",
+			text: `<head><style>body{margin:0}</style><STYLE media="print">nav{display:none}</STYLE></head>`,
+			want: []string{`body{margin:0}`, `nav{display:none}`},
+		},
+		{
+			desc: "nonce attribute",
+			text: `<style nonce="{{.Nonce}}">body{margin:0}</style>`,
+			want: []string{`body{margin:0}`},
+		},
+		{
+			desc: "dynamic content",
+			text: `<style>body{margin:0}{{.Sheet}}</style><style>p{}</style>`,
+			want: []string{`p{}`},
+		},
+		{
+			desc: "invoked templates",
+			text: `{{define "a"}}<style>a{}</style>{{template "b"}}{{end}}{{define "b"}}<style>b{}</style>{{end}}{{template "a"}}{{if .}}<style>c{}</style>{{end}}`,
+			want: []string{`a{}`, `b{}`, `c{}`},
+		},
+		{
+			desc: "conditional content",
+			text: `<style>a{}{{if .}}b{}{{end}}</style>`,
+		},
+		{
+			desc: "CSS comments",
+			text: `<style>a{/* comment */}</style>`,
+			want: []string{`a{/* comment */}`},
+		},
+		{
+			desc: "not a style element",
+			text: `<styles>a{}</styles><p>&lt;style&gt;</p>`,
+		},
+	} {
+		tmpl := Must(New("t").ParseFromTrustedTemplate(TrustedTemplate{test.text}))
+		got, err := tmpl.InlineStyleHashes()
+		if err != nil {
+			t.Errorf("%s: %v", test.desc, err)
+			continue
+		}
+		want := []string{}
+		for _, s := range test.want {
+			want = append(want, safehtml.CSPHash(s))
+		}
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %q, want hashes of %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestInlineStyleHashesEscapeError(t *testing.T) {
+	if _, err := Must(New("t").Parse(`<a href="{{.}}`)).InlineStyleHashes(); err == nil {
+		t.Error("InlineStyleHashes succeeded, want escaping error")
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build go1.16
+// +build go1.16
+
+package template
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"text/template/parse"
+
+	"github.com/google/safehtml/internal/diag"
+)
+
+// A Loader parses the template files in a TrustedFS and reparses them when
+// Reload is called, for example when the files change during development.
+//
+// Reload reparses only the files whose contents have changed, and retains the
+// results of escaping the templates that neither are defined in those files
+// nor invoke such a template directly or indirectly, so reloading a large
+// template set after a small change is fast.
+type Loader struct {
+	fsys     fs.FS
+	patterns []string
+	setup    func(*Template) *Template
+
+	mu sync.Mutex
+	// tmpl is the most recently loaded template set.
+	tmpl *Template
+	// files maps the names of the files parsed by the most recent load to
+	// their contents and templates.
+	files map[string]*loadedFile
+	// defs maps the name of each template in tmpl to the parse tree of its
+	// definition. These trees are never modified.
+	defs map[string]*parse.Tree
+}
+
+// A loadedFile is a template file parsed by a Loader.
+type loadedFile struct {
+	content string
+	// trees are the templates defined in the file.
+	trees map[string]*parse.Tree
+}
+
+// NewLoader returns a Loader for the files in tfs that match the given glob
+// patterns, and loads them. Like ParseFS, the loaded template set has the base
+// name of the first matching file, and each file defines a template with its
+// base name.
+//
+// If setup is not nil, it is called with each newly allocated template before
+// any file is parsed into it, and returns the template to parse the file into.
+// It should configure the template, for example using Funcs or WithPolicy,
+// in the same way every time it is called.
+func NewLoader(tfs TrustedFS, setup func(*Template) *Template, patterns ...string) (*Loader, error) {
+	if setup == nil {
+		setup = func(t *Template) *Template { return t }
+	}
+	l := &Loader{fsys: tfs.fsys, patterns: patterns, setup: setup}
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Template returns the most recently loaded template set. Executions of the
+// returned template are not affected by later calls to Reload. The returned
+// template cannot be parsed into; change the files and call Reload instead.
+func (l *Loader) Template() *Template {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.tmpl
+}
+
+// Reload reads the files matching the patterns of l again, and updates the
+// template set returned by Template if any of them changed. It returns the
+// sorted names of the templates that were added, removed, or redefined, or
+// that invoke such a template, and whose escaping therefore cannot be
+// retained.
+//
+// If an error occurs, the template set returned by Template is not changed.
+// Reload reports its errors, and the names of the templates it reloaded, to
+// the Logger set by safehtml.SetLogger.
+func (l *Loader) Reload() ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names, err := l.reload()
+	if err != nil {
+		diag.Log("safehtml/template: reload failed", "patterns", l.patterns, "error", err)
+	} else if len(names) > 0 {
+		diag.Log("safehtml/template: reloaded templates", "templates", names)
+	}
+	return names, err
+}
+
+// reload is the implementation of Reload, called with l.mu held.
+func (l *Loader) reload() ([]string, error) {
+	var filenames []string
+	for _, pattern := range l.patterns {
+		list, err := fs.Glob(l.fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) == 0 {
+			return nil, fmt.Errorf("html/template: pattern matches no files: %#q", pattern)
+		}
+		filenames = append(filenames, list...)
+	}
+	files := make(map[string]*loadedFile, len(filenames))
+	defs := make(map[string]*parse.Tree)
+	for _, filename := range filenames {
+		f, err := l.loadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		files[filename] = f
+		// Later definitions replace earlier ones unless they are empty,
+		// as in ParseFiles.
+		for name, tree := range f.trees {
+			if defs[name] == nil || !parse.IsEmptyTree(tree.Root) {
+				defs[name] = tree
+			}
+		}
+	}
+	affected := affectedTemplates(l.defs, defs)
+	if l.tmpl != nil && len(affected) == 0 {
+		l.files = files
+		return nil, nil
+	}
+	tmpl, err := l.build(filenames, files, defs, affected)
+	if err != nil {
+		return nil, err
+	}
+	l.tmpl, l.files, l.defs = tmpl, files, defs
+	names := make([]string, 0, len(affected))
+	for name := range affected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// loadFile reads the named file, and parses it unless it has not changed
+// since it was last loaded.
+func (l *Loader) loadFile(filename string) (*loadedFile, error) {
+	b, err := fs.ReadFile(l.fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+	if f := l.files[filename]; f != nil && f.content == string(b) {
+		return f, nil
+	}
+	t, err := l.setup(New(path.Base(filename))).Parse(stringConstant(b))
+	if err != nil {
+		return nil, err
+	}
+	f := &loadedFile{content: string(b), trees: make(map[string]*parse.Tree)}
+	for _, text := range t.text.Templates() {
+		f.trees[text.Name()] = text.Tree
+	}
+	return f, nil
+}
+
+// build returns a template set containing the templates defined by defs.
+// The escaped templates of the current template set that are not affected
+// are added to it as is, along with the templates derived from them while
+// escaping.
+func (l *Loader) build(filenames []string, files map[string]*loadedFile, defs map[string]*parse.Tree, affected map[string]bool) (*Template, error) {
+	t := l.setup(New(path.Base(filenames[0])))
+	ns := t.nameSpace
+	ns.sources = make(map[string][]string)
+	for _, filename := range filenames {
+		name := path.Base(filename)
+		ns.sources[name] = append(ns.sources[name], files[filename].content)
+	}
+	escaped, derived, output := l.retainedTemplates(defs, affected)
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tree := escaped[name]
+		if tree == nil {
+			tree = defs[name].Copy()
+		}
+		tmpl := ns.set[name]
+		if tmpl == nil {
+			tmpl = t.new(name)
+		}
+		text, err := t.text.AddParseTree(name, tree)
+		if err != nil {
+			return nil, err
+		}
+		tmpl.text, tmpl.Tree = text, tree
+		if escaped[name] != nil && output[name].state == stateText {
+			// Other retained templates are escaped again when executed, so
+			// that the usual errors are reported.
+			tmpl.escapeErr = errEscapeOK
+		}
+	}
+	for name, tree := range derived {
+		if _, err := t.text.AddParseTree(name, tree); err != nil {
+			return nil, err
+		}
+	}
+	for name, c := range output {
+		ns.esc.output[name] = c
+	}
+	// Retained templates have already been escaped, so they are executed
+	// without calling commit, which would otherwise add these functions.
+	t.text.Funcs(funcs).Funcs(ns.policyFuncs()).Funcs(ns.attributeFuncs()).Funcs(instrumentationFuncs)
+	ns.escaped = true
+	return t, nil
+}
+
+// retainedTemplates returns the escaped trees of the templates of the current
+// template set that are not affected, the templates derived from them while
+// escaping, and the output contexts of both, keyed by name. Only templates
+// that have been committed by the escaper are retained.
+func (l *Loader) retainedTemplates(defs map[string]*parse.Tree, affected map[string]bool) (escaped, derived map[string]*parse.Tree, output map[string]context) {
+	escaped = make(map[string]*parse.Tree)
+	derived = make(map[string]*parse.Tree)
+	output = make(map[string]context)
+	if l.tmpl == nil {
+		return escaped, derived, output
+	}
+	old := l.tmpl.nameSpace
+	old.mu.Lock()
+	defer old.mu.Unlock()
+	for name, c := range old.esc.output {
+		base := demangle(name)
+		if defs[base] == nil || affected[base] {
+			continue
+		}
+		text := l.tmpl.text.Lookup(name)
+		if text == nil || text.Tree == nil || text.Root == nil ||
+			len(text.Root.Nodes) == 0 || !isInstrumentationAction(text.Root.Nodes[0]) {
+			// Not committed.
+			continue
+		}
+		if name == base {
+			escaped[name] = text.Tree
+		} else {
+			derived[name] = text.Tree
+		}
+		output[name] = c
+	}
+	return escaped, derived, output
+}
+
+// affectedTemplates returns the set of templates in defs that differ from
+// their definitions in prev, or that invoke a template that differs, directly
+// or indirectly. The set also contains the names of the templates in prev
+// that are not in defs.
+func affectedTemplates(prev, defs map[string]*parse.Tree) map[string]bool {
+	affected := make(map[string]bool)
+	var queue []string
+	mark := func(name string) {
+		if !affected[name] {
+			affected[name] = true
+			queue = append(queue, name)
+		}
+	}
+	for name, tree := range defs {
+		if prev[name] != tree {
+			mark(name)
+		}
+	}
+	for name := range prev {
+		if defs[name] == nil {
+			mark(name)
+		}
+	}
+	callers := make(map[string][]string)
+	for name, tree := range defs {
+		for callee := range templateCalls(tree.Root, nil) {
+			callers[callee] = append(callers[callee], name)
+		}
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, caller := range callers[name] {
+			mark(caller)
+		}
+	}
+	return affected
+}
+
+// templateCalls adds the names of the templates invoked by {{template}}
+// actions in n to calls, and returns calls.
+func templateCalls(n parse.Node, calls map[string]bool) map[string]bool {
+	if calls == nil {
+		calls = make(map[string]bool)
+	}
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return calls
+		}
+		for _, c := range n.Nodes {
+			templateCalls(c, calls)
+		}
+	case *parse.TemplateNode:
+		calls[n.Name] = true
+	case *parse.IfNode:
+		templateCalls(n.List, calls)
+		templateCalls(n.ElseList, calls)
+	case *parse.RangeNode:
+		templateCalls(n.List, calls)
+		templateCalls(n.ElseList, calls)
+	case *parse.WithNode:
+		templateCalls(n.List, calls)
+		templateCalls(n.ElseList, calls)
+	}
+	return calls
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build go1.16
+// +build go1.16
+
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoaderReload(t *testing.T) {
+	fsys := fstest.MapFS{
+		"t/layout.tmpl": {Data: []byte(`{{define "layout"}}<div>{{template "nav" .}}{{template "body" .}}</div>{{end}}`)},
+		"t/nav.tmpl":    {Data: []byte(`{{define "nav"}}<nav>{{.}}</nav>{{end}}`)},
+		"t/body.tmpl":   {Data: []byte(`{{define "body"}}<p>{{.}}</p>{{end}}`)},
+		"t/other.tmpl":  {Data: []byte(`{{define "other"}}<b>{{upper .}}</b>{{end}}`)},
+	}
+	setup := func(t *Template) *Template {
+		return t.Funcs(FuncMap{"upper": strings.ToUpper})
+	}
+	l, err := NewLoader(TrustedFS{fsys: fsys}, setup, "t/*.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	execute := func(name string) string {
+		t.Helper()
+		var b strings.Builder
+		if err := l.Template().ExecuteTemplate(&b, name, "<x>"); err != nil {
+			t.Fatalf("executing %s: %v", name, err)
+		}
+		return b.String()
+	}
+	if got, want := execute("layout"), `<div><nav>&lt;x&gt;</nav><p>&lt;x&gt;</p></div>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := execute("other"), `<b>&lt;X&gt;</b>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Reloading unchanged files keeps the template set.
+	old := l.Template()
+	if affected, err := l.Reload(); err != nil || affected != nil || l.Template() != old {
+		t.Errorf("got Reload() = %v, %v and a new template set, want no change", affected, err)
+	}
+
+	fsys["t/nav.tmpl"] = &fstest.MapFile{Data: []byte(`{{define "nav"}}<nav><a href="/{{.}}">home</a></nav>{{end}}`)}
+	affected, err := l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"layout", "nav", "nav.tmpl"}; !reflect.DeepEqual(affected, want) {
+		t.Errorf("got affected templates %v, want %v", affected, want)
+	}
+	if l.Template() == old {
+		t.Fatal("Reload did not replace the template set")
+	}
+	for name, retained := range map[string]bool{"other": true, "body": true, "layout": false} {
+		if got := l.Template().Lookup(name).escapeErr == errEscapeOK; got != retained {
+			t.Errorf("%s: got retained %t, want %t", name, got, retained)
+		}
+	}
+	if got, want := execute("layout"), `<div><nav><a href="/%3cx%3e">home</a></nav><p>&lt;x&gt;</p></div>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := execute("other"), `<b>&lt;X&gt;</b>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := execute("body"), `<p>&lt;x&gt;</p>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// A syntax error keeps the current template set.
+	current := l.Template()
+	fsys["t/body.tmpl"] = &fstest.MapFile{Data: []byte(`{{define "body"}}{{if}}{{end}}`)}
+	if _, err := l.Reload(); err == nil {
+		t.Error("got no error reloading invalid template")
+	}
+	if l.Template() != current {
+		t.Error("failed Reload replaced the template set")
+	}
+
+	// Removing a template affects the templates that invoke it.
+	delete(fsys, "t/body.tmpl")
+	affected, err = l.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"body", "body.tmpl", "layout"}; !reflect.DeepEqual(affected, want) {
+		t.Errorf("got affected templates %v, want %v", affected, want)
+	}
+	if err := l.Template().ExecuteTemplate(&strings.Builder{}, "layout", nil); err == nil {
+		t.Error("got no error executing template that invokes a removed template")
+	}
+}
+
+func TestLoaderRetainsEscapingErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bad.tmpl":  {Data: []byte(`{{define "bad"}}<a title={{.}}>{{end}}`)},
+		"good.tmpl": {Data: []byte(`{{define "good"}}{{.}}{{end}}`)},
+	}
+	l, err := NewLoader(TrustedFS{fsys: fsys}, nil, "*.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Template().ExecuteTemplate(&strings.Builder{}, "bad", nil); err == nil {
+		t.Fatal("got no error executing bad")
+	}
+	fsys["good.tmpl"] = &fstest.MapFile{Data: []byte(`{{define "good"}}<i>{{.}}</i>{{end}}`)}
+	if _, err := l.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Template().ExecuteTemplate(&strings.Builder{}, "bad", nil); err == nil {
+		t.Error("got no error executing bad after Reload")
+	}
+}
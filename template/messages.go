@@ -0,0 +1,152 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A MessageCatalog contains the translated messages of an application in one
+// language. Its messages are interpolated into templates using the msg
+// function returned by Funcs.
+type MessageCatalog struct {
+	messages map[string]catalogMessage
+}
+
+// A catalogMessage is a message of a MessageCatalog.
+type catalogMessage struct {
+	// text is the text of a plain-text message.
+	text string
+	// markup is the template into which the placeholders of a
+	// markup-bearing message are substituted, or nil for plain-text
+	// messages.
+	markup *Template
+}
+
+// placeholderPattern matches the placeholders of messages, such as {name}.
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Delimiters of the actions substituting placeholders in the templates of
+// markup-bearing messages. They contain a NUL character, which messages
+// cannot contain, so the text of messages never contains actions.
+const (
+	placeholderLeftDelim  = "\x00{"
+	placeholderRightDelim = "}\x00"
+)
+
+// LoadMessageCatalog reads a MessageCatalog from the JSON file src, which maps
+// message keys to messages. A message is either a string, the text of a
+// plain-text message, or an object with the text of the message and whether
+// it contains markup:
+//
+//	{
+//		"greeting": "Hello, {name}!",
+//		"terms": {"text": "I accept the <a href=\"{url}\">terms</a>.", "html": true}
+//	}
+//
+// Messages may contain placeholders of the form {name}, which are substituted
+// with the arguments of msg.
+//
+// The text of markup-bearing messages is trusted HTML; it is parsed and
+// escaped as a template, so LoadMessageCatalog returns an error if the markup
+// is not well-formed or a placeholder occurs where actions are disallowed,
+// such as in an unquoted attribute value. Placeholders in markup-bearing
+// messages are sanitized for the context in which they occur, as actions are.
+func LoadMessageCatalog(src TrustedSource) (*MessageCatalog, error) {
+	_, b, err := readFileOS(src.String())
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("message catalog %s: %v", src, err)
+	}
+	c := &MessageCatalog{messages: make(map[string]catalogMessage, len(entries))}
+	for key, raw := range entries {
+		var entry struct {
+			Text string `json:"text"`
+			HTML bool   `json:"html"`
+		}
+		if err := json.Unmarshal(raw, &entry.Text); err != nil {
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				return nil, fmt.Errorf("message catalog %s: message %q: %v", src, key, err)
+			}
+		}
+		if strings.ContainsRune(entry.Text, 0) {
+			return nil, fmt.Errorf("message catalog %s: message %q contains a NUL character", src, key)
+		}
+		m := catalogMessage{text: entry.Text}
+		if entry.HTML {
+			text := placeholderPattern.ReplaceAllString(entry.Text, placeholderLeftDelim+".$1"+placeholderRightDelim)
+			m.markup, err = New(key).Delims(placeholderLeftDelim, placeholderRightDelim).ParseFromTrustedTemplate(TrustedTemplate{text})
+			if err == nil {
+				// Escape the template now to report errors in the markup
+				// or the contexts of its placeholders.
+				err = m.markup.escape()
+			}
+			if err != nil {
+				return nil, fmt.Errorf("message catalog %s: message %q: %v", src, key, err)
+			}
+		}
+		c.messages[key] = m
+	}
+	return c, nil
+}
+
+// Funcs returns a FuncMap containing the msg function, for use with Funcs:
+//
+//	msg key [name value]...
+//		Returns the message of c with the given key, substituting each
+//		placeholder {name} with the corresponding value.
+//
+// For plain-text messages, msg returns a string, which is escaped like any
+// other string in the context that it is interpolated into. For
+// markup-bearing messages, msg returns a safehtml.HTML value, in which values
+// are sanitized for the context of their placeholder in the markup. For
+// example,
+//
+//	{{msg "terms" "url" .TermsURL}}
+//
+// sanitizes .TermsURL as a URL.
+//
+// msg returns an error if c has no message with the given key, or if the
+// arguments do not provide a value for each placeholder of the message.
+func (c *MessageCatalog) Funcs() FuncMap {
+	return FuncMap{"msg": c.msg}
+}
+
+func (c *MessageCatalog) msg(key string, args ...interface{}) (interface{}, error) {
+	m, ok := c.messages[key]
+	if !ok {
+		return nil, fmt.Errorf("msg: no message with key %q", key)
+	}
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("msg %q: arguments must be name and value pairs", key)
+	}
+	values := make(map[string]interface{}, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		name, ok := args[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("msg %q: placeholder name %v is not a string", key, args[i])
+		}
+		values[name] = args[i+1]
+	}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(m.text, -1) {
+		if _, ok := values[match[1]]; !ok {
+			return nil, fmt.Errorf("msg %q: missing value for placeholder {%s}", key, match[1])
+		}
+	}
+	if m.markup != nil {
+		return m.markup.ExecuteToHTML(values)
+	}
+	return placeholderPattern.ReplaceAllStringFunc(m.text, func(placeholder string) string {
+		return fmt.Sprint(values[placeholder[1:len(placeholder)-1]])
+	}), nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func writeCatalog(t *testing.T, contents string) TrustedSource {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "messages.json")
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return TrustedSource{path}
+}
+
+func TestMessageCatalog(t *testing.T) {
+	c, err := LoadMessageCatalog(writeCatalog(t, `{
+		"greeting": "Hello, {name}!",
+		"terms": {"text": "I accept the <a href=\"{url}\" title=\"{name}\">terms</a>, {name}.", "html": true},
+		"braces": {"text": "<code>{{.}}</code>", "html": true}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range [...]struct {
+		desc  string
+		input stringConstant
+		data  interface{}
+		want  string
+	}{
+		{
+			"plain text",
+			`<p title="{{msg "greeting" "name" .}}">{{msg "greeting" "name" .}}</p>`,
+			`<Bob & "Alice">`,
+			`<p title="Hello, &lt;Bob &amp; &#34;Alice&#34;&gt;!">Hello, &lt;Bob &amp; &#34;Alice&#34;&gt;!</p>`,
+		},
+		{
+			"markup",
+			`<p>{{msg "terms" "url" .URL "name" .Name}}</p>`,
+			map[string]string{"URL": "javascript:alert(1)", "Name": "<b>"},
+			`<p>I accept the <a href="about:invalid#zGoSafez" title="&lt;b&gt;">terms</a>, &lt;b&gt;.</p>`,
+		},
+		{
+			"markup with safe value",
+			`{{msg "terms" "url" .URL "name" .Name}}`,
+			map[string]interface{}{"URL": safehtml.URLSanitized("/terms"), "Name": "you"},
+			`I accept the <a href="/terms" title="you">terms</a>, you.`,
+		},
+		{
+			"template delimiters in message",
+			`{{msg "braces"}}`,
+			nil,
+			`<code>{{.}}</code>`,
+		},
+	} {
+		tmpl := Must(New("page").Funcs(c.Funcs()).Parse(test.input))
+		var b strings.Builder
+		if err := tmpl.Execute(&b, test.data); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+	for _, input := range [...]stringConstant{
+		`{{msg "missing"}}`,
+		`{{msg "greeting"}}`,
+		`{{msg "greeting" "name"}}`,
+		`{{msg "greeting" 1 2}}`,
+	} {
+		tmpl := Must(New("page").Funcs(c.Funcs()).Parse(input))
+		if err := tmpl.Execute(&strings.Builder{}, nil); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestLoadMessageCatalogErrors(t *testing.T) {
+	for _, contents := range [...]string{
+		`[]`,
+		`{"a": 1}`,
+		`{"a": {"text": "<a href=\"{url}", "html": true}}`,
+		`{"a": {"text": "<a href={url}>", "html": true}}`,
+		`{"a": "\u0000"}`,
+	} {
+		if _, err := LoadMessageCatalog(writeCatalog(t, contents)); err == nil {
+			t.Errorf("%s: expected error", contents)
+		}
+	}
+	if _, err := LoadMessageCatalog(TrustedSource{filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
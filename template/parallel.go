@@ -0,0 +1,110 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/google/safehtml"
+)
+
+// parallelFuncName is the name of the function that renders fragments
+// concurrently.
+const parallelFuncName = "parallel"
+
+// WithParallelFragments adds the function parallel to t and all templates
+// associated with it, which starts rendering a template of the set in a new
+// goroutine and returns a safehtml.LazyHTML that waits for its output. A
+// page composed of several slow, independent sections can start rendering
+// them all before interpolating each, so that the sections are rendered
+// concurrently and the page takes as long as the slowest section rather than
+// all of them:
+//
+//	{{$nav := parallel "nav" .User}}{{$feed := parallel "feed" .Feed}}
+//	<nav>{{$nav}}</nav>
+//	<main>{{$feed}}</main>
+//
+// The output of the sections is interpolated in the order of the actions
+// that interpolate them, and an error rendering a section is returned by the
+// execution when its output is interpolated. Sections whose output is never
+// interpolated are rendered regardless, and their output is discarded.
+//
+// As with {{cached}}, each section is escaped as if it were executed on its
+// own. Sections are executed with the redactor, context, report-only mode,
+// and limits of the enclosing execution: their template invocations count
+// towards its maximum template call depth, so that a template that
+// recursively renders itself in parallel fails rather than starting
+// goroutines without bound, and they start counting sanitizer fallbacks from
+// its number of fallbacks. Interpolating a section waits for it at most
+// until the context of the execution, set using WithContext, is done. The
+// errors of sections in report-only mode are reported by the goroutine of the
+// enclosing execution when their output is interpolated, but redactors are
+// called concurrently by the sections. Sections must not modify data shared
+// with the enclosing execution or with each other, since they are executed
+// concurrently.
+//
+// Like Funcs, WithParallelFragments must be called before the template is
+// parsed. The return value is the template, so calls can be chained.
+func (t *Template) WithParallelFragments() *Template {
+	t.nameSpace.mu.Lock()
+	defer t.nameSpace.mu.Unlock()
+	t.nameSpace.parallelFragments = true
+	t.nameSpace.gen++
+	t.text.Funcs(template.FuncMap{parallelFuncName: parallelFunc(t, nil)})
+	return t
+}
+
+// parallelFunc returns the implementation of the parallel function for the
+// template set of t in the execution st, or for parsing if st is nil.
+func parallelFunc(t *Template, st *execState) func(string, ...interface{}) (safehtml.LazyHTML, error) {
+	return func(name string, data ...interface{}) (safehtml.LazyHTML, error) {
+		var arg interface{}
+		switch len(data) {
+		case 0:
+		case 1:
+			arg = data[0]
+		default:
+			return nil, fmt.Errorf("wrong number of arguments for parallel template %q: want at most 2, got %d", name, len(data)+1)
+		}
+		ctx := st.context()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report := st.report
+		opts := st.fragmentOptions()
+		var reported []*Error
+		if report != nil {
+			// Collect the errors of the section, to report them in the
+			// goroutine of st.
+			opts = append(opts, WithReportOnly(func(e *Error) {
+				reported = append(reported, e)
+			}))
+		}
+		var (
+			done = make(chan struct{})
+			html safehtml.HTML
+			err  error
+		)
+		go func() {
+			defer close(done)
+			html, err = t.executeFragment(name, arg, opts)
+		}()
+		return func() (safehtml.HTML, error) {
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return safehtml.HTML{}, ctx.Err()
+			}
+			for _, e := range reported {
+				report(e)
+			}
+			reported = nil
+			return html, err
+		}, nil
+	}
+}
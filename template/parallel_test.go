@@ -0,0 +1,122 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	gocontext "context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParallelFragments(t *testing.T) {
+	// Each section waits until both have started, which only happens if
+	// they are rendered concurrently.
+	var started sync.WaitGroup
+	started.Add(2)
+	tmpl := Must(New("page").WithParallelFragments().Funcs(FuncMap{
+		"arrive": func() (string, error) {
+			started.Done()
+			done := make(chan struct{})
+			go func() {
+				started.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return "", nil
+			case <-time.After(10 * time.Second):
+				return "", errors.New("sections were not rendered concurrently")
+			}
+		},
+	}).Parse(`{{$nav := parallel "nav" .Nav}}{{$feed := parallel "feed" .Feed}}` +
+		`<nav>{{$nav}}</nav><main>{{$feed}}</main>` +
+		`{{define "nav"}}{{arrive}}<a href="/">{{.}}</a>{{end}}` +
+		`{{define "feed"}}{{arrive}}{{range .}}<p>{{.}}</p>{{end}}{{end}}`))
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]interface{}{"Nav": "<Home>", "Feed": []string{"a", "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<nav><a href="/">&lt;Home&gt;</a></nav><main><p>a</p><p>b</p></main>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParallelFragmentsErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc, tmpl, want string
+	}{
+		{"section error", `{{$s := parallel "s"}}<p>{{$s}}</p>{{define "s"}}{{index . 1}}{{end}}`, `executing "s"`},
+		{"unknown section", `{{$s := parallel "missing" .}}<p>{{$s}}</p>`, `"missing" is undefined`},
+		{"arguments", `{{$s := parallel "s" 1 2}}{{define "s"}}{{end}}`, `wrong number of arguments for parallel template "s": want at most 2, got 3`},
+	} {
+		tmpl := Must(New("page").WithParallelFragments().Parse(stringConstant(test.tmpl)))
+		err := tmpl.Execute(&strings.Builder{}, map[string]interface{}{})
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: got error %v, want %q", test.desc, err, test.want)
+		}
+	}
+}
+
+func TestParallelFragmentsNotInterpolated(t *testing.T) {
+	tmpl := Must(New("page").WithParallelFragments().Parse(`{{$s := parallel "s" .}}{{if false}}{{$s}}{{end}}ok{{define "s"}}{{index . 1}}{{end}}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]interface{}{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := b.String(); got != "ok" {
+		t.Errorf("got %q, want %q", got, "ok")
+	}
+}
+
+func TestParallelFragmentsOptions(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+	tmpl := Must(New("page").WithParallelFragments().ContextFuncs(FuncMap{
+		"hang": func() string { <-hang; return "" },
+	}).Parse(`{{define "text"}}{{$s := parallel "p" .}}{{$s}}{{end}}` +
+		`{{define "p"}}<p>{{.}}</p>{{end}}` +
+		`{{define "script"}}{{$s := parallel "s" .}}{{$s}}{{end}}` +
+		`{{define "s"}}<script>var x = {{.}};</script>{{end}}` +
+		`{{define "recurse"}}{{$s := parallel "recurse" .}}{{$s}}{{end}}` +
+		`{{define "hang"}}{{$s := parallel "h"}}{{$s}}{{end}}` +
+		`{{define "h"}}{{hang}}{{end}}`))
+
+	var b strings.Builder
+	err := tmpl.ExecuteTemplateWithOptions(&b, "text", "secret", WithRedactor(func(string) string { return "<redacted>" }))
+	if want := "<p>&lt;redacted&gt;</p>"; err != nil || b.String() != want {
+		t.Errorf("redactor: got %q, %v, want %q", b.String(), err, want)
+	}
+
+	b.Reset()
+	var reported []*Error
+	err = tmpl.ExecuteTemplateWithOptions(&b, "script", "x", WithReportOnly(func(e *Error) {
+		reported = append(reported, e)
+	}))
+	if err != nil || len(reported) != 1 || reported[0].Name != "s" {
+		t.Errorf("report-only: got %v, reported %v, want one error reported for s", err, reported)
+	}
+
+	err = tmpl.ExecuteTemplateWithOptions(&strings.Builder{}, "recurse", nil, WithMaxTemplateDepth(4))
+	var depthErr *DepthError
+	if !errors.As(err, &depthErr) {
+		t.Fatalf("depth: got error %v, want a *DepthError", err)
+	}
+	if got, want := strings.Join(depthErr.Chain, " "), "recurse recurse recurse recurse recurse"; got != want {
+		t.Errorf("depth: got chain %q, want %q", got, want)
+	}
+
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = tmpl.ExecuteTemplateWithOptions(&strings.Builder{}, "hang", nil, WithContext(ctx))
+	if !errors.Is(err, gocontext.DeadlineExceeded) {
+		t.Errorf("deadline: got error %v, want %v", err, gocontext.DeadlineExceeded)
+	}
+}
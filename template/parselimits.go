@@ -0,0 +1,156 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"fmt"
+	"text/template"
+	"text/template/parse"
+)
+
+// A ParseLimit identifies a limit on the text parsed by Parse.
+type ParseLimit int
+
+const (
+	// SizeLimit is the limit set using MaxParseSize.
+	SizeLimit ParseLimit = iota + 1
+	// NodeLimit is the limit set using MaxParseNodes.
+	NodeLimit
+)
+
+// A ParseLimitError is wrapped by the *Error returned by Parse if the text
+// exceeds a limit set using MaxParseSize or MaxParseNodes. No templates are
+// parsed into the template set in this case.
+type ParseLimitError struct {
+	// Name is the name of the template being parsed.
+	Name string
+	// Limit is the limit that was exceeded.
+	Limit ParseLimit
+	// Max is the value of the limit.
+	Max int
+	// Actual is the size or number of parse nodes of the text.
+	Actual int
+}
+
+func (e *ParseLimitError) Error() string {
+	unit := "bytes"
+	if e.Limit == NodeLimit {
+		unit = "parse nodes"
+	}
+	return fmt.Sprintf("html/template: template %q has %d %s, exceeding the limit of %d", e.Name, e.Actual, unit, e.Max)
+}
+
+// MaxParseSize sets the maximum size in bytes of the text that each call to
+// Parse on t or any template associated with it accepts, including the calls
+// made by ParseFiles, ParseGlob and ParseFS for each file. A size of 0 or less
+// removes the limit. The return value is the template, so calls can be
+// chained.
+func (t *Template) MaxParseSize(size int) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.maxParseSize = size
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// MaxParseNodes sets the maximum number of parse nodes, such as text, actions
+// and the commands and arguments in them, in the templates defined by each
+// call to Parse on t or any template associated with it. A count of 0 or less
+// removes the limit. The return value is the template, so calls can be
+// chained.
+//
+// Unlike MaxParseSize, MaxParseNodes is enforced after the text is parsed, so
+// it is best combined with MaxParseSize to bound the memory used for parsing.
+func (t *Template) MaxParseNodes(nodes int) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.maxParseNodes = nodes
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// parseWithinLimits parses text into the underlying template of t, unless text
+// exceeds the limits of t's namespace.
+func (t *Template) parseWithinLimits(text string) (*template.Template, error) {
+	t.nameSpace.mu.Lock()
+	maxSize, maxNodes := t.nameSpace.maxParseSize, t.nameSpace.maxParseNodes
+	t.nameSpace.mu.Unlock()
+	if maxSize > 0 && len(text) > maxSize {
+		return nil, &ParseLimitError{Name: t.Name(), Limit: SizeLimit, Max: maxSize, Actual: len(text)}
+	}
+	if maxNodes <= 0 {
+		return t.text.Parse(text)
+	}
+	// Parse text into a copy of the underlying namespace, which shares the
+	// existing parse trees, so that t is unchanged if text exceeds the limit.
+	tmp, err := t.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmp.Parse(text); err != nil {
+		return nil, err
+	}
+	var trees []*template.Template
+	nodes := 0
+	for _, v := range tmp.Templates() {
+		if old := t.text.Lookup(v.Name()); old != nil && old.Tree == v.Tree {
+			continue
+		}
+		trees = append(trees, v)
+		nodes += countNodes(v.Root)
+	}
+	if nodes > maxNodes {
+		return nil, &ParseLimitError{Name: t.Name(), Limit: NodeLimit, Max: maxNodes, Actual: nodes}
+	}
+	for _, v := range trees {
+		if _, err := t.text.AddParseTree(v.Name(), v.Tree); err != nil {
+			return nil, err
+		}
+	}
+	return t.text, nil
+}
+
+// countNodes returns the number of nodes in the parse tree rooted at n.
+func countNodes(n parse.Node) int {
+	count := 1
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return 0
+		}
+		for _, c := range n.Nodes {
+			count += countNodes(c)
+		}
+	case *parse.ActionNode:
+		count += countNodes(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return 0
+		}
+		count += len(n.Decl)
+		for _, c := range n.Cmds {
+			count += countNodes(c)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			count += countNodes(arg)
+		}
+	case *parse.ChainNode:
+		count += countNodes(n.Node)
+	case *parse.IfNode:
+		count += countBranchNodes(&n.BranchNode)
+	case *parse.RangeNode:
+		count += countBranchNodes(&n.BranchNode)
+	case *parse.WithNode:
+		count += countBranchNodes(&n.BranchNode)
+	case *parse.TemplateNode:
+		count += countNodes(n.Pipe)
+	}
+	return count
+}
+
+func countBranchNodes(n *parse.BranchNode) int {
+	return countNodes(n.Pipe) + countNodes(n.List) + countNodes(n.ElseList)
+}
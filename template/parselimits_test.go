@@ -0,0 +1,73 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseLimits(t *testing.T) {
+	for _, test := range [...]struct {
+		desc            string
+		maxSize, nodes  int
+		input           stringConstant
+		wantLimit       ParseLimit
+		wantMax, actual int
+	}{
+		{desc: "no limits", input: `{{define "a"}}{{.}}{{end}}`},
+		{desc: "within size", maxSize: 26, input: `{{define "a"}}{{.}}{{end}}`},
+		{desc: "size", maxSize: 25, input: `{{define "a"}}{{.}}{{end}}`, wantLimit: SizeLimit, wantMax: 25, actual: 26},
+		// List, action, pipe, command and dot nodes for "a", and an empty
+		// list for the top-level template.
+		{desc: "within nodes", nodes: 6, input: `{{define "a"}}{{.}}{{end}}`},
+		{desc: "nodes", nodes: 5, input: `{{define "a"}}{{.}}{{end}}`, wantLimit: NodeLimit, wantMax: 5, actual: 6},
+		{desc: "nested nodes", nodes: 10, input: `{{if .A}}<p>{{.B | printf "%s"}}</p>{{end}}`, wantLimit: NodeLimit, wantMax: 10, actual: 15},
+	} {
+		tmpl := New("page").MaxParseSize(test.maxSize).MaxParseNodes(test.nodes)
+		_, err := tmpl.Parse(test.input)
+		if test.wantLimit == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.desc, err)
+			} else if tmpl.Lookup("a") == nil {
+				t.Errorf("%s: template not parsed", test.desc)
+			}
+			continue
+		}
+		var e *Error
+		var limitErr *ParseLimitError
+		if !errors.As(err, &e) || e.ErrorCode != ErrParseLimit || !errors.As(err, &limitErr) {
+			t.Errorf("%s: got error %#v, want *Error with code ErrParseLimit wrapping a *ParseLimitError", test.desc, err)
+			continue
+		}
+		want := ParseLimitError{Name: "page", Limit: test.wantLimit, Max: test.wantMax, Actual: test.actual}
+		if *limitErr != want {
+			t.Errorf("%s: got %+v, want %+v", test.desc, *limitErr, want)
+		}
+	}
+}
+
+func TestParseNodeLimitLeavesTemplateUnchanged(t *testing.T) {
+	tmpl := Must(New("page").MaxParseNodes(10).Parse(`{{define "a"}}old{{end}}`))
+	if _, err := tmpl.Parse(`{{define "a"}}{{.A}}{{.B}}{{.C}}{{end}}{{define "b"}}b{{end}}`); err == nil {
+		t.Fatal("got no error, want node limit error")
+	}
+	if tmpl.Lookup("b") != nil {
+		t.Error("template b was defined by Parse that exceeded the limit")
+	}
+	if _, err := tmpl.Parse(`{{define "c"}}c{{end}}`); err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, "a", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "old"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
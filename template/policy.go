@@ -0,0 +1,463 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/internal/safehtmlutil"
+	"github.com/google/safehtml/urlsafe"
+)
+
+// anyElement is the element name used in a Policy to refer to all elements.
+const anyElement = "*"
+
+// A Policy tightens the sanitization rules that a template set is escaped and
+// executed with, for applications that must adhere to stricter standards than
+// the defaults of this package. A Policy can only remove or restrict
+// allowances; it never allows content that would otherwise be rejected.
+//
+// The zero value is an empty policy that imposes no additional restrictions.
+// Methods that modify a Policy return it so that calls can be chained:
+//
+//	p := new(template.Policy).
+//		ForbidElements("iframe").
+//		ForbidURLSchemes("data")
+//	tmpl := template.New("page").WithPolicy(p)
+//
+// Element and attribute names are case-insensitive.
+type Policy struct {
+	forbiddenElements map[string]bool
+	// forbiddenAttrs[element][attr] reports whether attr is forbidden in element.
+	forbiddenAttrs map[string]map[string]bool
+	// requireTrustedResourceURL[element][attr] reports whether attr values in
+	// element must be safehtml.TrustedResourceURL values.
+	requireTrustedResourceURL map[string]map[string]bool
+	forbiddenURLSchemes       map[string]bool
+	// dataURLMIMETypes contains the MIME types of the data URLs that are
+	// allowed, or is nil if data URLs are not restricted.
+	dataURLMIMETypes map[string]bool
+	// maxURLLength is the maximum length of URLs, or 0 if it is unlimited.
+	maxURLLength        int
+	rejectURLWhitespace bool
+}
+
+// ForbidElements forbids the named elements from appearing anywhere in the
+// template text, whether or not they contain actions.
+func (p *Policy) ForbidElements(names ...string) *Policy {
+	if p.forbiddenElements == nil {
+		p.forbiddenElements = make(map[string]bool)
+	}
+	for _, name := range names {
+		p.forbiddenElements[strings.ToLower(name)] = true
+	}
+	return p
+}
+
+// ForbidAttributes forbids the named attributes from appearing in the given
+// element anywhere in the template text, whether or not their values contain
+// actions. If element is "*", the attributes are forbidden in all elements.
+func (p *Policy) ForbidAttributes(element string, attrs ...string) *Policy {
+	p.forbiddenAttrs = addElementAttrs(p.forbiddenAttrs, element, attrs)
+	return p
+}
+
+// RequireTrustedResourceURL requires actions in the values of the named
+// attributes of element to produce safehtml.TrustedResourceURL values, even
+// in attribute values that otherwise accept safehtml.URL values or sanitized
+// strings. For example,
+//
+//	p.RequireTrustedResourceURL("img", "src")
+//
+// only allows images to be loaded from application-controlled URLs. If element
+// is "*", the requirement applies to the attributes in all elements.
+func (p *Policy) RequireTrustedResourceURL(element string, attrs ...string) *Policy {
+	p.requireTrustedResourceURL = addElementAttrs(p.requireTrustedResourceURL, element, attrs)
+	return p
+}
+
+// ForbidURLSchemes forbids URLs with the given schemes (e.g. "data") from
+// being interpolated into URL attribute values.
+//
+// Template text containing a URL prefix with a forbidden scheme followed by an
+// action will fail to escape. At execution time, any string or safehtml.URL
+// value with a forbidden scheme is replaced by safehtml.InnocuousURL.
+// safehtml.TrustedResourceURL values, and URLs in the template text itself,
+// are not affected.
+func (p *Policy) ForbidURLSchemes(schemes ...string) *Policy {
+	if p.forbiddenURLSchemes == nil {
+		p.forbiddenURLSchemes = make(map[string]bool)
+	}
+	for _, scheme := range schemes {
+		p.forbiddenURLSchemes[strings.ToLower(strings.TrimSuffix(scheme, ":"))] = true
+	}
+	return p
+}
+
+// AllowDataURLMIMETypes restricts the data URLs that may be interpolated into
+// URL attribute values to those with base64-encoded content and one of the
+// given MIME types, such as "image/png". Without a call to
+// AllowDataURLMIMETypes, data URLs of any MIME type are allowed; after the
+// first call, only the MIME types passed to this and subsequent calls are. For
+// example,
+//
+//	p.AllowDataURLMIMETypes(urlsafe.DefaultDataURLMIMETypes()...).
+//		AllowDataURLMIMETypes("application/pdf")
+//
+// allows audio, image, and video data URLs, and PDF documents. To forbid data
+// URLs entirely, use ForbidURLSchemes("data") instead.
+//
+// Data URLs are enforced like forbidden schemes: template text containing a
+// disallowed data URL prefix followed by an action will fail to escape, and
+// disallowed data URL values are replaced by safehtml.InnocuousURL at
+// execution time.
+func (p *Policy) AllowDataURLMIMETypes(mimeTypes ...string) *Policy {
+	if p.dataURLMIMETypes == nil {
+		p.dataURLMIMETypes = make(map[string]bool)
+	}
+	for _, mimeType := range mimeTypes {
+		p.dataURLMIMETypes[strings.ToLower(mimeType)] = true
+	}
+	return p
+}
+
+// LimitURLLength limits the length of the URLs interpolated into URL attribute
+// values to n bytes, for applications whose URLs are consumed by parsers that
+// reject long URLs. If n is 0 or less, URL length is unlimited.
+//
+// Template text containing a URL prefix longer than n bytes followed by an
+// action will fail to escape. At execution time, any string or safehtml.URL
+// value that is longer than n bytes once normalized is replaced by
+// safehtml.InnocuousURL. The limit applies to each interpolated value, and to
+// each URL of a srcset attribute value, rather than to the whole attribute
+// value. safehtml.TrustedResourceURL values are not affected.
+func (p *Policy) LimitURLLength(n int) *Policy {
+	if n < 0 {
+		n = 0
+	}
+	p.maxURLLength = n
+	return p
+}
+
+// RejectURLWhitespace causes string and safehtml.URL values that contain ASCII
+// whitespace or control characters to be replaced by safehtml.InnocuousURL
+// when they are interpolated into URL attribute values, rather than having
+// those characters percent-encoded. This is for applications whose URLs are
+// consumed by parsers that treat such characters differently than browsers.
+// URLs in template text already may not contain such characters before an
+// action. safehtml.TrustedResourceURL values, and srcset attribute values,
+// whose candidates are separated by whitespace, are not affected.
+func (p *Policy) RejectURLWhitespace() *Policy {
+	p.rejectURLWhitespace = true
+	return p
+}
+
+func addElementAttrs(m map[string]map[string]bool, element string, attrs []string) map[string]map[string]bool {
+	if m == nil {
+		m = make(map[string]map[string]bool)
+	}
+	element = strings.ToLower(element)
+	if m[element] == nil {
+		m[element] = make(map[string]bool)
+	}
+	for _, attr := range attrs {
+		m[element][strings.ToLower(attr)] = true
+	}
+	return m
+}
+
+// clone returns a deep copy of p.
+func (p *Policy) clone() *Policy {
+	if p == nil {
+		return nil
+	}
+	return &Policy{
+		forbiddenElements:         copySet(p.forbiddenElements),
+		forbiddenAttrs:            copyElementAttrs(p.forbiddenAttrs),
+		requireTrustedResourceURL: copyElementAttrs(p.requireTrustedResourceURL),
+		forbiddenURLSchemes:       copySet(p.forbiddenURLSchemes),
+		dataURLMIMETypes:          copySet(p.dataURLMIMETypes),
+		maxURLLength:              p.maxURLLength,
+		rejectURLWhitespace:       p.rejectURLWhitespace,
+	}
+}
+
+func copySet(m map[string]bool) map[string]bool {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]bool, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func copyElementAttrs(m map[string]map[string]bool) map[string]map[string]bool {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[string]map[string]bool, len(m))
+	for k, v := range m {
+		ret[k] = copySet(v)
+	}
+	return ret
+}
+
+// WithPolicy sets the policy that t and all templates associated with it are
+// escaped and executed with. It must be called before any of the templates are
+// executed. Subsequent modifications to p do not affect t.
+func (t *Template) WithPolicy(p *Policy) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.policy = p.clone()
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// policyErrorf returns an error for content that is disallowed only by a
+// Policy.
+func policyErrorf(format string, args ...interface{}) error {
+	return codedErrorf(ErrPolicyViolation, format, args...)
+}
+
+// isElementForbidden reports whether p forbids element.
+func (p *Policy) isElementForbidden(element string) bool {
+	return p != nil && p.forbiddenElements[element]
+}
+
+// isAttrForbidden reports whether p forbids attr in element.
+func (p *Policy) isAttrForbidden(element, attr string) bool {
+	return p != nil && (p.forbiddenAttrs[element][attr] || p.forbiddenAttrs[anyElement][attr])
+}
+
+// checkTransition returns an error if transitioning to context c introduces an
+// element or attribute that p forbids.
+func (p *Policy) checkTransition(c context) error {
+	if p == nil {
+		return nil
+	}
+	if p.isElementForbidden(c.element.name) {
+		return policyErrorf("element %q is forbidden by the template policy", c.element.name)
+	}
+	if c.attr.name != "" && p.isAttrForbidden(c.element.name, c.attr.name) {
+		return policyErrorf("attribute %q is forbidden in %q elements by the template policy", c.attr.name, c.element.name)
+	}
+	return nil
+}
+
+// elementContentContext returns the sanitization context of the content of
+// element after p has been applied to sc, the default context for element.
+func (p *Policy) elementContentContext(element string, sc SanitizationContext) (SanitizationContext, error) {
+	if p.isElementForbidden(element) {
+		return 0, policyErrorf("actions must not occur in the element content context of a %q element, which is forbidden by the template policy", element)
+	}
+	return sc, nil
+}
+
+// attrValContext returns the sanitization context of the value of attr in
+// element after p has been applied to sc, the default context for the value.
+func (p *Policy) attrValContext(element, attr string, sc SanitizationContext) (SanitizationContext, error) {
+	if p == nil {
+		return sc, nil
+	}
+	if p.isElementForbidden(element) || p.isAttrForbidden(element, attr) {
+		return 0, policyErrorf("actions must not occur in the %q attribute value context of a %q element, which is forbidden by the template policy", attr, element)
+	}
+	if p.requireTrustedResourceURL[element][attr] || p.requireTrustedResourceURL[anyElement][attr] {
+		switch sc {
+		case SanitizationContextURL, SanitizationContextTrustedResourceURLOrURL:
+			return SanitizationContextTrustedResourceURL, nil
+		}
+	}
+	return sc, nil
+}
+
+// restrictsURLs reports whether p restricts the URLs in URL attribute values.
+func (p *Policy) restrictsURLs() bool {
+	return p != nil && (len(p.forbiddenURLSchemes) > 0 || p.dataURLMIMETypes != nil || p.maxURLLength > 0 || p.rejectURLWhitespace)
+}
+
+// validateURLPrefix returns an error if prefix, a safe URL prefix
+// that has been HTML-unescaped, has a scheme that p forbids, is a
+// data URL prefix that p does not allow, or is longer than p allows.
+func (p *Policy) validateURLPrefix(prefix string) error {
+	if !p.restrictsURLs() {
+		return nil
+	}
+	if p.maxURLLength > 0 && len(prefix) > p.maxURLLength {
+		return policyErrorf("URL prefix %q is longer than the maximum URL length of %d bytes allowed by the template policy", prefix, p.maxURLLength)
+	}
+	scheme := urlScheme(prefix)
+	if p.forbiddenURLSchemes[scheme] {
+		return policyErrorf("URL prefix %q has the scheme %q, which is forbidden by the template policy", prefix, scheme)
+	}
+	if scheme == "data" && !p.isDataURLAllowed(prefix) {
+		return policyErrorf("data URL prefix %q must have base64-encoded content of a MIME type allowed by the template policy", prefix)
+	}
+	return nil
+}
+
+// isURLForbidden reports whether p forbids url, a sanitized URL.
+func (p *Policy) isURLForbidden(url string) bool {
+	// URLs are normalized after they are sanitized, which may lengthen them.
+	if p.maxURLLength > 0 && len(urlsafe.NormalizeURL(url)) > p.maxURLLength {
+		return true
+	}
+	scheme := urlScheme(url)
+	return p.forbiddenURLSchemes[scheme] || scheme == "data" && !p.isDataURLAllowed(url)
+}
+
+// isDataURLAllowed reports whether p allows url, a data URL or data URL prefix.
+func (p *Policy) isDataURLAllowed(url string) bool {
+	if p.dataURLMIMETypes == nil {
+		return true
+	}
+	mimeType, ok := urlsafe.DataURLMIMEType(url)
+	return ok && p.dataURLMIMETypes[mimeType]
+}
+
+// urlScheme returns the lowercase scheme of url, or the empty string if
+// url has no scheme.
+func urlScheme(url string) string {
+	if !startsWithFullySpecifiedSchemePattern.MatchString(url) {
+		return ""
+	}
+	return strings.ToLower(url[:strings.IndexByte(url, ':')])
+}
+
+// funcs returns the sanitizers that must override the defaults in funcs
+// to enforce p at execution time.
+func (p *Policy) funcs() template.FuncMap {
+	if !p.restrictsURLs() {
+		return nil
+	}
+	filter := func(sanitize func(...interface{}) (string, error)) func(...interface{}) (string, error) {
+		return func(args ...interface{}) (string, error) {
+			if len(args) > 0 {
+				if _, ok := safehtmlutil.Indirect(args[0]).(safehtml.TrustedResourceURL); ok {
+					return sanitize(args...)
+				}
+			}
+			if in := safehtmlutil.Stringify(args...); p.rejectURLWhitespace && containsWhitespaceOrControlPattern.MatchString(in) {
+				diag.Log("safehtml/template: replaced URL containing whitespace", "url", in)
+				return safehtml.InnocuousURL, nil
+			}
+			out, err := sanitize(args...)
+			if err == nil && p.isURLForbidden(out) {
+				diag.Log("safehtml/template: replaced URL forbidden by policy", "url", out)
+				return safehtml.InnocuousURL, nil
+			}
+			return out, err
+		}
+	}
+	return template.FuncMap{
+		sanitizeURLFuncName:                     filter(sanitizeURL),
+		sanitizeTrustedResourceURLOrURLFuncName: filter(sanitizeTrustedResourceURLOrURL),
+		sanitizeURLSetFuncName: func(args ...interface{}) (string, error) {
+			out, err := sanitizeURLSet(args...)
+			if err != nil {
+				return out, err
+			}
+			// Candidates in the output of sanitizeURLSet are separated by " , ",
+			// and consist of a URL optionally followed by descriptors.
+			for _, candidate := range strings.Split(out, " , ") {
+				if fields := strings.Fields(candidate); len(fields) > 0 && p.isURLForbidden(fields[0]) {
+					diag.Log("safehtml/template: replaced URL set containing URL forbidden by policy", "url", fields[0])
+					return safehtml.InnocuousURL, nil
+				}
+			}
+			return out, nil
+		},
+	}
+}
+
+// apply modifies tables to reflect the restrictions in p.
+func (p *Policy) apply(tables *SanitizationTables) {
+	if p == nil {
+		return
+	}
+	for elem := range p.forbiddenElements {
+		delete(tables.ElementContent, elem)
+		delete(tables.ElementAttributes, elem)
+		tables.ForbiddenElements = append(tables.ForbiddenElements, elem)
+	}
+	var voids []string
+	for _, elem := range tables.VoidElements {
+		if !p.forbiddenElements[elem] {
+			voids = append(voids, elem)
+		}
+	}
+	tables.VoidElements = voids
+	for elem, attrs := range p.forbiddenAttrs {
+		for attr := range attrs {
+			if elem == anyElement {
+				delete(tables.GlobalAttributes, attr)
+				for _, elemAttrs := range tables.ElementAttributes {
+					delete(elemAttrs, attr)
+				}
+			} else if tables.ElementAttributes[elem] != nil {
+				delete(tables.ElementAttributes[elem], attr)
+			}
+			if tables.ForbiddenAttributes == nil {
+				tables.ForbiddenAttributes = make(map[string][]string)
+			}
+			tables.ForbiddenAttributes[elem] = append(tables.ForbiddenAttributes[elem], attr)
+		}
+	}
+	for _, attrs := range tables.ForbiddenAttributes {
+		sort.Strings(attrs)
+	}
+	require := func(m map[string]SanitizationContext, attr string) {
+		switch m[attr] {
+		case SanitizationContextURL, SanitizationContextTrustedResourceURLOrURL:
+			m[attr] = SanitizationContextTrustedResourceURL
+		}
+	}
+	for elem, attrs := range p.requireTrustedResourceURL {
+		for attr := range attrs {
+			if elem == anyElement {
+				require(tables.GlobalAttributes, attr)
+				for _, elemAttrs := range tables.ElementAttributes {
+					require(elemAttrs, attr)
+				}
+				continue
+			}
+			if sc, ok := tables.GlobalAttributes[attr]; ok && tables.hasGlobalAttributes(elem) {
+				// Record the global attribute as an attribute of elem, whose
+				// context differs from that of the other elements.
+				if _, ok := tables.ElementAttributes[elem][attr]; !ok {
+					if tables.ElementAttributes[elem] == nil {
+						tables.ElementAttributes[elem] = make(map[string]SanitizationContext)
+					}
+					tables.ElementAttributes[elem][attr] = sc
+				}
+			}
+			if tables.ElementAttributes[elem] != nil {
+				require(tables.ElementAttributes[elem], attr)
+			}
+		}
+	}
+	for scheme := range p.forbiddenURLSchemes {
+		tables.ForbiddenURLSchemes = append(tables.ForbiddenURLSchemes, scheme)
+	}
+	for mimeType := range p.dataURLMIMETypes {
+		tables.AllowedDataURLMIMETypes = append(tables.AllowedDataURLMIMETypes, mimeType)
+	}
+	if p.dataURLMIMETypes != nil && len(p.dataURLMIMETypes) == 0 && !p.forbiddenURLSchemes["data"] {
+		// No data URLs are allowed at all.
+		tables.ForbiddenURLSchemes = append(tables.ForbiddenURLSchemes, "data")
+	}
+	tables.MaxURLLength = p.maxURLLength
+	tables.RejectURLWhitespace = p.rejectURLWhitespace
+	sort.Strings(tables.ForbiddenElements)
+	sort.Strings(tables.ForbiddenURLSchemes)
+	sort.Strings(tables.AllowedDataURLMIMETypes)
+}
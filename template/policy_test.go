@@ -0,0 +1,364 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/registry"
+	"github.com/google/safehtml/testconversions"
+	"github.com/google/safehtml/urlsafe"
+)
+
+func TestPolicyEscapeErrors(t *testing.T) {
+	for _, test := range [...]struct {
+		desc   string
+		policy *Policy
+		input  stringConstant
+		err    string
+	}{
+		{
+			"forbidden element in template text",
+			new(Policy).ForbidElements("IFrame"),
+			`<p>Hello</p><iframe src="/embed"></iframe>`,
+			`element "iframe" is forbidden by the template policy`,
+		},
+		{
+			"forbidden element in conditional branch",
+			new(Policy).ForbidElements("iframe"),
+			`{{if .}}<iframe{{else}}<img{{end}} src="/x">`,
+			`element "iframe" is forbidden by the template policy`,
+		},
+		{
+			"forbidden attribute in one element",
+			new(Policy).ForbidAttributes("a", "target"),
+			`<a href="/" target="_blank">Link</a>`,
+			`attribute "target" is forbidden in "a" elements by the template policy`,
+		},
+		{
+			"attribute forbidden in all elements",
+			new(Policy).ForbidAttributes("*", "autofocus"),
+			`<input autofocus>`,
+			`attribute "autofocus" is forbidden in "input" elements by the template policy`,
+		},
+		{
+			"forbidden URL scheme in prefix",
+			new(Policy).ForbidURLSchemes("data:"),
+			`<img src="data:image/png;base64,{{.}}">`,
+			`URL prefix "data:image/png;base64," has the scheme "data", which is forbidden by the template policy`,
+		},
+		{
+			"data URL prefix with disallowed MIME type",
+			new(Policy).AllowDataURLMIMETypes("image/png"),
+			`<img src="data:image/svg+xml;base64,{{.}}">`,
+			`data URL prefix "data:image/svg+xml;base64," must have base64-encoded content of a MIME type allowed by the template policy`,
+		},
+		{
+			"data URL prefix without MIME type",
+			new(Policy).AllowDataURLMIMETypes("image/png"),
+			`<a href="data:{{.}}">`,
+			`data URL prefix "data:" must have base64-encoded content`,
+		},
+		{
+			"URL prefix longer than the maximum length",
+			new(Policy).LimitURLLength(8),
+			`<a href="/foo/bar/{{.}}">`,
+			`URL prefix "/foo/bar/" is longer than the maximum URL length of 8 bytes allowed by the template policy`,
+		},
+		{
+			"forbidden URL scheme in HTML-escaped prefix",
+			new(Policy).ForbidURLSchemes("data"),
+			`<a href="DATA&colon;text/plain,{{.}}">`,
+			`which is forbidden by the template policy`,
+		},
+	} {
+		tmpl := New(test.desc).WithPolicy(test.policy)
+		Must(tmpl.Parse(test.input))
+		err := tmpl.Execute(&bytes.Buffer{}, "x")
+		if err == nil {
+			t.Errorf("%s: expected error", test.desc)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error\n\t%s\nwant error containing\n\t%s", test.desc, err, test.err)
+		}
+		var tmplErr *Error
+		if !errors.As(err, &tmplErr) || tmplErr.ErrorCode != ErrPolicyViolation {
+			t.Errorf("%s: got error %#v, want ErrorCode ErrPolicyViolation", test.desc, err)
+		}
+	}
+}
+
+func TestPolicyAllowsUnaffectedContent(t *testing.T) {
+	p := new(Policy).
+		ForbidElements("iframe").
+		ForbidAttributes("a", "target").
+		ForbidURLSchemes("data")
+	tmpl := Must(New("").WithPolicy(p).Parse(`<a href="{{.}}" title="t">Link</a><area target="_blank"><img src="https://foo.com/{{.}}">`))
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, "x"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := b.String(), `<a href="x" title="t">Link</a><area target="_blank"><img src="https://foo.com/x">`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPolicyRequireTrustedResourceURL(t *testing.T) {
+	for _, test := range [...]struct {
+		desc   string
+		policy *Policy
+		data   interface{}
+		want   string
+		err    string
+	}{
+		{
+			"TrustedResourceURL accepted",
+			new(Policy).RequireTrustedResourceURL("img", "src"),
+			safehtml.TrustedResourceURLFromConstant("/logo.png"),
+			`<img src="/logo.png">`, "",
+		},
+		{
+			"URL rejected",
+			new(Policy).RequireTrustedResourceURL("img", "src"),
+			safehtml.URLSanitized("/logo.png"),
+			"", "expected a safehtml.TrustedResourceURL value",
+		},
+		{
+			"string rejected with wildcard element",
+			new(Policy).RequireTrustedResourceURL("*", "src"),
+			"/logo.png",
+			"", "expected a safehtml.TrustedResourceURL value",
+		},
+		{
+			"string allowed in other attribute",
+			new(Policy).RequireTrustedResourceURL("img", "srcset"),
+			"/logo.png",
+			`<img src="/logo.png">`, "",
+		},
+	} {
+		tmpl := Must(New("").WithPolicy(test.policy).Parse(`<img src="{{.}}">`))
+		var b bytes.Buffer
+		err := tmpl.Execute(&b, test.data)
+		switch {
+		case test.err != "" && err == nil:
+			t.Errorf("%s: expected error", test.desc)
+		case test.err != "" && !strings.Contains(err.Error(), test.err):
+			t.Errorf("%s: got error\n\t%s\nwant error containing\n\t%s", test.desc, err, test.err)
+		case test.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+		case test.err == "" && b.String() != test.want:
+			t.Errorf("%s: got %q, want %q", test.desc, b.String(), test.want)
+		}
+	}
+}
+
+func TestPolicyForbiddenURLSchemesAtExecution(t *testing.T) {
+	p := new(Policy).ForbidURLSchemes("data")
+	tmpl := Must(New("").WithPolicy(p).Parse(`<a href="{{.A}}"></a><form action="{{.B}}"></form><img srcset="{{.C}}"><img src="{{.D}}">`))
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, map[string]interface{}{
+		"A": "data:text/html,foo",
+		"B": safehtml.URLSanitized("DATA:image/png;base64,AAAA"),
+		"C": "/a.png 1x, data:image/png;base64,AAAA 2x",
+		"D": testconversions.MakeTrustedResourceURLForTest("data:image/png;base64,AAAA"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<a href="about:invalid#zGoSafez"></a><form action="about:invalid#zGoSafez"></form><img srcset="about:invalid#zGoSafez"><img src="data:image/png;base64,AAAA">`
+	if got := b.String(); got != want {
+		t.Errorf("got:\n\t%s\nwant:\n\t%s", got, want)
+	}
+}
+
+func TestPolicyDataURLMIMETypes(t *testing.T) {
+	p := new(Policy).
+		AllowDataURLMIMETypes(urlsafe.DefaultDataURLMIMETypes()...).
+		AllowDataURLMIMETypes("Application/PDF")
+	tmpl := Must(New("").WithPolicy(p).Parse(`<a href="{{.}}"></a><img srcset="{{.}} 2x"><img src="data:image/png;base64,{{.}}">`))
+	for _, test := range [...]struct {
+		data, want string
+	}{
+		{
+			"data:application/pdf;base64,JVBE",
+			`<a href="data:application/pdf;base64,JVBE"></a><img srcset="data:application/pdf;base64,JVBE 2x"><img src="data:image/png;base64,data:application/pdf;base64,JVBE">`,
+		},
+		{
+			"data:image/gif;base64,R0lG",
+			`<a href="data:image/gif;base64,R0lG"></a><img srcset="data:image/gif;base64,R0lG 2x"><img src="data:image/png;base64,data:image/gif;base64,R0lG">`,
+		},
+		{
+			"data:text/html;base64,PHNj",
+			`<a href="about:invalid#zGoSafez"></a><img srcset="about:invalid#zGoSafez 2x"><img src="data:image/png;base64,data:text/html;base64,PHNj">`,
+		},
+		{
+			"data:image/png,<svg>",
+			`<a href="about:invalid#zGoSafez"></a><img srcset="about:invalid#zGoSafez 2x"><img src="data:image/png;base64,data:image/png,%3csvg%3e">`,
+		},
+		{
+			"/a.png",
+			`<a href="/a.png"></a><img srcset="/a.png 2x"><img src="data:image/png;base64,/a.png">`,
+		},
+	} {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, test.data); err != nil {
+			t.Errorf("%q: unexpected error: %v", test.data, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%q: got:\n\t%s\nwant:\n\t%s", test.data, got, test.want)
+		}
+	}
+	tables := New("").WithPolicy(p).SanitizationTables()
+	if got, want := len(tables.AllowedDataURLMIMETypes), len(urlsafe.DefaultDataURLMIMETypes())+1; got != want {
+		t.Errorf("got %d AllowedDataURLMIMETypes, want %d", got, want)
+	}
+	if got := New("").WithPolicy(new(Policy).AllowDataURLMIMETypes()).SanitizationTables().ForbiddenURLSchemes; len(got) != 1 || got[0] != "data" {
+		t.Errorf("empty data URL allowlist: ForbiddenURLSchemes = %q, want data", got)
+	}
+}
+
+func TestPolicyURLLimits(t *testing.T) {
+	for _, test := range [...]struct {
+		desc   string
+		policy *Policy
+		data   interface{}
+		want   string
+	}{
+		{
+			"URL within the maximum length",
+			new(Policy).LimitURLLength(10),
+			"/abcdefghi",
+			`<a href="/abcdefghi"></a><img srcset="/abcdefghi 2x">`,
+		},
+		{
+			"URL longer than the maximum length",
+			new(Policy).LimitURLLength(10),
+			"/abcdefghij",
+			`<a href="about:invalid#zGoSafez"></a><img srcset="about:invalid#zGoSafez 2x">`,
+		},
+		{
+			"URL longer than the maximum length once normalized",
+			new(Policy).LimitURLLength(10),
+			"/a b c d e",
+			`<a href="about:invalid#zGoSafez"></a><img srcset="about:invalid#zGoSafez 2x">`,
+		},
+		{
+			"whitespace normalized by default",
+			new(Policy),
+			"/a\tb",
+			`<a href="/a%09b"></a><img srcset="about:invalid#zGoSafez 2x">`,
+		},
+		{
+			"whitespace rejected",
+			new(Policy).RejectURLWhitespace(),
+			"/a\tb",
+			`<a href="about:invalid#zGoSafez"></a><img srcset="about:invalid#zGoSafez 2x">`,
+		},
+		{
+			"control character in URL rejected",
+			new(Policy).RejectURLWhitespace(),
+			safehtml.URLSanitized("/a\x00b"),
+			"<a href=\"about:invalid#zGoSafez\"></a><img srcset=\"/a\uFFFDb 2x\">",
+		},
+		{
+			"URL without whitespace allowed",
+			new(Policy).RejectURLWhitespace(),
+			"/a%20b",
+			`<a href="/a%20b"></a><img srcset="/a%20b 2x">`,
+		},
+	} {
+		tmpl := Must(New("").WithPolicy(test.policy).Parse(`<a href="{{.}}"></a><img srcset="{{.}} 2x">`))
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, test.data); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+	tables := New("").WithPolicy(new(Policy).LimitURLLength(2048).RejectURLWhitespace()).SanitizationTables()
+	if tables.MaxURLLength != 2048 || !tables.RejectURLWhitespace {
+		t.Errorf("got MaxURLLength %d and RejectURLWhitespace %t, want 2048 and true", tables.MaxURLLength, tables.RejectURLWhitespace)
+	}
+}
+
+func TestPolicyIsCopied(t *testing.T) {
+	p := new(Policy)
+	tmpl := Must(New("").WithPolicy(p).Parse(`<iframe></iframe>`))
+	p.ForbidElements("iframe")
+	if err := tmpl.Execute(&bytes.Buffer{}, nil); err != nil {
+		t.Errorf("modifying policy after WithPolicy affected template: %v", err)
+	}
+}
+
+func TestPolicyClone(t *testing.T) {
+	tmpl := Must(New("").WithPolicy(new(Policy).ForbidElements("iframe")).Parse(`<iframe></iframe>`))
+	clone, err := tmpl.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.Execute(&bytes.Buffer{}, nil); err == nil {
+		t.Errorf("expected cloned template to inherit policy")
+	}
+}
+
+func TestPolicySanitizationTables(t *testing.T) {
+	p := new(Policy).
+		ForbidElements("iframe", "img").
+		ForbidAttributes("*", "srcdoc").
+		ForbidAttributes("a", "target", "href").
+		RequireTrustedResourceURL("*", "src").
+		ForbidURLSchemes("data")
+	tables := New("").WithPolicy(p).SanitizationTables()
+	if _, ok := tables.ElementContent["iframe"]; ok {
+		t.Errorf("forbidden element iframe in ElementContent")
+	}
+	for _, elem := range tables.VoidElements {
+		if elem == "img" {
+			t.Errorf("forbidden element img in VoidElements")
+		}
+	}
+	if _, ok := tables.ElementAttributes["a"]["href"]; ok {
+		t.Errorf("forbidden attribute a href in ElementAttributes")
+	}
+	if got, want := tables.ElementAttributes["video"]["src"], SanitizationContextTrustedResourceURL; got != want {
+		t.Errorf("video src context = %v, want %v", got, want)
+	}
+	if got, want := strings.Join(tables.ForbiddenElements, ","), "iframe,img"; got != want {
+		t.Errorf("ForbiddenElements = %q, want %q", got, want)
+	}
+	if got, want := strings.Join(tables.ForbiddenAttributes["a"], ","), "href,target"; got != want {
+		t.Errorf("ForbiddenAttributes[a] = %q, want %q", got, want)
+	}
+	if got, want := strings.Join(tables.ForbiddenURLSchemes, ","), "data"; got != want {
+		t.Errorf("ForbiddenURLSchemes = %q, want %q", got, want)
+	}
+	if got, want := DefaultSanitizationTables().ElementAttributes["video"]["src"], SanitizationContextTrustedResourceURLOrURL; got != want {
+		t.Errorf("policy affected default tables: video src context = %v, want %v", got, want)
+	}
+
+	// Element-scoped restrictions of global attributes are recorded in
+	// ElementAttributes.
+	r := registry.Default().AllowAttribute("x-url", registry.URL)
+	tables = New("").WithRegistry(r).WithPolicy(new(Policy).RequireTrustedResourceURL("div", "x-url").RequireTrustedResourceURL("x-unknown", "x-url")).SanitizationTables()
+	if got, want := tables.ElementAttributes["div"]["x-url"], SanitizationContextTrustedResourceURL; got != want {
+		t.Errorf("div x-url context = %v, want %v", got, want)
+	}
+	if got, want := tables.GlobalAttributes["x-url"], SanitizationContextURL; got != want {
+		t.Errorf("global x-url context = %v, want %v", got, want)
+	}
+	if _, ok := tables.ElementAttributes["x-unknown"]; ok {
+		t.Errorf("ElementAttributes has an element to which global attributes do not apply")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// policyJSON is the JSON form of a Policy.
+type policyJSON struct {
+	ForbiddenElements         []string            `json:"forbiddenElements,omitempty"`
+	ForbiddenAttributes       map[string][]string `json:"forbiddenAttributes,omitempty"`
+	RequireTrustedResourceURL map[string][]string `json:"requireTrustedResourceURL,omitempty"`
+	ForbiddenURLSchemes       []string            `json:"forbiddenURLSchemes,omitempty"`
+	// AllowedDataURLMIMETypes is a pointer so that policies allowing no
+	// data URLs, whose list is empty, can be told from policies allowing all
+	// data URLs, which have no list.
+	AllowedDataURLMIMETypes *[]string `json:"allowedDataURLMIMETypes,omitempty"`
+	MaxURLLength            int       `json:"maxURLLength,omitempty"`
+	RejectURLWhitespace     bool      `json:"rejectURLWhitespace,omitempty"`
+}
+
+// MarshalJSON returns the JSON form of p, an object whose members correspond
+// to the methods of Policy, and are omitted if the methods were not called:
+//
+//	{
+//		"forbiddenElements": ["iframe"],
+//		"forbiddenAttributes": {"*": ["style"], "a": ["target"]},
+//		"requireTrustedResourceURL": {"img": ["src"]},
+//		"forbiddenURLSchemes": ["data"],
+//		"allowedDataURLMIMETypes": ["image/png"],
+//		"maxURLLength": 2048,
+//		"rejectURLWhitespace": true
+//	}
+//
+// Names are sorted, so equal policies have the same JSON form.
+func (p *Policy) MarshalJSON() ([]byte, error) {
+	var pj policyJSON
+	if p != nil {
+		pj = policyJSON{
+			ForbiddenElements:         sortedSet(p.forbiddenElements),
+			ForbiddenAttributes:       sortedElementAttrs(p.forbiddenAttrs),
+			RequireTrustedResourceURL: sortedElementAttrs(p.requireTrustedResourceURL),
+			ForbiddenURLSchemes:       sortedSet(p.forbiddenURLSchemes),
+			MaxURLLength:              p.maxURLLength,
+			RejectURLWhitespace:       p.rejectURLWhitespace,
+		}
+		if p.dataURLMIMETypes != nil {
+			mimeTypes := append([]string{}, sortedSet(p.dataURLMIMETypes)...)
+			pj.AllowedDataURLMIMETypes = &mimeTypes
+		}
+	}
+	return json.Marshal(pj)
+}
+
+// UnmarshalJSON sets p to the policy whose JSON form, as described in
+// MarshalJSON, is b. It returns an error if b has members other than those
+// of the JSON form, or if their values are invalid, such as empty names or a
+// negative maximum URL length.
+func (p *Policy) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var pj policyJSON
+	if err := dec.Decode(&pj); err != nil {
+		return fmt.Errorf("invalid policy: %v", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid policy: unexpected data after the policy object")
+	}
+	if pj.MaxURLLength < 0 {
+		return fmt.Errorf("invalid policy: maxURLLength is negative: %d", pj.MaxURLLength)
+	}
+	if err := checkPolicyNames("forbiddenElements", pj.ForbiddenElements); err != nil {
+		return err
+	}
+	if err := checkPolicyNames("forbiddenURLSchemes", pj.ForbiddenURLSchemes); err != nil {
+		return err
+	}
+	if pj.AllowedDataURLMIMETypes != nil {
+		if err := checkPolicyNames("allowedDataURLMIMETypes", *pj.AllowedDataURLMIMETypes); err != nil {
+			return err
+		}
+	}
+	for member, m := range map[string]map[string][]string{
+		"forbiddenAttributes":       pj.ForbiddenAttributes,
+		"requireTrustedResourceURL": pj.RequireTrustedResourceURL,
+	} {
+		for element, attrs := range m {
+			if element == "" {
+				return fmt.Errorf("invalid policy: %s contains an empty element name", member)
+			}
+			if err := checkPolicyNames(member, attrs); err != nil {
+				return err
+			}
+		}
+	}
+	np := new(Policy).LimitURLLength(pj.MaxURLLength)
+	if len(pj.ForbiddenElements) > 0 {
+		np.ForbidElements(pj.ForbiddenElements...)
+	}
+	for element, attrs := range pj.ForbiddenAttributes {
+		np.ForbidAttributes(element, attrs...)
+	}
+	for element, attrs := range pj.RequireTrustedResourceURL {
+		np.RequireTrustedResourceURL(element, attrs...)
+	}
+	if len(pj.ForbiddenURLSchemes) > 0 {
+		np.ForbidURLSchemes(pj.ForbiddenURLSchemes...)
+	}
+	if pj.AllowedDataURLMIMETypes != nil {
+		np.AllowDataURLMIMETypes(*pj.AllowedDataURLMIMETypes...)
+	}
+	if pj.RejectURLWhitespace {
+		np.RejectURLWhitespace()
+	}
+	*p = *np
+	return nil
+}
+
+// LoadPolicy reads a Policy from the JSON file src, in the form described in
+// Policy.MarshalJSON, so that a vetted policy can be distributed to the
+// applications that must adhere to it.
+func LoadPolicy(src TrustedSource) (*Policy, error) {
+	_, b, err := readFileOS(src.String())
+	if err != nil {
+		return nil, err
+	}
+	p := new(Policy)
+	if err := p.UnmarshalJSON(b); err != nil {
+		return nil, fmt.Errorf("policy %s: %v", src, err)
+	}
+	return p, nil
+}
+
+// checkPolicyNames returns an error if names, the value of the member of the
+// JSON form of a Policy, contains an empty or blank name.
+func checkPolicyNames(member string, names []string) error {
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid policy: %s contains an empty name", member)
+		}
+	}
+	return nil
+}
+
+// sortedSet returns the sorted elements of the set m, or nil if it is empty.
+func sortedSet(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func sortedElementAttrs(m map[string]map[string]bool) map[string][]string {
+	var ret map[string][]string
+	for element, attrs := range m {
+		if len(attrs) == 0 {
+			continue
+		}
+		if ret == nil {
+			ret = make(map[string][]string)
+		}
+		ret[element] = sortedSet(attrs)
+	}
+	return ret
+}
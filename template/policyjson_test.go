@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPolicyJSON(t *testing.T) {
+	for _, test := range [...]struct {
+		desc string
+		p    *Policy
+		want string
+	}{
+		{"nil", nil, `null`},
+		{"empty", new(Policy), `{}`},
+		{
+			"all restrictions",
+			new(Policy).
+				ForbidElements("IFRAME", "embed").
+				ForbidAttributes("*", "style").
+				ForbidAttributes("a", "target", "ping").
+				RequireTrustedResourceURL("img", "src").
+				ForbidURLSchemes("javascript:", "ftp").
+				AllowDataURLMIMETypes("image/png").
+				LimitURLLength(2048).
+				RejectURLWhitespace(),
+			`{"forbiddenElements":["embed","iframe"],` +
+				`"forbiddenAttributes":{"*":["style"],"a":["ping","target"]},` +
+				`"requireTrustedResourceURL":{"img":["src"]},` +
+				`"forbiddenURLSchemes":["ftp","javascript"],` +
+				`"allowedDataURLMIMETypes":["image/png"],` +
+				`"maxURLLength":2048,"rejectURLWhitespace":true}`,
+		},
+		{"no data URLs", new(Policy).AllowDataURLMIMETypes(), `{"allowedDataURLMIMETypes":[]}`},
+	} {
+		b, err := json.Marshal(test.p)
+		if err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		if got := string(b); got != test.want {
+			t.Errorf("%s: got %s, want %s", test.desc, got, test.want)
+		}
+		var p Policy
+		if err := json.Unmarshal(b, &p); err != nil {
+			t.Fatalf("%s: %v", test.desc, err)
+		}
+		want := test.p
+		if want == nil {
+			want = new(Policy)
+		}
+		if !reflect.DeepEqual(&p, want) {
+			t.Errorf("%s: unmarshaled %+v, want %+v", test.desc, p, *want)
+		}
+	}
+}
+
+func TestPolicyUnmarshalJSONErrors(t *testing.T) {
+	for _, input := range [...]string{
+		`[]`,
+		`{"forbiddenElement": ["iframe"]}`,
+		`{"forbiddenElements": "iframe"}`,
+		`{"forbiddenElements": [""]}`,
+		`{"forbiddenAttributes": {"": ["style"]}}`,
+		`{"requireTrustedResourceURL": {"img": [" "]}}`,
+		`{"maxURLLength": -1}`,
+		`{"maxURLLength": 1.5}`,
+		`{} {}`,
+	} {
+		if err := new(Policy).UnmarshalJSON([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	p, err := LoadPolicy(writeCatalog(t, `{
+		"forbiddenElements": ["iframe"],
+		"forbiddenURLSchemes": ["data"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := Must(New("page").WithPolicy(p).Parse(`<a href="{{.}}">x</a>`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "data:image/png;base64,AAAA"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<a href="about:invalid#zGoSafez">x</a>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if err := Must(New("frame").WithPolicy(p).Parse(`<iframe></iframe>`)).escape(); err == nil {
+		t.Error("expected error for forbidden element")
+	}
+	if _, err := LoadPolicy(writeCatalog(t, `{"forbiddenElements": 1}`)); err == nil || !strings.Contains(err.Error(), "policy") {
+		t.Errorf("got error %v, want invalid policy error", err)
+	}
+	if _, err := LoadPolicy(TrustedSource{filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
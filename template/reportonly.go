@@ -0,0 +1,117 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"html"
+	htmltemplate "html/template"
+	"strings"
+
+	"github.com/google/safehtml/internal/safehtmlutil"
+)
+
+// WithReportOnly returns an ExecuteOption that executes a template in
+// report-only mode, for measuring how a migration from html/template would
+// break pages before enforcing the semantics of this package.
+//
+// In report-only mode, values that would cause the execution to fail, such as
+// a string where a safehtml.Script value is expected, are passed to report and
+// then interpolated as html/template would interpolate them in the same
+// context, so that the output matches that of html/template. The Errors passed
+// to report have the name of the template the value occurred in, and describe
+// the value the execution would have failed on.
+//
+// Report-only mode does not affect Parse errors, escaping errors, or Policy
+// violations that are enforced by substituting safehtml.InnocuousURL, since
+// their causes are known before templates are executed. It must not be used
+// in production once the migration is complete.
+func WithReportOnly(report func(*Error)) ExecuteOption {
+	return func(c *executeConfig) {
+		c.report = report
+	}
+}
+
+// reportedError returns the Error reported in report-only mode for err, the
+// error of a sanitizer.
+func (st *execState) reportedError(err error) *Error {
+	name := ""
+	if len(st.calls) > 0 {
+		name = st.calls[len(st.calls)-1].Name
+	}
+	return &Error{
+		ErrorCode:           errorCode(err, ErrExec),
+		Name:                name,
+		Description:         err.Error(),
+		SanitizationContext: errorContext(err),
+	}
+}
+
+// An htmlTemplateFallback interpolates values as html/template does in the
+// context of an action in a fixed snippet of template text.
+type htmlTemplateFallback struct {
+	tmpl           *htmltemplate.Template
+	prefix, suffix string
+	// inAttr reports whether the action is in an attribute value, whose
+	// output is escaped again by sanitizeHTML.
+	inAttr bool
+}
+
+func newHTMLTemplateFallback(prefix, suffix string, inAttr bool) htmlTemplateFallback {
+	return htmlTemplateFallback{
+		tmpl:   htmltemplate.Must(htmltemplate.New("fallback").Parse(prefix + "{{.}}" + suffix)),
+		prefix: prefix,
+		suffix: suffix,
+		inAttr: inAttr,
+	}
+}
+
+// execute returns the output of the action of f for the arguments of a
+// sanitizer.
+func (f htmlTemplateFallback) execute(args []interface{}) string {
+	var data interface{}
+	if len(args) == 1 {
+		data = args[0]
+	} else {
+		data = safehtmlutil.Stringify(args...)
+	}
+	var b strings.Builder
+	if err := f.tmpl.Execute(&b, data); err != nil {
+		return ""
+	}
+	out := b.String()
+	out = out[len(f.prefix) : len(out)-len(f.suffix)]
+	if f.inAttr {
+		out = html.UnescapeString(out)
+	}
+	return out
+}
+
+var (
+	attrFallback    = newHTMLTemplateFallback(`<p title="`, `">`, true).execute
+	contentFallback = newHTMLTemplateFallback(`<p>`, `</p>`, false).execute
+)
+
+// reportOnlyFallbacks maps the names of the sanitizers that can fail at
+// execution time to functions returning the output of html/template for their
+// arguments in report-only mode.
+var reportOnlyFallbacks = map[string]func(args []interface{}) string{
+	sanitizeAsyncEnumFuncName:          attrFallback,
+	sanitizeDirEnumFuncName:            attrFallback,
+	sanitizeIdentifierFuncName:         attrFallback,
+	sanitizeLoadingEnumFuncName:        attrFallback,
+	sanitizeTargetEnumFuncName:         attrFallback,
+	sanitizeHTMLValOnlyFuncName:        contentFallback,
+	sanitizeScriptFuncName:             newHTMLTemplateFallback(`<script>`, `</script>`, false).execute,
+	sanitizeStyleFuncName:              newHTMLTemplateFallback(`<p style="`, `">`, true).execute,
+	sanitizeStyleSheetFuncName:         newHTMLTemplateFallback(`<style>`, `</style>`, false).execute,
+	sanitizeTrustedResourceURLFuncName: newHTMLTemplateFallback(`<script src="`, `"></script>`, true).execute,
+	// Substitutions after a TrustedResourceURL prefix are query-escaped
+	// after validation, much like html/template escapes them.
+	validateTrustedResourceURLSubstitutionFuncName: func(args []interface{}) string {
+		return safehtmlutil.Stringify(args...)
+	},
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml"
+)
+
+func TestWithReportOnly(t *testing.T) {
+	for _, test := range [...]struct {
+		desc    string
+		input   stringConstant
+		data    interface{}
+		want    string
+		wantCtx SanitizationContext
+	}{
+		{
+			"script",
+			`<script>var x = {{.}};</script>`,
+			"</script><b>",
+			`<script>var x = "\u003c/script\u003e\u003cb\u003e";</script>`,
+			SanitizationContextScript,
+		},
+		{
+			"style attribute",
+			`<p style="{{.}}">`,
+			"color: red; background: url(javascript:alert(1))",
+			`<p style="ZgotmplZ">`,
+			SanitizationContextStyle,
+		},
+		{
+			"identifier",
+			`<p id="{{.}}">`,
+			`a"b`,
+			`<p id="a&#34;b">`,
+			SanitizationContextIdentifier,
+		},
+		{
+			"enum",
+			`<a target="{{.}}">`,
+			"_top",
+			`<a target="_top">`,
+			SanitizationContextTargetEnum,
+		},
+		{
+			"trusted resource URL",
+			`<script src="{{.}}"></script>`,
+			"javascript:alert(1)",
+			`<script src="#ZgotmplZ"></script>`,
+			SanitizationContextTrustedResourceURL,
+		},
+		{
+			"trusted resource URL substitution",
+			`<script src="/static/{{.}}"></script>`,
+			"../secret",
+			`<script src="/static/..%2fsecret"></script>`,
+			SanitizationContextTrustedResourceURL,
+		},
+	} {
+		tmpl := Must(New("page").Parse(test.input))
+		var reported []*Error
+		var b strings.Builder
+		if err := tmpl.ExecuteWithOptions(&b, test.data, WithReportOnly(func(err *Error) {
+			reported = append(reported, err)
+		})); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.desc, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+		if len(reported) != 1 {
+			t.Errorf("%s: got %d reported errors, want 1", test.desc, len(reported))
+			continue
+		}
+		if err := reported[0]; err.Name != "page" || err.SanitizationContext != test.wantCtx {
+			t.Errorf("%s: reported error %v in template %q and context %s, want template %q and context %s",
+				test.desc, err, err.Name, err.SanitizationContext, "page", test.wantCtx)
+		}
+	}
+}
+
+func TestWithReportOnlyValidValues(t *testing.T) {
+	tmpl := Must(New("page").Parse(`<script>{{.Script}}</script><a href="{{.URL}}">{{.Text}}</a>`))
+	data := struct {
+		Script safehtml.Script
+		URL    string
+		Text   string
+	}{safehtml.ScriptFromConstant(`alert(1)`), "javascript:alert(1)", "<b>"}
+	var b strings.Builder
+	err := tmpl.ExecuteWithOptions(&b, data, WithReportOnly(func(err *Error) {
+		t.Errorf("unexpected report: %v", err)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<script>alert(1)</script><a href="about:invalid#zGoSafez">&lt;b&gt;</a>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	// Executions without the option still fail.
+	if err := tmpl.Execute(&b, struct{ Script, URL, Text string }{}); err == nil {
+		t.Error("expected error without report-only mode")
+	}
+}
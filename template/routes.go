@@ -0,0 +1,281 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/safehtmlutil"
+	"github.com/google/safehtml/uncheckedconversions"
+)
+
+// Routes is a set of named routes of an application, from which templates
+// generate the URLs of its pages, instead of building hrefs by hand:
+//
+//	routes := template.NewRoutes()
+//	routes.Add("user", "/users/{id}")
+//	t := template.Must(template.New("t").Funcs(routes.Funcs()).Parse(
+//		`<a href="{{url "user" "id" .ID "tab" "posts"}}">Profile</a>`))
+//
+// Route patterns use the syntax of the patterns of http.ServeMux, chi, and
+// gorilla/mux, so they can be registered from the same definitions as the
+// routes of a router. A pattern is an absolute path, optionally preceded by
+// a method and a space, such as "GET /users/{id}", in which wildcards are
+// enclosed in braces:
+//   - {name} matches a path segment.
+//   - {name:regexp} matches a path segment that matches regexp, as in chi
+//     and gorilla/mux.
+//   - {name...}, at the end of the pattern, matches the rest of the path, as
+//     in http.ServeMux.
+//   - {$}, at the end of the pattern, matches the end of the path, as in
+//     http.ServeMux.
+//
+// Routes is safe for concurrent use.
+type Routes struct {
+	mu     sync.RWMutex
+	routes map[string]*route
+}
+
+// A route is a parsed route pattern.
+type route struct {
+	parts []routePart
+}
+
+// A routePart is either literal text or a wildcard in a route pattern.
+type routePart struct {
+	literal string
+	// param is the name of the wildcard, if the part is a wildcard.
+	param string
+	// rest reports whether the wildcard matches the rest of the path.
+	rest bool
+	// re, if non-nil, matches the values of the wildcard.
+	re *regexp.Regexp
+}
+
+// NewRoutes returns an empty set of routes.
+func NewRoutes() *Routes {
+	return &Routes{routes: make(map[string]*route)}
+}
+
+// routeParamPattern matches the names of route wildcards.
+var routeParamPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Add adds a route with the given name and pattern, replacing any route with
+// the same name. It returns an error if pattern is not a valid route pattern.
+func (r *Routes) Add(name, pattern string) error {
+	rt, err := parseRoute(pattern)
+	if err != nil {
+		return fmt.Errorf("route %q: %v", name, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[name] = rt
+	return nil
+}
+
+func parseRoute(pattern string) (*route, error) {
+	path := pattern
+	if i := strings.IndexByte(path, ' '); i != -1 {
+		// Drop the method of the pattern.
+		path = strings.TrimLeft(path[i+1:], " ")
+	}
+	// Browsers treat both "//" and "/\" as the start of an authority.
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return nil, fmt.Errorf("pattern %q is not an absolute path", pattern)
+	}
+	rt := new(route)
+	seen := make(map[string]bool)
+	for path != "" {
+		i := strings.IndexByte(path, '{')
+		if i == -1 {
+			rt.parts = append(rt.parts, routePart{literal: path})
+			break
+		}
+		if i > 0 {
+			rt.parts = append(rt.parts, routePart{literal: path[:i]})
+		}
+		end := closingBrace(path[i:])
+		if end == -1 {
+			return nil, fmt.Errorf("pattern %q has an unclosed wildcard", pattern)
+		}
+		wildcard := path[i+1 : i+end]
+		path = path[i+end+1:]
+		if wildcard == "$" {
+			if path != "" {
+				return nil, fmt.Errorf("pattern %q has {$} before its end", pattern)
+			}
+			break
+		}
+		var part routePart
+		switch {
+		case strings.HasSuffix(wildcard, "..."):
+			if path != "" {
+				return nil, fmt.Errorf("pattern %q has {%s} before its end", pattern, wildcard)
+			}
+			part = routePart{param: strings.TrimSuffix(wildcard, "..."), rest: true}
+		case strings.Contains(wildcard, ":"):
+			j := strings.IndexByte(wildcard, ':')
+			re, err := regexp.Compile("^(?:" + wildcard[j+1:] + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("pattern %q: %v", pattern, err)
+			}
+			part = routePart{param: wildcard[:j], re: re}
+		default:
+			part = routePart{param: wildcard}
+		}
+		if !routeParamPattern.MatchString(part.param) {
+			return nil, fmt.Errorf("pattern %q has invalid wildcard name %q", pattern, part.param)
+		}
+		if seen[part.param] {
+			return nil, fmt.Errorf("pattern %q has duplicate wildcard %q", pattern, part.param)
+		}
+		seen[part.param] = true
+		rt.parts = append(rt.parts, part)
+	}
+	return rt, nil
+}
+
+// closingBrace returns the index of the brace that closes the wildcard at
+// the start of s, allowing for braces in regular expressions, or -1.
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// URL returns the URL of the named route. params are alternating parameter
+// names and values, or a single map[string]string. Parameters named by the
+// wildcards of the route replace them, escaped as path segments, and other
+// parameters are added to the query of the URL, in the order given or in the
+// order of their names for a map. Values that are not strings are formatted
+// with fmt.Sprint.
+//
+// URL returns an error if the route does not exist, if a wildcard has no
+// parameter or its value does not match the regular expression of the
+// wildcard, or if a value replacing a wildcard is "." or "..", or has such a
+// segment for a {name...} wildcard.
+func (r *Routes) URL(name string, params ...interface{}) (safehtml.URL, error) {
+	r.mu.RLock()
+	rt, ok := r.routes[name]
+	r.mu.RUnlock()
+	if !ok {
+		return safehtml.URL{}, fmt.Errorf("url: no route named %q", name)
+	}
+	keys, vals, err := routeParams(params)
+	if err != nil {
+		return safehtml.URL{}, fmt.Errorf("url: route %q: %v", name, err)
+	}
+	used := make([]bool, len(keys))
+	param := func(name string) (string, bool) {
+		for i, k := range keys {
+			if k == name {
+				used[i] = true
+				return vals[i], true
+			}
+		}
+		return "", false
+	}
+	var b strings.Builder
+	for _, p := range rt.parts {
+		if p.param == "" {
+			b.WriteString(p.literal)
+			continue
+		}
+		val, ok := param(p.param)
+		if !ok {
+			return safehtml.URL{}, fmt.Errorf("url: route %q: missing parameter %q", name, p.param)
+		}
+		if p.re != nil && !p.re.MatchString(val) {
+			return safehtml.URL{}, fmt.Errorf("url: route %q: parameter %q with value %q does not match %s", name, p.param, val, p.re)
+		}
+		segments := []string{val}
+		if p.rest {
+			segments = strings.Split(val, "/")
+		}
+		for i, s := range segments {
+			if s == "." || s == ".." {
+				return safehtml.URL{}, fmt.Errorf("url: route %q: parameter %q with value %q must not contain dot-segments", name, p.param, val)
+			}
+			if i > 0 {
+				b.WriteByte('/')
+			}
+			b.WriteString(url.PathEscape(s))
+		}
+	}
+	sep := "?"
+	for i, k := range keys {
+		if used[i] {
+			continue
+		}
+		b.WriteString(sep)
+		b.WriteString(safehtmlutil.QueryEscapeURL(k))
+		b.WriteByte('=')
+		b.WriteString(safehtmlutil.QueryEscapeURL(vals[i]))
+		sep = "&"
+	}
+	u := b.String()
+	if strings.HasPrefix(u, "//") {
+		// An empty wildcard value at the start of the path would make it
+		// the start of an authority.
+		return safehtml.URL{}, fmt.Errorf("url: route %q: URL %q is not an absolute path", name, u)
+	}
+	// The URL is an absolute path, since patterns must start with a single
+	// slash, and wildcard values cannot add path segments or other components.
+	return uncheckedconversions.URLFromStringKnownToSatisfyTypeContract(u), nil
+}
+
+// routeParams returns the names and values of params, as described in
+// Routes.URL.
+func routeParams(params []interface{}) (keys, vals []string, err error) {
+	if len(params) == 1 {
+		if m, ok := params[0].(map[string]string); ok {
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				vals = append(vals, m[k])
+			}
+			return keys, vals, nil
+		}
+	}
+	if len(params)%2 != 0 {
+		return nil, nil, fmt.Errorf("odd number of parameter names and values: %d", len(params))
+	}
+	for i := 0; i < len(params); i += 2 {
+		k, ok := params[i].(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("parameter name %v is a %T, not a string", params[i], params[i])
+		}
+		keys = append(keys, k)
+		vals = append(vals, fmt.Sprint(params[i+1]))
+	}
+	return keys, vals, nil
+}
+
+// Funcs returns a FuncMap containing a "url" function that calls r.URL, to be
+// added to templates with Template.Funcs:
+//
+//	{{url "user" "id" .ID}}
+func (r *Routes) Funcs() FuncMap {
+	return FuncMap{"url": r.URL}
+}
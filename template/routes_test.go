@@ -0,0 +1,101 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoutesAddErrors(t *testing.T) {
+	for _, pattern := range [...]string{
+		"users/{id}",
+		"//evil.example/{id}",
+		`/\evil.example`,
+		"https://example.com/{id}",
+		"/users/{id",
+		"/users/{id}/{id}",
+		"/users/{1d}",
+		"/files/{path...}/edit",
+		"/{$}/x",
+		"/users/{id:[0-9+}",
+	} {
+		if err := NewRoutes().Add("r", pattern); err == nil {
+			t.Errorf("Add(%q): expected error", pattern)
+		}
+	}
+}
+
+func TestRoutesURL(t *testing.T) {
+	r := NewRoutes()
+	for name, pattern := range map[string]string{
+		"home":  "/{$}",
+		"user":  "GET /users/{id}",
+		"post":  "/users/{user}/posts/{id:[0-9]+}",
+		"file":  "/files/{path...}",
+		"brace": "/dates/{date:[0-9]{4}-[0-9]{2}}",
+		"root":  "/{page}",
+		"all":   "/{path...}",
+	} {
+		if err := r.Add(name, pattern); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, test := range [...]struct {
+		name   string
+		params []interface{}
+		want   string
+		err    string
+	}{
+		{"home", nil, "/", ""},
+		{"user", []interface{}{"id", "gopher/../admin?x"}, "/users/gopher%2F..%2Fadmin%3Fx", ""},
+		{"user", []interface{}{"id", 42, "tab", "a b&c"}, "/users/42?tab=a%20b%26c", ""},
+		{"user", []interface{}{map[string]string{"id": "7", "z": "1", "a": "2"}}, "/users/7?a=2&z=1", ""},
+		{"post", []interface{}{"user", "g", "id", "12"}, "/users/g/posts/12", ""},
+		{"file", []interface{}{"path", "docs/a b/c.txt"}, "/files/docs/a%20b/c.txt", ""},
+		{"brace", []interface{}{"date", "2026-10"}, "/dates/2026-10", ""},
+		{"post", []interface{}{"user", "g", "id", "x"}, "", "does not match"},
+		{"user", nil, "", `missing parameter "id"`},
+		{"user", []interface{}{"id"}, "", "odd number"},
+		{"user", []interface{}{1, 2}, "", "not a string"},
+		{"user", []interface{}{"id", ".."}, "", "dot-segments"},
+		{"file", []interface{}{"path", "a/../../etc"}, "", "dot-segments"},
+		{"file", []interface{}{"path", "/a"}, "/files//a", ""},
+		{"all", []interface{}{"path", "/evil.example"}, "", "not an absolute path"},
+		{"root", []interface{}{"page", ""}, "/", ""},
+		{"missing", nil, "", `no route named "missing"`},
+	} {
+		got, err := r.URL(test.name, test.params...)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("URL(%q, %v) = %q, %v, want error containing %q", test.name, test.params, got, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.String() != test.want {
+			t.Errorf("URL(%q, %v) = %q, %v, want %q", test.name, test.params, got, err, test.want)
+		}
+	}
+}
+
+func TestRoutesFuncs(t *testing.T) {
+	r := NewRoutes()
+	if err := r.Add("user", "/users/{id}"); err != nil {
+		t.Fatal(err)
+	}
+	tmpl := Must(New("t").Funcs(r.Funcs()).Parse(`<a href="{{url "user" "id" .ID "tab" "posts"}}">{{.ID}}</a>`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, struct{ ID string }{`"><script>`}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<a href="/users/%22%3E%3Cscript%3E?tab=posts">&#34;&gt;&lt;script&gt;</a>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if err := tmpl.Execute(&b, struct{ ID string }{".."}); err == nil {
+		t.Error("expected error for invalid parameter")
+	}
+}
@@ -6,16 +6,19 @@ package template
 
 import (
 	"fmt"
+	"html"
 	"regexp"
 	"strings"
+
+	"github.com/google/safehtml/registry"
 )
 
 // sanitizerForContext returns an ordered list of function names that will be called to
-// sanitize data values found in the HTML context defined by c.
-func sanitizerForContext(c context) ([]string, error) {
+// sanitize data values found in the HTML context defined by c under registry r and policy p.
+func sanitizerForContext(c context, r *registry.Registry, p *Policy) ([]string, error) {
 	switch c.state {
 	case stateTag, stateAttrName, stateAfterName:
-		return nil, fmt.Errorf("actions must not affect element or attribute names")
+		return nil, codedErrorf(ErrActionInName, "actions must not affect element or attribute names")
 	case stateHTMLCmt:
 		return []string{sanitizeHTMLCommentFuncName}, nil
 	}
@@ -27,12 +30,12 @@ func sanitizerForContext(c context) ([]string, error) {
 		// We are in an attribute value context.
 		if c.delim != delimDoubleQuote && c.delim != delimSingleQuote {
 			// TODO: consider disallowing single-quoted or unquoted attribute values completely, even in hardcoded template text.
-			return nil, fmt.Errorf("unquoted attribute values disallowed")
+			return nil, codedErrorf(ErrUnquotedAttr, "unquoted attribute values disallowed")
 		}
-		return sanitizersForAttributeValue(c)
+		return sanitizersForAttributeValue(c, r, p)
 	}
 	// Otherwise, we are in an element content context.
-	elementContentSanitizer, err := sanitizerForElementContent(c)
+	elementContentSanitizer, err := sanitizerForElementContent(c, r, p)
 	return appendIfNotEmpty([]string{}, elementContentSanitizer), err
 }
 
@@ -47,8 +50,9 @@ func appendIfNotEmpty(slice []string, strings ...string) []string {
 }
 
 // sanitizersForAttributeValue returns a list of names of functions that will be
-// called in order to sanitize data values found the HTML attribtue value context c.
-func sanitizersForAttributeValue(c context) ([]string, error) {
+// called in order to sanitize data values found the HTML attribtue value context c
+// under registry r and policy p.
+func sanitizersForAttributeValue(c context, r *registry.Registry, p *Policy) ([]string, error) {
 	// Ensure that all combinations of element and attribute names for this context results
 	// in the same attribute value sanitization context.
 	var elems, attrs []string
@@ -62,34 +66,37 @@ func sanitizersForAttributeValue(c context) ([]string, error) {
 	} else {
 		attrs = c.attr.names
 	}
-	var sc0 sanitizationContext
+	var sc0 SanitizationContext
 	var elem0, attr0 string
 	for i, elem := range elems {
 		for j, attr := range attrs {
-			sc, err := sanitizationContextForAttrVal(elem, attr, c.linkRel)
+			sc, err := sanitizationContextForAttrVal(r, elem, attr, c.linkRel)
+			if err == nil {
+				sc, err = p.attrValContext(elem, attr, sc)
+			}
 			if err != nil {
 				if len(elems) == 1 && len(attrs) == 1 {
 					return nil, err
 				}
-				return nil, fmt.Errorf(`conditional branch with {element=%q, attribute=%q} results in sanitization error: %s`, elem, attr, err)
+				return nil, codedErrorf(errorCode(err, ErrEscapeAction), `conditional branch with {element=%q, attribute=%q} results in sanitization error: %s`, elem, attr, err)
 			}
 			if i == 0 && j == 0 {
 				sc0, elem0, attr0 = sc, elem, attr
 				continue
 			}
 			if sc != sc0 {
-				return nil, fmt.Errorf(
+				return nil, codedErrorf(ErrBranchSanitization,
 					`conditional branches end in different attribute value sanitization contexts: {element=%q, attribute=%q} has sanitization context %q, {element=%q, attribute=%q} has sanitization context %q`,
 					elem0, attr0, sc0, elem, attr, sc)
 			}
 		}
 	}
 	if sc0.isEnum() && c.attr.value != "" {
-		return nil, fmt.Errorf("partial substitutions are disallowed in the %q attribute value context of a %q element", c.attr.name, c.element.name)
+		return nil, sanitizationErrorf(sc0, ErrPartialSubstitution, "partial substitutions are disallowed in the %q attribute value context of a %q element", c.attr.name, c.element.name)
 	}
-	if sc0 == sanitizationContextStyle && c.attr.value != "" {
+	if sc0 == SanitizationContextStyle && c.attr.value != "" {
 		if err := validateDoesNotEndsWithCharRefPrefix(c.attr.value); err != nil {
-			return nil, fmt.Errorf("action cannot be interpolated into the %q attribute value of this %q element: %s", c.attr.name, c.element.name, err)
+			return nil, sanitizationErrorf(sc0, errorCode(err, ErrEscapeAction), "action cannot be interpolated into the %q attribute value of this %q element: %s", c.attr.name, c.element.name, err)
 		}
 	}
 	// ret is a stack of sanitizer names that will be built in reverse.
@@ -110,17 +117,22 @@ func sanitizersForAttributeValue(c context) ([]string, error) {
 	}
 	// Action occurs after a URL or TrustedResourceURL prefix.
 	if c.attr.ambiguousValue {
-		return nil, fmt.Errorf("actions must not occur after an ambiguous URL prefix in the %q attribute value context of a %q element", c.attr.name, c.element.name)
+		return nil, sanitizationErrorf(sc0, ErrAmbigURLPrefix, "actions must not occur after an ambiguous URL prefix in the %q attribute value context of a %q element", c.attr.name, c.element.name)
 	}
 	validator, ok := urlPrefixValidators[sc0]
 	if !ok {
 		return nil, fmt.Errorf("cannot validate attribute value prefix %q in the %q sanitization context", c.attr.value, sc0)
 	}
 	if err := validator(c.attr.value); err != nil {
-		return nil, fmt.Errorf("action cannot be interpolated into the %q URL attribute value of this %q element: %s", c.attr.name, c.element.name, err)
+		return nil, sanitizationErrorf(sc0, errorCode(err, ErrUnsafeURLPrefix), "action cannot be interpolated into the %q URL attribute value of this %q element: %s", c.attr.name, c.element.name, err)
+	}
+	if sc0 != SanitizationContextTrustedResourceURL {
+		if err := p.validateURLPrefix(html.UnescapeString(c.attr.value)); err != nil {
+			return nil, err
+		}
 	}
 	switch {
-	case sc0 == sanitizationContextTrustedResourceURL:
+	case sc0 == SanitizationContextTrustedResourceURL:
 		// Untrusted data that occurs anywhere after TrustedResourceURL prefix must be query-escaped
 		// to prevent the injection of any new path segments or URL components. Moreover, they must
 		// not contain any ".." dot-segments.
@@ -144,43 +156,15 @@ func reverse(s []string) []string {
 }
 
 // sanitizationContextForAttrVal returns the sanitization context for attr when it
-// appears within element.
-func sanitizationContextForAttrVal(element, attr, linkRel string) (sanitizationContext, error) {
-	if element == "link" && attr == "href" {
-		// Special case: safehtml.URL values are allowed in a link element's href attribute if that element's
-		// rel attribute possesses certain values.
-		relVals := strings.Fields(linkRel)
-		for _, val := range relVals {
-			if urlLinkRelVals[val] {
-				return sanitizationContextTrustedResourceURLOrURL, nil
-			}
-		}
-	}
-	if dataAttributeNamePattern.MatchString(attr) {
-		// Special case: data-* attributes are specified by HTML5 to hold custom data private to
-		// the page or application; they should not be interpreted by browsers. Therefore, no
-		// sanitization is required for these attribute values.
-		return sanitizationContextNone, nil
-	}
-	if sc, ok := elementSpecificAttrValSanitizationContext[attr][element]; ok {
-		return sc, nil
-	}
-	sc, isAllowedAttr := globalAttrValSanitizationContext[attr]
-	_, isAllowedElement := elementContentSanitizationContext[element]
-	if isAllowedAttr && (isAllowedElement || allowedVoidElements[element]) {
-		// Only sanitize attributes that appear in elements whose semantics are known.
-		// Thes attributes might have different semantics in other standard or custom
-		// elements that our sanitization policy does not handle correctly.
-		return sc, nil
+// appears within element, according to the contracts in r.
+func sanitizationContextForAttrVal(r *registry.Registry, element, attr, linkRel string) (SanitizationContext, error) {
+	sc, ok := r.Attribute(element, attr, linkRel)
+	if !ok {
+		return 0, codedErrorf(ErrDisallowedAttr, "actions must not occur in the %q attribute value context of a %q element", attr, element)
 	}
-	return 0, fmt.Errorf("actions must not occur in the %q attribute value context of a %q element", attr, element)
+	return SanitizationContext(sc), nil
 }
 
-// dataAttributeNamePattern matches valid data attribute names.
-// This pattern is conservative and matches only a subset of the valid names defined in
-// https://html.spec.whatwg.org/multipage/dom.html#embedding-custom-non-visible-data-with-the-data-*-attributes
-var dataAttributeNamePattern = regexp.MustCompile(`^data-[a-z_][-a-z0-9_]*$`)
-
 // endsWithCharRefPrefixPattern matches strings that end in an incomplete
 // HTML character reference.
 //
@@ -192,14 +176,14 @@ var endsWithCharRefPrefixPattern = regexp.MustCompile(
 // with an incomplete HTML character reference.
 func validateDoesNotEndsWithCharRefPrefix(prefix string) error {
 	if endsWithCharRefPrefixPattern.MatchString(prefix) {
-		return fmt.Errorf(`prefix %q ends with an incomplete HTML character reference; did you mean "&amp;" instead of "&"?`, prefix)
+		return codedErrorf(ErrIncompleteCharRef, `prefix %q ends with an incomplete HTML character reference; did you mean "&amp;" instead of "&"?`, prefix)
 	}
 	return nil
 }
 
 // sanitizerForElementContent returns the name of the function that will be called
-// to sanitize data values found in the HTML element content context c.
-func sanitizerForElementContent(c context) (string, error) {
+// to sanitize data values found in the HTML element content context c under registry r and policy p.
+func sanitizerForElementContent(c context, r *registry.Registry, p *Policy) (string, error) {
 	// Ensure that all other possible element names for this context result in the same
 	// element content sanitization context.
 	var elems []string
@@ -208,22 +192,25 @@ func sanitizerForElementContent(c context) (string, error) {
 	} else {
 		elems = c.element.names
 	}
-	var sc0 sanitizationContext
+	var sc0 SanitizationContext
 	var elem0 string
 	for i, elem := range elems {
-		var sc sanitizationContext
+		var sc SanitizationContext
 		var err error
 		if elem == "" {
 			// Special case: an empty element name represents a context outside of a HTML element.
-			sc = sanitizationContextHTML
+			sc = SanitizationContextHTML
 		} else {
-			sc, err = sanitizationContextForElementContent(elem)
+			sc, err = sanitizationContextForElementContent(r, elem)
+			if err == nil {
+				sc, err = p.elementContentContext(elem, sc)
+			}
 		}
 		if err != nil {
 			if len(elems) == 1 {
 				return "", err
 			}
-			return "", fmt.Errorf(`conditional branch with element %q results in sanitization error: %s`, elem, err)
+			return "", codedErrorf(errorCode(err, ErrEscapeAction), `conditional branch with element %q results in sanitization error: %s`, elem, err)
 		}
 		if i == 0 {
 			sc0, elem0 = sc, elem
@@ -231,20 +218,21 @@ func sanitizerForElementContent(c context) (string, error) {
 		}
 		if sc != sc0 {
 			return "",
-				fmt.Errorf(`conditional branches end in different element content sanitization contexts: element %q has sanitization context %q, element %q has sanitization context %q`,
+				codedErrorf(ErrBranchSanitization, `conditional branches end in different element content sanitization contexts: element %q has sanitization context %q, element %q has sanitization context %q`,
 					elem0, sc0, elem, sc)
 		}
 	}
 	return sc0.sanitizerName(), nil
 }
 
-// sanitizationContextForElementContent returns the element content sanitization context for the given element.
-func sanitizationContextForElementContent(element string) (sanitizationContext, error) {
-	sc, ok := elementContentSanitizationContext[element]
+// sanitizationContextForElementContent returns the element content sanitization context for the given element,
+// according to the contracts in r.
+func sanitizationContextForElementContent(r *registry.Registry, element string) (SanitizationContext, error) {
+	sc, ok := r.ElementContent(element)
 	if !ok {
-		return 0, fmt.Errorf("actions must not occur in the element content context of a %q element", element)
+		return 0, codedErrorf(ErrDisallowedElementContent, "actions must not occur in the element content context of a %q element", element)
 	}
-	return sc, nil
+	return SanitizationContext(sc), nil
 }
 
 // sanitizeHTMLComment returns the empty string regardless of input.
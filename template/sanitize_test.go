@@ -6,6 +6,7 @@ package template
 
 import (
 	"bytes"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -180,6 +181,136 @@ func TestNilEmptySliceData(t *testing.T) {
 	}
 }
 
+// badge is a safehtml.HTMLer with a value receiver.
+type badge string
+
+func (b badge) HTML() safehtml.HTML {
+	return safehtml.HTMLConcat(testconversions.MakeHTMLForTest("<b>"), safehtml.HTMLEscaped(string(b)), testconversions.MakeHTMLForTest("</b>"))
+}
+
+// avatar is a safehtml.HTMLer with a pointer receiver.
+type avatar struct{ name string }
+
+func (a *avatar) HTML() safehtml.HTML {
+	return safehtml.HTMLConcat(testconversions.MakeHTMLForTest("<i>"), safehtml.HTMLEscaped(a.name), testconversions.MakeHTMLForTest("</i>"))
+}
+
+func TestHTMLer(t *testing.T) {
+	a := &avatar{"<Gopher>"}
+	var nilAvatar *avatar
+	for _, test := range [...]struct {
+		desc, tmpl string
+		in         interface{}
+		want, err  string
+	}{
+		{"value receiver", `<p>{{ . }}</p>`, badge("<new>"), `<p><b>&lt;new&gt;</b></p>`, ""},
+		{"pointer to value receiver", `<p>{{ . }}</p>`, func() *badge { b := badge("x"); return &b }(), `<p><b>x</b></p>`, ""},
+		{"pointer receiver", `<p>{{ . }}</p>`, a, `<p><i>&lt;Gopher&gt;</i></p>`, ""},
+		{"pointer to pointer receiver", `<p>{{ . }}</p>`, &a, `<p><i>&lt;Gopher&gt;</i></p>`, ""},
+		{"nil pointer", `<p>{{ . }}</p>`, nilAvatar, `<p>&lt;nil&gt;</p>`, ""},
+		{"srcdoc", `<iframe srcdoc="{{ . }}"></iframe>`, badge("x"), `<iframe srcdoc="&lt;b&gt;x&lt;/b&gt;"></iframe>`, ""},
+		{"srcdoc nil pointer", `<iframe srcdoc="{{ . }}"></iframe>`, nilAvatar, "", "expected a safehtml.HTML value"},
+		{"URL context", `<a href="{{ . }}"></a>`, badge("javascript:x"), `<a href="about:invalid#zGoSafez"></a>`, ""},
+		{"SVG", `<p>{{ . }}</p>`, safehtml.SVGFromConstant(`<svg><rect width="1"></rect></svg>`), `<p><svg><rect width="1"></rect></svg></p>`, ""},
+	} {
+		var b bytes.Buffer
+		err := Must(New("").ParseFromTrustedTemplate(TrustedTemplate{test.tmpl})).Execute(&b, test.in)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: got error %v, want %q", test.desc, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.desc, err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestLazyHTML(t *testing.T) {
+	calls := 0
+	sidebar := safehtml.LazyHTML(func() (safehtml.HTML, error) {
+		calls++
+		return badge("<new>").HTML(), nil
+	})
+	errRender := errors.New("render failed")
+	for _, test := range [...]struct {
+		desc, tmpl string
+		in         interface{}
+		want       string
+		calls      int
+		err        error
+	}{
+		{"HTML content", `<aside>{{ .Sidebar }}</aside>`, sidebar, `<aside><b>&lt;new&gt;</b></aside>`, 1, nil},
+		{"pointer", `<aside>{{ .Sidebar }}</aside>`, &sidebar, `<aside><b>&lt;new&gt;</b></aside>`, 1, nil},
+		{"srcdoc", `<iframe srcdoc="{{ .Sidebar }}"></iframe>`, sidebar, `<iframe srcdoc="&lt;b&gt;&amp;lt;new&amp;gt;&lt;/b&gt;"></iframe>`, 1, nil},
+		{"branch not executed", `{{ if .Show }}<aside>{{ .Sidebar }}</aside>{{ end }}`, sidebar, ``, 0, nil},
+		{"branch executed", `{{ if not .Show }}<aside>{{ .Sidebar }}</aside>{{ end }}`, sidebar, `<aside><b>&lt;new&gt;</b></aside>`, 1, nil},
+		{"once", `{{ .Sidebar }}{{ .Sidebar }}`, sidebar.Once(), `<b>&lt;new&gt;</b><b>&lt;new&gt;</b>`, 1, nil},
+		{"error", `<aside>{{ .Sidebar }}</aside>`, safehtml.LazyHTML(func() (safehtml.HTML, error) { return safehtml.HTML{}, errRender }), "", 0, errRender},
+		{"nil", `<aside>{{ .Sidebar }}</aside>`, safehtml.LazyHTML(nil), "", 0, errNilLazyHTML},
+		{"nil in srcdoc", `<iframe srcdoc="{{ .Sidebar }}"></iframe>`, safehtml.LazyHTML(nil), "", 0, errNilLazyHTML},
+	} {
+		calls = 0
+		var b bytes.Buffer
+		data := map[string]interface{}{"Sidebar": test.in, "Show": false}
+		err := Must(New("").ParseFromTrustedTemplate(TrustedTemplate{test.tmpl})).Execute(&b, data)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: got error %v, want %v", test.desc, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.desc, err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+		if calls != test.calls {
+			t.Errorf("%s: LazyHTML called %d times, want %d", test.desc, calls, test.calls)
+		}
+	}
+}
+
+func TestESIInclude(t *testing.T) {
+	const tmpl = `<esi:include src="{{ .Src }}" alt="{{ .Alt }}" onerror="continue"/>`
+	for _, test := range [...]struct {
+		desc      string
+		src, alt  interface{}
+		want, err string
+	}{
+		{
+			"TrustedResourceURLs",
+			safehtml.TrustedResourceURLFromConstant("/fragments/header?a=1&b=2"),
+			safehtml.TrustedResourceURLFromConstant("/fallback"),
+			`<esi:include src="/fragments/header?a=1&amp;b=2" alt="/fallback" onerror="continue"/>`,
+			"",
+		},
+		{"string src", "/fragments/header", safehtml.TrustedResourceURLFromConstant("/fallback"), "", "expected a safehtml.TrustedResourceURL value"},
+		{"URL alt", safehtml.TrustedResourceURLFromConstant("/fragments/header"), safehtml.URLSanitized("/fallback"), "", "expected a safehtml.TrustedResourceURL value"},
+	} {
+		var b bytes.Buffer
+		data := struct{ Src, Alt interface{} }{test.src, test.alt}
+		err := Must(New("").ParseFromTrustedTemplate(TrustedTemplate{tmpl})).Execute(&b, data)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: got error %v, want %q", test.desc, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.desc, err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+	}
+}
+
 var testConversionFuncs = FuncMap{
 	"makeHTMLForTest":               func(s string) safehtml.HTML { return testconversions.MakeHTMLForTest(s) },
 	"makeURLForTest":                func(s string) safehtml.URL { return testconversions.MakeURLForTest(s) },
@@ -1053,41 +1184,6 @@ func TestValidateDoesNotEndsWithCharRefPrefix(t *testing.T) {
 	}
 }
 
-func TestDataAttributeNamePattern(t *testing.T) {
-	for _, test := range [...]struct {
-		in   string
-		want bool
-	}{
-		{`data-a`, true},
-		{`data-foo`, true},
-		{`data-foo-bar`, true},
-		{`data-f0o-b4r`, true},
-		{`data-_foo`, true},
-		// Does not begin with "data-".
-		{`data`, false},
-		{`foo`, false},
-		// No characters after hyphen.
-		{`data-`, false},
-		// Suffix starts with a digit.
-		{`data-4oo`, false},
-		// Contains ACSII upper alphas.
-		// Note: this test case isn't strictly necessary, since sanitizerForContext is given
-		// lower-case attribute names.
-		{`data-Foo`, false},
-		// Contains colon characters.
-		{`data-foo:bar`, false},
-		// Contains unicode characters that are allowed in XML names
-		// (https://www.w3.org/TR/xml/#NT-Name), but conservatively rejected
-		// by our regexp pattern.
-		{"data-\u037Fbar", false},
-		{"data-fo\u0300", false},
-	} {
-		if got := dataAttributeNamePattern.MatchString(test.in); got != test.want {
-			t.Errorf("dataAttributeNamePattern.MatchString(%q) = %t", test.in, got)
-		}
-	}
-}
-
 const testSanitizationLogicWant = `cannot escape action {{.}}: unquoted attribute values disallowed`
 
 // TestSanitizationLogic ensures that the underlying html/template sanitization logic is
@@ -1136,6 +1232,10 @@ func TestSanitizationLogic(t *testing.T) {
 		testSanitizationLogicCheckError(t, err, test.parseFuncName, "ExecuteTemplate")
 		_, err = test.tmpl.ExecuteTemplateToHTML(templateName, nil)
 		testSanitizationLogicCheckError(t, err, test.parseFuncName, "ExecuteTemplateToHTML")
+		_, err = test.tmpl.ExecuteToBytes(nil)
+		testSanitizationLogicCheckError(t, err, test.parseFuncName, "ExecuteToBytes")
+		_, err = test.tmpl.ExecuteTemplateToBytes(templateName, nil)
+		testSanitizationLogicCheckError(t, err, test.parseFuncName, "ExecuteTemplateToBytes")
 	}
 }
 
@@ -1220,7 +1320,7 @@ func TestExecuteErrors(t *testing.T) {
 		{
 			desc: `dynamic element name suffix 1`,
 			tmpl: `<a{{ "foo" }} title="foo">`,
-			want: `actions must not affect element or attribute names`,
+			want: `expected a safehtml.Attribute value in the start tag`,
 		},
 		{
 			desc: `dynamic element name suffix 2`,
@@ -1232,22 +1332,22 @@ func TestExecuteErrors(t *testing.T) {
 		{
 			desc: `dynamic whole attribute name 1`,
 			tmpl: `<area {{ "foo" }}>`,
-			want: `actions must not affect element or attribute names`,
+			want: `expected a safehtml.Attribute value in the start tag`,
 		},
 		{
 			desc: `dynamic whole attribute name 2`,
 			tmpl: `<area {{ "foo" }} title="foo">`,
-			want: `actions must not affect element or attribute names`,
+			want: `expected a safehtml.Attribute value in the start tag`,
 		},
 		{
 			desc: `dynamic whole attribute name 3`,
 			tmpl: `<area title="foo" {{ "foo" }}>`,
-			want: `actions must not affect element or attribute names`,
+			want: `expected a safehtml.Attribute value in the start tag`,
 		},
 		{
 			desc: `dynamic whole attribute name 4`,
 			tmpl: `<area {{ "foo" }}="foo">`,
-			want: `actions must not affect element or attribute names`,
+			want: `expected space, attr name, or end of tag`,
 		},
 		{
 			desc: `dynamic attribute name suffix`,
@@ -1257,7 +1357,7 @@ func TestExecuteErrors(t *testing.T) {
 		{
 			desc: `dynamic attribute name prefix`,
 			tmpl: `<area {{ "foo" }}t="foo">`,
-			want: `actions must not affect element or attribute names`,
+			want: `expected a safehtml.Attribute value in the start tag`,
 		},
 		{
 			desc: `missing quote in the else branch`,
@@ -7,48 +7,91 @@
 package template
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"text/template"
 
 	"github.com/google/safehtml/internal/safehtmlutil"
 	"github.com/google/safehtml"
 )
 
-// sanitizationContext determines what type of sanitization to perform
-// on a template action.
-type sanitizationContext uint8
+// A SanitizationContext determines what type of sanitization to perform
+// on a template action. The zero value is not a valid SanitizationContext.
+type SanitizationContext uint8
 
 const (
-	_ = iota
-	sanitizationContextAsyncEnum
-	sanitizationContextDirEnum
-	sanitizationContextHTML
-	sanitizationContextHTMLValOnly
-	sanitizationContextIdentifier
-	sanitizationContextLoadingEnum
-	sanitizationContextNone
-	sanitizationContextRCDATA
-	sanitizationContextScript
-	sanitizationContextStyle
-	sanitizationContextStyleSheet
-	sanitizationContextTargetEnum
-	sanitizationContextTrustedResourceURL
-	sanitizationContextTrustedResourceURLOrURL
-	sanitizationContextURL
-	sanitizationContextURLSet
+	_ SanitizationContext = iota
+	// SanitizationContextAsyncEnum accepts only the string "async".
+	SanitizationContextAsyncEnum
+	// SanitizationContextDirEnum accepts only the strings "auto", "ltr" and "rtl".
+	SanitizationContextDirEnum
+	// SanitizationContextHTML accepts safehtml.HTML values, and escapes other values.
+	SanitizationContextHTML
+	// SanitizationContextHTMLValOnly accepts only safehtml.HTML values.
+	SanitizationContextHTMLValOnly
+	// SanitizationContextIdentifier accepts only safehtml.Identifier values.
+	SanitizationContextIdentifier
+	// SanitizationContextLoadingEnum accepts only the strings "eager" and "lazy".
+	SanitizationContextLoadingEnum
+	// SanitizationContextNone accepts values of any type without
+	// context-specific sanitization.
+	SanitizationContextNone
+	// SanitizationContextRCDATA escapes all values.
+	SanitizationContextRCDATA
+	// SanitizationContextScript accepts only safehtml.Script values.
+	SanitizationContextScript
+	// SanitizationContextStyle accepts only safehtml.Style values.
+	SanitizationContextStyle
+	// SanitizationContextStyleSheet accepts only safehtml.StyleSheet values.
+	SanitizationContextStyleSheet
+	// SanitizationContextTargetEnum accepts only the strings "_blank" and "_self".
+	SanitizationContextTargetEnum
+	// SanitizationContextTrustedResourceURL accepts only
+	// safehtml.TrustedResourceURL values.
+	SanitizationContextTrustedResourceURL
+	// SanitizationContextTrustedResourceURLOrURL accepts
+	// safehtml.TrustedResourceURL and safehtml.URL values, and sanitizes other
+	// values as URLs.
+	SanitizationContextTrustedResourceURLOrURL
+	// SanitizationContextURL accepts safehtml.URL values, and sanitizes other
+	// values as URLs.
+	SanitizationContextURL
+	// SanitizationContextURLSet accepts safehtml.URLSet values, and sanitizes
+	// other values as sets of URLs.
+	SanitizationContextURLSet
 )
 
-// String returns the string representation of sanitizationContext s.
-func (s sanitizationContext) String() string {
+// String returns the name of s used in error messages, such as "HTML".
+func (s SanitizationContext) String() string {
 	if int(s) >= len(sanitizationContextInfo) {
 		return fmt.Sprintf("invalid sanitization context %d", s)
 	}
 	return sanitizationContextInfo[s].name
 }
 
-// sanitizerName returns the name of the sanitizer to call in sanitizationContext s.
+// MarshalText returns the name of s.
+func (s SanitizationContext) MarshalText() ([]byte, error) {
+	if s == 0 || int(s) >= len(sanitizationContextInfo) {
+		return nil, fmt.Errorf("invalid sanitization context %d", s)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText sets s to the SanitizationContext with the given name.
+func (s *SanitizationContext) UnmarshalText(text []byte) error {
+	for sc, info := range sanitizationContextInfo {
+		if sc != 0 && info.name == string(text) {
+			*s = SanitizationContext(sc)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sanitization context %q", text)
+}
+
+// sanitizerName returns the name of the sanitizer to call in SanitizationContext s.
 // It returns an empty string if no sanitization is required in s.
-func (s sanitizationContext) sanitizerName() string {
+func (s SanitizationContext) sanitizerName() string {
 	if int(s) >= len(sanitizationContextInfo) {
 		return fmt.Sprintf("invalid sanitization context %d", s)
 	}
@@ -56,13 +99,13 @@ func (s sanitizationContext) sanitizerName() string {
 }
 
 // isEnum reports reports whether s is a sanitization context for enumerated values.
-func (s sanitizationContext) isEnum() bool {
-	return s == sanitizationContextAsyncEnum || s == sanitizationContextDirEnum || s == sanitizationContextLoadingEnum || s == sanitizationContextTargetEnum
+func (s SanitizationContext) isEnum() bool {
+	return s == SanitizationContextAsyncEnum || s == SanitizationContextDirEnum || s == SanitizationContextLoadingEnum || s == SanitizationContextTargetEnum
 }
 
 // isURLorTrustedResourceURL reports reports whether s is a sanitization context for URL or TrustedResourceURL values.
-func (s sanitizationContext) isURLorTrustedResourceURL() bool {
-	return s == sanitizationContextTrustedResourceURL || s == sanitizationContextTrustedResourceURLOrURL || s == sanitizationContextURL
+func (s SanitizationContext) isURLorTrustedResourceURL() bool {
+	return s == SanitizationContextTrustedResourceURL || s == SanitizationContextTrustedResourceURLOrURL || s == SanitizationContextURL
 }
 
 // sanitizationContextInfo[x] contains the name for sanitization context x and the
@@ -72,22 +115,22 @@ func (s sanitizationContext) isURLorTrustedResourceURL() bool {
 var sanitizationContextInfo = [...]struct {
 	name, sanitizerName string
 }{
-	sanitizationContextAsyncEnum:               {"AsyncEnum", sanitizeAsyncEnumFuncName},
-	sanitizationContextDirEnum:                 {"DirEnum", sanitizeDirEnumFuncName},
-	sanitizationContextHTML:                    {"HTML", sanitizeHTMLFuncName},
-	sanitizationContextHTMLValOnly:             {"HTMLValOnly", sanitizeHTMLValOnlyFuncName},
-	sanitizationContextIdentifier:              {"Identifier", sanitizeIdentifierFuncName},
-	sanitizationContextLoadingEnum:             {"LoadingEnum", sanitizeLoadingEnumFuncName},
-	sanitizationContextNone:                    {"None", ""},
-	sanitizationContextRCDATA:                  {"RCDATA", sanitizeRCDATAFuncName},
-	sanitizationContextScript:                  {"Script", sanitizeScriptFuncName},
-	sanitizationContextStyle:                   {"Style", sanitizeStyleFuncName},
-	sanitizationContextStyleSheet:              {"StyleSheet", sanitizeStyleSheetFuncName},
-	sanitizationContextTargetEnum:              {"TargetEnum", sanitizeTargetEnumFuncName},
-	sanitizationContextTrustedResourceURL:      {"TrustedResourceURL", sanitizeTrustedResourceURLFuncName},
-	sanitizationContextTrustedResourceURLOrURL: {"TrustedResourceURLOrURL", sanitizeTrustedResourceURLOrURLFuncName},
-	sanitizationContextURL:                     {"URL", sanitizeURLFuncName},
-	sanitizationContextURLSet:                  {"URLSet", sanitizeURLSetFuncName},
+	SanitizationContextAsyncEnum:               {"AsyncEnum", sanitizeAsyncEnumFuncName},
+	SanitizationContextDirEnum:                 {"DirEnum", sanitizeDirEnumFuncName},
+	SanitizationContextHTML:                    {"HTML", sanitizeHTMLFuncName},
+	SanitizationContextHTMLValOnly:             {"HTMLValOnly", sanitizeHTMLValOnlyFuncName},
+	SanitizationContextIdentifier:              {"Identifier", sanitizeIdentifierFuncName},
+	SanitizationContextLoadingEnum:             {"LoadingEnum", sanitizeLoadingEnumFuncName},
+	SanitizationContextNone:                    {"None", ""},
+	SanitizationContextRCDATA:                  {"RCDATA", sanitizeRCDATAFuncName},
+	SanitizationContextScript:                  {"Script", sanitizeScriptFuncName},
+	SanitizationContextStyle:                   {"Style", sanitizeStyleFuncName},
+	SanitizationContextStyleSheet:              {"StyleSheet", sanitizeStyleSheetFuncName},
+	SanitizationContextTargetEnum:              {"TargetEnum", sanitizeTargetEnumFuncName},
+	SanitizationContextTrustedResourceURL:      {"TrustedResourceURL", sanitizeTrustedResourceURLFuncName},
+	SanitizationContextTrustedResourceURLOrURL: {"TrustedResourceURLOrURL", sanitizeTrustedResourceURLOrURLFuncName},
+	SanitizationContextURL:                     {"URL", sanitizeURLFuncName},
+	SanitizationContextURLSet:                  {"URLSet", sanitizeURLSetFuncName},
 }
 
 var funcs = template.FuncMap{
@@ -136,333 +179,6 @@ const (
 	sanitizeURLSetFuncName                         = "_sanitizeURLSet"
 )
 
-// urlLinkRelVals contains values for a link element's rel attribute that indicate that the same link
-// element's href attribute may contain a safehtml.URL value.
-var urlLinkRelVals = map[string]bool{
-	"alternate":    true,
-	"author":       true,
-	"bookmark":     true,
-	"canonical":    true,
-	"cite":         true,
-	"dns-prefetch": true,
-	"help":         true,
-	"icon":         true,
-	"license":      true,
-	"next":         true,
-	"preconnect":   true,
-	"prefetch":     true,
-	"preload":      true,
-	"prerender":    true,
-	"prev":         true,
-	"search":       true,
-	"subresource":  true,
-}
-
-// elementSpecificAttrValSanitizationContext[x][y] is the sanitization context for
-// attribute x when it appears within element y.
-var elementSpecificAttrValSanitizationContext = map[string]map[string]sanitizationContext{
-	"accept": {
-		"input": sanitizationContextNone,
-	},
-	"action": {
-		"form": sanitizationContextURL,
-	},
-	"defer": {
-		"script": sanitizationContextNone,
-	},
-	"formaction": {
-		"button": sanitizationContextURL,
-		"input":  sanitizationContextURL,
-	},
-	"formmethod": {
-		"button": sanitizationContextNone,
-		"input":  sanitizationContextNone,
-	},
-	"href": {
-		"a":    sanitizationContextTrustedResourceURLOrURL,
-		"area": sanitizationContextTrustedResourceURLOrURL,
-	},
-	"method": {
-		"form": sanitizationContextNone,
-	},
-	"pattern": {
-		"input": sanitizationContextNone,
-	},
-	"readonly": {
-		"input":    sanitizationContextNone,
-		"textarea": sanitizationContextNone,
-	},
-	"src": {
-		"audio":  sanitizationContextTrustedResourceURLOrURL,
-		"img":    sanitizationContextTrustedResourceURLOrURL,
-		"input":  sanitizationContextTrustedResourceURLOrURL,
-		"source": sanitizationContextTrustedResourceURLOrURL,
-		"video":  sanitizationContextTrustedResourceURLOrURL,
-	},
-	"srcdoc": {
-		"iframe": sanitizationContextHTMLValOnly,
-	},
-	"srcset": {
-		"img":    sanitizationContextURLSet,
-		"source": sanitizationContextURLSet,
-	},
-}
-
-// globalAttrValSanitizationContext[x] is the sanitization context for attribute x when
-// it appears within any element not in the key set of elementSpecificAttrValSanitizationContext[x].
-var globalAttrValSanitizationContext = map[string]sanitizationContext{
-	"align":                 sanitizationContextNone,
-	"alt":                   sanitizationContextNone,
-	"aria-activedescendant": sanitizationContextIdentifier,
-	"aria-atomic":           sanitizationContextNone,
-	"aria-autocomplete":     sanitizationContextNone,
-	"aria-busy":             sanitizationContextNone,
-	"aria-checked":          sanitizationContextNone,
-	"aria-controls":         sanitizationContextIdentifier,
-	"aria-current":          sanitizationContextNone,
-	"aria-describedby":      sanitizationContextIdentifier,
-	"aria-disabled":         sanitizationContextNone,
-	"aria-dropeffect":       sanitizationContextNone,
-	"aria-expanded":         sanitizationContextNone,
-	"aria-haspopup":         sanitizationContextNone,
-	"aria-hidden":           sanitizationContextNone,
-	"aria-invalid":          sanitizationContextNone,
-	"aria-label":            sanitizationContextNone,
-	"aria-labelledby":       sanitizationContextIdentifier,
-	"aria-level":            sanitizationContextNone,
-	"aria-live":             sanitizationContextNone,
-	"aria-multiline":        sanitizationContextNone,
-	"aria-multiselectable":  sanitizationContextNone,
-	"aria-orientation":      sanitizationContextNone,
-	"aria-owns":             sanitizationContextIdentifier,
-	"aria-posinset":         sanitizationContextNone,
-	"aria-pressed":          sanitizationContextNone,
-	"aria-readonly":         sanitizationContextNone,
-	"aria-relevant":         sanitizationContextNone,
-	"aria-required":         sanitizationContextNone,
-	"aria-selected":         sanitizationContextNone,
-	"aria-setsize":          sanitizationContextNone,
-	"aria-sort":             sanitizationContextNone,
-	"aria-valuemax":         sanitizationContextNone,
-	"aria-valuemin":         sanitizationContextNone,
-	"aria-valuenow":         sanitizationContextNone,
-	"aria-valuetext":        sanitizationContextNone,
-	"async":                 sanitizationContextAsyncEnum,
-	"autocapitalize":        sanitizationContextNone,
-	"autocomplete":          sanitizationContextNone,
-	"autocorrect":           sanitizationContextNone,
-	"autofocus":             sanitizationContextNone,
-	"autoplay":              sanitizationContextNone,
-	"bgcolor":               sanitizationContextNone,
-	"border":                sanitizationContextNone,
-	"cellpadding":           sanitizationContextNone,
-	"cellspacing":           sanitizationContextNone,
-	"checked":               sanitizationContextNone,
-	"cite":                  sanitizationContextNone,
-	"class":                 sanitizationContextNone,
-	"color":                 sanitizationContextNone,
-	"cols":                  sanitizationContextNone,
-	"colspan":               sanitizationContextNone,
-	"contenteditable":       sanitizationContextNone,
-	"controls":              sanitizationContextNone,
-	"datetime":              sanitizationContextNone,
-	"dir":                   sanitizationContextDirEnum,
-	"disabled":              sanitizationContextNone,
-	"download":              sanitizationContextNone,
-	"draggable":             sanitizationContextNone,
-	"enctype":               sanitizationContextNone,
-	"face":                  sanitizationContextNone,
-	"for":                   sanitizationContextIdentifier,
-	"formenctype":           sanitizationContextNone,
-	"frameborder":           sanitizationContextNone,
-	"height":                sanitizationContextNone,
-	"hidden":                sanitizationContextNone,
-	"href":                  sanitizationContextTrustedResourceURL,
-	"hreflang":              sanitizationContextNone,
-	"id":                    sanitizationContextIdentifier,
-	"ismap":                 sanitizationContextNone,
-	"itemid":                sanitizationContextNone,
-	"itemprop":              sanitizationContextNone,
-	"itemref":               sanitizationContextNone,
-	"itemscope":             sanitizationContextNone,
-	"itemtype":              sanitizationContextNone,
-	"label":                 sanitizationContextNone,
-	"lang":                  sanitizationContextNone,
-	"list":                  sanitizationContextIdentifier,
-	"loading":               sanitizationContextLoadingEnum,
-	"loop":                  sanitizationContextNone,
-	"max":                   sanitizationContextNone,
-	"maxlength":             sanitizationContextNone,
-	"media":                 sanitizationContextNone,
-	"min":                   sanitizationContextNone,
-	"minlength":             sanitizationContextNone,
-	"multiple":              sanitizationContextNone,
-	"muted":                 sanitizationContextNone,
-	"name":                  sanitizationContextIdentifier,
-	"nonce":                 sanitizationContextNone,
-	"open":                  sanitizationContextNone,
-	"placeholder":           sanitizationContextNone,
-	"poster":                sanitizationContextNone,
-	"preload":               sanitizationContextNone,
-	"rel":                   sanitizationContextNone,
-	"required":              sanitizationContextNone,
-	"reversed":              sanitizationContextNone,
-	"role":                  sanitizationContextNone,
-	"rows":                  sanitizationContextNone,
-	"rowspan":               sanitizationContextNone,
-	"selected":              sanitizationContextNone,
-	"shape":                 sanitizationContextNone,
-	"size":                  sanitizationContextNone,
-	"sizes":                 sanitizationContextNone,
-	"slot":                  sanitizationContextNone,
-	"span":                  sanitizationContextNone,
-	"spellcheck":            sanitizationContextNone,
-	"src":                   sanitizationContextTrustedResourceURL,
-	"start":                 sanitizationContextNone,
-	"step":                  sanitizationContextNone,
-	"style":                 sanitizationContextStyle,
-	"summary":               sanitizationContextNone,
-	"tabindex":              sanitizationContextNone,
-	"target":                sanitizationContextTargetEnum,
-	"title":                 sanitizationContextNone,
-	"translate":             sanitizationContextNone,
-	"type":                  sanitizationContextNone,
-	"valign":                sanitizationContextNone,
-	"value":                 sanitizationContextNone,
-	"width":                 sanitizationContextNone,
-	"wrap":                  sanitizationContextNone,
-}
-
-// elementContentSanitizationContext maps element names to element content sanitization contexts.
-var elementContentSanitizationContext = map[string]sanitizationContext{
-	"a":          sanitizationContextHTML,
-	"abbr":       sanitizationContextHTML,
-	"acronym":    sanitizationContextHTML,
-	"address":    sanitizationContextHTML,
-	"article":    sanitizationContextHTML,
-	"aside":      sanitizationContextHTML,
-	"audio":      sanitizationContextHTML,
-	"b":          sanitizationContextHTML,
-	"basefont":   sanitizationContextHTML,
-	"bdi":        sanitizationContextHTML,
-	"bdo":        sanitizationContextHTML,
-	"big":        sanitizationContextHTML,
-	"blockquote": sanitizationContextHTML,
-	"body":       sanitizationContextHTML,
-	"button":     sanitizationContextHTML,
-	"canvas":     sanitizationContextHTML,
-	"caption":    sanitizationContextHTML,
-	"center":     sanitizationContextHTML,
-	"cite":       sanitizationContextHTML,
-	"code":       sanitizationContextHTML,
-	"colgroup":   sanitizationContextHTML,
-	"command":    sanitizationContextHTML,
-	"data":       sanitizationContextHTML,
-	"datalist":   sanitizationContextHTML,
-	"dd":         sanitizationContextHTML,
-	"del":        sanitizationContextHTML,
-	"details":    sanitizationContextHTML,
-	"dfn":        sanitizationContextHTML,
-	"dialog":     sanitizationContextHTML,
-	"dir":        sanitizationContextHTML,
-	"div":        sanitizationContextHTML,
-	"dl":         sanitizationContextHTML,
-	"dt":         sanitizationContextHTML,
-	"em":         sanitizationContextHTML,
-	"fieldset":   sanitizationContextHTML,
-	"figcaption": sanitizationContextHTML,
-	"figure":     sanitizationContextHTML,
-	"font":       sanitizationContextHTML,
-	"footer":     sanitizationContextHTML,
-	"form":       sanitizationContextHTML,
-	"frame":      sanitizationContextHTML,
-	"frameset":   sanitizationContextHTML,
-	"h1":         sanitizationContextHTML,
-	"h2":         sanitizationContextHTML,
-	"h3":         sanitizationContextHTML,
-	"h4":         sanitizationContextHTML,
-	"h5":         sanitizationContextHTML,
-	"h6":         sanitizationContextHTML,
-	"head":       sanitizationContextHTML,
-	"header":     sanitizationContextHTML,
-	"hgroup":     sanitizationContextHTML,
-	"html":       sanitizationContextHTML,
-	"i":          sanitizationContextHTML,
-	"iframe":     sanitizationContextHTML,
-	"ins":        sanitizationContextHTML,
-	"kbd":        sanitizationContextHTML,
-	"label":      sanitizationContextHTML,
-	"legend":     sanitizationContextHTML,
-	"lh":         sanitizationContextHTML,
-	"li":         sanitizationContextHTML,
-	"main":       sanitizationContextHTML,
-	"map":        sanitizationContextHTML,
-	"mark":       sanitizationContextHTML,
-	"menu":       sanitizationContextHTML,
-	"meter":      sanitizationContextHTML,
-	"nav":        sanitizationContextHTML,
-	"nobr":       sanitizationContextHTML,
-	"noscript":   sanitizationContextHTML,
-	"ol":         sanitizationContextHTML,
-	"optgroup":   sanitizationContextHTML,
-	"option":     sanitizationContextHTML,
-	"output":     sanitizationContextHTML,
-	"p":          sanitizationContextHTML,
-	"picture":    sanitizationContextHTML,
-	"pre":        sanitizationContextHTML,
-	"progress":   sanitizationContextHTML,
-	"q":          sanitizationContextHTML,
-	"rb":         sanitizationContextHTML,
-	"rp":         sanitizationContextHTML,
-	"rt":         sanitizationContextHTML,
-	"rtc":        sanitizationContextHTML,
-	"ruby":       sanitizationContextHTML,
-	"s":          sanitizationContextHTML,
-	"samp":       sanitizationContextHTML,
-	"script":     sanitizationContextScript,
-	"section":    sanitizationContextHTML,
-	"select":     sanitizationContextHTML,
-	"slot":       sanitizationContextHTML,
-	"small":      sanitizationContextHTML,
-	"span":       sanitizationContextHTML,
-	"strike":     sanitizationContextHTML,
-	"strong":     sanitizationContextHTML,
-	"style":      sanitizationContextStyleSheet,
-	"sub":        sanitizationContextHTML,
-	"summary":    sanitizationContextHTML,
-	"sup":        sanitizationContextHTML,
-	"table":      sanitizationContextHTML,
-	"tbody":      sanitizationContextHTML,
-	"td":         sanitizationContextHTML,
-	"textarea":   sanitizationContextRCDATA,
-	"tfoot":      sanitizationContextHTML,
-	"th":         sanitizationContextHTML,
-	"thead":      sanitizationContextHTML,
-	"time":       sanitizationContextHTML,
-	"title":      sanitizationContextRCDATA,
-	"tr":         sanitizationContextHTML,
-	"tt":         sanitizationContextHTML,
-	"u":          sanitizationContextHTML,
-	"ul":         sanitizationContextHTML,
-	"var":        sanitizationContextHTML,
-	"video":      sanitizationContextHTML,
-}
-
-// allowedVoidElements is a set of names of void elements actions may appear in.
-var allowedVoidElements = map[string]bool{
-	"area":   true,
-	"br":     true,
-	"col":    true,
-	"hr":     true,
-	"img":    true,
-	"input":  true,
-	"link":   true,
-	"param":  true,
-	"source": true,
-	"track":  true,
-	"wbr":    true,
-}
-
 var sanitizeAsyncEnumValues = map[string]bool{
 	"async": true,
 }
@@ -472,7 +188,7 @@ func sanitizeAsyncEnum(args ...interface{}) (string, error) {
 	if sanitizeAsyncEnumValues[input] {
 		return input, nil
 	}
-	return "", fmt.Errorf(`expected one of the following strings: ["async"]`)
+	return "", sanitizationErrorf(SanitizationContextAsyncEnum, ErrUnexpectedValue, `expected one of the following strings: ["async"]`)
 }
 
 var sanitizeDirEnumValues = map[string]bool{
@@ -486,12 +202,14 @@ func sanitizeDirEnum(args ...interface{}) (string, error) {
 	if sanitizeDirEnumValues[input] {
 		return input, nil
 	}
-	return "", fmt.Errorf(`expected one of the following strings: ["auto" "ltr" "rtl"]`)
+	return "", sanitizationErrorf(SanitizationContextDirEnum, ErrUnexpectedValue, `expected one of the following strings: ["auto" "ltr" "rtl"]`)
 }
 
 func sanitizeHTML(args ...interface{}) (string, error) {
 	if len(args) > 0 {
-		if safeTypeValue, ok := safehtmlutil.Indirect(args[0]).(safehtml.HTML); ok {
+		if safeTypeValue, ok, err := asHTML(args[0]); err != nil {
+			return "", err
+		} else if ok {
 			return safeTypeValue.String(), nil
 		}
 	}
@@ -501,11 +219,46 @@ func sanitizeHTML(args ...interface{}) (string, error) {
 
 func sanitizeHTMLValOnly(args ...interface{}) (string, error) {
 	if len(args) > 0 {
-		if safeTypeValue, ok := safehtmlutil.Indirect(args[0]).(safehtml.HTML); ok {
+		if safeTypeValue, ok, err := asHTML(args[0]); err != nil {
+			return "", err
+		} else if ok {
 			return safeTypeValue.String(), nil
 		}
 	}
-	return "", fmt.Errorf(`expected a safehtml.HTML value`)
+	return "", sanitizationErrorf(SanitizationContextHTMLValOnly, ErrExpectedSafeType, `expected a safehtml.HTML value`)
+}
+
+// errNilLazyHTML is returned by asHTML for nil safehtml.LazyHTML values, which
+// would otherwise be interpolated as the string form of a function.
+var errNilLazyHTML = errors.New("cannot interpolate a nil safehtml.LazyHTML")
+
+// asHTML returns the safehtml.HTML value of a, which is either a
+// safehtml.HTML value, a safehtml.HTMLer, or a safehtml.LazyHTML, or a
+// pointer to one, and the error of the LazyHTML, if any.
+// Nil pointers are not HTMLers, since their HTML method might panic, and nil
+// LazyHTML values are errors.
+func asHTML(a interface{}) (safehtml.HTML, bool, error) {
+	for {
+		switch v := a.(type) {
+		case safehtml.HTML:
+			return v, true, nil
+		case safehtml.LazyHTML:
+			if v == nil {
+				return safehtml.HTML{}, true, errNilLazyHTML
+			}
+			h, err := v()
+			return h, true, err
+		case safehtml.HTMLer:
+			if rv := reflect.ValueOf(v); rv.Kind() != reflect.Ptr || !rv.IsNil() {
+				return v.HTML(), true, nil
+			}
+		}
+		rv := reflect.ValueOf(a)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return safehtml.HTML{}, false, nil
+		}
+		a = rv.Elem().Interface()
+	}
 }
 
 func sanitizeIdentifier(args ...interface{}) (string, error) {
@@ -514,7 +267,7 @@ func sanitizeIdentifier(args ...interface{}) (string, error) {
 			return safeTypeValue.String(), nil
 		}
 	}
-	return "", fmt.Errorf(`expected a safehtml.Identifier value`)
+	return "", sanitizationErrorf(SanitizationContextIdentifier, ErrExpectedSafeType, `expected a safehtml.Identifier value`)
 }
 
 var sanitizeLoadingEnumValues = map[string]bool{
@@ -527,7 +280,7 @@ func sanitizeLoadingEnum(args ...interface{}) (string, error) {
 	if sanitizeLoadingEnumValues[input] {
 		return input, nil
 	}
-	return "", fmt.Errorf(`expected one of the following strings: ["eager" "lazy"]`)
+	return "", sanitizationErrorf(SanitizationContextLoadingEnum, ErrUnexpectedValue, `expected one of the following strings: ["eager" "lazy"]`)
 }
 
 func sanitizeRCDATA(args ...interface{}) (string, error) {
@@ -541,7 +294,7 @@ func sanitizeScript(args ...interface{}) (string, error) {
 			return safeTypeValue.String(), nil
 		}
 	}
-	return "", fmt.Errorf(`expected a safehtml.Script value`)
+	return "", sanitizationErrorf(SanitizationContextScript, ErrExpectedSafeType, `expected a safehtml.Script value`)
 }
 
 func sanitizeStyle(args ...interface{}) (string, error) {
@@ -550,7 +303,7 @@ func sanitizeStyle(args ...interface{}) (string, error) {
 			return safeTypeValue.String(), nil
 		}
 	}
-	return "", fmt.Errorf(`expected a safehtml.Style value`)
+	return "", sanitizationErrorf(SanitizationContextStyle, ErrExpectedSafeType, `expected a safehtml.Style value`)
 }
 
 func sanitizeStyleSheet(args ...interface{}) (string, error) {
@@ -559,7 +312,7 @@ func sanitizeStyleSheet(args ...interface{}) (string, error) {
 			return safeTypeValue.String(), nil
 		}
 	}
-	return "", fmt.Errorf(`expected a safehtml.StyleSheet value`)
+	return "", sanitizationErrorf(SanitizationContextStyleSheet, ErrExpectedSafeType, `expected a safehtml.StyleSheet value`)
 }
 
 var sanitizeTargetEnumValues = map[string]bool{
@@ -572,7 +325,7 @@ func sanitizeTargetEnum(args ...interface{}) (string, error) {
 	if sanitizeTargetEnumValues[input] {
 		return input, nil
 	}
-	return "", fmt.Errorf(`expected one of the following strings: ["_blank" "_self"]`)
+	return "", sanitizationErrorf(SanitizationContextTargetEnum, ErrUnexpectedValue, `expected one of the following strings: ["_blank" "_self"]`)
 }
 
 func sanitizeTrustedResourceURL(args ...interface{}) (string, error) {
@@ -581,7 +334,7 @@ func sanitizeTrustedResourceURL(args ...interface{}) (string, error) {
 			return safeTypeValue.String(), nil
 		}
 	}
-	return "", fmt.Errorf(`expected a safehtml.TrustedResourceURL value`)
+	return "", sanitizationErrorf(SanitizationContextTrustedResourceURL, ErrExpectedSafeType, `expected a safehtml.TrustedResourceURL value`)
 }
 
 func sanitizeTrustedResourceURLOrURL(args ...interface{}) (string, error) {
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// WithSecretCheck returns an ExecuteOption that scans the output of a
+// template, including the content of script elements, for strings that look
+// like credentials, and passes each one found to report:
+//
+//   - JSON Web Tokens;
+//   - API keys and access tokens in the formats of well-known providers,
+//     such as AWS access key IDs, Google API keys, and GitHub tokens;
+//   - bearer tokens, as in an Authorization header;
+//   - session cookies, such as "SESSIONID=..." from a Cookie header;
+//   - PEM-encoded private keys.
+//
+// Secrets are usually rendered by mistake, such as when a struct holding a
+// token is passed whole to a template that prints its fields for debugging,
+// and no sanitizer catches them, since they are only text. WithSecretCheck
+// is a last line of defense against shipping such templates.
+//
+// The check is a heuristic, which finds neither every secret nor only
+// secrets. Problems describe the kind of secret and only its first few
+// characters, so that reports can be logged without leaking it again. Like
+// WithHTMLCheck, WithSecretCheck buffers the output until the execution
+// completes, and is meant for development and tests rather than production.
+func WithSecretCheck(report func(HTMLProblem)) ExecuteOption {
+	return func(c *executeConfig) {
+		c.checkSecrets = report
+	}
+}
+
+// secretPatterns are the patterns of the secrets found by WithSecretCheck,
+// with their descriptions.
+var secretPatterns = [...]struct {
+	desc    string
+	pattern *regexp.Regexp
+}{
+	{"JSON Web Token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{16,}`)},
+	{"AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]|github_pat)_[A-Za-z0-9_]{36,}`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"Stripe secret key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}`)},
+	{"bearer token", regexp.MustCompile(`\b[Bb]earer [A-Za-z0-9._~+/-]{20,}=*`)},
+	{"session cookie", regexp.MustCompile(`(?i)\b(?:session|sessionid|session_id|sid|phpsessid|jsessionid|connect\.sid)=[A-Za-z0-9%._+/-]{16,}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+}
+
+// secretPrefixLen is the number of characters of a secret that its problem
+// describes.
+const secretPrefixLen = 6
+
+// checkSecrets passes the secrets in the output b to report, in the order
+// they occur in b. templateAt is as in checkHTML.
+func checkSecrets(b []byte, templateAt func(int) string, report func(HTMLProblem)) {
+	type secret struct {
+		start, end int
+		desc       string
+	}
+	var found []secret
+	for _, p := range secretPatterns {
+		for _, loc := range p.pattern.FindAllIndex(b, -1) {
+			found = append(found, secret{loc[0], loc[1], p.desc})
+		}
+	}
+	// Report overlapping matches, such as a bearer token that is also a JSON
+	// Web Token, once.
+	sort.SliceStable(found, func(i, j int) bool { return found[i].start < found[j].start })
+	end := 0
+	for _, s := range found {
+		if s.start < end {
+			continue
+		}
+		end = s.end
+		prefix := b[s.start:s.end]
+		if len(prefix) > secretPrefixLen {
+			prefix = prefix[:secretPrefixLen]
+		}
+		line := 1 + bytes.Count(b[:s.start], []byte("\n"))
+		report(HTMLProblem{line, []string{templateAt(s.start)}, fmt.Sprintf("possible %s %q... in output", s.desc, prefix)})
+	}
+}
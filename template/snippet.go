@@ -0,0 +1,106 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// ErrorSnippets sets whether escaping errors for t and all templates
+// associated with it include a Snippet of the template source. Snippets are
+// included by default. The return value is the template, so calls can be
+// chained.
+func (t *Template) ErrorSnippets(enabled bool) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.noErrorSnippets = !enabled
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// maxSnippetWidth is the maximum number of bytes of a source line that a
+// snippet includes.
+const maxSnippetWidth = 80
+
+// snippet returns the line of template source containing n, followed by a
+// line with a caret marking the position of n, or the empty string if the
+// source of n is unknown.
+func (ns *nameSpace) snippet(n parse.Node) string {
+	loc, _ := (*parse.Tree)(nil).ErrorContext(n)
+	// loc has the form "name:line:col", where name may contain colons.
+	i := strings.LastIndexByte(loc, ':')
+	if i < 0 {
+		return ""
+	}
+	j := strings.LastIndexByte(loc[:i], ':')
+	if j < 0 {
+		return ""
+	}
+	name := loc[:j]
+	line, err1 := strconv.Atoi(loc[j+1 : i])
+	col, err2 := strconv.Atoi(loc[i+1:])
+	if err1 != nil || err2 != nil {
+		return ""
+	}
+	pos := int(n.Position())
+	// Templates with the same parse name may have been parsed from several
+	// sources. Prefer the most recent source in which n has the same position.
+	sources := ns.sources[name]
+	for k := len(sources) - 1; k >= 0; k-- {
+		src := sources[k]
+		if pos > len(src) || 1+strings.Count(src[:pos], "\n") != line {
+			continue
+		}
+		start := strings.LastIndexByte(src[:pos], '\n') + 1
+		if pos-start != col {
+			continue
+		}
+		end := strings.IndexByte(src[pos:], '\n')
+		if end < 0 {
+			end = len(src)
+		} else {
+			end += pos
+		}
+		return caretSnippet(src[start:end], col)
+	}
+	return ""
+}
+
+// caretSnippet returns line followed by a line with a caret below the byte at
+// offset col.
+func caretSnippet(line string, col int) string {
+	line = strings.TrimSuffix(line, "\r")
+	prefix, suffix := "", ""
+	if len(line) > maxSnippetWidth {
+		start := col - maxSnippetWidth/2
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxSnippetWidth
+		if end > len(line) {
+			end = len(line)
+			start = end - maxSnippetWidth
+		}
+		if start > 0 {
+			prefix = "..."
+		}
+		if end < len(line) {
+			suffix = "..."
+		}
+		line, col = line[start:end], col-start+len(prefix)
+		line = prefix + line + suffix
+	}
+	// Preserve tabs so that the caret lines up with the source line.
+	indent := []byte(line[:col])
+	for i, b := range indent {
+		if b != '\t' {
+			indent[i] = ' '
+		}
+	}
+	return line + "\n" + string(indent) + "^"
+}
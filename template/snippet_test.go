@@ -0,0 +1,81 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorSnippet(t *testing.T) {
+	for _, test := range [...]struct {
+		desc    string
+		input   stringConstant
+		snippet string
+	}{
+		{
+			"single line",
+			`<a onclick="{{.}}">`,
+			"<a onclick=\"{{.}}\">\n              ^",
+		},
+		{
+			"tabs preserved",
+			"<html>\n\t<p>\n\t\t<a onclick=\"{{.}}\">\n</html>",
+			"\t\t<a onclick=\"{{.}}\">\n\t\t              ^",
+		},
+		{
+			"long line",
+			stringConstant(`<p>` + strings.Repeat("x", 100) + `<a onclick="{{.}}">` + strings.Repeat("y", 100) + `</p>`),
+			"..." + strings.Repeat("x", 26) + `<a onclick="{{.}}">` + strings.Repeat("y", 35) + "...\n" + strings.Repeat(" ", 43) + "^",
+		},
+		{
+			"nested template",
+			"{{define \"a\"}}\n<a onclick=\"{{template \"b\" .}}\">{{end}}{{define \"b\"}}{{.}}{{end}}\n{{template \"a\" .}}",
+			"<a onclick=\"{{template \"b\" .}}\">{{end}}{{define \"b\"}}{{.}}{{end}}\n" + strings.Repeat(" ", 55) + "^",
+		},
+	} {
+		err := Must(New("page").Parse(test.input)).Execute(&bytes.Buffer{}, nil)
+		var tmplErr *Error
+		if !errors.As(err, &tmplErr) {
+			t.Errorf("%s: got error %v, want *Error", test.desc, err)
+			continue
+		}
+		if tmplErr.Snippet != test.snippet {
+			t.Errorf("%s: got snippet\n%s\nwant\n%s", test.desc, tmplErr.Snippet, test.snippet)
+		}
+		if want := "\n\t" + strings.ReplaceAll(test.snippet, "\n", "\n\t"); !strings.HasSuffix(err.Error(), want) {
+			t.Errorf("%s: got error\n%s\nwant error ending in\n%s", test.desc, err, want)
+		}
+	}
+}
+
+func TestErrorSnippetMultipleSources(t *testing.T) {
+	tmpl := New("page")
+	Must(tmpl.Parse(`{{define "a"}}<p>{{.}}</p>{{end}}`))
+	Must(tmpl.Parse(`{{define "b"}}<a onclick="{{.}}">{{end}}`))
+	err := tmpl.ExecuteTemplate(&bytes.Buffer{}, "b", nil)
+	var tmplErr *Error
+	if !errors.As(err, &tmplErr) {
+		t.Fatalf("got error %v, want *Error", err)
+	}
+	if want := "{{define \"b\"}}<a onclick=\"{{.}}\">{{end}}\n                            ^"; tmplErr.Snippet != want {
+		t.Errorf("got snippet\n%s\nwant\n%s", tmplErr.Snippet, want)
+	}
+}
+
+func TestErrorSnippetsDisabled(t *testing.T) {
+	err := Must(New("page").ErrorSnippets(false).Parse(`<a onclick="{{.}}">`)).Execute(&bytes.Buffer{}, nil)
+	var tmplErr *Error
+	if !errors.As(err, &tmplErr) {
+		t.Fatalf("got error %v, want *Error", err)
+	}
+	if tmplErr.Snippet != "" || strings.Contains(err.Error(), "\n") {
+		t.Errorf("got error with snippet:\n%s", err)
+	}
+}
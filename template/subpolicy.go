@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// A subPolicy is the policy of the templates whose names begin with prefix.
+type subPolicy struct {
+	prefix string
+	policy *Policy
+}
+
+// WithPolicyFor sets the policy that the templates associated with t whose
+// names begin with prefix are escaped and executed with, instead of the policy
+// set using WithPolicy, so that templates with different capabilities can
+// share a template set. For example,
+//
+//	tmpl.WithPolicy(new(template.Policy).ForbidElements("iframe")).
+//		WithPolicyFor("admin/", nil)
+//
+// forbids iframe elements in all templates except those whose names begin
+// with "admin/". If the prefixes of several calls match the name of a
+// template, the longest one applies. Calling WithPolicyFor again with the same
+// prefix replaces its policy.
+//
+// The policy of a template applies to its own text, and to the values of the
+// actions in it, wherever it is invoked from. It does not apply to the
+// templates that it invokes, which are escaped and executed with their own
+// policies. Like
+// WithPolicy, WithPolicyFor must be called before any of the templates are
+// executed, and subsequent modifications to p do not affect t. The
+// SanitizationTables of t reflect only the policy set using WithPolicy.
+func (t *Template) WithPolicyFor(prefix string, p *Policy) *Template {
+	ns := t.nameSpace
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	for i, sp := range ns.subPolicies {
+		if sp.prefix == prefix {
+			ns.subPolicies[i].policy = p.clone()
+			return t
+		}
+	}
+	ns.subPolicies = append(ns.subPolicies, subPolicy{prefix, p.clone()})
+	return t
+}
+
+// policyFor returns the policy of the template with the given name, and the
+// index of its sub-policy in ns.subPolicies, or -1 if the policy of ns
+// applies.
+func (ns *nameSpace) policyFor(name string) (*Policy, int) {
+	p, index := ns.policy, -1
+	for i, sp := range ns.subPolicies {
+		if strings.HasPrefix(name, sp.prefix) && (index == -1 || len(sp.prefix) > len(ns.subPolicies[index].prefix)) {
+			p, index = sp.policy, i
+		}
+	}
+	return p, index
+}
+
+// subPolicyFuncNames contains the names of the sanitizers that policies
+// override to enforce their restrictions at execution time.
+var subPolicyFuncNames = [...]string{
+	sanitizeTrustedResourceURLOrURLFuncName,
+	sanitizeURLFuncName,
+	sanitizeURLSetFuncName,
+}
+
+// subPolicyFuncSuffix separates the names of the sanitizers of sub-policies
+// from the index of the sub-policy.
+const subPolicyFuncSuffix = "_subPolicy"
+
+// subPolicyFuncName returns the name of the sanitizer with the given name in
+// templates with the sub-policy with the given index.
+func subPolicyFuncName(name string, index int) string {
+	return name + subPolicyFuncSuffix + strconv.Itoa(index)
+}
+
+// baseFuncName returns the name of the sanitizer named name, which may be that
+// of a sub-policy, in templates without sub-policies.
+func baseFuncName(name string) string {
+	if i := strings.Index(name, subPolicyFuncSuffix); i != -1 {
+		return name[:i]
+	}
+	return name
+}
+
+// policyFuncs returns the sanitizers that must override the defaults in funcs
+// to enforce the policies of ns at execution time, including the sanitizers
+// of each sub-policy under the names given by subPolicyFuncName.
+func (ns *nameSpace) policyFuncs() template.FuncMap {
+	ret := ns.policy.funcs()
+	if len(ns.subPolicies) == 0 {
+		return ret
+	}
+	if ret == nil {
+		ret = make(template.FuncMap)
+	}
+	for i, sp := range ns.subPolicies {
+		spFuncs := sp.policy.funcs()
+		for _, name := range subPolicyFuncNames {
+			f, ok := spFuncs[name]
+			if !ok {
+				f = funcs[name]
+			}
+			ret[subPolicyFuncName(name, i)] = f
+		}
+	}
+	return ret
+}
+
+// cloneSubPolicies returns a deep copy of sps.
+func cloneSubPolicies(sps []subPolicy) []subPolicy {
+	if sps == nil {
+		return nil
+	}
+	ret := make([]subPolicy, len(sps))
+	for i, sp := range sps {
+		ret[i] = subPolicy{sp.prefix, sp.policy.clone()}
+	}
+	return ret
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"strings"
+	"testing"
+)
+
+func subPolicyTemplate() *Template {
+	noFrames := new(Policy).ForbidElements("iframe")
+	return Must(New("root").
+		WithPolicy(noFrames).
+		WithPolicyFor("admin/", nil).
+		WithPolicyFor("admin/reports/", new(Policy).ForbidURLSchemes("mailto")).
+		WithPolicyFor("public/", noFrames.ForbidURLSchemes("data")).
+		Parse(`{{define "admin/embed"}}<iframe src="/embed"></iframe>{{end}}` +
+			`{{define "admin/link"}}<a href="{{.}}">admin</a>{{end}}` +
+			`{{define "admin/reports/link"}}<a href="{{.}}">report</a>{{end}}` +
+			`{{define "public/link"}}<a href="{{.}}">public</a>{{end}}` +
+			`{{define "other/link"}}<a href="{{.}}">other</a>{{end}}` +
+			`{{define "public/embed"}}<iframe src="/embed"></iframe>{{end}}` +
+			`{{define "admin/page"}}{{template "public/link" .}}{{template "admin/link" .}}{{end}}` +
+			`{{define "public/page"}}{{template "admin/embed"}}{{end}}`))
+}
+
+func TestWithPolicyFor(t *testing.T) {
+	for _, test := range [...]struct {
+		name, data, want string
+	}{
+		{"admin/embed", "", `<iframe src="/embed"></iframe>`},
+		{"admin/link", "data:image/png;base64,AAAA", `<a href="data:image/png;base64,AAAA">admin</a>`},
+		{"admin/link", "mailto:a@example.com", `<a href="mailto:a@example.com">admin</a>`},
+		{"admin/reports/link", "mailto:a@example.com", `<a href="about:invalid#zGoSafez">report</a>`},
+		{"admin/reports/link", "data:image/png;base64,AAAA", `<a href="data:image/png;base64,AAAA">report</a>`},
+		{"public/link", "data:image/png;base64,AAAA", `<a href="about:invalid#zGoSafez">public</a>`},
+		{"other/link", "data:image/png;base64,AAAA", `<a href="data:image/png;base64,AAAA">other</a>`},
+		{
+			"admin/page",
+			"data:image/png;base64,AAAA",
+			`<a href="about:invalid#zGoSafez">public</a><a href="data:image/png;base64,AAAA">admin</a>`,
+		},
+		// Invoking a template with a less restrictive policy is allowed.
+		{"public/page", "", `<iframe src="/embed"></iframe>`},
+	} {
+		for _, opts := range [][]ExecuteOption{nil, {WithMaxSanitizerFallbacks(10)}} {
+			var b strings.Builder
+			if err := subPolicyTemplate().ExecuteTemplateWithOptions(&b, test.name, test.data, opts...); err != nil {
+				t.Errorf("%s(%q): %v", test.name, test.data, err)
+				continue
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("%s(%q) = %q, want %q", test.name, test.data, got, test.want)
+			}
+		}
+	}
+}
+
+func TestWithPolicyForEscapeErrors(t *testing.T) {
+	for _, name := range [...]string{"public/embed", "root"} {
+		tmpl := subPolicyTemplate()
+		if name == "root" {
+			tmpl = Must(tmpl.Parse(`{{template "public/embed"}}`))
+		}
+		err := tmpl.ExecuteTemplate(&strings.Builder{}, name, nil)
+		if err == nil || !strings.Contains(err.Error(), `element "iframe" is forbidden by the template policy`) {
+			t.Errorf("%s: got error %v, want policy violation", name, err)
+		}
+	}
+}
+
+func TestWithPolicyForClone(t *testing.T) {
+	tmpl := subPolicyTemplate()
+	clone := Must(tmpl.Clone())
+	tmpl.WithPolicyFor("public/", nil)
+	if err := clone.ExecuteTemplate(&strings.Builder{}, "public/embed", nil); err == nil {
+		t.Error("modifying the sub-policies of a template changed its clone")
+	}
+	if err := tmpl.ExecuteTemplate(&strings.Builder{}, "public/embed", nil); err != nil {
+		t.Errorf("replacing a sub-policy: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"github.com/google/safehtml/registry"
+)
+
+// SanitizationTables is a snapshot of the tables that determine which
+// elements and attributes actions may occur in, and which sanitization
+// context applies to each. Its JSON encoding is deterministic, so snapshots
+// taken from different binaries or configurations can be compared directly.
+//
+// Sanitization contexts are encoded in JSON by their names, such as "HTML",
+// "URL", or "TrustedResourceURL".
+type SanitizationTables struct {
+	// ElementContent maps the name of each element whose content may contain
+	// actions to the sanitization context of that content.
+	ElementContent map[string]SanitizationContext `json:"elementContent"`
+	// VoidElements contains the sorted names of void elements whose
+	// attribute values may contain actions.
+	VoidElements []string `json:"voidElements"`
+	// GlobalAttributes maps attribute names to the sanitization context of
+	// the attribute value in any element in ElementContent or VoidElements.
+	GlobalAttributes map[string]SanitizationContext `json:"globalAttributes"`
+	// ElementAttributes maps element names to attribute names to the
+	// sanitization context of the attribute value within that element.
+	// These entries take precedence over GlobalAttributes, and also apply to
+	// elements that are in neither ElementContent nor VoidElements.
+	ElementAttributes map[string]map[string]SanitizationContext `json:"elementAttributes"`
+	// URLLinkRelValues contains the sorted values of a link element's rel
+	// attribute that cause its href attribute value to be sanitized in
+	// SanitizationContextTrustedResourceURLOrURL instead of
+	// SanitizationContextTrustedResourceURL.
+	URLLinkRelValues []string `json:"urlLinkRelValues"`
+	// DataAttributes is the sanitization context of the values of custom
+	// data-* attributes in any element.
+	DataAttributes SanitizationContext `json:"dataAttributes"`
+	// ForbiddenElements contains the sorted names of elements that must not
+	// appear in template text at all.
+	ForbiddenElements []string `json:"forbiddenElements,omitempty"`
+	// ForbiddenAttributes maps element names to the sorted names of attributes
+	// that must not appear in those elements in template text at all. The
+	// element name "*" refers to all elements.
+	ForbiddenAttributes map[string][]string `json:"forbiddenAttributes,omitempty"`
+	// ForbiddenURLSchemes contains the sorted URL schemes that are replaced by
+	// safehtml.InnocuousURL in URL attribute values at execution time.
+	ForbiddenURLSchemes []string `json:"forbiddenURLSchemes,omitempty"`
+	// AllowedDataURLMIMETypes contains the sorted MIME types of the data URLs
+	// that are allowed in URL attribute values. If it is empty, data URLs of
+	// any MIME type are allowed, unless "data" is in ForbiddenURLSchemes.
+	AllowedDataURLMIMETypes []string `json:"allowedDataURLMIMETypes,omitempty"`
+	// MaxURLLength is the maximum length in bytes of the URLs interpolated
+	// into URL attribute values, or 0 if it is unlimited.
+	MaxURLLength int `json:"maxURLLength,omitempty"`
+	// RejectURLWhitespace reports whether URLs containing ASCII whitespace or
+	// control characters are replaced by safehtml.InnocuousURL in URL
+	// attribute values, rather than percent-encoded.
+	RejectURLWhitespace bool `json:"rejectURLWhitespace,omitempty"`
+}
+
+// hasGlobalAttributes reports whether GlobalAttributes apply to elem, which is
+// the case if it is in ElementContent or VoidElements.
+func (tables *SanitizationTables) hasGlobalAttributes(elem string) bool {
+	if _, ok := tables.ElementContent[elem]; ok {
+		return true
+	}
+	for _, v := range tables.VoidElements {
+		if v == elem {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSanitizationTables returns a snapshot of the stock sanitization
+// tables of this package.
+func DefaultSanitizationTables() SanitizationTables {
+	return registryTables(nil)
+}
+
+// registryTables returns a snapshot of the sanitization tables defined by the
+// contracts in r.
+func registryTables(r *registry.Registry) SanitizationTables {
+	tables := SanitizationTables{
+		ElementContent:    make(map[string]SanitizationContext),
+		VoidElements:      r.VoidElements(),
+		GlobalAttributes:  make(map[string]SanitizationContext),
+		ElementAttributes: make(map[string]map[string]SanitizationContext),
+		URLLinkRelValues:  r.URLLinkRelValues(),
+		DataAttributes:    SanitizationContextNone,
+	}
+	for elem, sc := range r.Elements() {
+		tables.ElementContent[elem] = SanitizationContext(sc)
+	}
+	for attr, sc := range r.GlobalAttributes() {
+		tables.GlobalAttributes[attr] = SanitizationContext(sc)
+	}
+	for elem, attrs := range r.ElementAttributes() {
+		tables.ElementAttributes[elem] = make(map[string]SanitizationContext, len(attrs))
+		for attr, sc := range attrs {
+			tables.ElementAttributes[elem][attr] = SanitizationContext(sc)
+		}
+	}
+	return tables
+}
+
+// SanitizationTables returns a snapshot of the sanitization tables in effect
+// for t and all templates associated with it, including the contracts of any
+// Registry set using WithRegistry and the restrictions of any Policy set using
+// WithPolicy.
+func (t *Template) SanitizationTables() SanitizationTables {
+	t.nameSpace.mu.Lock()
+	r, p := t.nameSpace.registry, t.nameSpace.policy
+	t.nameSpace.mu.Unlock()
+	tables := registryTables(r)
+	p.apply(&tables)
+	return tables
+}
+
+// WithRegistry sets the element and attribute contracts that t and all
+// templates associated with it are escaped with, in place of the stock
+// contracts returned by registry.Default. Using the same Registry wherever
+// HTML is produced ensures that its extensions apply consistently. WithRegistry
+// must be called before any of the templates are executed. Subsequent
+// extensions of r do not affect t.
+func (t *Template) WithRegistry(r *registry.Registry) *Template {
+	if r != nil {
+		r = r.Clone()
+	}
+	t.nameSpace.mu.Lock()
+	t.nameSpace.registry = r
+	t.nameSpace.mu.Unlock()
+	return t
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml/registry"
+)
+
+func TestDefaultSanitizationTables(t *testing.T) {
+	tables := DefaultSanitizationTables()
+	for _, test := range [...]struct {
+		desc      string
+		got, want SanitizationContext
+	}{
+		{"content of div", tables.ElementContent["div"], SanitizationContextHTML},
+		{"content of script", tables.ElementContent["script"], SanitizationContextScript},
+		{"content of textarea", tables.ElementContent["textarea"], SanitizationContextRCDATA},
+		{"global src", tables.GlobalAttributes["src"], SanitizationContextTrustedResourceURL},
+		{"global id", tables.GlobalAttributes["id"], SanitizationContextIdentifier},
+		{"global target", tables.GlobalAttributes["target"], SanitizationContextTargetEnum},
+		{"a href", tables.ElementAttributes["a"]["href"], SanitizationContextTrustedResourceURLOrURL},
+		{"img srcset", tables.ElementAttributes["img"]["srcset"], SanitizationContextURLSet},
+		{"form action", tables.ElementAttributes["form"]["action"], SanitizationContextURL},
+		{"data attributes", tables.DataAttributes, SanitizationContextNone},
+	} {
+		if test.got != test.want {
+			t.Errorf("%s: got %v, want %v", test.desc, test.got, test.want)
+		}
+	}
+	if _, ok := tables.ElementContent["object"]; ok {
+		t.Errorf("object element unexpectedly allowed")
+	}
+	if len(tables.VoidElements) == 0 || tables.VoidElements[0] != "area" {
+		t.Errorf("VoidElements = %q, want sorted list starting with \"area\"", tables.VoidElements)
+	}
+	if len(tables.URLLinkRelValues) == 0 || tables.URLLinkRelValues[0] != "alternate" {
+		t.Errorf("URLLinkRelValues = %q, want sorted list starting with \"alternate\"", tables.URLLinkRelValues)
+	}
+}
+
+func TestSanitizationTablesSnapshotIsCopy(t *testing.T) {
+	tables := New("").SanitizationTables()
+	tables.ElementContent["object"] = SanitizationContextHTML
+	tables.ElementAttributes["a"]["href"] = SanitizationContextNone
+	if _, ok := DefaultSanitizationTables().ElementContent["object"]; ok {
+		t.Errorf("modifying snapshot affected ElementContent table")
+	}
+	if got := DefaultSanitizationTables().ElementAttributes["a"]["href"]; got != SanitizationContextTrustedResourceURLOrURL {
+		t.Errorf("modifying snapshot affected ElementAttributes table: a href = %v", got)
+	}
+}
+
+func TestSanitizationTablesJSONDeterministic(t *testing.T) {
+	first, err := json.Marshal(DefaultSanitizationTables())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		b, err := json.Marshal(DefaultSanitizationTables())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(first, b) {
+			t.Fatalf("JSON encoding of sanitization tables is not deterministic")
+		}
+	}
+}
+
+func TestSanitizationTablesJSONRoundTrip(t *testing.T) {
+	b, err := json.Marshal(DefaultSanitizationTables())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"div":"HTML"`; !bytes.Contains(b, []byte(want)) {
+		t.Errorf("JSON encoding does not contain %s", want)
+	}
+	var tables SanitizationTables
+	if err := json.Unmarshal(b, &tables); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tables, DefaultSanitizationTables()) {
+		t.Errorf("decoded tables differ from the encoded tables")
+	}
+}
+
+func TestSanitizationContextText(t *testing.T) {
+	for sc := SanitizationContextAsyncEnum; sc <= SanitizationContextURLSet; sc++ {
+		text, err := sc.MarshalText()
+		if err != nil {
+			t.Fatalf("%d: %v", sc, err)
+		}
+		if string(text) != sc.String() {
+			t.Errorf("%d: MarshalText returned %q, want %q", sc, text, sc.String())
+		}
+		var got SanitizationContext
+		if err := got.UnmarshalText(text); err != nil || got != sc {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v", text, got, err, sc)
+		}
+	}
+	if _, err := SanitizationContext(0).MarshalText(); err == nil {
+		t.Error("MarshalText of the zero value succeeded")
+	}
+	var sc SanitizationContext
+	if err := sc.UnmarshalText([]byte("Bogus")); err == nil {
+		t.Error("UnmarshalText of an unknown name succeeded")
+	}
+}
+
+func TestSanitizationContextMatchesRegistry(t *testing.T) {
+	for sc := SanitizationContextAsyncEnum; sc <= SanitizationContextURLSet; sc++ {
+		if got, want := registry.Context(sc).String(), sc.String(); got != want {
+			t.Errorf("registry context %d is %s, want %s", sc, got, want)
+		}
+	}
+}
+
+func TestWithRegistry(t *testing.T) {
+	const text = `<div hx-target="{{.}}"></div>`
+	if err := Must(New("page").Parse(text)).Execute(&strings.Builder{}, nil); err == nil {
+		t.Fatal("got no error for action in attribute without a contract")
+	}
+	r := registry.Default().AllowAttribute("hx-target", registry.None)
+	tmpl := Must(New("page").WithRegistry(r).Parse(text))
+	// Extensions after WithRegistry do not affect the template.
+	r.AllowAttribute("hx-swap", registry.None)
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "#main"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<div hx-target="#main"></div>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	tables := tmpl.SanitizationTables()
+	if got := tables.GlobalAttributes["hx-target"]; got != SanitizationContextNone {
+		t.Errorf("got hx-target context %v, want None", got)
+	}
+	if _, ok := tables.GlobalAttributes["hx-swap"]; ok {
+		t.Error("extension made after WithRegistry is in the sanitization tables")
+	}
+}
@@ -6,16 +6,22 @@ package template
 
 import (
 	"bytes"
+	gocontext "context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"text/template"
 	"text/template/parse"
 
 	"log"
 	"github.com/google/safehtml"
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/registry"
 	"github.com/google/safehtml/uncheckedconversions"
 )
 
@@ -44,11 +50,53 @@ type nameSpace struct {
 	// cspCompatible indicates whether inline event handlers and
 	// javascript: URIs are disallowed in templates in this namespace.
 	cspCompatible bool
-	esc           escaper
+	// policy restricts the content allowed in templates in this namespace.
+	// It is nil if no policy has been set.
+	policy *Policy
+	// subPolicies contains the policies set using WithPolicyFor, which
+	// replace policy in the templates whose names begin with their prefixes.
+	subPolicies []subPolicy
+	// registry contains the element and attribute contracts of templates in
+	// this namespace. It is nil if the stock contracts apply.
+	registry *registry.Registry
+	// fragmentCache memoizes the output of {{cached}} actions. It is nil if
+	// no FragmentCache has been added.
+	fragmentCache *FragmentCache
+	// parallelFragments indicates whether WithParallelFragments has added
+	// the parallel function.
+	parallelFragments bool
+	// maxTemplateDepth is the maximum template call depth of executions of
+	// templates in this namespace, or 0 if there is no limit.
+	maxTemplateDepth int
+	// contextFuncs contains the functions added using ContextFuncs, which
+	// are bound to the context of each execution.
+	contextFuncs template.FuncMap
+	// sources maps parse names to the template sources parsed with that
+	// name, in the order they were parsed.
+	sources map[string][]string
+	// noErrorSnippets indicates whether escaping errors omit source snippets.
+	noErrorSnippets bool
+	// maxParseSize and maxParseNodes limit the size and number of parse
+	// nodes of the text parsed by each call to Parse, or are 0 if there is no
+	// limit.
+	maxParseSize, maxParseNodes int
+	// gen is incremented whenever the underlying templates or their
+	// functions change, invalidating the states in execStates.
+	gen int
+	// maxFallbacks is the default maximum number of sanitizer fallbacks of
+	// executions, or 0 if there is no limit.
+	maxFallbacks int
+	// tracer creates spans for executions. It is nil if no Tracer has been
+	// set.
+	tracer Tracer
+	// execStates contains pooled execStates for executions without and with
+	// a redactor.
+	execStates [2]sync.Pool
+	esc        escaper
 }
 
 // Templates returns a slice of the templates associated with t, including t
-// itself.
+// itself, in order of their names.
 func (t *Template) Templates() []*Template {
 	ns := t.nameSpace
 	ns.mu.Lock()
@@ -58,6 +106,7 @@ func (t *Template) Templates() []*Template {
 	for _, v := range ns.set {
 		m = append(m, v)
 	}
+	sort.Slice(m, func(i, j int) bool { return m[i].Name() < m[j].Name() })
 	return m
 }
 
@@ -93,7 +142,7 @@ func (t *Template) checkCanParse() error {
 	t.nameSpace.mu.Lock()
 	defer t.nameSpace.mu.Unlock()
 	if t.nameSpace.escaped {
-		return fmt.Errorf("html/template: cannot Parse after Execute")
+		return &Error{ErrorCode: ErrParse, Description: "cannot Parse after Execute"}
 	}
 	return nil
 }
@@ -105,7 +154,8 @@ func (t *Template) escape() error {
 	t.nameSpace.escaped = true
 	if t.escapeErr == nil {
 		if t.Tree == nil {
-			return fmt.Errorf("template: %q is an incomplete or empty template", t.Name())
+			desc := fmt.Sprintf("%q is an incomplete or empty template", t.Name())
+			return &Error{ErrorCode: ErrNoSuchTemplate, Description: desc, Err: fmt.Errorf("template: %s", desc)}
 		}
 		if err := escapeTemplate(t, t.text.Root, t.Name()); err != nil {
 			return err
@@ -123,11 +173,10 @@ func (t *Template) escape() error {
 // the output writer.
 // A template may be executed safely in parallel, although if parallel
 // executions share a Writer the output may be interleaved.
+// A non-nil error returned by Execute is an *Error whose ErrorCode
+// identifies the problem.
 func (t *Template) Execute(wr io.Writer, data interface{}) error {
-	if err := t.escape(); err != nil {
-		return err
-	}
-	return t.text.Execute(wr, data)
+	return t.ExecuteWithOptions(wr, data)
 }
 
 // ExecuteToHTML applies a parsed template to the specified data object,
@@ -141,6 +190,18 @@ func (t *Template) ExecuteToHTML(data interface{}) (safehtml.HTML, error) {
 	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(buf.String()), nil
 }
 
+// ExecuteToBytes is like ExecuteToHTML, but returns the output as a byte
+// slice, for callers that write it to a byte-oriented destination and would
+// otherwise convert it back from a string. The output is safe HTML like the
+// output of ExecuteToHTML, but carries no type guarantee once returned.
+func (t *Template) ExecuteToBytes(data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // MustParseAndExecuteToHTML is a helper that returns the safehtml.HTML value produced
 // by parsing text as a template body and executing it with no data. Any errors
 // encountered parsing or executing the template are fatal. This function is intended
@@ -153,10 +214,12 @@ func (t *Template) ExecuteToHTML(data interface{}) (safehtml.HTML, error) {
 func MustParseAndExecuteToHTML(text stringConstant) safehtml.HTML {
 	t, err := New("").Parse(text)
 	if err != nil {
+		diag.Log("safehtml/template: MustParseAndExecuteToHTML failed", "error", err)
 		log.Fatal(err)
 	}
 	html, err := t.ExecuteToHTML(nil)
 	if err != nil {
+		diag.Log("safehtml/template: MustParseAndExecuteToHTML failed", "error", err)
 		log.Fatal(err)
 	}
 	return html
@@ -169,12 +232,10 @@ func MustParseAndExecuteToHTML(text stringConstant) safehtml.HTML {
 // the output writer.
 // A template may be executed safely in parallel, although if parallel
 // executions share a Writer the output may be interleaved.
+// A non-nil error returned by ExecuteTemplate is an *Error whose ErrorCode
+// identifies the problem.
 func (t *Template) ExecuteTemplate(wr io.Writer, name string, data interface{}) error {
-	tmpl, err := t.lookupAndEscapeTemplate(name)
-	if err != nil {
-		return err
-	}
-	return tmpl.text.Execute(wr, data)
+	return t.ExecuteTemplateWithOptions(wr, name, data)
 }
 
 // ExecuteTemplateToHTML applies the template associated with t that has
@@ -189,37 +250,65 @@ func (t *Template) ExecuteTemplateToHTML(name string, data interface{}) (safehtm
 	return uncheckedconversions.HTMLFromStringKnownToSatisfyTypeContract(buf.String()), nil
 }
 
+// ExecuteTemplateToBytes is like ExecuteTemplateToHTML, but returns the
+// output as a byte slice.
+func (t *Template) ExecuteTemplateToBytes(name string, data interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // lookupAndEscapeTemplate guarantees that the template with the given name
 // is escaped, or returns an error if it cannot be. It returns the named
-// template.
-func (t *Template) lookupAndEscapeTemplate(name string) (tmpl *Template, err error) {
+// template. If ctx is not nil, escaping is traced in a span.
+func (t *Template) lookupAndEscapeTemplate(ctx gocontext.Context, name string) (tmpl *Template, err error) {
 	t.nameSpace.mu.Lock()
 	defer t.nameSpace.mu.Unlock()
 	t.nameSpace.escaped = true
 	tmpl = t.set[name]
 	if tmpl == nil {
-		return nil, fmt.Errorf("html/template: %q is undefined", name)
+		return nil, &Error{ErrorCode: ErrNoSuchTemplate, Description: fmt.Sprintf("%q is undefined", name)}
 	}
 	if tmpl.escapeErr != nil && tmpl.escapeErr != errEscapeOK {
 		return nil, tmpl.escapeErr
 	}
 	if tmpl.text.Tree == nil || tmpl.text.Root == nil {
-		return nil, fmt.Errorf("html/template: %q is an incomplete template", name)
+		return nil, &Error{ErrorCode: ErrNoSuchTemplate, Description: fmt.Sprintf("%q is an incomplete template", name)}
 	}
 	if t.text.Lookup(name) == nil {
 		panic("html/template internal error: template escaping out of sync")
 	}
 	if tmpl.escapeErr == nil {
+		var span Span
+		if ctx != nil && t.nameSpace.tracer != nil {
+			_, span = t.nameSpace.tracer.StartSpan(ctx, escapeSpanName)
+			span.SetAttribute(templateNameAttr, name)
+		}
 		err = escapeTemplate(tmpl, tmpl.text.Root, name)
+		if span != nil {
+			span.End(err)
+		}
 	}
 	return tmpl, err
 }
 
-// DefinedTemplates returns a string listing the defined templates,
-// prefixed by the string "; defined templates are: ". If there are none,
-// it returns the empty string. Used to generate an error message.
+// DefinedTemplates returns a string listing the defined templates in order of
+// their names, prefixed by the string "; defined templates are: ". If there
+// are none, it returns the empty string. Used to generate an error message.
 func (t *Template) DefinedTemplates() string {
-	return t.text.DefinedTemplates()
+	var names []string
+	for _, tmpl := range t.text.Templates() {
+		if tmpl.Tree != nil && tmpl.Root != nil {
+			names = append(names, strconv.Quote(tmpl.Name()))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return "; defined templates are: " + strings.Join(names, ", ")
 }
 
 // Parse parses text as a template body for t.
@@ -233,6 +322,7 @@ func (t *Template) DefinedTemplates() string {
 // is considered empty and will not replace an existing template's body.
 // This allows using Parse to add new named template definitions without
 // overwriting the main template body.
+// A non-nil error returned by Parse is an *Error with ErrorCode ErrParse.
 //
 // To guarantee that the template body is never controlled by an attacker, text
 // must be an untyped string constant, which is always under programmer control.
@@ -241,9 +331,9 @@ func (t *Template) Parse(text stringConstant) (*Template, error) {
 		return nil, err
 	}
 
-	ret, err := t.text.Parse(string(text))
+	ret, err := t.parseWithinLimits(string(text))
 	if err != nil {
-		return nil, err
+		return nil, parseError(t.Name(), err)
 	}
 
 	// In general, all the named templates might have changed underfoot.
@@ -251,6 +341,10 @@ func (t *Template) Parse(text stringConstant) (*Template, error) {
 	// The template.Template set has been updated; update ours.
 	t.nameSpace.mu.Lock()
 	defer t.nameSpace.mu.Unlock()
+	if t.nameSpace.sources == nil {
+		t.nameSpace.sources = make(map[string][]string)
+	}
+	t.nameSpace.sources[t.Name()] = append(t.nameSpace.sources[t.Name()], string(text))
 	for _, v := range ret.Templates() {
 		name := v.Name()
 		tmpl := t.set[name]
@@ -299,7 +393,25 @@ func (t *Template) Clone() (*Template, error) {
 	if err != nil {
 		return nil, err
 	}
-	ns := &nameSpace{set: make(map[string]*Template)}
+	ns := &nameSpace{
+		set:               make(map[string]*Template),
+		policy:            t.nameSpace.policy,
+		subPolicies:       cloneSubPolicies(t.nameSpace.subPolicies),
+		registry:          t.nameSpace.registry,
+		fragmentCache:     t.nameSpace.fragmentCache,
+		parallelFragments: t.nameSpace.parallelFragments,
+		maxTemplateDepth:  t.nameSpace.maxTemplateDepth,
+		contextFuncs:      t.nameSpace.contextFuncs,
+		maxFallbacks:      t.nameSpace.maxFallbacks,
+		tracer:            t.nameSpace.tracer,
+		noErrorSnippets:   t.nameSpace.noErrorSnippets,
+		maxParseSize:      t.nameSpace.maxParseSize,
+		maxParseNodes:     t.nameSpace.maxParseNodes,
+		sources:           make(map[string][]string, len(t.nameSpace.sources)),
+	}
+	for name, srcs := range t.nameSpace.sources {
+		ns.sources[name] = srcs[:len(srcs):len(srcs)]
+	}
 	ns.esc = makeEscaper(ns)
 	ret := &Template{
 		nil,
@@ -389,6 +501,12 @@ type FuncMap map[string]interface{}
 // type. However, it is legal to overwrite elements of the map. The return
 // value is the template, so calls can be chained.
 func (t *Template) Funcs(funcMap FuncMap) *Template {
+	t.nameSpace.mu.Lock()
+	for name := range funcMap {
+		delete(t.nameSpace.contextFuncs, name)
+	}
+	t.nameSpace.gen++
+	t.nameSpace.mu.Unlock()
 	t.text.Funcs(template.FuncMap(funcMap))
 	return t
 }
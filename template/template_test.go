@@ -53,6 +53,24 @@ func TestMustParseAndExecuteToHTML(t *testing.T) {
 	}
 }
 
+func TestExecuteToBytes(t *testing.T) {
+	tmpl := Must(New("page").Parse(`<p>{{.}}</p>{{define "item"}}<li>{{.}}</li>{{end}}`))
+	b, err := tmpl.ExecuteToBytes("<x>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `<p>&lt;x&gt;</p>`; got != want {
+		t.Errorf("ExecuteToBytes: got %q, want %q", got, want)
+	}
+	b, err = tmpl.ExecuteTemplateToBytes("item", "<x>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `<li>&lt;x&gt;</li>`; got != want {
+		t.Errorf("ExecuteTemplateToBytes: got %q, want %q", got, want)
+	}
+}
+
 func TestTemplateClone(t *testing.T) {
 	// https://golang.org/issue/12996
 	orig := New("name")
@@ -185,3 +203,22 @@ func TestDontAllowJSTemplateSubstitution(t *testing.T) {
 		t.Errorf("Parsed template %v, got error %v, expected %v", template, err, want)
 	}
 }
+
+func TestTemplatesOrder(t *testing.T) {
+	tmpl := Must(New("root").Parse(`{{define "c"}}c{{end}}{{define "a"}}a{{end}}{{define "b"}}b{{end}}`))
+	for i := 0; i < 10; i++ {
+		var names []string
+		for _, t := range tmpl.Templates() {
+			names = append(names, t.Name())
+		}
+		if got, want := strings.Join(names, " "), "a b c root"; got != want {
+			t.Fatalf("got templates %q, want %q", got, want)
+		}
+		if got, want := tmpl.DefinedTemplates(), `; defined templates are: "a", "b", "c", "root"`; got != want {
+			t.Fatalf("got DefinedTemplates() %q, want %q", got, want)
+		}
+	}
+	if got := New("empty").DefinedTemplates(); got != "" {
+		t.Errorf("got DefinedTemplates() %q for template without definitions, want empty", got)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package templatebench provides end-to-end benchmarks of safehtml/template,
+// which measure the execution of representative templates: a large page,
+// many small fragments, and pages dominated by URL and style values.
+//
+// The benchmarks can be run from any package, for example to compare the
+// performance of safehtml/template versions or of an application's build
+// configuration:
+//
+//	func BenchmarkSafeHTML(b *testing.B) { templatebench.Run(b) }
+package templatebench
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/template"
+)
+
+// A Benchmark is a named benchmark function.
+type Benchmark struct {
+	Name string
+	F    func(b *testing.B)
+}
+
+// Benchmarks returns the benchmarks of this package, in the order Run runs them.
+func Benchmarks() []Benchmark {
+	return []Benchmark{
+		{"LargePage", LargePage},
+		{"SmallFragments", SmallFragments},
+		{"URLHeavy", URLHeavy},
+		{"StyleHeavy", StyleHeavy},
+	}
+}
+
+// Run runs every benchmark returned by Benchmarks as a sub-benchmark of b.
+func Run(b *testing.B) {
+	for _, bm := range Benchmarks() {
+		b.Run(bm.Name, bm.F)
+	}
+}
+
+// LargePage measures the execution of a page with a header, a navigation
+// bar, and a list of 200 articles containing text, links, and attributes.
+func LargePage(b *testing.B) { benchmark(b, largePage) }
+
+// SmallFragments measures 100 executions of a one-line template to
+// safehtml.HTML values, which are then interpolated into a list.
+func SmallFragments(b *testing.B) { benchmark(b, smallFragments) }
+
+// URLHeavy measures the execution of a page with 100 rows of links and
+// images, whose URLs are sanitized and normalized.
+func URLHeavy(b *testing.B) { benchmark(b, urlHeavy) }
+
+// StyleHeavy measures the execution of a page with a stylesheet and 100
+// elements with safehtml.Style attribute values.
+func StyleHeavy(b *testing.B) { benchmark(b, styleHeavy) }
+
+// A workload executes a template once. It is created outside the timed
+// section of a benchmark, so that parsing and escaping are not measured.
+type workload func() error
+
+func benchmark(b *testing.B, newWorkload func() (workload, error)) {
+	run, err := newWorkload()
+	if err != nil {
+		b.Fatalf("templatebench: %v", err)
+	}
+	// The first execution escapes the templates.
+	if err := run(); err != nil {
+		b.Fatalf("templatebench: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := run(); err != nil {
+			b.Fatalf("templatebench: %v", err)
+		}
+	}
+}
+
+// executor returns a workload that executes t with data, discarding the output.
+func executor(t *template.Template, data interface{}) workload {
+	return func() error { return t.Execute(ioutil.Discard, data) }
+}
+
+type article struct {
+	ID                          safehtml.Identifier
+	Title, Author, Summary, URL string
+	Tags                        []string
+	Comments                    int
+}
+
+func largePage() (workload, error) {
+	t, err := template.New("page").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head><title>{{.Title}}</title><link rel="stylesheet" href="{{.CSS}}"></head>
+<body>
+<header><h1>{{.Title}}</h1><nav>{{range .Nav}}<a href="{{.URL}}">{{.Title}}</a> {{end}}</nav></header>
+<main>
+{{range .Articles}}<article id="{{.ID}}" class="article">
+<h2><a href="{{.URL}}" title="{{.Title}}">{{.Title}}</a></h2>
+<p class="byline">By {{.Author}}, {{.Comments}} comments</p>
+<p>{{.Summary}}</p>
+<ul class="tags">{{range .Tags}}<li><a href="/tags/{{.}}">{{.}}</a></li>{{end}}</ul>
+</article>
+{{end}}</main>
+<footer><p>&copy; {{.Title}}</p></footer>
+</body>
+</html>`)
+	if err != nil {
+		return nil, err
+	}
+	type link struct{ Title, URL string }
+	data := struct {
+		Lang, Title string
+		CSS         safehtml.TrustedResourceURL
+		Nav         []link
+		Articles    []article
+	}{
+		Lang:  "en",
+		Title: "The <Daily> Benchmark",
+		CSS:   safehtml.TrustedResourceURLFromConstant("/static/site.css"),
+	}
+	for i := 0; i < 10; i++ {
+		data.Nav = append(data.Nav, link{fmt.Sprintf("Section %d", i), fmt.Sprintf("/section/%d", i)})
+	}
+	for i := 0; i < 200; i++ {
+		data.Articles = append(data.Articles, article{
+			ID:       safehtml.IdentifierFromConstantPrefix("article", fmt.Sprint(i)),
+			Title:    fmt.Sprintf("Article %d: \"Quotes\" & <Brackets>", i),
+			Author:   "O'Gopher",
+			Summary:  "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.",
+			URL:      fmt.Sprintf("https://example.com/articles/%d?ref=front&utm=bench", i),
+			Tags:     []string{"go", "html", "security"},
+			Comments: i % 17,
+		})
+	}
+	return executor(t, data), nil
+}
+
+func smallFragments() (workload, error) {
+	item, err := template.New("item").Parse(`<span class="item-{{.Kind}}" title="{{.Name}}">{{.Name}}</span>`)
+	if err != nil {
+		return nil, err
+	}
+	list, err := template.New("list").Parse(`<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>`)
+	if err != nil {
+		return nil, err
+	}
+	type entry struct{ Kind, Name string }
+	var entries []entry
+	for i := 0; i < 100; i++ {
+		entries = append(entries, entry{fmt.Sprint(i % 5), fmt.Sprintf("<entry %d>", i)})
+	}
+	htmls := make([]safehtml.HTML, len(entries))
+	return func() error {
+		for i, e := range entries {
+			h, err := item.ExecuteToHTML(e)
+			if err != nil {
+				return err
+			}
+			htmls[i] = h
+		}
+		return list.Execute(ioutil.Discard, htmls)
+	}, nil
+}
+
+func urlHeavy() (workload, error) {
+	t, err := template.New("urls").Parse(`<table>
+{{range .}}<tr>
+<td><a href="{{.Link}}">{{.Link}}</a></td>
+<td><a href="/search?q={{.Query}}&amp;page={{.Page}}">search</a></td>
+<td><img src="{{.Image}}" alt="{{.Query}}"></td>
+<td><a href="{{.Unsafe}}">unsafe</a></td>
+</tr>
+{{end}}</table>`)
+	if err != nil {
+		return nil, err
+	}
+	type row struct {
+		Link, Query, Image, Unsafe string
+		Page                       int
+	}
+	var rows []row
+	for i := 0; i < 100; i++ {
+		rows = append(rows, row{
+			Link:   fmt.Sprintf("https://example.com/path/to/page %d?a=1&b=[2]#frag", i),
+			Query:  fmt.Sprintf("golang & html/template #%d", i),
+			Image:  fmt.Sprintf("//cdn.example.com/img/%d.png", i),
+			Unsafe: fmt.Sprintf("javascript:alert(%d)", i),
+			Page:   i,
+		})
+	}
+	return executor(t, rows), nil
+}
+
+func styleHeavy() (workload, error) {
+	t, err := template.New("styles").Parse(`<style>{{.Sheet}}</style>
+<div class="grid">{{range .Cells}}<div class="cell" style="{{.Style}}">{{.Label}}</div>{{end}}</div>`)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := safehtml.CSSRule(".cell", safehtml.StyleFromProperties(safehtml.StyleProperties{
+		Display: "inline-block",
+		Padding: "4px",
+		Width:   "10%",
+	}))
+	if err != nil {
+		return nil, err
+	}
+	type cell struct {
+		Style safehtml.Style
+		Label string
+	}
+	data := struct {
+		Sheet safehtml.StyleSheet
+		Cells []cell
+	}{Sheet: rule}
+	for i := 0; i < 100; i++ {
+		data.Cells = append(data.Cells, cell{
+			Style: safehtml.StyleFromProperties(safehtml.StyleProperties{
+				BackgroundColor:     fmt.Sprintf("#%06x", i*0x020304),
+				BackgroundImageURLs: []string{fmt.Sprintf("/img/bg-%d.png", i)},
+				Color:               "white",
+				FontFamily:          []string{"Roboto", `"Open Sans"`, "sans-serif"},
+			}),
+			Label: fmt.Sprintf("Cell %d", i),
+		})
+	}
+	return executor(t, data), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package templatebench
+
+import "testing"
+
+func BenchmarkTemplate(b *testing.B) { Run(b) }
+
+// TestAllocationBudgets guards against performance regressions by checking
+// that executions allocate at most about 20% more than when the budgets were
+// last updated.
+func TestAllocationBudgets(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector changes allocation counts")
+	}
+	for _, test := range [...]struct {
+		name        string
+		newWorkload func() (workload, error)
+		budget      float64
+	}{
+		{"LargePage", largePage, 33000},
+		{"SmallFragments", smallFragments, 5000},
+		{"URLHeavy", urlHeavy, 13200},
+		{"StyleHeavy", styleHeavy, 2700},
+	} {
+		run, err := test.newWorkload()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if err := run(); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		allocs := testing.AllocsPerRun(10, func() {
+			if err := run(); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+		})
+		if allocs > test.budget {
+			t.Errorf("%s: got %v allocations per execution, want at most %v", test.name, allocs, test.budget)
+		}
+	}
+}
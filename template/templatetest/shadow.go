@@ -0,0 +1,115 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package templatetest
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"testing"
+
+	"github.com/google/safehtml/template"
+)
+
+// An Execution is an execution of a template compared by a Shadow.
+type Execution struct {
+	// Template is the name of the executed template. If empty, the template
+	// parsed by NewShadow is executed.
+	Template string
+	// Data is the data that the template is executed with.
+	Data interface{}
+}
+
+// A Difference describes an execution whose result differs between
+// safehtml/template and html/template.
+type Difference struct {
+	Execution
+	// Output and Err are the output and error of safehtml/template.
+	Output string
+	Err    error
+	// HTMLTemplateOutput and HTMLTemplateErr are the output and error of
+	// html/template.
+	HTMLTemplateOutput string
+	HTMLTemplateErr    error
+}
+
+func (d Difference) Error() string {
+	name := d.Template
+	if name == "" {
+		name = shadowName
+	}
+	switch {
+	case d.Err != nil:
+		return fmt.Sprintf("%s: safehtml/template failed: %v; html/template output: %q", name, d.Err, d.HTMLTemplateOutput)
+	case d.HTMLTemplateErr != nil:
+		return fmt.Sprintf("%s: html/template failed: %v; safehtml/template output: %q", name, d.HTMLTemplateErr, d.Output)
+	}
+	return fmt.Sprintf("%s: safehtml/template output %q differs from html/template output %q", name, d.Output, d.HTMLTemplateOutput)
+}
+
+// shadowName is the name of the template parsed by NewShadow.
+const shadowName = "shadow"
+
+// A Shadow executes the same template text using both safehtml/template and
+// html/template, to quantify and review the differences in behavior before
+// migrating templates from html/template.
+type Shadow struct {
+	safe *template.Template
+	std  *htmltemplate.Template
+}
+
+// NewShadow parses text, which may define additional templates, using both
+// safehtml/template and html/template with the given functions. It returns an
+// error if either package fails to parse text.
+func NewShadow(text template.TrustedTemplate, funcs map[string]interface{}) (*Shadow, error) {
+	safe, err := template.New(shadowName).Funcs(funcs).ParseFromTrustedTemplate(text)
+	if err != nil {
+		return nil, fmt.Errorf("safehtml/template: %v", err)
+	}
+	std, err := htmltemplate.New(shadowName).Funcs(funcs).Parse(text.String())
+	if err != nil {
+		return nil, fmt.Errorf("html/template: %v", err)
+	}
+	return &Shadow{safe: safe, std: std}, nil
+}
+
+// Compare performs each execution using both packages and returns the
+// executions whose results differ, in order. Results differ if exactly one of
+// the packages fails, or if both succeed with different output; executions
+// failing in both packages are not reported.
+func (s *Shadow) Compare(executions ...Execution) []Difference {
+	var diffs []Difference
+	for _, e := range executions {
+		d := Difference{Execution: e}
+		var safeOut, stdOut bytes.Buffer
+		if e.Template == "" {
+			d.Err = s.safe.Execute(&safeOut, e.Data)
+			d.HTMLTemplateErr = s.std.Execute(&stdOut, e.Data)
+		} else {
+			d.Err = s.safe.ExecuteTemplate(&safeOut, e.Template, e.Data)
+			d.HTMLTemplateErr = s.std.ExecuteTemplate(&stdOut, e.Template, e.Data)
+		}
+		d.Output, d.HTMLTemplateOutput = safeOut.String(), stdOut.String()
+		if (d.Err != nil) != (d.HTMLTemplateErr != nil) || (d.Err == nil && d.Output != d.HTMLTemplateOutput) {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// CheckShadow parses text and funcs using NewShadow, compares the given
+// executions, and reports each difference found as a test error.
+func CheckShadow(tb testing.TB, text template.TrustedTemplate, funcs map[string]interface{}, executions ...Execution) {
+	tb.Helper()
+	s, err := NewShadow(text, funcs)
+	if err != nil {
+		tb.Fatalf("templatetest: %v", err)
+	}
+	for _, d := range s.Compare(executions...) {
+		tb.Errorf("templatetest: %v", d)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package templatetest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml/template"
+)
+
+const shadowPages = `{{define "text"}}<p title="{{.}}">{{.}}</p>{{end}}` +
+	`{{define "link"}}<a href="{{.}}">link</a>{{end}}` +
+	`{{define "script"}}<script>var x = {{.}};</script>{{end}}` +
+	`{{define "upper"}}<b>{{upper .}}</b>{{end}}`
+
+func TestShadowCompare(t *testing.T) {
+	s, err := NewShadow(template.MakeTrustedTemplate(shadowPages), map[string]interface{}{"upper": strings.ToUpper})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diffs := s.Compare(
+		Execution{"text", `<a & "b">`},
+		Execution{"link", "/about"},
+		Execution{"link", "javascript:alert(1)"},
+		Execution{"script", "x"},
+		Execution{"upper", "shout"},
+		Execution{"missing", nil},
+	)
+	if len(diffs) != 2 {
+		t.Fatalf("got %d differences, want 2: %v", len(diffs), diffs)
+	}
+	if d := diffs[0]; d.Data != "javascript:alert(1)" || d.Output != `<a href="about:invalid#zGoSafez">link</a>` || d.HTMLTemplateOutput != `<a href="#ZgotmplZ">link</a>` {
+		t.Errorf("unexpected first difference: %v", d)
+	}
+	if d := diffs[1]; d.Template != "script" || d.Err == nil || d.HTMLTemplateErr != nil || d.HTMLTemplateOutput != `<script>var x = "x";</script>` {
+		t.Errorf("unexpected second difference: %v", d)
+	}
+}
+
+func TestNewShadowParseError(t *testing.T) {
+	if _, err := NewShadow(template.MakeTrustedTemplate(`{{if}}`), nil); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package templatetest provides helpers for testing applications that render
+// pages using safehtml/template.
+//
+// A Server mounts a template set on an httptest.Server, executing a named
+// template with sample data for each configured route. Check then crawls
+// every route and verifies the integrity of the rendered links and assets:
+//   - Every same-origin URL in an attribute that the sanitization tables of
+//     the template set sanitize as a URL, such as href, src, srcset, and
+//     action, must resolve to a response with a non-error status code.
+//   - Every URL in a TrustedResourceURL attribute context (e.g. the src of a
+//     <script> element) must either be same-origin or have one of the
+//     configured allowed origins.
+//
+// A Shadow executes the same template text using both safehtml/template and
+// html/template, and reports the executions whose output or errors differ,
+// to quantify and review the changes in behavior of a migration.
+package templatetest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml/template"
+	"golang.org/x/net/html"
+)
+
+// A Route describes a page served by a Server.
+type Route struct {
+	// Path is the URL path at which the page is served, e.g. "/about".
+	Path string
+	// Template is the name of the template executed to render the page. If
+	// empty, the template passed to NewServer is executed.
+	Template string
+	// Data is the sample data that the template is executed with.
+	Data interface{}
+}
+
+// Config configures a Server.
+type Config struct {
+	// Routes are the pages rendered from templates.
+	Routes []Route
+	// Assets, if non-nil, handles requests for paths not matched by any
+	// route, such as stylesheets, scripts, and images.
+	Assets http.Handler
+	// AllowedOrigins contains the origins, of the form scheme://host[:port],
+	// from which TrustedResourceURLs may load resources. Resources served by
+	// the Server itself are always allowed.
+	AllowedOrigins []string
+}
+
+// A Server is an httptest.Server that serves pages rendered from a template set.
+type Server struct {
+	*httptest.Server
+	tmpl *template.Template
+	cfg  Config
+}
+
+// NewServer starts and returns a new Server that serves the routes in cfg
+// using templates from tmpl. The caller should call Close when finished.
+func NewServer(tmpl *template.Template, cfg Config) *Server {
+	s := &Server{tmpl: tmpl, cfg: cfg}
+	mux := http.NewServeMux()
+	hasRoot := false
+	for _, r := range cfg.Routes {
+		r := r
+		hasRoot = hasRoot || r.Path == "/"
+		mux.HandleFunc(r.Path, func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != r.Path {
+				s.serveAsset(w, req)
+				return
+			}
+			s.render(w, r)
+		})
+	}
+	if !hasRoot {
+		mux.HandleFunc("/", s.serveAsset)
+	}
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// render executes the template for r into w.
+func (s *Server) render(w http.ResponseWriter, r Route) {
+	var buf bytes.Buffer
+	var err error
+	if r.Template == "" {
+		err = s.tmpl.Execute(&buf, r.Data)
+	} else {
+		err = s.tmpl.ExecuteTemplate(&buf, r.Template, r.Data)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// serveAsset serves req using the configured asset handler, if any.
+func (s *Server) serveAsset(w http.ResponseWriter, req *http.Request) {
+	if s.cfg.Assets == nil {
+		http.NotFound(w, req)
+		return
+	}
+	s.cfg.Assets.ServeHTTP(w, req)
+}
+
+// A Problem describes an integrity check failure on a rendered page.
+type Problem struct {
+	// Page is the path of the route on which the problem was found.
+	Page string
+	// Element and Attr are the lowercase names of the element and attribute
+	// containing offending URL. Both are empty if the page itself failed to render.
+	Element, Attr string
+	// URL is the offending URL as it appears in the rendered page.
+	URL string
+	// Description is a human-readable description of the problem.
+	Description string
+}
+
+func (p Problem) Error() string {
+	if p.Element == "" {
+		return fmt.Sprintf("%s: %s", p.Page, p.Description)
+	}
+	return fmt.Sprintf("%s: <%s %s=%q>: %s", p.Page, p.Element, p.Attr, p.URL, p.Description)
+}
+
+// Check renders every route and returns the integrity problems found, sorted
+// by page. It returns an error only if the Server could not be reached.
+func (s *Server) Check() ([]Problem, error) {
+	base, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, err
+	}
+	allowed := map[string]bool{origin(base): true}
+	for _, o := range s.cfg.AllowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(o, "/"))] = true
+	}
+	tables := s.tmpl.SanitizationTables()
+	// status caches the status code of every same-origin URL fetched so far.
+	status := make(map[string]int)
+	var problems []Problem
+	for _, r := range s.cfg.Routes {
+		page := base.ResolveReference(&url.URL{Path: r.Path})
+		code, body, err := s.fetch(page.String())
+		if err != nil {
+			return nil, err
+		}
+		if code != http.StatusOK {
+			problems = append(problems, Problem{Page: r.Path, Description: fmt.Sprintf("rendering failed with status %d: %s", code, strings.TrimSpace(string(body)))})
+			continue
+		}
+		for _, ref := range extractRefs(body, &tables) {
+			u, err := page.Parse(strings.TrimSpace(ref.url))
+			if err != nil {
+				problems = append(problems, ref.problem(r.Path, fmt.Sprintf("cannot parse URL: %v", err)))
+				continue
+			}
+			sameOrigin := origin(u) == origin(base)
+			if ref.trustedResourceURL && u.Host != "" && !allowed[origin(u)] {
+				problems = append(problems, ref.problem(r.Path, fmt.Sprintf("origin %q is not an allowed TrustedResourceURL origin", origin(u))))
+				continue
+			}
+			if !sameOrigin || (u.Scheme != "http" && u.Scheme != "https") {
+				// Only resources served by this Server are fetched.
+				continue
+			}
+			target := *u
+			target.Fragment = ""
+			code, ok := status[target.String()]
+			if !ok {
+				if code, _, err = s.fetch(target.String()); err != nil {
+					return nil, err
+				}
+				status[target.String()] = code
+			}
+			// Form actions that only accept POST requests resolve, although
+			// they are fetched with GET requests.
+			if code >= 400 && code != http.StatusMethodNotAllowed {
+				problems = append(problems, ref.problem(r.Path, fmt.Sprintf("URL does not resolve: status %d", code)))
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Page < problems[j].Page })
+	return problems, nil
+}
+
+// fetch issues a GET request for u and returns the response status code and body.
+func (s *Server) fetch(u string) (int, []byte, error) {
+	resp, err := s.Client().Get(u)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
+
+// CheckIntegrity starts a Server for tmpl and cfg, checks the integrity of
+// every route, and reports each problem found as a test error.
+func CheckIntegrity(tb testing.TB, tmpl *template.Template, cfg Config) {
+	tb.Helper()
+	s := NewServer(tmpl, cfg)
+	defer s.Close()
+	problems, err := s.Check()
+	if err != nil {
+		tb.Fatalf("templatetest: %v", err)
+	}
+	for _, p := range problems {
+		tb.Errorf("templatetest: %v", p)
+	}
+}
+
+// origin returns the lowercase origin of u, or the empty string if u has no host.
+func origin(u *url.URL) string {
+	if u.Host == "" {
+		return ""
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// A ref is a URL-valued attribute found in a rendered page.
+type ref struct {
+	element, attr, url string
+	// trustedResourceURL reports whether the attribute value is in a
+	// TrustedResourceURL sanitization context.
+	trustedResourceURL bool
+}
+
+func (r ref) problem(page, description string) Problem {
+	return Problem{Page: page, Element: r.element, Attr: r.attr, URL: r.url, Description: description}
+}
+
+// extractRefs returns the URLs in the URL-valued attributes of the HTML
+// document b, such as href, src, srcset, and action, according to the
+// sanitization contexts in tables.
+func extractRefs(b []byte, tables *template.SanitizationTables) []ref {
+	var refs []ref
+	z := html.NewTokenizer(bytes.NewReader(b))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return refs
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			var rel string
+			for _, a := range tok.Attr {
+				if a.Key == "rel" {
+					rel = strings.ToLower(a.Val)
+				}
+			}
+			for _, a := range tok.Attr {
+				sc, ok := attrContext(tables, tok.Data, a.Key, rel)
+				if !ok {
+					continue
+				}
+				urls := []string{a.Val}
+				switch sc {
+				case template.SanitizationContextURLSet:
+					// Candidates are URLs optionally followed by descriptors,
+					// separated by commas.
+					urls = nil
+					for _, candidate := range strings.Split(a.Val, ",") {
+						if fields := strings.Fields(candidate); len(fields) > 0 {
+							urls = append(urls, fields[0])
+						}
+					}
+				case template.SanitizationContextURL, template.SanitizationContextTrustedResourceURL, template.SanitizationContextTrustedResourceURLOrURL:
+				default:
+					continue
+				}
+				for _, u := range urls {
+					refs = append(refs, ref{
+						element: tok.Data,
+						attr:    a.Key,
+						url:     u,
+						// Attributes that accept safehtml.URL values load
+						// content that is not trusted as code.
+						trustedResourceURL: sc == template.SanitizationContextTrustedResourceURL,
+					})
+				}
+			}
+		}
+	}
+}
+
+// attrContext returns the sanitization context of the value of attr in
+// element according to tables, where rel is the value of the element's rel
+// attribute, and reports whether tables allow untrusted values in it at all.
+func attrContext(tables *template.SanitizationTables, element, attr, rel string) (template.SanitizationContext, bool) {
+	if element == "link" && attr == "href" {
+		for _, val := range strings.Fields(rel) {
+			if i := sort.SearchStrings(tables.URLLinkRelValues, val); i < len(tables.URLLinkRelValues) && tables.URLLinkRelValues[i] == val {
+				return template.SanitizationContextTrustedResourceURLOrURL, true
+			}
+		}
+	}
+	if sc, ok := tables.ElementAttributes[element][attr]; ok {
+		return sc, true
+	}
+	_, isAllowedElement := tables.ElementContent[element]
+	if i := sort.SearchStrings(tables.VoidElements, element); i < len(tables.VoidElements) && tables.VoidElements[i] == element {
+		isAllowedElement = true
+	}
+	if sc, ok := tables.GlobalAttributes[attr]; ok && isAllowedElement {
+		return sc, true
+	}
+	return 0, false
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package templatetest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/safehtml"
+	"github.com/google/safehtml/template"
+)
+
+const pages = `
+{{define "index"}}<link rel="stylesheet" href="{{.CSS}}"><a href="{{.About}}">About</a><a href="#top">Top</a><a href="mailto:a@b.c">Mail</a>{{end}}
+{{define "about"}}<script src="{{.Script}}"></script><img src="{{.Img}}"><a href="https://elsewhere.example/">Elsewhere</a>{{end}}
+{{define "broken"}}<script>{{.}}</script>{{end}}
+`
+
+func newTemplate(t *testing.T) *template.Template {
+	t.Helper()
+	return template.Must(template.New("pages").Parse(pages))
+}
+
+var assets = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/static/app.css" || r.URL.Path == "/static/logo.png" {
+		w.Write([]byte("ok"))
+		return
+	}
+	http.NotFound(w, r)
+})
+
+func TestCheckIntegrity(t *testing.T) {
+	CheckIntegrity(t, newTemplate(t), Config{
+		Routes: []Route{
+			{"/", "index", map[string]interface{}{
+				"CSS":   safehtml.TrustedResourceURLFromConstant("/static/app.css"),
+				"About": "/about",
+			}},
+			{"/about", "about", map[string]interface{}{
+				"Script": safehtml.TrustedResourceURLFromConstant("https://cdn.example/app.js"),
+				"Img":    "/static/logo.png",
+			}},
+		},
+		Assets:         assets,
+		AllowedOrigins: []string{"https://CDN.example/"},
+	})
+}
+
+func TestCheckReportsProblems(t *testing.T) {
+	s := NewServer(newTemplate(t), Config{
+		Routes: []Route{
+			{"/", "index", map[string]interface{}{
+				"CSS":   safehtml.TrustedResourceURLFromConstant("/static/missing.css"),
+				"About": "/about",
+			}},
+			{"/about", "about", map[string]interface{}{
+				"Script": safehtml.TrustedResourceURLFromConstant("https://evil.example/app.js"),
+				"Img":    "/static/logo.png",
+			}},
+			{"/broken", "broken", "alert(1)"},
+		},
+		Assets: assets,
+	})
+	defer s.Close()
+	problems, err := s.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type summary struct{ page, element, attr, url string }
+	var got []summary
+	for _, p := range problems {
+		got = append(got, summary{p.Page, p.Element, p.Attr, p.URL})
+	}
+	want := []summary{
+		{"/", "link", "href", "/static/missing.css"},
+		{"/about", "script", "src", "https://evil.example/app.js"},
+		{"/broken", "", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() problems = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractRefs(t *testing.T) {
+	page := []byte(`<link rel="Canonical" href="/c"><link rel="stylesheet" href="/s.css"><iframe src="/f"></iframe><video src="/v"></video><a href="/a"><x-a href="/x">` +
+		`<img srcset="/1.png 1x, /2.png 2x"><form action="/post"><button formaction="/b"></button></form>`)
+	tables := template.DefaultSanitizationTables()
+	got := extractRefs(page, &tables)
+	want := []ref{
+		{"link", "href", "/c", false},
+		{"link", "href", "/s.css", true},
+		{"iframe", "src", "/f", true},
+		{"video", "src", "/v", false},
+		{"a", "href", "/a", false},
+		{"img", "srcset", "/1.png", false},
+		{"img", "srcset", "/2.png", false},
+		{"form", "action", "/post", false},
+		{"button", "formaction", "/b", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractRefs() = %+v, want %+v", got, want)
+	}
+
+	// The contexts follow the policy of the template under test.
+	tables = template.New("page").WithPolicy(new(template.Policy).RequireTrustedResourceURL("a", "href")).SanitizationTables()
+	got = extractRefs(page, &tables)
+	want[4].trustedResourceURL = true
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractRefs() with policy = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package template
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// TextFuncs returns a FuncMap of functions that format values as text in the
+// language identified by tag, for use with Funcs:
+//
+//	tmpl := template.New("page").Funcs(template.TextFuncs(language.English))
+//
+// The functions return plain strings, which are escaped like any other string
+// in the context that they are interpolated into:
+//
+//	formatDate t layout
+//		Returns t formatted with time.Time.Format. layout may also be one
+//		of "date" (2006-01-02), "time" (15:04), or "datetime"
+//		(2006-01-02 15:04).
+//	formatNumber n
+//		Returns the number n with the digit grouping and decimal separator
+//		of the language, such as "1,234.5" in English.
+//	formatPercent n
+//		Returns the fraction n as a percentage, such as "25%" for 0.25.
+//	plural n one other
+//		Returns one if the integer n has the singular plural form in the
+//		language, and other otherwise. For example,
+//		{{.N}} {{plural .N "file" "files"}}.
+//	truncate s n
+//		Returns s truncated to at most n characters, ending with "…" if
+//		it is truncated. It never splits a UTF-8 encoded character.
+//	title s
+//		Returns s with the first letter of each word in title case.
+//	upper s
+//		Returns s in upper case.
+//	lower s
+//		Returns s in lower case.
+func TextFuncs(tag language.Tag) FuncMap {
+	p := message.NewPrinter(tag)
+	return FuncMap{
+		"formatDate": formatDate,
+		"formatNumber": func(n interface{}) (string, error) {
+			if !isNumber(n) {
+				return "", fmt.Errorf("formatNumber: %T is not a number", n)
+			}
+			return p.Sprint(number.Decimal(n)), nil
+		},
+		"formatPercent": func(n interface{}) (string, error) {
+			if !isNumber(n) {
+				return "", fmt.Errorf("formatPercent: %T is not a number", n)
+			}
+			return p.Sprint(number.Percent(n)), nil
+		},
+		"plural": func(n int, one, other string) string {
+			if n < 0 {
+				n = -n
+			}
+			if plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0) == plural.One {
+				return one
+			}
+			return other
+		},
+		"truncate": truncate,
+		// Casers are not safe for concurrent use, so each call uses its own.
+		"title": func(s string) string { return cases.Title(tag).String(s) },
+		"upper": func(s string) string { return cases.Upper(tag).String(s) },
+		"lower": func(s string) string { return cases.Lower(tag).String(s) },
+	}
+}
+
+// dateLayouts maps the names of the layouts accepted by formatDate to their
+// time.Time.Format layouts.
+var dateLayouts = map[string]string{
+	"date":     "2006-01-02",
+	"time":     "15:04",
+	"datetime": "2006-01-02 15:04",
+}
+
+func formatDate(t time.Time, layout string) string {
+	if l, ok := dateLayouts[layout]; ok {
+		layout = l
+	}
+	return t.Format(layout)
+}
+
+func isNumber(n interface{}) bool {
+	switch n.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+func truncate(s string, n int) string {
+	if n < 0 {
+		n = 0
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	if n == 0 {
+		return ""
+	}
+	// Leave room for the ellipsis.
+	i, runes := 0, 0
+	for runes < n-1 {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		runes++
+	}
+	return s[:i] + "…"
+}
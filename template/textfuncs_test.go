@@ -0,0 +1,65 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package template
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func TestTextFuncs(t *testing.T) {
+	date := time.Date(2026, 3, 4, 15, 6, 0, 0, time.UTC)
+	for _, test := range [...]struct {
+		tag   language.Tag
+		input stringConstant
+		data  interface{}
+		want  string
+	}{
+		{language.English, `{{formatDate . "date"}}`, date, "2026-03-04"},
+		{language.English, `{{formatDate . "datetime"}}`, date, "2026-03-04 15:06"},
+		{language.English, `{{formatDate . "Jan 2, 2006"}}`, date, "Mar 4, 2026"},
+		{language.English, `{{formatNumber .}}`, 1234567.5, "1,234,567.5"},
+		{language.German, `{{formatNumber .}}`, 1234567.5, "1.234.567,5"},
+		{language.English, `{{formatPercent .}}`, 0.25, "25%"},
+		{language.English, `{{.}} {{plural . "file" "files"}}`, 1, "1 file"},
+		{language.English, `{{.}} {{plural . "file" "files"}}`, 2, "2 files"},
+		{language.English, `{{.}} {{plural . "file" "files"}}`, 0, "0 files"},
+		{language.French, `{{.}} {{plural . "fichier" "fichiers"}}`, 0, "0 fichier"},
+		{language.English, `{{truncate . 5}}`, "Hello, world", "Hell…"},
+		{language.English, `{{truncate . 5}}`, "Héllo", "Héllo"},
+		{language.English, `{{truncate . 3}}`, "日本語テキスト", "日本…"},
+		{language.English, `{{truncate . 0}}`, "abc", ""},
+		{language.English, `{{title .}}`, "the <quick> brown fox", "The &lt;Quick&gt; Brown Fox"},
+		{language.Turkish, `{{upper .}}`, "istanbul", "İSTANBUL"},
+		{language.English, `{{lower .}}`, "ABC", "abc"},
+		{language.English, `<a title="{{title .}}">`, `"x" & y`, `<a title="&#34;X&#34; &amp; Y">`},
+	} {
+		tmpl := Must(New("").Funcs(TextFuncs(test.tag)).Parse(test.input))
+		var b strings.Builder
+		if err := tmpl.Execute(&b, test.data); err != nil {
+			t.Errorf("%s with %v: unexpected error: %v", test.input, test.data, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s with %v: got %q, want %q", test.input, test.data, got, test.want)
+		}
+	}
+}
+
+func TestTextFuncsErrors(t *testing.T) {
+	tmpl := Must(New("").Funcs(TextFuncs(language.English)).Parse(`{{formatNumber .}}`))
+	err := tmpl.Execute(&strings.Builder{}, "12")
+	if err == nil || !strings.Contains(err.Error(), "formatNumber: string is not a number") {
+		t.Errorf("got error %v, want error for a string", err)
+	}
+}
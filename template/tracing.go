@@ -0,0 +1,144 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	gocontext "context"
+	"io"
+)
+
+// A Tracer creates spans for the executions of templates, so that the time
+// spent escaping and executing templates can be attributed in production. Its
+// methods mirror a subset of the OpenTelemetry tracing API, which a Tracer
+// can adapt without this package depending on it:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) StartSpan(ctx context.Context, name string) (context.Context, template.Span) {
+//		ctx, span := t.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttribute(key string, value interface{}) {
+//		s.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+//	}
+//
+//	func (s otelSpan) End(err error) {
+//		if err != nil {
+//			s.RecordError(err)
+//			s.SetStatus(codes.Error, err.Error())
+//		}
+//		s.Span.End()
+//	}
+//
+// A Tracer must be safe for concurrent use.
+type Tracer interface {
+	// StartSpan starts a span with the given name as a child of the span in
+	// ctx, if any, and returns a context containing the new span.
+	StartSpan(ctx gocontext.Context, name string) (gocontext.Context, Span)
+}
+
+// A Span is a span created by a Tracer.
+type Span interface {
+	// SetAttribute sets an attribute of the span, such as the name of the
+	// executed template.
+	SetAttribute(key string, value interface{})
+	// End ends the span. err is the error of the traced operation, or nil
+	// if it succeeded.
+	End(err error)
+}
+
+// Names of the spans and span attributes created for executions.
+const (
+	executeSpanName = "safehtml/template.Execute"
+	escapeSpanName  = "safehtml/template.Escape"
+
+	templateNameAttr = "safehtml.template.name"
+	outputSizeAttr   = "safehtml.template.output_size"
+)
+
+// WithTracer sets the Tracer that creates spans for the executions of t and
+// all templates associated with it. It must be called before any of the
+// templates are executed.
+//
+// Each execution has a span named "safehtml/template.Execute", with the
+// attributes safehtml.template.name, the name of the executed template, and
+// safehtml.template.output_size, the number of bytes written. The first
+// execution of each template also has a child span named
+// "safehtml/template.Escape" for escaping the template. Spans are children of
+// the span in the context of executions with WithContext.
+func (t *Template) WithTracer(tr Tracer) *Template {
+	t.nameSpace.mu.Lock()
+	t.nameSpace.tracer = tr
+	t.nameSpace.mu.Unlock()
+	return t
+}
+
+// trace calls execute, the execution of the named template with opts, in a
+// span if the namespace has a Tracer. execute must trace escaping the template
+// in ctx, which is nil if there is no span. If the execution has a context,
+// the functions added using ContextFuncs are bound by a context containing the
+// span instead.
+func (ns *nameSpace) trace(wr io.Writer, name string, opts []ExecuteOption, execute func(ctx gocontext.Context, wr io.Writer, opts []ExecuteOption) error) error {
+	ns.mu.Lock()
+	tr := ns.tracer
+	ns.mu.Unlock()
+	if tr == nil {
+		return execute(nil, wr, opts)
+	}
+	var c executeConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = gocontext.Background()
+	}
+	ctx, span := tr.StartSpan(ctx, executeSpanName)
+	span.SetAttribute(templateNameAttr, name)
+	if c.ctx != nil {
+		opts = append(opts[:len(opts):len(opts)], WithContext(ctx))
+	}
+	cw := &countingWriter{w: wr}
+	err := execute(ctx, cw, opts)
+	span.SetAttribute(outputSizeAttr, cw.n)
+	span.End(err)
+	return err
+}
+
+// tracedEscape escapes t like escape, in a span if ctx is not nil and t has
+// not been escaped yet.
+func (t *Template) tracedEscape(ctx gocontext.Context) error {
+	if ctx == nil {
+		return t.escape()
+	}
+	t.nameSpace.mu.Lock()
+	tr, escaped := t.nameSpace.tracer, t.escapeErr != nil
+	t.nameSpace.mu.Unlock()
+	if tr == nil || escaped {
+		return t.escape()
+	}
+	_, span := tr.StartSpan(ctx, escapeSpanName)
+	span.SetAttribute(templateNameAttr, t.Name())
+	err := t.escape()
+	span.End(err)
+	return err
+}
+
+// A countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += n
+	return n, err
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"bytes"
+	gocontext "context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type fakeSpan struct {
+	name   string
+	parent *fakeSpan
+	attrs  map[string]interface{}
+	ended  bool
+	err    error
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+
+func (s *fakeSpan) End(err error) { s.ended, s.err = true, err }
+
+type spanKey struct{}
+
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (tr *fakeTracer) StartSpan(ctx gocontext.Context, name string) (gocontext.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(*fakeSpan)
+	s := &fakeSpan{name: name, parent: parent, attrs: map[string]interface{}{}}
+	tr.mu.Lock()
+	tr.spans = append(tr.spans, s)
+	tr.mu.Unlock()
+	return gocontext.WithValue(ctx, spanKey{}, s), s
+}
+
+func TestTracer(t *testing.T) {
+	tr := &fakeTracer{}
+	tmpl := Must(New("page").Parse(`<p>{{template "item" .}}</p>{{define "item"}}<b>{{.}}</b>{{end}}`)).WithTracer(tr)
+	root := &fakeSpan{name: "request"}
+	ctx := gocontext.WithValue(gocontext.Background(), spanKey{}, root)
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteWithOptions(&buf, "x", WithContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpl.ExecuteTemplate(&buf, "item", "y"); err != nil {
+		t.Fatal(err)
+	}
+	type span struct {
+		name, parent string
+		attrs        map[string]interface{}
+	}
+	var got []span
+	for _, s := range tr.spans {
+		if !s.ended {
+			t.Errorf("span %q not ended", s.name)
+		}
+		var parent string
+		if s.parent != nil {
+			parent = s.parent.name
+		}
+		got = append(got, span{s.name, parent, s.attrs})
+	}
+	want := []span{
+		{executeSpanName, "request", map[string]interface{}{templateNameAttr: "page", outputSizeAttr: len(`<p><b>x</b></p>`)}},
+		{escapeSpanName, executeSpanName, map[string]interface{}{templateNameAttr: "page"}},
+		{executeSpanName, "", map[string]interface{}{templateNameAttr: "item", outputSizeAttr: len(`<b>y</b>`)}},
+		{escapeSpanName, executeSpanName, map[string]interface{}{templateNameAttr: "item"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got spans\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestTracerError(t *testing.T) {
+	tr := &fakeTracer{}
+	tmpl := Must(New("page").Parse(`<a href="{{.}}`)).WithTracer(tr)
+	err := tmpl.Execute(&bytes.Buffer{}, "x")
+	if err == nil {
+		t.Fatal("Execute succeeded, want escaping error")
+	}
+	if len(tr.spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(tr.spans))
+	}
+	for _, s := range tr.spans {
+		if s.err != err {
+			t.Errorf("span %q ended with error %v, want %v", s.name, s.err, err)
+		}
+	}
+}
+
+func TestTracerClone(t *testing.T) {
+	tr := &fakeTracer{}
+	tmpl := Must(Must(New("page").Parse(`{{.}}`)).WithTracer(tr).Clone())
+	if err := tmpl.Execute(&bytes.Buffer{}, "x"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.spans) == 0 {
+		t.Error("clone does not trace executions")
+	}
+}
@@ -94,6 +94,7 @@ func tTag(c context, s []byte) (context, int) {
 	// Find the attribute name.
 	i := eatWhiteSpace(s, 0)
 	if i == len(s) {
+		c.afterSpace = true
 		return c, len(s)
 	}
 	if s[i] == '>' {
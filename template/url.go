@@ -5,21 +5,19 @@
 package template
 
 import (
-	"fmt"
 	"html"
 	"regexp"
-	"strings"
 
 	"github.com/google/safehtml/internal/safehtmlutil"
-	"github.com/google/safehtml"
+	"github.com/google/safehtml/urlsafe"
 )
 
 // urlPrefixValidators maps URL and TrustedResourceURL sanitization contexts to functions return an error
 // if the given string is unsafe to use as a URL prefix in that sanitization context.
-var urlPrefixValidators = map[sanitizationContext]func(string) error{
-	sanitizationContextURL:                     validateURLPrefix,
-	sanitizationContextTrustedResourceURLOrURL: validateURLPrefix,
-	sanitizationContextTrustedResourceURL:      validateTrustedResourceURLPrefix,
+var urlPrefixValidators = map[SanitizationContext]func(string) error{
+	SanitizationContextURL:                     validateURLPrefix,
+	SanitizationContextTrustedResourceURLOrURL: validateURLPrefix,
+	SanitizationContextTrustedResourceURL:      validateTrustedResourceURLPrefix,
 }
 
 // startsWithFullySpecifiedSchemePattern matches strings that have a fully-specified scheme component.
@@ -29,53 +27,49 @@ var startsWithFullySpecifiedSchemePattern = regexp.MustCompile(
 
 // validateURLPrefix validates if the given non-empty prefix is a safe safehtml.URL prefix.
 //
-// Prefixes are considered unsafe if they end in an incomplete HTML character reference
-// or percent-encoding character triplet.
-//
-// If the prefix contains a fully-specified scheme component, it is considered safe only if
-// it starts with an allowed scheme. See safehtml.URLSanitized for more details.
-//
-// Otherwise, the prefix is safe only if it contains '/', '?', or '#', since the presence of any
-// of these runes ensures that this prefix, when combined with some arbitrary suffix, cannot be
-// interpreted as a part of a scheme.
+// Prefixes are considered unsafe if they end in an incomplete HTML character reference,
+// or if they are rejected by urlsafe.ValidateURLPrefix after HTML-unescaping.
 func validateURLPrefix(prefix string) error {
 	decoded, err := decodeURLPrefix(prefix)
 	if err != nil {
 		return err
 	}
-	switch {
-	case startsWithFullySpecifiedSchemePattern.MatchString(decoded):
-		if safehtml.URLSanitized(decoded).String() != decoded {
-			return fmt.Errorf("URL prefix %q contains an unsafe scheme", prefix)
-		}
-	case !strings.ContainsAny(decoded, "/?#"):
-		// If the URL prefix does not already have a ':' scheme delimiter, and does not contain
-		// '/', '?', or '#', any ':' following this prefix will be intepreted as a scheme
-		// delimiter, causing this URL prefix to be interpreted as being part of a scheme.
-		// e.g. `<a href="java{{ "script:" }}alert(1)>`
-		return fmt.Errorf("URL prefix %q is unsafe; it might be interpreted as part of a scheme", prefix)
-	}
-	return nil
+	return prefixError(prefix, urlsafe.ValidateURLPrefix(decoded))
 }
 
 // validateTrustedResourceURLPrefix validates if the given non-empty prefix is a safe
 // safehtml.TrustedResourceURL prefix.
 //
-// Prefixes are considered unsafe if they end in an incomplete HTML character reference
-// or percent-encoding character triplet.
-//
-// See safehtmlutil.IsSafeTrustedResourceURLPrefix for details on how the prefix is validated.
+// Prefixes are considered unsafe if they end in an incomplete HTML character reference,
+// or if they are rejected by urlsafe.ValidateTrustedResourceURLPrefix after HTML-unescaping.
 func validateTrustedResourceURLPrefix(prefix string) error {
 	decoded, err := decodeURLPrefix(prefix)
 	if err != nil {
 		return err
 	}
-	if !safehtmlutil.IsSafeTrustedResourceURLPrefix(decoded) {
-		return fmt.Errorf("%q is a disallowed TrustedResourceURL prefix", prefix)
+	if err := urlsafe.ValidateTrustedResourceURLPrefix(decoded); err != nil {
+		if perr := err.(*urlsafe.PrefixError); perr.Reason == trustedResourceURLPrefixReason {
+			return codedErrorf(ErrUnsafeURLPrefix, "%q is a disallowed TrustedResourceURL prefix", prefix)
+		}
+		return prefixError(prefix, err)
 	}
 	return nil
 }
 
+// trustedResourceURLPrefixReason is the reason of the urlsafe.PrefixError for
+// prefixes rejected by urlsafe.IsSafeTrustedResourceURLPrefix.
+const trustedResourceURLPrefixReason = "is not a safe TrustedResourceURL prefix"
+
+// prefixError returns an ErrUnsafeURLPrefix error for the given prefix with the
+// reason of err, which is a *urlsafe.PrefixError for the HTML-unescaped prefix,
+// or nil if err is nil.
+func prefixError(prefix string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return codedErrorf(ErrUnsafeURLPrefix, "URL prefix %q %s", prefix, err.(*urlsafe.PrefixError).Reason)
+}
+
 // endsWithPercentEncodingPrefixPattern matches strings that end in an incomplete
 // URL percent encoding triplet.
 //
@@ -94,19 +88,19 @@ var containsWhitespaceOrControlPattern = regexp.MustCompile(`[[:space:]]|[[:cntr
 //   - contains whitespace before or after HTML-unescaping.
 func decodeURLPrefix(prefix string) (string, error) {
 	if containsWhitespaceOrControlPattern.MatchString(prefix) {
-		return "", fmt.Errorf("URL prefix %q contains whitespace or control characters", prefix)
+		return "", codedErrorf(ErrUnsafeURLPrefix, "URL prefix %q contains whitespace or control characters", prefix)
 	}
 	if err := validateDoesNotEndsWithCharRefPrefix(prefix); err != nil {
-		return "", fmt.Errorf("URL %s", err)
+		return "", codedErrorf(errorCode(err, ErrUnsafeURLPrefix), "URL %s", err)
 	}
 	decoded := html.UnescapeString(prefix)
 	// Check again for whitespace that might have previously been masked by a HTML reference,
 	// such as in "javascript&NewLine;".
 	if containsWhitespaceOrControlPattern.MatchString(decoded) {
-		return "", fmt.Errorf("URL prefix %q contains whitespace or control characters", prefix)
+		return "", codedErrorf(ErrUnsafeURLPrefix, "URL prefix %q contains whitespace or control characters", prefix)
 	}
 	if endsWithPercentEncodingPrefixPattern.MatchString(decoded) {
-		return "", fmt.Errorf("URL prefix %q ends with an incomplete percent-encoding character triplet", prefix)
+		return "", codedErrorf(ErrUnsafeURLPrefix, "URL prefix %q ends with an incomplete percent-encoding character triplet", prefix)
 	}
 	return decoded, nil
 }
@@ -116,7 +110,7 @@ func validateTrustedResourceURLSubstitution(args ...interface{}) (string, error)
 	if safehtmlutil.URLContainsDoubleDotSegment(input) {
 		// Reject substitutions containing the ".." dot-segment to prevent the final TrustedResourceURL from referencing
 		// a resource higher up in the path name hierarchy than the path specified in the prefix.
-		return "", fmt.Errorf(`cannot substitute %q after TrustedResourceURL prefix: ".." is disallowed`, input)
+		return "", sanitizationErrorf(SanitizationContextTrustedResourceURL, ErrUnexpectedValue, `cannot substitute %q after TrustedResourceURL prefix: ".." is disallowed`, input)
 	}
 	return input, nil
 }
@@ -7,6 +7,8 @@ package template
 import (
 	"strings"
 	"testing"
+
+	"github.com/google/safehtml"
 )
 
 func TestValidateURLPrefix(t *testing.T) {
@@ -146,3 +148,18 @@ func TestDecodeURLPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestSrcsetFromCandidates(t *testing.T) {
+	srcset := safehtml.SrcsetFromCandidates([]safehtml.ImageCandidate{
+		{URL: safehtml.URLSanitized("/a,b.png"), Descriptor: "1x"},
+		{URL: safehtml.URLSanitized("/c.png"), Descriptor: "2x"},
+	})
+	tmpl := Must(New("").Parse(`<img srcset="{{.}}"><img srcset="/d.png 3x, {{.}}">`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, srcset); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), `<img srcset="/a,b.png 1x , /c.png 2x"><img srcset="/d.png 3x, /a,b.png 1x , /c.png 2x">`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
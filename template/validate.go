@@ -0,0 +1,88 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"sort"
+	"strings"
+)
+
+// A ValidationError is returned by Validate. It lists every problem found
+// while escaping the templates associated with a template.
+type ValidationError struct {
+	// Errors are the escaping errors, ordered by the name of the template in
+	// which they were found.
+	Errors []*Error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns e.Errors, so that errors.Is and errors.As examine each of
+// them in versions of Go that support wrapping multiple errors.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Validate escapes t and every template associated with it that has a body,
+// as if each of them were executed, and returns a *ValidationError listing
+// all the escaping errors. Unlike Execute, which reports only the first error
+// in the executed template, Validate allows all problems in a template set to
+// be fixed at once. It returns nil if every template can be executed.
+//
+// Validate does not affect t unless t has already been executed, so templates
+// can still be parsed after calling Validate. Parse reports only the first
+// syntax error in its input, since parsing cannot resume after it.
+func (t *Template) Validate() error {
+	v, err := t.Clone()
+	if err != nil {
+		// t has been executed, so escaping it in place is safe.
+		v = t
+	}
+	ns := v.nameSpace
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.escaped = true
+	names := make([]string, 0, len(ns.set))
+	for name := range ns.set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []*Error
+	// A template that cannot be escaped also causes an error in every
+	// template that calls it, so report each error only once.
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tmpl := ns.set[name]
+		err := tmpl.escapeErr
+		if err == nil {
+			if tmpl.text.Tree == nil || tmpl.text.Root == nil {
+				continue
+			}
+			err = escapeTemplate(tmpl, tmpl.text.Root, name)
+		}
+		e, ok := err.(*Error)
+		if !ok || seen[e.Error()] {
+			continue
+		}
+		seen[e.Error()] = true
+		errs = append(errs, e)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
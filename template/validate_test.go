@@ -0,0 +1,66 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package template
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tmpl := Must(New("page").Parse(`{{define "page"}}<a title={{.}}>{{template "link" .}}{{end}}` +
+		`{{define "link"}}<a href="javascript:{{.}}">{{end}}` +
+		`{{define "ok"}}<p>{{.}}</p>{{end}}` +
+		`{{define "style"}}<a onclick="{{.}}">{{end}}`))
+	err := tmpl.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("got error %v, want *ValidationError", err)
+	}
+	var got []ErrorCode
+	for _, e := range verr.Errors {
+		got = append(got, e.ErrorCode)
+	}
+	want := []ErrorCode{ErrUnsafeURLPrefix, ErrUnquotedAttr, ErrDisallowedAttr}
+	if len(got) != len(want) {
+		t.Fatalf("got error codes %v, want %v (error: %v)", got, want, err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("error %d: got code %d, want %d", i, got[i], want[i])
+		}
+	}
+	if n := strings.Count(err.Error(), "cannot escape action"); n != len(want) {
+		t.Errorf("got %d errors in message, want %d:\n%s", n, len(want), err)
+	}
+
+	// Validate does not prevent fixing the templates.
+	Must(tmpl.Parse(`{{define "page"}}<a title="{{.}}">{{end}}{{define "link"}}<a href="/{{.}}">{{end}}{{define "style"}}<b>{{.}}</b>{{end}}`))
+	if err := tmpl.Validate(); err != nil {
+		t.Fatalf("unexpected error after fixing templates: %v", err)
+	}
+	if err := tmpl.Execute(ioutil.Discard, "x"); err != nil {
+		t.Errorf("unexpected execution error: %v", err)
+	}
+}
+
+func TestValidateAfterExecute(t *testing.T) {
+	tmpl := Must(New("page").Parse(`{{define "page"}}{{.}}{{end}}{{define "bad"}}<a title={{.}}>{{end}}`))
+	if err := tmpl.Execute(ioutil.Discard, "x"); err != nil {
+		t.Fatal(err)
+	}
+	err := tmpl.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 1 || verr.Errors[0].ErrorCode != ErrUnquotedAttr {
+		t.Errorf("got error %v, want one ErrUnquotedAttr error", err)
+	}
+	if err := tmpl.Validate(); err == nil {
+		t.Error("got no error from second Validate, want the same error")
+	}
+}
@@ -27,6 +27,7 @@ var styleSheet = raw.StyleSheet.(func(string) safehtml.StyleSheet)
 var url = raw.URL.(func(string) safehtml.URL)
 var trustedResourceURL = raw.TrustedResourceURL.(func(string) safehtml.TrustedResourceURL)
 var identifier = raw.Identifier.(func(string) safehtml.Identifier)
+var svg = raw.SVG.(func(string) safehtml.SVG)
 
 // MakeHTMLForTest converts a plain string into a HTML.
 // This function must only be used in tests.
@@ -69,3 +70,9 @@ func MakeTrustedResourceURLForTest(s string) safehtml.TrustedResourceURL {
 func MakeIdentifierForTest(s string) safehtml.Identifier {
 	return identifier(s)
 }
+
+// MakeSVGForTest converts a plain string into an SVG.
+// This function must only be used in tests.
+func MakeSVGForTest(s string) safehtml.SVG {
+	return svg(s)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/safehtml/registry"
+)
+
+// TimeOptions are the options of TimeHTML.
+type TimeOptions struct {
+	// Now is the time that relative times are relative to. If it is zero,
+	// time.Now() is used.
+	Now time.Time
+	// Layout is the time.Time.Format layout of the text of the element. If it
+	// is empty, the text is the time relative to Now in English, such as
+	// "3 hours ago" or "in 2 days".
+	Layout string
+	// TitleLayout is the optional time.Time.Format layout of the title
+	// attribute of the element, which browsers show as a tooltip, such as
+	// time.RFC1123 to show the absolute time of a relative time.
+	TitleLayout string
+	// Data contains data attributes to add to the element, such as the
+	// attributes that client-side libraries use to update relative times,
+	// keyed by their full names, such as "data-timeago". Names that are not
+	// valid data attribute names are omitted.
+	Data map[string]string
+}
+
+// TimeHTML returns a time element for t, whose datetime attribute is t in the
+// machine-readable RFC 3339 format, and whose text is the human-readable time
+// described by opts:
+//
+//	<time datetime="2026-03-04T15:06:00Z" title="Wed, 04 Mar 2026 15:06:00 UTC">3 hours ago</time>
+//
+// Every attribute value and the text are escaped.
+func TimeHTML(t time.Time, opts TimeOptions) HTML {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	text := relativeTime(t, now)
+	if opts.Layout != "" {
+		text = t.Format(opts.Layout)
+	}
+	var w elementWriter
+	w.open("time")
+	w.attr("datetime", t.Format(time.RFC3339))
+	if opts.TitleLayout != "" {
+		w.attr("title", t.Format(opts.TitleLayout))
+	}
+	names := make([]string, 0, len(opts.Data))
+	for name := range opts.Data {
+		if registry.IsDataAttribute(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.requiredAttr(name, opts.Data[name])
+	}
+	w.closeTag()
+	w.text(text)
+	w.end("time")
+	return w.html()
+}
+
+// relativeTimeUnits contains the units of relative times, from the largest.
+var relativeTimeUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+}
+
+// relativeTime returns t relative to now in English, in the largest unit that
+// the difference contains at least once.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	for _, u := range relativeTimeUnits {
+		n := int64(d / u.d)
+		if n == 0 {
+			continue
+		}
+		s := strconv.FormatInt(n, 10) + " " + u.name
+		if n != 1 {
+			s += "s"
+		}
+		if future {
+			return "in " + s
+		}
+		return s + " ago"
+	}
+	return "just now"
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeHTML(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 6, 0, 0, time.UTC)
+	for _, test := range [...]struct {
+		desc string
+		t    time.Time
+		opts TimeOptions
+		want string
+	}{
+		{
+			"just now",
+			now.Add(-30 * time.Second),
+			TimeOptions{Now: now},
+			`<time datetime="2026-03-04T15:05:30Z">just now</time>`,
+		},
+		{
+			"minute ago",
+			now.Add(-time.Minute),
+			TimeOptions{Now: now},
+			`<time datetime="2026-03-04T15:05:00Z">1 minute ago</time>`,
+		},
+		{
+			"hours ago with title",
+			now.Add(-3*time.Hour - 59*time.Minute),
+			TimeOptions{Now: now, TitleLayout: time.RFC1123},
+			`<time datetime="2026-03-04T11:07:00Z" title="Wed, 04 Mar 2026 11:07:00 UTC">3 hours ago</time>`,
+		},
+		{
+			"future",
+			now.Add(50 * time.Hour),
+			TimeOptions{Now: now},
+			`<time datetime="2026-03-06T17:06:00Z">in 2 days</time>`,
+		},
+		{
+			"years ago",
+			now.AddDate(-2, 0, -1),
+			TimeOptions{Now: now},
+			`<time datetime="2024-03-03T15:06:00Z">2 years ago</time>`,
+		},
+		{
+			"layout",
+			now,
+			TimeOptions{Now: now, Layout: "Jan 2, 2006 <15:04>"},
+			`<time datetime="2026-03-04T15:06:00Z">Mar 4, 2026 &lt;15:06&gt;</time>`,
+		},
+		{
+			"data attributes",
+			now.Add(-48 * time.Hour),
+			TimeOptions{Now: now, Data: map[string]string{
+				"data-timeago":   `"2 days"`,
+				"data-format":    "",
+				"onclick":        "alert(1)",
+				"data-X":         "x",
+				"data-a\" b=\"c": "x",
+			}},
+			`<time datetime="2026-03-02T15:06:00Z" data-format="" data-timeago="&#34;2 days&#34;">2 days ago</time>`,
+		},
+	} {
+		if got := TimeHTML(test.t, test.opts).String(); got != test.want {
+			t.Errorf("%s: got:\n\t%s\nwant:\n\t%s", test.desc, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package safehtml
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLToText returns the readable text of h, with its tags removed and its
+// entities decoded, such as for alt text, search indexing, and plain-text
+// email bodies. The returned string is plain text, not HTML, and must not be
+// used as HTML without being escaped again.
+//
+// As browsers do, HTMLToText collapses runs of whitespace in text into a
+// single space, except in pre and textarea elements. It separates block
+// elements such as div and li by a line break, and paragraphs, headings, and
+// other elements that browsers render with a margin by an empty line. br
+// elements are line breaks, and table cells are separated by a space. The
+// content of script, style, and template elements is dropped, as are
+// comments.
+func HTMLToText(h HTML) string {
+	z := html.NewTokenizer(strings.NewReader(h.str))
+	var b strings.Builder
+	// breaks is the number of line breaks to write before the next text,
+	// and space whether to write a space before it.
+	breaks, space := 0, false
+	// pre is the number of open elements whose whitespace is preserved, and
+	// skip the number of open elements whose content is dropped.
+	pre, skip := 0, 0
+	// preStart reports whether the previous token is a pre start tag, which
+	// a newline that the browser drops may follow.
+	preStart := false
+	lineBreak := func(n int) {
+		if n > breaks {
+			breaks = n
+		}
+	}
+	for {
+		tt := z.Next()
+		afterPreStart := preStart
+		preStart = false
+		switch tt {
+		case html.ErrorToken:
+			// The tokenizer only fails at the end of its input, since reading
+			// from a strings.Reader does not fail.
+			return b.String()
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+			text := string(z.Text())
+			if afterPreStart {
+				text = strings.TrimPrefix(text, "\n")
+			}
+			if pre == 0 {
+				fields := strings.FieldsFunc(text, isHTMLWhitespace)
+				if len(fields) == 0 {
+					space = space || text != ""
+					continue
+				}
+				if isHTMLWhitespace(rune(text[0])) {
+					space = true
+				}
+				trailing := isHTMLWhitespace(rune(text[len(text)-1]))
+				text = strings.Join(fields, " ")
+				writeText(&b, text, &breaks, &space)
+				space = trailing
+				continue
+			}
+			writeText(&b, text, &breaks, &space)
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			start := tt != html.EndTagToken
+			switch {
+			case droppedTextElements[tag]:
+				if tt == html.StartTagToken {
+					skip++
+				} else if tt == html.EndTagToken && skip > 0 {
+					skip--
+				}
+			case skip > 0:
+			case tag == "br":
+				if start {
+					// Unlike block boundaries, consecutive br elements each
+					// break a line.
+					breaks++
+				}
+			case tag == "td" || tag == "th":
+				space = true
+			case paragraphElements[tag]:
+				lineBreak(2)
+			case blockElements[tag]:
+				lineBreak(1)
+			}
+			if tag == "pre" || tag == "textarea" || tag == "listing" {
+				if tt == html.StartTagToken {
+					pre++
+					preStart = true
+				} else if tt == html.EndTagToken && pre > 0 {
+					pre--
+				}
+			}
+		}
+	}
+}
+
+// writeText writes text to b, preceded by the pending line breaks, or else
+// by a pending space, except at the start of b.
+func writeText(b *strings.Builder, text string, breaks *int, space *bool) {
+	if b.Len() > 0 {
+		if *breaks > 0 {
+			b.WriteString(strings.Repeat("\n", *breaks))
+		} else if *space {
+			b.WriteByte(' ')
+		}
+	}
+	*breaks, *space = 0, false
+	b.WriteString(text)
+}
+
+// isHTMLWhitespace reports whether r is ASCII whitespace, including line
+// breaks, as defined in https://infra.spec.whatwg.org/#ascii-whitespace.
+func isHTMLWhitespace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r'
+}
+
+// droppedTextElements contains the elements whose content HTMLToText drops,
+// because it is not readable text.
+var droppedTextElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"template": true,
+}
+
+// paragraphElements contains the block elements that browsers render with a
+// vertical margin by default, which HTMLToText separates from their
+// surroundings by an empty line.
+var paragraphElements = map[string]bool{
+	"blockquote": true,
+	"dl":         true,
+	"figure":     true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"hr":         true,
+	"ol":         true,
+	"p":          true,
+	"pre":        true,
+	"table":      true,
+	"ul":         true,
+}
+
+// blockElements contains the other elements that browsers render on their own
+// lines by default.
+var blockElements = map[string]bool{
+	"address":    true,
+	"article":    true,
+	"aside":      true,
+	"caption":    true,
+	"dd":         true,
+	"details":    true,
+	"dialog":     true,
+	"div":        true,
+	"dt":         true,
+	"fieldset":   true,
+	"figcaption": true,
+	"footer":     true,
+	"form":       true,
+	"header":     true,
+	"legend":     true,
+	"li":         true,
+	"main":       true,
+	"nav":        true,
+	"option":     true,
+	"section":    true,
+	"summary":    true,
+	"textarea":   true,
+	"tr":         true,
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestHTMLToText(t *testing.T) {
+	for _, test := range [...]struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Hello, world", "Hello, world"},
+		{"<b>Fish</b> &amp; <i>chips</i> &lt;3", "Fish & chips <3"},
+		{"  lots   of\n\twhitespace  ", "lots of whitespace"},
+		{"<p>One</p><p>Two</p>", "One\n\nTwo"},
+		{"<h1>Title</h1>Text", "Title\n\nText"},
+		{"<div>One</div><div>Two</div>", "One\nTwo"},
+		{"<ul><li>One</li> <li>Two</li></ul>After", "One\nTwo\n\nAfter"},
+		{"One<br>Two<br><br>Three<br>", "One\nTwo\n\nThree"},
+		{"<br>Leading", "Leading"},
+		{"<table><tr><td>a</td><td>b</td></tr><tr><th>c</th><td>d</td></tr></table>", "a b\nc d"},
+		{"<pre>\n  keep\n   this</pre>done", "  keep\n   this\n\ndone"},
+		{"Before<script>var x = '<b>';</script><style>p{}</style><template><p>t</p></template>After", "BeforeAfter"},
+		{"a<!-- comment -->b", "ab"},
+		{"word<span> spaced </span>word", "word spaced word"},
+		{`<a href="/x" title="ignored">link</a>`, "link"},
+		{"<textarea>a  &lt;b&gt;</textarea>", "a  <b>"},
+	} {
+		if got := HTMLToText(HTML{test.in}); got != test.want {
+			t.Errorf("HTMLToText(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
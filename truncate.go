@@ -0,0 +1,151 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package safehtml
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/safehtml/registry"
+	"golang.org/x/net/html"
+)
+
+// HTMLTruncate returns h truncated to its first maxRunes runes of text, such
+// as for previews of articles and search result snippets. Entities such as
+// &amp; count as one rune, and markup does not count. The elements that are
+// open at the point of truncation are closed, so that the result is balanced,
+// and tags, attributes, and entities are never cut. The content of script and
+// style elements is kept or dropped whole, and does not count. If h has at
+// most maxRunes runes of text, HTMLTruncate returns h unchanged.
+//
+// HTMLTruncate tokenizes h rather than parsing it. It recognizes the end tags
+// that are implied when, for example, a p or li element is followed by
+// another, but not those of elements that the HTML parser closes for other
+// reasons, such as misnested formatting elements.
+func HTMLTruncate(h HTML, maxRunes int) HTML {
+	if maxRunes < 0 {
+		maxRunes = 0
+	}
+	z := html.NewTokenizer(strings.NewReader(h.str))
+	var b strings.Builder
+	// open is the stack of the open elements.
+	var open []string
+	// cut and cutOpen are the length of b and the open elements after the
+	// last text written, where b is cut if the next text does not fit, so
+	// that it does not end with empty elements.
+	cut, cutOpen := 0, []string(nil)
+	remaining := maxRunes
+	for {
+		tt := z.Next()
+		// Copy the raw token before Text and TagName, which unescape and
+		// lowercase it in place.
+		raw := string(z.Raw())
+		switch tt {
+		case html.ErrorToken:
+			// The tokenizer only fails at the end of its input, since reading
+			// from a strings.Reader does not fail.
+			return h
+		case html.TextToken:
+			if len(open) > 0 && truncateRawTextElements[open[len(open)-1]] {
+				b.WriteString(raw)
+				continue
+			}
+			text := string(z.Text())
+			n := utf8.RuneCountInString(text)
+			if n <= remaining {
+				remaining -= n
+				b.WriteString(raw)
+				cut, cutOpen = b.Len(), append(cutOpen[:0], open...)
+				continue
+			}
+			if remaining == 0 {
+				s := b.String()[:cut]
+				b.Reset()
+				b.WriteString(s)
+				open = cutOpen
+			}
+			i := 0
+			for ; remaining > 0; remaining-- {
+				_, size := utf8.DecodeRuneInString(text[i:])
+				i += size
+			}
+			b.WriteString(html.EscapeString(text[:i]))
+			for j := len(open) - 1; j >= 0; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+			return HTML{b.String()}
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			for len(open) > 0 && impliedEndTags[tag][open[len(open)-1]] {
+				open = open[:len(open)-1]
+			}
+			if !isVoidElement(tag) {
+				open = append(open, tag)
+			}
+			b.WriteString(raw)
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == tag {
+					open = open[:i]
+					break
+				}
+			}
+			b.WriteString(raw)
+		default:
+			// Self-closing tags, comments, and doctypes.
+			b.WriteString(raw)
+		}
+	}
+}
+
+// truncateRawTextElements contains the elements whose content is not text,
+// which HTMLTruncate neither counts nor cuts.
+var truncateRawTextElements = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// impliedEndTags maps elements to the open elements that their start tags
+// close when those are the current element.
+var impliedEndTags = map[string]map[string]bool{
+	"li":     {"li": true},
+	"dt":     {"dt": true, "dd": true},
+	"dd":     {"dt": true, "dd": true},
+	"option": {"option": true},
+	"tr":     {"tr": true, "td": true, "th": true},
+	"td":     {"td": true, "th": true},
+	"th":     {"td": true, "th": true},
+}
+
+func init() {
+	// Block start tags close open p elements.
+	for _, tag := range [...]string{
+		"address", "article", "aside", "blockquote", "details", "div", "dl", "fieldset",
+		"figcaption", "figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header",
+		"hr", "main", "nav", "ol", "p", "pre", "section", "table", "ul",
+	} {
+		if impliedEndTags[tag] == nil {
+			impliedEndTags[tag] = make(map[string]bool)
+		}
+		impliedEndTags[tag]["p"] = true
+	}
+}
+
+func isVoidElement(tag string) bool {
+	for _, v := range (*registry.Registry)(nil).VoidElements() {
+		if v == tag {
+			return true
+		}
+	}
+	return false
+}
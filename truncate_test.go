@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+//go:build !safehtml_minimal
+// +build !safehtml_minimal
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestHTMLTruncate(t *testing.T) {
+	for _, test := range [...]struct {
+		in       string
+		maxRunes int
+		want     string
+	}{
+		{"", 5, ""},
+		{"Hello", 5, "Hello"},
+		{"Hello, world", 5, "Hello"},
+		{"<p>Hello, <b>world</b></p>", 8, "<p>Hello, <b>w</b></p>"},
+		{"<p>Hello, <b>world</b></p>", 0, ""},
+		{`<a href="/x" title="a long title">link text</a>`, 4, `<a href="/x" title="a long title">link</a>`},
+		{"a &amp; b &lt; c", 5, "a &amp; b"},
+		{"a &amp; b", 3, "a &amp;"},
+		{"héllo wörld", 7, "héllo w"},
+		{"one<br>two", 4, "one<br>t"},
+		{"<b>one</b><i>two</i>", 3, "<b>one</b>"},
+		{"<ul><li>one<li>two<li>three</ul>", 8, "<ul><li>one<li>two<li>th</li></ul>"},
+		{"<p>one<p>two<div>three</div>", 7, "<p>one<p>two<div>t</div>"},
+		{"<table><tr><td>a<td>b<tr><td>cd</table>", 3, "<table><tr><td>a<td>b<tr><td>c</td></tr></table>"},
+		{"<style>p{color:red}</style><p>text</p>", 2, "<style>p{color:red}</style><p>te</p>"},
+		{"<SPAN>Upper case</SPAN>", 5, "<SPAN>Upper</span>"},
+		{"<!-- comment --><svg><circle r=\"1\"/></svg>text", 2, "<!-- comment --><svg><circle r=\"1\"/></svg>te"},
+		{"<textarea>a &lt;b&gt;</textarea>", 3, "<textarea>a &lt;</textarea>"},
+		{"<b>unclosed", 2, "<b>un</b>"},
+	} {
+		if got := HTMLTruncate(HTML{test.in}, test.maxRunes); got.String() != test.want {
+			t.Errorf("HTMLTruncate(%q, %d) = %q, want %q", test.in, test.maxRunes, got.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A TrustedElementName is an immutable name of an element that HTMLElement
+// allows, such as "h2" or "strong", which can only be constructed from an
+// untyped string constant. Code that renders one of several elements can
+// choose among TrustedElementName values, rather than concatenating tags:
+//
+//	var headings = [...]TrustedElementName{
+//		TrustedElementNameFromConstant("h1"),
+//		TrustedElementNameFromConstant("h2"),
+//		TrustedElementNameFromConstant("h3"),
+//	}
+//	h, err := HTMLElementFromTrustedName(headings[level], nil, title)
+//
+// safehtml/template does not accept TrustedElementName values in element
+// names, since the sanitization contexts of a start tag depend on its element;
+// templates choose among elements with conditionals, as in
+// `{{if .Strong}}<strong>{{else}}<b>{{end}}`.
+type TrustedElementName struct {
+	// We declare a TrustedElementName not as a string but as a struct
+	// wrapping a string to prevent construction through string conversion.
+	str string
+}
+
+// TrustedElementNameFromConstant returns the element name name, which must be
+// an untyped string constant. It panics if HTMLElement does not allow the
+// element.
+func TrustedElementNameFromConstant(name stringConstant) TrustedElementName {
+	n, err := TryTrustedElementNameFromConstant(name)
+	if err != nil {
+		panic(err.Error())
+	}
+	return n
+}
+
+// TryTrustedElementNameFromConstant is like TrustedElementNameFromConstant,
+// but returns an error instead of panicking if HTMLElement does not allow the
+// element.
+func TryTrustedElementNameFromConstant(name stringConstant) (TrustedElementName, error) {
+	s := strings.ToLower(string(name))
+	if _, _, err := checkElement(nil, s); err != nil {
+		return TrustedElementName{}, err
+	}
+	return TrustedElementName{s}, nil
+}
+
+// String returns the lowercase element name of n.
+func (n TrustedElementName) String() string {
+	return n.str
+}
+
+// HTMLElementFromTrustedName is like HTMLElement, but returns the element
+// with the trusted name name. It returns an error for the zero
+// TrustedElementName.
+func HTMLElementFromTrustedName(name TrustedElementName, attrs []Attr, children ...HTML) (HTML, error) {
+	if name.str == "" {
+		return HTML{}, fmt.Errorf("empty element name")
+	}
+	return HTMLElement(name.str, attrs, children...)
+}
+
+// A TrustedAttributeName is an immutable attribute name, other than the name
+// of an event handler attribute such as onclick, which can only be
+// constructed from an untyped string constant, such as one of several ARIA
+// attributes chosen by a component.
+type TrustedAttributeName struct {
+	// We declare a TrustedAttributeName not as a string but as a struct
+	// wrapping a string to prevent construction through string conversion.
+	str string
+}
+
+// TrustedAttributeNameFromConstant returns the attribute name name, which
+// must be an untyped string constant. It panics if name is not a valid
+// attribute name, or if it is the name of an event handler attribute.
+func TrustedAttributeNameFromConstant(name stringConstant) TrustedAttributeName {
+	n, err := TryTrustedAttributeNameFromConstant(name)
+	if err != nil {
+		panic(err.Error())
+	}
+	return n
+}
+
+// TryTrustedAttributeNameFromConstant is like
+// TrustedAttributeNameFromConstant, but returns an error instead of panicking
+// if name is invalid.
+func TryTrustedAttributeNameFromConstant(name stringConstant) (TrustedAttributeName, error) {
+	s := strings.ToLower(string(name))
+	if err := checkAttributeName(s); err != nil {
+		return TrustedAttributeName{}, err
+	}
+	return TrustedAttributeName{s}, nil
+}
+
+// String returns the lowercase attribute name of n.
+func (n TrustedAttributeName) String() string {
+	return n.str
+}
+
+// TrustedAttr returns the Attr, for HTMLElement, with the trusted name name
+// and value.
+func TrustedAttr(name TrustedAttributeName, value interface{}) Attr {
+	return Attr{name.str, value}
+}
+
+// AttributeFromTrustedName returns the Attribute, for safehtml/template, with
+// the trusted name name and value, or an error if the value does not have
+// one of the types described for Attr or name is the zero
+// TrustedAttributeName.
+func AttributeFromTrustedName(name TrustedAttributeName, value interface{}) (Attribute, error) {
+	return newAttribute(name.str, value)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestTrustedElementName(t *testing.T) {
+	for _, test := range [...]struct {
+		name, want, err string
+	}{
+		{"h1", "h1", ""},
+		{"STRONG", "strong", ""},
+		{"img", "img", ""},
+		{"script", "", `element "script" is not allowed`},
+		{"style", "", `element "style" is not allowed`},
+		{"h1 onclick", "", `element "h1 onclick" is not allowed`},
+	} {
+		n, err := TryTrustedElementNameFromConstant(stringConstant(test.name))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("TryTrustedElementNameFromConstant(%q) error = %v, want %q", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("TryTrustedElementNameFromConstant(%q) failed: %v", test.name, err)
+		} else if got := n.String(); got != test.want {
+			t.Errorf("TryTrustedElementNameFromConstant(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestTrustedAttributeName(t *testing.T) {
+	for _, test := range [...]struct {
+		name, want, err string
+	}{
+		{"aria-label", "aria-label", ""},
+		{"Title", "title", ""},
+		{"onclick", "", `"onclick" is not an allowed attribute name`},
+		{"x=y", "", `"x=y" is not an allowed attribute name`},
+	} {
+		n, err := TryTrustedAttributeNameFromConstant(stringConstant(test.name))
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Errorf("TryTrustedAttributeNameFromConstant(%q) error = %v, want %q", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("TryTrustedAttributeNameFromConstant(%q) failed: %v", test.name, err)
+		} else if got := n.String(); got != test.want {
+			t.Errorf("TryTrustedAttributeNameFromConstant(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestHTMLElementFromTrustedName(t *testing.T) {
+	headings := [...]TrustedElementName{
+		TrustedElementNameFromConstant("h1"),
+		TrustedElementNameFromConstant("h2"),
+		TrustedElementNameFromConstant("h3"),
+	}
+	label := TrustedAttributeNameFromConstant("aria-label")
+	for level, want := range [...]string{
+		`<h1 aria-label="Title">Gophers</h1>`,
+		`<h2 aria-label="Title">Gophers</h2>`,
+		`<h3 aria-label="Title">Gophers</h3>`,
+	} {
+		got, err := HTMLElementFromTrustedName(headings[level], []Attr{TrustedAttr(label, "Title")}, HTMLEscaped("Gophers"))
+		if err != nil {
+			t.Errorf("level %d: unexpected error: %v", level+1, err)
+		} else if got.String() != want {
+			t.Errorf("level %d: got %q, want %q", level+1, got, want)
+		}
+	}
+	if _, err := HTMLElementFromTrustedName(TrustedElementName{}, nil); err == nil {
+		t.Error("HTMLElementFromTrustedName accepted the zero TrustedElementName")
+	}
+	a, err := AttributeFromTrustedName(label, "Close")
+	if err != nil || a.String() != "aria-label=Close" {
+		t.Errorf("AttributeFromTrustedName = %v, %v, want aria-label=Close", a, err)
+	}
+	if _, err := AttributeFromTrustedName(TrustedAttributeName{}, "x"); err == nil {
+		t.Error("AttributeFromTrustedName accepted the zero TrustedAttributeName")
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"flag"
 	"github.com/google/safehtml/internal/safehtmlutil"
+	"github.com/google/safehtml/urlsafe"
 )
 
 // A TrustedResourceURL is an immutable string-like type referencing the
@@ -50,10 +51,51 @@ type TrustedResourceURL struct {
 // TrustedResourceURLWithParams constructs a new TrustedResourceURL with the
 // given key-value pairs added as query parameters.
 //
-// Map entries with empty keys or values are ignored. The order of appended
-// keys is guaranteed to be stable but may differ from the order in input.
+// Map entries with empty keys or values are ignored. The parameters are
+// appended in a deterministic order, sorted by their escaped keys and values,
+// which may differ from the order in input. Use
+// TrustedResourceURLWithOrderedParams to choose the order.
 func TrustedResourceURLWithParams(t TrustedResourceURL, params map[string]string) TrustedResourceURL {
-	url := t.str
+	stringParams := make([]string, 0, len(params))
+	for k, v := range params {
+		if k == "" || v == "" {
+			continue
+		}
+		stringParams = append(stringParams, queryParam(k, v))
+	}
+	sort.Strings(stringParams)
+	return TrustedResourceURL{appendQuery(t.str, stringParams)}
+}
+
+// A QueryParam is a key-value pair in the query of a URL.
+type QueryParam struct {
+	Key, Value string
+}
+
+// TrustedResourceURLWithOrderedParams is like TrustedResourceURLWithParams,
+// but appends the parameters in the given order. Keys may be repeated.
+// Parameters with empty keys or values are ignored.
+func TrustedResourceURLWithOrderedParams(t TrustedResourceURL, params ...QueryParam) TrustedResourceURL {
+	stringParams := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.Key == "" || p.Value == "" {
+			continue
+		}
+		stringParams = append(stringParams, queryParam(p.Key, p.Value))
+	}
+	return TrustedResourceURL{appendQuery(t.str, stringParams)}
+}
+
+func queryParam(k, v string) string {
+	return safehtmlutil.QueryEscapeURL(k) + "=" + safehtmlutil.QueryEscapeURL(v)
+}
+
+// appendQuery appends the escaped query parameters params to url, before its
+// fragment if any.
+func appendQuery(url string, params []string) string {
+	if len(params) == 0 {
+		return url
+	}
 	var fragment string
 	if i := strings.IndexByte(url, '#'); i != -1 {
 		// The fragment identifier component will always appear at the end
@@ -74,19 +116,7 @@ func TrustedResourceURLWithParams(t TrustedResourceURL, params map[string]string
 			sep = "&"
 		}
 	}
-	stringParams := make([]string, 0, len(params))
-	for k, v := range params {
-		if k == "" || v == "" {
-			continue
-		}
-		stringParam := safehtmlutil.QueryEscapeURL(k) + "=" + safehtmlutil.QueryEscapeURL(v)
-		stringParams = append(stringParams, stringParam)
-	}
-	if len(stringParams) > 0 {
-		sort.Strings(stringParams)
-		url += sep + strings.Join(stringParams, "&")
-	}
-	return TrustedResourceURL{url + fragment}
+	return url + sep + strings.Join(params, "&") + fragment
 }
 
 // TrustedResourceURLFromConstant constructs a TrustedResourceURL with its underlying
@@ -130,7 +160,7 @@ func TrustedResourceURLFormatFromFlag(format flag.Value, args map[string]string)
 }
 
 func trustedResourceURLFormat(format string, args map[string]string) (TrustedResourceURL, error) {
-	if !safehtmlutil.IsSafeTrustedResourceURLPrefix(format) {
+	if !urlsafe.IsSafeTrustedResourceURLPrefix(format) {
 		return TrustedResourceURL{}, fmt.Errorf("%q is a disallowed TrustedResourceURL format string", format)
 	}
 	var err error
@@ -188,7 +218,7 @@ func (t TrustedResourceURL) String() string {
 // `<origin>` must contain only alphanumerics, '.', ':', '[', ']', or '-', and
 // `<pathStart>` is any character except `/` and `\`.
 func TrustedResourceURLAppend(t TrustedResourceURL, s string) (TrustedResourceURL, error) {
-	if !safehtmlutil.IsSafeTrustedResourceURLPrefix(t.str) {
+	if !urlsafe.IsSafeTrustedResourceURLPrefix(t.str) {
 		return TrustedResourceURL{}, fmt.Errorf("cannot append to TrustedResourceURL %q because it has an unsafe prefix", t)
 	}
 	return TrustedResourceURL{t.str + safehtmlutil.QueryEscapeURL(s)}, nil
@@ -68,6 +68,34 @@ func TestTrustedResourceURLWithParams(t *testing.T) {
 	}
 }
 
+func TestTrustedResourceURLWithOrderedParams(t *testing.T) {
+	for _, test := range [...]struct {
+		tru    TrustedResourceURL
+		params []QueryParam
+		want   string
+	}{
+		{
+			TrustedResourceURLFromConstant(`https://example.com/`),
+			nil,
+			`https://example.com/`,
+		},
+		{
+			TrustedResourceURLFromConstant(`https://example.com/`),
+			[]QueryParam{{`c`, `3`}, {`a`, `1`}, {`c`, `2`}, {`b`, ``}, {``, `x`}},
+			`https://example.com/?c=3&a=1&c=2`,
+		},
+		{
+			TrustedResourceURLFromConstant(`https://example.com/?z=0#foo`),
+			[]QueryParam{{`b`, `&`}, {`a`, `=`}},
+			`https://example.com/?z=0&b=%26&a=%3d#foo`,
+		},
+	} {
+		if got := TrustedResourceURLWithOrderedParams(test.tru, test.params...).String(); got != test.want {
+			t.Errorf("TrustedResourceURLWithOrderedParams(%#v, %v) = %q, want %q", test.tru, test.params, got, test.want)
+		}
+	}
+}
+
 type testFlagValue string
 
 func (t *testFlagValue) String() string { return string(*t) }
@@ -33,6 +33,7 @@ var styleSheet = raw.StyleSheet.(func(string) safehtml.StyleSheet)
 var url = raw.URL.(func(string) safehtml.URL)
 var trustedResourceURL = raw.TrustedResourceURL.(func(string) safehtml.TrustedResourceURL)
 var identifier = raw.Identifier.(func(string) safehtml.Identifier)
+var svg = raw.SVG.(func(string) safehtml.SVG)
 
 // HTMLFromStringKnownToSatisfyTypeContract converts a string into a HTML.
 func HTMLFromStringKnownToSatisfyTypeContract(s string) safehtml.HTML {
@@ -127,3 +128,8 @@ func TrustedResourceURLFromStringKnownToSatisfyTypeContract(s string) safehtml.T
 func IdentifierFromStringKnownToSatisfyTypeContract(s string) safehtml.Identifier {
 	return identifier(s)
 }
+
+// SVGFromStringKnownToSatisfyTypeContract converts a string into an SVG.
+func SVGFromStringKnownToSatisfyTypeContract(s string) safehtml.SVG {
+	return svg(s)
+}
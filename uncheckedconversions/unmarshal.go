@@ -0,0 +1,106 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package uncheckedconversions
+
+import "github.com/google/safehtml"
+
+// The following types wrap the safe types of package safehtml, which can be
+// marshaled but not unmarshaled, to opt in to unmarshaling them from text and
+// JSON without checking their type contracts. They are meant for fields of
+// structs decoded from trusted data, such as caches and messages written by
+// the application itself, and must never hold data from untrusted sources:
+//
+//	var cached struct {
+//		Title string
+//		Body  uncheckedconversions.UnmarshaledHTML
+//	}
+//	err := json.Unmarshal(b, &cached)
+//	render(cached.Body.HTML)
+
+// UnmarshaledHTML is a safehtml.HTML that can be unmarshaled.
+type UnmarshaledHTML struct {
+	safehtml.HTML
+}
+
+// UnmarshalText sets the HTML of h to text, without checking its type
+// contract.
+func (h *UnmarshaledHTML) UnmarshalText(text []byte) error {
+	h.HTML = html(string(text))
+	return nil
+}
+
+// UnmarshaledURL is a safehtml.URL that can be unmarshaled.
+type UnmarshaledURL struct {
+	safehtml.URL
+}
+
+// UnmarshalText sets the URL of u to text, without checking its type
+// contract.
+func (u *UnmarshaledURL) UnmarshalText(text []byte) error {
+	u.URL = url(string(text))
+	return nil
+}
+
+// UnmarshaledTrustedResourceURL is a safehtml.TrustedResourceURL that can be unmarshaled.
+type UnmarshaledTrustedResourceURL struct {
+	safehtml.TrustedResourceURL
+}
+
+// UnmarshalText sets the TrustedResourceURL of t to text, without checking its type
+// contract.
+func (t *UnmarshaledTrustedResourceURL) UnmarshalText(text []byte) error {
+	t.TrustedResourceURL = trustedResourceURL(string(text))
+	return nil
+}
+
+// UnmarshaledScript is a safehtml.Script that can be unmarshaled.
+type UnmarshaledScript struct {
+	safehtml.Script
+}
+
+// UnmarshalText sets the Script of s to text, without checking its type
+// contract.
+func (s *UnmarshaledScript) UnmarshalText(text []byte) error {
+	s.Script = script(string(text))
+	return nil
+}
+
+// UnmarshaledStyle is a safehtml.Style that can be unmarshaled.
+type UnmarshaledStyle struct {
+	safehtml.Style
+}
+
+// UnmarshalText sets the Style of s to text, without checking its type
+// contract.
+func (s *UnmarshaledStyle) UnmarshalText(text []byte) error {
+	s.Style = style(string(text))
+	return nil
+}
+
+// UnmarshaledStyleSheet is a safehtml.StyleSheet that can be unmarshaled.
+type UnmarshaledStyleSheet struct {
+	safehtml.StyleSheet
+}
+
+// UnmarshalText sets the StyleSheet of s to text, without checking its type
+// contract.
+func (s *UnmarshaledStyleSheet) UnmarshalText(text []byte) error {
+	s.StyleSheet = styleSheet(string(text))
+	return nil
+}
+
+// UnmarshaledIdentifier is a safehtml.Identifier that can be unmarshaled.
+type UnmarshaledIdentifier struct {
+	safehtml.Identifier
+}
+
+// UnmarshalText sets the Identifier of i to text, without checking its type
+// contract.
+func (i *UnmarshaledIdentifier) UnmarshalText(text []byte) error {
+	i.Identifier = identifier(string(text))
+	return nil
+}
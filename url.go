@@ -7,8 +7,15 @@
 package safehtml
 
 import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
+
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/internal/safehtmlutil"
+	"github.com/google/safehtml/urlsafe"
 )
 
 // A URL is an immutable string-like type that is safe to use in URL contexts in
@@ -61,68 +68,122 @@ const InnocuousURL = "about:invalid#zGoSafez"
 // interchange-valid UTF-8 since the percent-decoded representation is unsafe to use in an
 // HTML context regardless of UTF-8 validity.
 func URLSanitized(url string) URL {
-	if !isSafeURL(url) {
+	if !urlsafe.IsSafeURL(url) {
+		diag.Log("safehtml: replaced unsafe URL", "url", url)
 		return URL{InnocuousURL}
 	}
 	return URL{url}
 }
 
-// safeURLPattern matches URLs that
-//
-//		(a) Start with (capture) an explicit scheme. The scheme needs to be further validated to ban
-//	     javascript; or
-//		(b) Contain no scheme. To ensure that the URL cannot be interpreted as a
-//		    disallowed scheme URL, ':' may only appear after one of the runes [/?#].
+// DataURLFromBytes returns a data URL whose content is data, base64-encoded,
+// with the given MIME type, such as "image/png".
 //
-// Warning: Matching this regex is not enough to validate the SafeUrl contract. See the isSafeUrl
-// implementation for more information.
-//
-// The origin (RFC 6454) in which a URL is loaded depends on
-// its scheme.  We assume that the scheme used by the current document is HTTPS, HTTP, or
-// something equivalent.  We allow relative URLs unless in a particularly sensitive context
-// called a "TrustedResourceUrl" context. In a non-TrustedResourceURL context we allow absolute
-// URLs whose scheme is on a white-list.
+// mimeType must be one of the audio, image, and video MIME types returned by
+// urlsafe.DefaultDataURLMIMETypes, whose content browsers never execute as
+// script, or DataURLFromBytes returns an error. The returned URL is therefore
+// also accepted by safehtml/template Policies that restrict data URLs with
+// Policy.AllowDataURLMIMETypes(urlsafe.DefaultDataURLMIMETypes()...).
+func DataURLFromBytes(mimeType string, data []byte) (URL, error) {
+	mimeType = strings.ToLower(mimeType)
+	if !isDefaultDataURLMIMEType(mimeType) {
+		return URL{}, fmt.Errorf("%q is not an allowed data URL MIME type", mimeType)
+	}
+	return URL{"data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)}, nil
+}
+
+func isDefaultDataURLMIMEType(mimeType string) bool {
+	for _, t := range urlsafe.DefaultDataURLMIMETypes() {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// URLFormatFromConstant constructs a URL from a format string, which must be
+// an untyped string constant, and string arguments.
 //
-// The position of the first colon (':') character determines whether a URL is absolute or relative.
-// Looking at the prefix leading up to the first colon allows us to identify relative and absolute URLs,
-// extract the scheme, and minimize the risk of a user-agent concluding a URL specifies a scheme not in
-// our allowlist.
+// Arguments are specified as a map of labels, which must contain only
+// alphanumeric and '_' runes, to string values. Each `%{<label>}` marker in
+// the format string is replaced by the string value identified by <label>,
+// escaped for the component of the URL that the marker is in: values in the
+// path are escaped as path segments, so they cannot add segments, end the
+// path, or add a scheme to a relative URL, values in the query are escaped as query parameter keys or values,
+// and values in the fragment are escaped as path segments. Arguments that do
+// not match any label in the format string are ignored.
 //
-// According to RFC 3986 Section 3, the normative interpretation of the canonicial WHATWG specification
-// (https://url.spec.whatwg.org/#url-scheme-string), colons can appear in a URL in these locations:
-//   - A colon after a non-empty run of (ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )) ends a scheme.
-//     If the colon after the scheme is not followed by "//" then any subsequent colons are part
-//     of an opaque URI body.
-//   - Otherwise, a colon after a hash (#) must be in the fragment.
-//   - Otherwise, a colon after a (?) must be in the query.
-//   - Otherwise, a colon after a single solidus ("/") must be in the path.
-//   - Otherwise, a colon after a double solidus ("//") must be in the authority (before port).
-//   - Otherwise, a colon after a valid protocol must be in the opaque part of the URL.
-var safeURLPattern = regexp.MustCompile(`^(?:([a-z0-9+.-]+):|[^&:\/?#]*(?:[\/?#]|$))`)
+// URLFormatFromConstant returns an error if a marker is in the scheme or
+// authority of the URL, if an argument in the path contains the ".."
+// dot-segment, if an argument is missing, or if the resulting URL is not
+// safe, as described in URLSanitized.
+func URLFormatFromConstant(format stringConstant, args map[string]string) (URL, error) {
+	f := string(format)
+	pathStart := urlPathStart(f)
+	queryStart, fragmentStart := len(f), len(f)
+	if i := strings.IndexByte(f, '#'); i != -1 {
+		queryStart, fragmentStart = i, i
+	}
+	if i := strings.IndexByte(f[:fragmentStart], '?'); i != -1 {
+		queryStart = i
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range trustedResourceURLFormatMarkerPattern.FindAllStringIndex(f, -1) {
+		name := f[m[0]+len("%{") : m[1]-len("}")]
+		if m[0] < pathStart {
+			return URL{}, fmt.Errorf("argument %q must not be in the scheme or authority of URL format %q", name, f)
+		}
+		val, ok := args[name]
+		if !ok {
+			return URL{}, fmt.Errorf("expected argument named %q", name)
+		}
+		b.WriteString(f[last:m[0]])
+		switch {
+		case m[0] < queryStart:
+			if safehtmlutil.URLContainsDoubleDotSegment(val) {
+				return URL{}, fmt.Errorf(`argument %q with value %q must not contain ".."`, name, val)
+			}
+			val = url.PathEscape(val)
+			if pathStart == 0 {
+				// Escape colons in relative URLs, so that the value cannot
+				// add a scheme to the URL.
+				val = strings.ReplaceAll(val, ":", "%3A")
+			}
+			b.WriteString(val)
+		case m[0] < fragmentStart:
+			b.WriteString(url.QueryEscape(val))
+		default:
+			b.WriteString(url.PathEscape(val))
+		}
+		last = m[1]
+	}
+	b.WriteString(f[last:])
+	ret := b.String()
+	if !urlsafe.IsSafeURL(ret) {
+		return URL{}, fmt.Errorf("URL %q formatted from %q is not safe", ret, f)
+	}
+	return URL{ret}, nil
+}
 
-// isSafeURL matches url to a subset of URLs that will not cause script execution if used in
-// a URL context within a HTML document. Specifically, this method returns true if url:
-//
-//	(a) Starts with an explicit scheme that is not javascript; or
-//	(b) Contains no scheme. To ensure that the URL cannot be interpreted as a
-//	    disallowed scheme URL, the runes ':', and '&' may only appear
-//	    after one of the runes [/?#].
-func isSafeURL(url string) bool {
-	// Ignore case.
-	url = strings.ToLower(url)
-	submatches := safeURLPattern.FindStringSubmatch(url)
-	if submatches == nil {
-		// No match
-		return false
+// urlPathStart returns the index of the start of the path of u, after its
+// scheme and authority, if any.
+func urlPathStart(u string) int {
+	i := 0
+	if m := urlSchemePattern.FindStringIndex(u); m != nil {
+		i = m[1]
 	}
-	if len(submatches) == 0 {
-		// Implicit URL scheme. This is safe
-		return true
+	if strings.HasPrefix(u[i:], "//") {
+		if j := strings.IndexAny(u[i+2:], "/?#"); j != -1 {
+			return i + 2 + j
+		}
+		return len(u)
 	}
-	// Block javascript: URLs
-	return len(submatches) == 2 && submatches[1] != "javascript"
+	return i
 }
 
+// urlSchemePattern matches the scheme of absolute URLs, with its colon.
+var urlSchemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
 // String returns the string form of the URL.
 func (u URL) String() string {
 	return u.str
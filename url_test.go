@@ -0,0 +1,87 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/safehtml/urlsafe"
+)
+
+func TestDataURLFromBytes(t *testing.T) {
+	for _, test := range [...]struct {
+		mimeType string
+		data     []byte
+		want     string
+		err      string
+	}{
+		{"image/png", []byte("\x89PNG"), "data:image/png;base64,iVBORw==", ""},
+		{"Video/MP4", nil, "data:video/mp4;base64,", ""},
+		{"text/html", []byte("<script>"), "", `"text/html" is not an allowed data URL MIME type`},
+		{"image/svg+xml", []byte("<svg>"), "", `"image/svg+xml" is not an allowed data URL MIME type`},
+		{"image/png;charset=utf-8", nil, "", "is not an allowed data URL MIME type"},
+	} {
+		got, err := DataURLFromBytes(test.mimeType, test.data)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("DataURLFromBytes(%q): got error %v, want error containing %q", test.mimeType, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("DataURLFromBytes(%q): unexpected error: %v", test.mimeType, err)
+			continue
+		}
+		if got.String() != test.want {
+			t.Errorf("DataURLFromBytes(%q) = %q, want %q", test.mimeType, got, test.want)
+		}
+		if mimeType, ok := urlsafe.DataURLMIMEType(got.String()); !ok || mimeType != strings.ToLower(test.mimeType) {
+			t.Errorf("urlsafe.DataURLMIMEType(%q) = %q, %t, want %q, true", got, mimeType, ok, strings.ToLower(test.mimeType))
+		}
+	}
+}
+
+func TestURLFormatFromConstant(t *testing.T) {
+	args := map[string]string{
+		"user":  "gopher/admin?x#y",
+		"q":     "a&b=c d",
+		"frag":  "sec tion#2",
+		"colon": "javascript:alert(1)",
+		"dots":  "../secret",
+		"plain": "a..b",
+	}
+	for _, test := range [...]struct {
+		format stringConstant
+		want   string
+		err    string
+	}{
+		{"https://example.com/users/%{user}/profile", "https://example.com/users/gopher%2Fadmin%3Fx%23y/profile", ""},
+		{"/search?q=%{q}&page=1", "/search?q=a%26b%3Dc+d&page=1", ""},
+		{"/docs#%{frag}", "/docs#sec%20tion%232", ""},
+		{"/a/%{user}?q=%{q}#%{frag}", "/a/gopher%2Fadmin%3Fx%23y?q=a%26b%3Dc+d#sec%20tion%232", ""},
+		{"%{colon}", "javascript%3Aalert%281%29", ""},
+		{"mailto:%{user}", "mailto:gopher%2Fadmin%3Fx%23y", ""},
+		{"/search?q=%{plain}", "/search?q=a..b", ""},
+		{"/files/%{dots}", "", `must not contain ".."`},
+		{"https://%{user}/", "", "must not be in the scheme or authority"},
+		{"%{user}://example.com/", "", "is not safe"},
+		{"/users/%{missing}", "", `expected argument named "missing"`},
+		{"javascript:%{q}", "", "is not safe"},
+	} {
+		got, err := URLFormatFromConstant(test.format, args)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("URLFormatFromConstant(%q) = %q, %v, want error containing %q", test.format, got, err, test.err)
+			}
+			continue
+		}
+		if err != nil || got.String() != test.want {
+			t.Errorf("URLFormatFromConstant(%q) = %q, %v, want %q", test.format, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package urlsafe
+
+import (
+	"regexp"
+	"strings"
+)
+
+// defaultDataURLMIMETypes contains the audio, image, and video MIME types of
+// data URLs that are safe to load, since browsers never execute their content
+// as script.
+var defaultDataURLMIMETypes = []string{
+	"audio/3gpp",
+	"audio/3gpp2",
+	"audio/aac",
+	"audio/l16",
+	"audio/midi",
+	"audio/mp3",
+	"audio/mp4",
+	"audio/mpeg",
+	"audio/oga",
+	"audio/ogg",
+	"audio/opus",
+	"audio/wav",
+	"audio/webm",
+	"audio/x-m4a",
+	"audio/x-matroska",
+	"audio/x-wav",
+	"image/bmp",
+	"image/gif",
+	"image/jpeg",
+	"image/jpg",
+	"image/png",
+	"image/tiff",
+	"image/webp",
+	"image/x-icon",
+	"video/mp4",
+	"video/mpeg",
+	"video/ogg",
+	"video/quicktime",
+	"video/webm",
+	"video/x-matroska",
+}
+
+// DefaultDataURLMIMETypes returns the sorted audio, image, and video MIME
+// types of data URLs that are safe to load. They are the MIME types of the
+// data URLs that safehtml/template allows when a template Policy restricts
+// data URLs, unless the Policy specifies others.
+func DefaultDataURLMIMETypes() []string {
+	return append([]string(nil), defaultDataURLMIMETypes...)
+}
+
+// dataURLPattern matches data URLs with base64-encoded content, and captures
+// their MIME type. It does not allow MIME type parameters other than base64.
+var dataURLPattern = regexp.MustCompile(`(?i)^data:([a-z0-9.+-]+/[a-z0-9.+-]+);base64,[a-z0-9+/]*={0,2}$`)
+
+// DataURLMIMEType returns the lowercase MIME type of url, and true, if url is a
+// data URL with base64-encoded content, such as "data:image/png;base64,iVBO".
+// Otherwise, it returns the empty string and false.
+//
+// A trusted prefix of a data URL, such as "data:image/png;base64,", is itself
+// a valid data URL, so DataURLMIMEType can also be used to check prefixes.
+func DataURLMIMEType(url string) (mimeType string, ok bool) {
+	m := dataURLPattern.FindStringSubmatch(url)
+	if m == nil {
+		return "", false
+	}
+	return strings.ToLower(m[1]), true
+}
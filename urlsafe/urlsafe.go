@@ -0,0 +1,273 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+// Package urlsafe provides the URL encoders and safety checks used by package
+// safehtml and safehtml/template, for code that builds or validates URLs
+// outside of those packages and must do so in the same way.
+//
+// The output of the encoders is not guaranteed to be a safe URL: it must still
+// be sanitized, for example by safehtml.URLSanitized, before it is used as one.
+// IsSafeURL, ValidateURLPrefix, and their TrustedResourceURL counterparts
+// report whether a URL, or the trusted prefix of a URL whose remainder is not
+// trusted, satisfies the rules that safehtml/template enforces.
+package urlsafe
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// safeURLPattern matches URLs that
+//
+//		(a) Start with (capture) an explicit scheme. The scheme needs to be further validated to ban
+//	     javascript; or
+//		(b) Contain no scheme. To ensure that the URL cannot be interpreted as a
+//		    disallowed scheme URL, ':' may only appear after one of the runes [/?#].
+//
+// Warning: Matching this regex is not enough to validate the SafeUrl contract. See the isSafeUrl
+// implementation for more information.
+//
+// The origin (RFC 6454) in which a URL is loaded depends on
+// its scheme.  We assume that the scheme used by the current document is HTTPS, HTTP, or
+// something equivalent.  We allow relative URLs unless in a particularly sensitive context
+// called a "TrustedResourceUrl" context. In a non-TrustedResourceURL context we allow absolute
+// URLs whose scheme is on a white-list.
+//
+// The position of the first colon (':') character determines whether a URL is absolute or relative.
+// Looking at the prefix leading up to the first colon allows us to identify relative and absolute URLs,
+// extract the scheme, and minimize the risk of a user-agent concluding a URL specifies a scheme not in
+// our allowlist.
+//
+// According to RFC 3986 Section 3, the normative interpretation of the canonicial WHATWG specification
+// (https://url.spec.whatwg.org/#url-scheme-string), colons can appear in a URL in these locations:
+//   - A colon after a non-empty run of (ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )) ends a scheme.
+//     If the colon after the scheme is not followed by "//" then any subsequent colons are part
+//     of an opaque URI body.
+//   - Otherwise, a colon after a hash (#) must be in the fragment.
+//   - Otherwise, a colon after a (?) must be in the query.
+//   - Otherwise, a colon after a single solidus ("/") must be in the path.
+//   - Otherwise, a colon after a double solidus ("//") must be in the authority (before port).
+//   - Otherwise, a colon after a valid protocol must be in the opaque part of the URL.
+var safeURLPattern = regexp.MustCompile(`^(?:([a-z0-9+.-]+):|[^&:\/?#]*(?:[\/?#]|$))`)
+
+// IsSafeURL reports whether url is in the subset of URLs that will not cause script execution
+// if used in a URL context within a HTML document, which safehtml.URLSanitized accepts.
+// Specifically, it returns true if url:
+//
+//	(a) Starts with an explicit scheme that is not javascript; or
+//	(b) Contains no scheme. To ensure that the URL cannot be interpreted as a
+//	    disallowed scheme URL, the runes ':', and '&' may only appear
+//	    after one of the runes [/?#].
+func IsSafeURL(url string) bool {
+	// Ignore case.
+	url = strings.ToLower(url)
+	submatches := safeURLPattern.FindStringSubmatch(url)
+	if submatches == nil {
+		// No match
+		return false
+	}
+	if len(submatches) == 0 {
+		// Implicit URL scheme. This is safe
+		return true
+	}
+	// Block javascript: URLs
+	return len(submatches) == 2 && submatches[1] != "javascript"
+}
+
+// IsSafeTrustedResourceURLPrefix returns whether the given prefix is safe to use as a
+// TrustedResourceURL prefix.
+//
+// TrustedResourceURL prefixes must start with one of the following:
+//   - `https://<origin>/`
+//   - `//<origin>/`
+//   - `/<pathStart>`
+//   - `about:blank#`
+//
+// `<origin>` must contain only alphanumerics, '.', ':', '[', ']', or '-'.
+// These restrictions do not enforce a well-formed domain name, so '.' and '1.2' are valid.
+//
+// `<pathStart>` is any character except `/` and `\`. Based on
+// https://url.spec.whatwg.org/commit-snapshots/56b74ce7cca8883eab62e9a12666e2fac665d03d/#url-parsing,
+// an initial / which is not followed by another / or \ will end up in the "path state" and from there
+// it can only go to the "fragment state" and "query state".
+func IsSafeTrustedResourceURLPrefix(prefix string) bool {
+	return safeTrustedResourceURLPrefixPattern.MatchString(prefix)
+}
+
+var safeTrustedResourceURLPrefixPattern = regexp.MustCompile(`(?i)^(?:` +
+	`(?:https:)?//[0-9a-z.:\[\]-]+/|` +
+	`/[^/\\]|` +
+	`about:blank#)`)
+
+// A PrefixError describes why a URL prefix is unsafe.
+type PrefixError struct {
+	// Prefix is the unsafe prefix.
+	Prefix string
+	// Reason describes the problem, for example "contains an unsafe scheme".
+	Reason string
+}
+
+func (e *PrefixError) Error() string {
+	return fmt.Sprintf("URL prefix %q %s", e.Prefix, e.Reason)
+}
+
+// ValidateURLPrefix returns a *PrefixError if prefix is unsafe to use as the
+// prefix of a URL whose remainder is untrusted, and nil otherwise. These are
+// the rules that safehtml/template applies to the text before an action in a
+// URL attribute value, after HTML-unescaping it.
+//
+// A prefix is unsafe if it contains ASCII whitespace or control characters, or
+// ends in an incomplete percent-encoding triplet. If it starts with a scheme,
+// it is unsafe unless IsSafeURL accepts it. Otherwise, it is unsafe unless it
+// contains '/', '?', or '#', since the presence of any of these runes ensures
+// that the prefix, when combined with an arbitrary suffix, cannot be
+// interpreted as part of a scheme.
+func ValidateURLPrefix(prefix string) error {
+	if err := validatePrefixCharacters(prefix); err != nil {
+		return err
+	}
+	switch {
+	case startsWithFullySpecifiedSchemePattern.MatchString(prefix):
+		if !IsSafeURL(prefix) {
+			return &PrefixError{prefix, "contains an unsafe scheme"}
+		}
+	case !strings.ContainsAny(prefix, "/?#"):
+		// If the URL prefix does not already have a ':' scheme delimiter, and does not contain
+		// '/', '?', or '#', any ':' following this prefix will be intepreted as a scheme
+		// delimiter, causing this URL prefix to be interpreted as being part of a scheme.
+		// e.g. `<a href="java{{ "script:" }}alert(1)>`
+		return &PrefixError{prefix, "is unsafe; it might be interpreted as part of a scheme"}
+	}
+	return nil
+}
+
+// ValidateTrustedResourceURLPrefix returns a *PrefixError if prefix is unsafe
+// to use as the prefix of a TrustedResourceURL whose remainder is untrusted,
+// and nil otherwise. A prefix is unsafe if it contains ASCII whitespace or
+// control characters, ends in an incomplete percent-encoding triplet, or is
+// not accepted by IsSafeTrustedResourceURLPrefix.
+//
+// The untrusted remainder must still be query-escaped, and must not contain
+// the ".." dot-segment.
+func ValidateTrustedResourceURLPrefix(prefix string) error {
+	if err := validatePrefixCharacters(prefix); err != nil {
+		return err
+	}
+	if !IsSafeTrustedResourceURLPrefix(prefix) {
+		return &PrefixError{prefix, "is not a safe TrustedResourceURL prefix"}
+	}
+	return nil
+}
+
+// startsWithFullySpecifiedSchemePattern matches strings that have a fully-specified scheme component.
+// See RFC 3986 Section 3.
+var startsWithFullySpecifiedSchemePattern = regexp.MustCompile(
+	`^[[:alpha:]](?:[[:alnum:]]|[+.-])*:`)
+
+// endsWithPercentEncodingPrefixPattern matches strings that end in an incomplete
+// URL percent encoding triplet.
+//
+// See https://tools.ietf.org/html/rfc3986#section-2.1.
+var endsWithPercentEncodingPrefixPattern = regexp.MustCompile(
+	`%[[:xdigit:]]?$`)
+
+// containsWhitespaceOrControlPattern matches strings that contain ASCII whitespace
+// or control characters.
+var containsWhitespaceOrControlPattern = regexp.MustCompile(`[[:space:]]|[[:cntrl:]]`)
+
+// validatePrefixCharacters returns a *PrefixError if prefix contains
+// whitespace or control characters, or ends in an incomplete percent-encoding
+// triplet.
+func validatePrefixCharacters(prefix string) error {
+	if containsWhitespaceOrControlPattern.MatchString(prefix) {
+		return &PrefixError{prefix, "contains whitespace or control characters"}
+	}
+	if endsWithPercentEncodingPrefixPattern.MatchString(prefix) {
+		return &PrefixError{prefix, "ends with an incomplete percent-encoding character triplet"}
+	}
+	return nil
+}
+
+// QueryEscapeURL percent-encodes s so that it can be embedded in a URL query.
+// The output can be embedded in an HTML attribute without further escaping.
+func QueryEscapeURL(s string) string {
+	return urlProcessor(false, s)
+}
+
+// NormalizeURL normalizes the URL content s so it can be embedded in a quote-delimited
+// string or parenthesis delimited url(...).
+// The normalizer does not encode all HTML specials. Specifically, it does not
+// encode '&' so correct embedding in an HTML attribute requires escaping of
+// '&' to '&amp;'.
+func NormalizeURL(s string) string {
+	return urlProcessor(true, s)
+}
+
+// urlProcessor normalizes (when norm is true) or escapes its input to produce
+// a valid hierarchical or opaque URL part.
+func urlProcessor(norm bool, s string) string {
+	var b bytes.Buffer
+	written := 0
+	// The byte loop below assumes that all URLs use UTF-8 as the
+	// content-encoding. This is similar to the URI to IRI encoding scheme
+	// defined in section 3.1 of  RFC 3987, and behaves the same as the
+	// EcmaScript builtin encodeURIComponent.
+	// It should not cause any misencoding of URLs in pages with
+	// Content-type: text/html;charset=UTF-8.
+	for i, n := 0, len(s); i < n; i++ {
+		c := s[i]
+		switch c {
+		// Single quote and parens are sub-delims in RFC 3986, but we
+		// escape them so the output can be embedded in single
+		// quoted attributes and unquoted CSS url(...) constructs.
+		// Single quotes are reserved in URLs, but are only used in
+		// the obsolete "mark" rule in an appendix in RFC 3986
+		// so can be safely encoded.
+		case '!', '#', '$', '&', '*', '+', ',', '/', ':', ';', '=', '?', '@', '[', ']':
+			if norm {
+				continue
+			}
+		// Unreserved according to RFC 3986 sec 2.3
+		// "For consistency, percent-encoded octets in the ranges of
+		// ALPHA (%41-%5A and %61-%7A), DIGIT (%30-%39), hyphen (%2D),
+		// period (%2E), underscore (%5F), or tilde (%7E) should not be
+		// created by URI producers
+		case '-', '.', '_', '~':
+			continue
+		case '%':
+			// When normalizing do not re-encode valid escapes.
+			if norm && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+				continue
+			}
+		default:
+			// Unreserved according to RFC 3986 sec 2.3
+			if 'a' <= c && c <= 'z' {
+				continue
+			}
+			if 'A' <= c && c <= 'Z' {
+				continue
+			}
+			if '0' <= c && c <= '9' {
+				continue
+			}
+		}
+		b.WriteString(s[written:i])
+		fmt.Fprintf(&b, "%%%02x", c)
+		written = i + 1
+	}
+	if written == 0 {
+		return s
+	}
+	b.WriteString(s[written:])
+	return b.String()
+}
+
+// isHex reports whether the given character is a hex digit.
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -9,6 +9,9 @@ package safehtml
 import (
 	"bytes"
 	"strconv"
+
+	"github.com/google/safehtml/internal/diag"
+	"github.com/google/safehtml/urlsafe"
 )
 
 // https://infra.spec.whatwg.org/#ascii-whitespace
@@ -50,7 +53,7 @@ func URLSetSanitized(str string) URLSet {
 		_, str = consumeIn(str, asciiWhitespace)
 
 		// Append sanitized content onto buffer.
-		if len(url) != 0 && isSafeURL(url) && isOptionalSrcMetadataWellFormed(metadata) {
+		if len(url) != 0 && urlsafe.IsSafeURL(url) && isOptionalSrcMetadataWellFormed(metadata) {
 			if buffer.Len() != 0 {
 				// The space before the comma is necessary because
 				// a comma adjacent to a URL will attach to it.
@@ -62,6 +65,8 @@ func URLSetSanitized(str string) URLSet {
 				buffer.WriteByte(' ')
 				buffer.WriteString(metadata)
 			}
+		} else if len(url) != 0 {
+			diag.Log("safehtml: dropped unsafe srcset candidate", "url", url, "descriptor", metadata)
 		}
 
 		// Consume any trailing comma
@@ -78,6 +83,42 @@ func URLSetSanitized(str string) URLSet {
 	return URLSet{buffer.String()}
 }
 
+// An ImageCandidate is an image candidate of a srcset attribute value: the URL
+// of an image, and an optional descriptor of its width (e.g. "480w") or pixel
+// density (e.g. "2x").
+type ImageCandidate struct {
+	URL        URL
+	Descriptor string
+}
+
+// SrcsetFromCandidates returns a URLSet of the given image candidates, which
+// can be used as a srcset attribute value, and is accepted as one by
+// safehtml/template.
+//
+// Candidates whose descriptors are not well-formed, such as "2 x", are
+// omitted. If no candidates remain, the result is InnocuousURL.
+func SrcsetFromCandidates(candidates []ImageCandidate) URLSet {
+	var buffer bytes.Buffer
+	for _, c := range candidates {
+		url := urlsafe.NormalizeURL(c.URL.String())
+		if len(url) == 0 || !isOptionalSrcMetadataWellFormed(c.Descriptor) {
+			continue
+		}
+		if buffer.Len() != 0 {
+			buffer.WriteString(" , ")
+		}
+		appendURLToSet(url, &buffer)
+		if len(c.Descriptor) != 0 {
+			buffer.WriteByte(' ')
+			buffer.WriteString(c.Descriptor)
+		}
+	}
+	if buffer.Len() == 0 {
+		return URLSet{InnocuousURL}
+	}
+	return URLSet{buffer.String()}
+}
+
 // appendURLToSet appends a URL so that it does not start or end with a comma
 //
 // https://html.spec.whatwg.org/multipage/images.html#srcset-attributes
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file or at
+// https://developers.google.com/open-source/licenses/bsd
+
+package safehtml
+
+import (
+	"testing"
+)
+
+func TestSrcsetFromCandidates(t *testing.T) {
+	for _, test := range [...]struct {
+		desc       string
+		candidates []ImageCandidate
+		want       string
+	}{
+		{
+			"single candidate",
+			[]ImageCandidate{{URL: URLSanitized("/a.png")}},
+			"/a.png",
+		},
+		{
+			"descriptors",
+			[]ImageCandidate{
+				{URL: URLSanitized("/a.png"), Descriptor: "480w"},
+				{URL: URLSanitized("/b.png"), Descriptor: "1.5x"},
+			},
+			"/a.png 480w , /b.png 1.5x",
+		},
+		{
+			"commas and whitespace in URLs",
+			[]ImageCandidate{
+				{URL: URLSanitized(",/a b.png,"), Descriptor: "2x"},
+				{URL: URLSanitized("data:image/png;base64,AAAA")},
+			},
+			"%2c/a%20b.png%2c 2x , data:image/png;base64,AAAA",
+		},
+		{
+			"malformed descriptors omitted",
+			[]ImageCandidate{
+				{URL: URLSanitized("/a.png"), Descriptor: "2 x"},
+				{URL: URLSanitized("/b.png"), Descriptor: "2x, /c.png"},
+				{URL: URLSanitized("/d.png"), Descriptor: "2x"},
+			},
+			"/d.png 2x",
+		},
+		{
+			"unsafe URL",
+			[]ImageCandidate{{URL: URLSanitized("javascript:alert(1)"), Descriptor: "2x"}},
+			InnocuousURL + " 2x",
+		},
+		{
+			"no candidates",
+			nil,
+			InnocuousURL,
+		},
+	} {
+		got := SrcsetFromCandidates(test.candidates).String()
+		if got != test.want {
+			t.Errorf("%s: got %q, want %q", test.desc, got, test.want)
+		}
+		if reparsed := URLSetSanitized(got).String(); reparsed != got {
+			t.Errorf("%s: URLSetSanitized(%q) = %q, want unchanged", test.desc, got, reparsed)
+		}
+	}
+}